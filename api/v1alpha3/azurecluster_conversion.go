@@ -75,11 +75,15 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.NetworkSpec.APIServerLB.FrontendIPsCount = restored.Spec.NetworkSpec.APIServerLB.FrontendIPsCount
 	dst.Spec.NetworkSpec.APIServerLB.IdleTimeoutInMinutes = restored.Spec.NetworkSpec.APIServerLB.IdleTimeoutInMinutes
+	dst.Spec.NetworkSpec.APIServerLB.LoadBalancingRules = restored.Spec.NetworkSpec.APIServerLB.LoadBalancingRules
+	dst.Spec.NetworkSpec.APIServerLB.Probes = restored.Spec.NetworkSpec.APIServerLB.Probes
+	dst.Spec.NetworkSpec.APIServerLB.InboundNATPools = restored.Spec.NetworkSpec.APIServerLB.InboundNATPools
 
 	for _, restoredFrontendIP := range restored.Spec.NetworkSpec.APIServerLB.FrontendIPs {
 		for i, dstFrontendIP := range dst.Spec.NetworkSpec.APIServerLB.FrontendIPs {
 			if restoredFrontendIP.Name == dstFrontendIP.Name && restoredFrontendIP.PublicIP != nil {
 				dst.Spec.NetworkSpec.APIServerLB.FrontendIPs[i].PublicIP.IPTags = restoredFrontendIP.PublicIP.IPTags
+				dst.Spec.NetworkSpec.APIServerLB.FrontendIPs[i].PublicIP.PublicIPClassSpec = restoredFrontendIP.PublicIP.PublicIPClassSpec
 			}
 		}
 	}
@@ -89,7 +93,7 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 
 	// Here we manually restore outbound security rules. Since v1alpha3 only supports ingress ("Inbound") rules, all v1alpha4/v1beta1 outbound rules are dropped when an AzureCluster
 	// is converted to v1alpha3. We loop through all security group rules. For all previously existing outbound rules we restore the full rule.
-	// Also restores ServiceEndpoints
+	// Also restores ServiceEndpoints and route table routes.
 	for _, restoredSubnet := range restored.Spec.NetworkSpec.Subnets {
 		for i, dstSubnet := range dst.Spec.NetworkSpec.Subnets {
 			if dstSubnet.Name != restoredSubnet.Name {
@@ -102,23 +106,61 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 					restoredOutboundRules = append(restoredOutboundRules, restoredSecurityRule)
 				}
 			}
+			// For inbound rules, we restore the fields that do not exist in v1alpha3.
+			restoreSecurityRules(restoredSubnet.SecurityGroup.SecurityRules, dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules)
 			dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules = append(dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules, restoredOutboundRules...)
 			dst.Spec.NetworkSpec.Subnets[i].NatGateway = restoredSubnet.NatGateway
 
 			dst.Spec.NetworkSpec.Subnets[i].ServiceEndpoints = restoredSubnet.ServiceEndpoints
+			dst.Spec.NetworkSpec.Subnets[i].RouteTable.Routes = restoredSubnet.RouteTable.Routes
+			dst.Spec.NetworkSpec.Subnets[i].PrivateEndpoints = restoredSubnet.PrivateEndpoints
 
 			break
 		}
 	}
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
+	dst.Status.IdentityRefs = restored.Status.IdentityRefs
 
 	// Restore list of virtual network peerings
 	dst.Spec.NetworkSpec.Vnet.Peerings = restored.Spec.NetworkSpec.Vnet.Peerings
 
+	// Restore virtual network DDoS protection, encryption, DNS servers and flow timeout.
+	dst.Spec.NetworkSpec.Vnet.DDoSProtectionPlanID = restored.Spec.NetworkSpec.Vnet.DDoSProtectionPlanID
+	dst.Spec.NetworkSpec.Vnet.Encryption = restored.Spec.NetworkSpec.Vnet.Encryption
+	dst.Spec.NetworkSpec.Vnet.DNSServers = restored.Spec.NetworkSpec.Vnet.DNSServers
+	dst.Spec.NetworkSpec.Vnet.FlowTimeoutInMinutes = restored.Spec.NetworkSpec.Vnet.FlowTimeoutInMinutes
+
+	// Restore application security groups.
+	dst.Spec.NetworkSpec.ApplicationSecurityGroups = restored.Spec.NetworkSpec.ApplicationSecurityGroups
+
+	// Restore public IP prefixes.
+	dst.Spec.NetworkSpec.PublicIPPrefixes = restored.Spec.NetworkSpec.PublicIPPrefixes
+
+	// Restore private DNS zone configuration.
+	dst.Spec.NetworkSpec.PrivateDNSZone = restored.Spec.NetworkSpec.PrivateDNSZone
+
+	// Restore Azure Firewall configuration.
+	dst.Spec.NetworkSpec.Firewall = restored.Spec.NetworkSpec.Firewall
+
 	return nil
 }
 
+// restoreSecurityRules restores the fields of the security rules that do not exist in v1alpha3.
+func restoreSecurityRules(restored, dst infrav1.SecurityRules) {
+	for _, restoredRule := range restored {
+		for i, dstRule := range dst {
+			if dstRule.Name == restoredRule.Name {
+				dst[i].Action = restoredRule.Action
+				dst[i].Sources = restoredRule.Sources
+				dst[i].Destinations = restoredRule.Destinations
+				dst[i].SourceApplicationSecurityGroups = restoredRule.SourceApplicationSecurityGroups
+				dst[i].DestinationApplicationSecurityGroups = restoredRule.DestinationApplicationSecurityGroups
+			}
+		}
+	}
+}
+
 // ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *AzureCluster) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1.AzureCluster)
@@ -265,6 +307,11 @@ func Convert_v1beta1_SubnetSpec_To_v1alpha3_SubnetSpec(in *infrav1.SubnetSpec, o
 	return nil
 }
 
+// Convert_v1beta1_RouteTable_To_v1alpha3_RouteTable converts a route table from v1beta1 to v1alpha3.
+func Convert_v1beta1_RouteTable_To_v1alpha3_RouteTable(in *infrav1.RouteTable, out *RouteTable, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_RouteTable_To_v1alpha3_RouteTable(in, out, s)
+}
+
 // Convert_v1beta1_SecurityGroup_To_v1alpha3_SecurityGroup converts a security group from v1beta1 to v1alpha3.
 func Convert_v1beta1_SecurityGroup_To_v1alpha3_SecurityGroup(in *infrav1.SecurityGroup, out *SecurityGroup, s apiconversion.Scope) error {
 	out.ID = in.ID
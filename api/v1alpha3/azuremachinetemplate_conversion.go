@@ -65,10 +65,37 @@ func (src *AzureMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.DNSServers = restored.Spec.Template.Spec.DNSServers
 	}
 
+	if len(restored.Spec.Template.Spec.NetworkInterfaces) > 0 {
+		dst.Spec.Template.Spec.NetworkInterfaces = restored.Spec.Template.Spec.NetworkInterfaces
+	}
+
 	if len(restored.Spec.Template.Spec.VMExtensions) > 0 {
 		dst.Spec.Template.Spec.VMExtensions = restored.Spec.Template.Spec.VMExtensions
 	}
 
+	if restored.Spec.Template.Spec.Diagnostics != nil {
+		dst.Spec.Template.Spec.Diagnostics = restored.Spec.Template.Spec.Diagnostics
+	}
+
+	dst.Spec.Template.Spec.CapacityReservationGroupID = restored.Spec.Template.Spec.CapacityReservationGroupID
+	dst.Spec.Template.Spec.DedicatedHostGroupID = restored.Spec.Template.Spec.DedicatedHostGroupID
+	dst.Spec.Template.Spec.ProximityPlacementGroupID = restored.Spec.Template.Spec.ProximityPlacementGroupID
+
+	if restored.Spec.Template.Spec.SecurityProfile != nil && dst.Spec.Template.Spec.SecurityProfile != nil {
+		dst.Spec.Template.Spec.SecurityProfile.SecurityType = restored.Spec.Template.Spec.SecurityProfile.SecurityType
+		dst.Spec.Template.Spec.SecurityProfile.UefiSettings = restored.Spec.Template.Spec.SecurityProfile.UefiSettings
+	}
+
+	if restored.Spec.Template.Spec.OSDisk.ManagedDisk != nil && dst.Spec.Template.Spec.OSDisk.ManagedDisk != nil {
+		dst.Spec.Template.Spec.OSDisk.ManagedDisk.SecurityProfile = restored.Spec.Template.Spec.OSDisk.ManagedDisk.SecurityProfile
+	}
+
+	for i := range dst.Spec.Template.Spec.DataDisks {
+		if i < len(restored.Spec.Template.Spec.DataDisks) && restored.Spec.Template.Spec.DataDisks[i].ManagedDisk != nil && dst.Spec.Template.Spec.DataDisks[i].ManagedDisk != nil {
+			dst.Spec.Template.Spec.DataDisks[i].ManagedDisk.SecurityProfile = restored.Spec.Template.Spec.DataDisks[i].ManagedDisk.SecurityProfile
+		}
+	}
+
 	if restored.Spec.Template.Spec.SpotVMOptions != nil && restored.Spec.Template.Spec.SpotVMOptions.EvictionPolicy != nil {
 		dst.Spec.Template.Spec.SpotVMOptions.EvictionPolicy = restored.Spec.Template.Spec.SpotVMOptions.EvictionPolicy
 	}
@@ -41,21 +41,29 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 	// Restore list of virtual network peerings
 	dst.Spec.NetworkSpec.Vnet.Peerings = restored.Spec.NetworkSpec.Vnet.Peerings
 
-	// Restore API Server LB IP tags.
+	// Restore virtual network DDoS protection, encryption, DNS servers and flow timeout.
+	dst.Spec.NetworkSpec.Vnet.DDoSProtectionPlanID = restored.Spec.NetworkSpec.Vnet.DDoSProtectionPlanID
+	dst.Spec.NetworkSpec.Vnet.Encryption = restored.Spec.NetworkSpec.Vnet.Encryption
+	dst.Spec.NetworkSpec.Vnet.DNSServers = restored.Spec.NetworkSpec.Vnet.DNSServers
+	dst.Spec.NetworkSpec.Vnet.FlowTimeoutInMinutes = restored.Spec.NetworkSpec.Vnet.FlowTimeoutInMinutes
+
+	// Restore API Server LB IP tags and public IP settings.
 	for _, restoredFrontendIP := range restored.Spec.NetworkSpec.APIServerLB.FrontendIPs {
 		for i, dstFrontendIP := range dst.Spec.NetworkSpec.APIServerLB.FrontendIPs {
 			if restoredFrontendIP.Name == dstFrontendIP.Name && restoredFrontendIP.PublicIP != nil {
 				dst.Spec.NetworkSpec.APIServerLB.FrontendIPs[i].PublicIP.IPTags = restoredFrontendIP.PublicIP.IPTags
+				dst.Spec.NetworkSpec.APIServerLB.FrontendIPs[i].PublicIP.PublicIPClassSpec = restoredFrontendIP.PublicIP.PublicIPClassSpec
 			}
 		}
 	}
 
-	// Restore outbound LB IP tags.
+	// Restore outbound LB IP tags and public IP settings.
 	if restored.Spec.NetworkSpec.ControlPlaneOutboundLB != nil {
 		for _, restoredFrontendIP := range restored.Spec.NetworkSpec.ControlPlaneOutboundLB.FrontendIPs {
 			for i, dstFrontendIP := range dst.Spec.NetworkSpec.ControlPlaneOutboundLB.FrontendIPs {
 				if restoredFrontendIP.Name == dstFrontendIP.Name && restoredFrontendIP.PublicIP != nil {
 					dst.Spec.NetworkSpec.ControlPlaneOutboundLB.FrontendIPs[i].PublicIP.IPTags = restoredFrontendIP.PublicIP.IPTags
+					dst.Spec.NetworkSpec.ControlPlaneOutboundLB.FrontendIPs[i].PublicIP.PublicIPClassSpec = restoredFrontendIP.PublicIP.PublicIPClassSpec
 				}
 			}
 		}
@@ -65,35 +73,99 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 			for i, dstFrontendIP := range dst.Spec.NetworkSpec.NodeOutboundLB.FrontendIPs {
 				if restoredFrontendIP.Name == dstFrontendIP.Name && restoredFrontendIP.PublicIP != nil {
 					dst.Spec.NetworkSpec.NodeOutboundLB.FrontendIPs[i].PublicIP.IPTags = restoredFrontendIP.PublicIP.IPTags
+					dst.Spec.NetworkSpec.NodeOutboundLB.FrontendIPs[i].PublicIP.PublicIPClassSpec = restoredFrontendIP.PublicIP.PublicIPClassSpec
 				}
 			}
 		}
 	}
 
-	// Restore NAT Gateway IP tags and ServiceEndpoints.
+	// Restore load balancing rules, probes, inbound NAT pools and frontend public IP settings.
+	restoreLoadBalancerRules(&restored.Spec.NetworkSpec.APIServerLB, &dst.Spec.NetworkSpec.APIServerLB)
+	if restored.Spec.NetworkSpec.NodeOutboundLB != nil && dst.Spec.NetworkSpec.NodeOutboundLB != nil {
+		restoreLoadBalancerRules(restored.Spec.NetworkSpec.NodeOutboundLB, dst.Spec.NetworkSpec.NodeOutboundLB)
+	}
+	if restored.Spec.NetworkSpec.ControlPlaneOutboundLB != nil && dst.Spec.NetworkSpec.ControlPlaneOutboundLB != nil {
+		restoreLoadBalancerRules(restored.Spec.NetworkSpec.ControlPlaneOutboundLB, dst.Spec.NetworkSpec.ControlPlaneOutboundLB)
+	}
+
+	// Restore application security groups.
+	dst.Spec.NetworkSpec.ApplicationSecurityGroups = restored.Spec.NetworkSpec.ApplicationSecurityGroups
+
+	// Restore public IP prefixes.
+	dst.Spec.NetworkSpec.PublicIPPrefixes = restored.Spec.NetworkSpec.PublicIPPrefixes
+
+	// Restore private DNS zone configuration.
+	dst.Spec.NetworkSpec.PrivateDNSZone = restored.Spec.NetworkSpec.PrivateDNSZone
+
+	// Restore Azure Firewall configuration.
+	dst.Spec.NetworkSpec.Firewall = restored.Spec.NetworkSpec.Firewall
+
+	// Restore NAT Gateway IP tags and public IP settings, ServiceEndpoints, route table routes, security rules and private endpoints.
 	for _, restoredSubnet := range restored.Spec.NetworkSpec.Subnets {
 		for i, dstSubnet := range dst.Spec.NetworkSpec.Subnets {
 			if dstSubnet.Name == restoredSubnet.Name {
 				dst.Spec.NetworkSpec.Subnets[i].NatGateway.NatGatewayIP.IPTags = restoredSubnet.NatGateway.NatGatewayIP.IPTags
+				dst.Spec.NetworkSpec.Subnets[i].NatGateway.NatGatewayIP.PublicIPClassSpec = restoredSubnet.NatGateway.NatGatewayIP.PublicIPClassSpec
 				dst.Spec.NetworkSpec.Subnets[i].ServiceEndpoints = restoredSubnet.ServiceEndpoints
+				dst.Spec.NetworkSpec.Subnets[i].RouteTable.Routes = restoredSubnet.RouteTable.Routes
+				restoreSecurityRules(restoredSubnet.SecurityGroup.SecurityRules, dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules)
+				dst.Spec.NetworkSpec.Subnets[i].PrivateEndpoints = restoredSubnet.PrivateEndpoints
 			}
 		}
 	}
 
-	// Restore Azure Bastion IP tags.
+	// Restore Azure Bastion IP tags, public IP settings and host settings.
 	if restored.Spec.BastionSpec.AzureBastion != nil && dst.Spec.BastionSpec.AzureBastion != nil {
 		if restored.Spec.BastionSpec.AzureBastion.PublicIP.Name == dst.Spec.BastionSpec.AzureBastion.PublicIP.Name {
 			dst.Spec.BastionSpec.AzureBastion.PublicIP.IPTags = restored.Spec.BastionSpec.AzureBastion.PublicIP.IPTags
+			dst.Spec.BastionSpec.AzureBastion.PublicIP.PublicIPClassSpec = restored.Spec.BastionSpec.AzureBastion.PublicIP.PublicIPClassSpec
 		}
 		if restored.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.Name == dst.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.Name {
 			dst.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.IPTags = restored.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.IPTags
+			dst.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.PublicIPClassSpec = restored.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.PublicIPClassSpec
 		}
 		dst.Spec.BastionSpec.AzureBastion.Subnet.ServiceEndpoints = restored.Spec.BastionSpec.AzureBastion.Subnet.ServiceEndpoints
+		dst.Spec.BastionSpec.AzureBastion.Subnet.RouteTable.Routes = restored.Spec.BastionSpec.AzureBastion.Subnet.RouteTable.Routes
+		restoreSecurityRules(restored.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.SecurityRules, dst.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.SecurityRules)
+		dst.Spec.BastionSpec.AzureBastion.Subnet.PrivateEndpoints = restored.Spec.BastionSpec.AzureBastion.Subnet.PrivateEndpoints
+		dst.Spec.BastionSpec.AzureBastion.Sku = restored.Spec.BastionSpec.AzureBastion.Sku
+		dst.Spec.BastionSpec.AzureBastion.ScaleUnits = restored.Spec.BastionSpec.AzureBastion.ScaleUnits
+		dst.Spec.BastionSpec.AzureBastion.EnableTunneling = restored.Spec.BastionSpec.AzureBastion.EnableTunneling
+		dst.Spec.BastionSpec.AzureBastion.EnableIPConnect = restored.Spec.BastionSpec.AzureBastion.EnableIPConnect
+		dst.Spec.BastionSpec.AzureBastion.EnableShareableLink = restored.Spec.BastionSpec.AzureBastion.EnableShareableLink
+		dst.Spec.BastionSpec.AzureBastion.DisableCopyPaste = restored.Spec.BastionSpec.AzureBastion.DisableCopyPaste
 	}
 
+	// Restore the identities referenced by the cluster.
+	dst.Status.IdentityRefs = restored.Status.IdentityRefs
+
 	return nil
 }
 
+// restoreLoadBalancerRules restores the load balancing rules, probes, inbound NAT pools and frontend public IP settings
+// that do not exist in v1alpha4.
+func restoreLoadBalancerRules(restored, dst *infrav1.LoadBalancerSpec) {
+	dst.LoadBalancingRules = restored.LoadBalancingRules
+	dst.Probes = restored.Probes
+	dst.InboundNATPools = restored.InboundNATPools
+	dst.FrontendPublicIP = restored.FrontendPublicIP
+}
+
+// restoreSecurityRules restores the fields of the security rules that do not exist in v1alpha4.
+func restoreSecurityRules(restored, dst infrav1.SecurityRules) {
+	for _, restoredRule := range restored {
+		for i, dstRule := range dst {
+			if dstRule.Name == restoredRule.Name {
+				dst[i].Action = restoredRule.Action
+				dst[i].Sources = restoredRule.Sources
+				dst[i].Destinations = restoredRule.Destinations
+				dst[i].SourceApplicationSecurityGroups = restoredRule.SourceApplicationSecurityGroups
+				dst[i].DestinationApplicationSecurityGroups = restoredRule.DestinationApplicationSecurityGroups
+			}
+		}
+	}
+}
+
 // ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *AzureCluster) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1.AzureCluster)
@@ -160,6 +232,11 @@ func Convert_v1alpha4_AzureClusterSpec_To_v1beta1_AzureClusterSpec(in *AzureClus
 	return nil
 }
 
+// Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus converts an Azure cluster status from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in *infrav1.AzureClusterStatus, out *AzureClusterStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in, out, s)
+}
+
 // Convert_v1beta1_AzureClusterSpec_To_v1alpha4_AzureClusterSpec is an autogenerated conversion function.
 func Convert_v1beta1_AzureClusterSpec_To_v1alpha4_AzureClusterSpec(in *infrav1.AzureClusterSpec, out *AzureClusterSpec, s apiconversion.Scope) error {
 	if err := autoConvert_v1beta1_AzureClusterSpec_To_v1alpha4_AzureClusterSpec(in, out, s); err != nil {
@@ -312,12 +389,29 @@ func Convert_v1alpha4_SecurityGroup_To_v1beta1_SecurityGroup(in *SecurityGroup,
 	}
 
 	// Convert SecurityGroupClass fields
-	out.SecurityRules = *(*infrav1.SecurityRules)(unsafe.Pointer(&in.SecurityRules))
+	if in.SecurityRules != nil {
+		out.SecurityRules = make(infrav1.SecurityRules, len(in.SecurityRules))
+		for i := range in.SecurityRules {
+			if err := Convert_v1alpha4_SecurityRule_To_v1beta1_SecurityRule(&in.SecurityRules[i], &out.SecurityRules[i], s); err != nil {
+				return err
+			}
+		}
+	}
 	out.Tags = *(*infrav1.Tags)(&in.Tags)
 
 	return nil
 }
 
+// Convert_v1beta1_AzureBastion_To_v1alpha4_AzureBastion converts an Azure Bastion from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureBastion_To_v1alpha4_AzureBastion(in *infrav1.AzureBastion, out *AzureBastion, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureBastion_To_v1alpha4_AzureBastion(in, out, s)
+}
+
+// Convert_v1beta1_RouteTable_To_v1alpha4_RouteTable converts a route table from v1beta1 to v1alpha4.
+func Convert_v1beta1_RouteTable_To_v1alpha4_RouteTable(in *infrav1.RouteTable, out *RouteTable, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_RouteTable_To_v1alpha4_RouteTable(in, out, s)
+}
+
 // Convert_v1beta1_SecurityGroup_To_v1alpha4_SecurityGroup is an autogenerated conversion function.
 func Convert_v1beta1_SecurityGroup_To_v1alpha4_SecurityGroup(in *infrav1.SecurityGroup, out *SecurityGroup, s apiconversion.Scope) error {
 	if err := autoConvert_v1beta1_SecurityGroup_To_v1alpha4_SecurityGroup(in, out, s); err != nil {
@@ -325,12 +419,24 @@ func Convert_v1beta1_SecurityGroup_To_v1alpha4_SecurityGroup(in *infrav1.Securit
 	}
 
 	// Convert SecurityGroupClass fields
-	out.SecurityRules = *(*SecurityRules)(unsafe.Pointer(&in.SecurityRules))
+	if in.SecurityRules != nil {
+		out.SecurityRules = make(SecurityRules, len(in.SecurityRules))
+		for i := range in.SecurityRules {
+			if err := Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(&in.SecurityRules[i], &out.SecurityRules[i], s); err != nil {
+				return err
+			}
+		}
+	}
 	out.Tags = *(*Tags)(&in.Tags)
 
 	return nil
 }
 
+// Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule converts a security rule from v1beta1 to v1alpha4.
+func Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in *infrav1.SecurityRule, out *SecurityRule, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in, out, s)
+}
+
 // Convert_v1alpha4_NatGateway_To_v1beta1_NatGateway converts a NAT gateway from v1alpha4 to v1beta1.
 func Convert_v1alpha4_NatGateway_To_v1beta1_NatGateway(in *NatGateway, out *infrav1.NatGateway, s apiconversion.Scope) error {
 	if err := autoConvert_v1alpha4_NatGateway_To_v1beta1_NatGateway(in, out, s); err != nil {
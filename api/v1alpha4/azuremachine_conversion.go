@@ -48,10 +48,37 @@ func (src *AzureMachine) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.DNSServers = restored.Spec.DNSServers
 	}
 
+	if len(restored.Spec.NetworkInterfaces) > 0 {
+		dst.Spec.NetworkInterfaces = restored.Spec.NetworkInterfaces
+	}
+
 	if len(restored.Spec.VMExtensions) > 0 {
 		dst.Spec.VMExtensions = restored.Spec.VMExtensions
 	}
 
+	if restored.Spec.Diagnostics != nil {
+		dst.Spec.Diagnostics = restored.Spec.Diagnostics
+	}
+
+	dst.Spec.CapacityReservationGroupID = restored.Spec.CapacityReservationGroupID
+	dst.Spec.DedicatedHostGroupID = restored.Spec.DedicatedHostGroupID
+	dst.Spec.ProximityPlacementGroupID = restored.Spec.ProximityPlacementGroupID
+
+	if restored.Spec.SecurityProfile != nil && dst.Spec.SecurityProfile != nil {
+		dst.Spec.SecurityProfile.SecurityType = restored.Spec.SecurityProfile.SecurityType
+		dst.Spec.SecurityProfile.UefiSettings = restored.Spec.SecurityProfile.UefiSettings
+	}
+
+	if restored.Spec.OSDisk.ManagedDisk != nil && dst.Spec.OSDisk.ManagedDisk != nil {
+		dst.Spec.OSDisk.ManagedDisk.SecurityProfile = restored.Spec.OSDisk.ManagedDisk.SecurityProfile
+	}
+
+	for i := range dst.Spec.DataDisks {
+		if i < len(restored.Spec.DataDisks) && restored.Spec.DataDisks[i].ManagedDisk != nil && dst.Spec.DataDisks[i].ManagedDisk != nil {
+			dst.Spec.DataDisks[i].ManagedDisk.SecurityProfile = restored.Spec.DataDisks[i].ManagedDisk.SecurityProfile
+		}
+	}
+
 	if restored.Spec.SpotVMOptions != nil && restored.Spec.SpotVMOptions.EvictionPolicy != nil {
 		dst.Spec.SpotVMOptions.EvictionPolicy = restored.Spec.SpotVMOptions.EvictionPolicy
 	}
@@ -114,3 +141,13 @@ func Convert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(in *infrav1.A
 func Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in *infrav1.SpotVMOptions, out *SpotVMOptions, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in, out, s)
 }
+
+// Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile converts a SecurityProfile from v1beta1 to v1alpha4.
+func Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in *infrav1.SecurityProfile, out *SecurityProfile, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in, out, s)
+}
+
+// Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters converts ManagedDiskParameters from v1beta1 to v1alpha4.
+func Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in *infrav1.ManagedDiskParameters, out *ManagedDiskParameters, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in, out, s)
+}
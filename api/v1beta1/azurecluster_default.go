@@ -37,6 +37,18 @@ const (
 	DefaultAzureBastionSubnetName = "AzureBastionSubnet"
 	// DefaultAzureBastionSubnetRole is the default Subnet role for AzureBastion.
 	DefaultAzureBastionSubnetRole = SubnetBastion
+	// DefaultAzureBastionSku is the default SKU for AzureBastion.
+	DefaultAzureBastionSku = BasicBastionHostSku
+	// DefaultAzureBastionScaleUnits is the default number of scale units for AzureBastion.
+	DefaultAzureBastionScaleUnits = 2
+	// DefaultAzureFirewallSubnetCIDR is the default Subnet CIDR for Azure Firewall.
+	DefaultAzureFirewallSubnetCIDR = "10.255.255.0/26"
+	// DefaultAzureFirewallSubnetName is the name Azure requires for the subnet of an Azure Firewall.
+	DefaultAzureFirewallSubnetName = "AzureFirewallSubnet"
+	// DefaultAzureFirewallTier is the default tier for Azure Firewall.
+	DefaultAzureFirewallTier = AzureFirewallTierStandard
+	// DefaultPrivateDNSZoneGroupName is the default name of the private DNS zone group of a private endpoint.
+	DefaultPrivateDNSZoneGroupName = "default"
 	// DefaultInternalLBIPAddress is the default internal load balancer ip address.
 	DefaultInternalLBIPAddress = "10.0.0.100"
 	// DefaultOutboundRuleIdleTimeoutInMinutes is the default for IdleTimeoutInMinutes for the load balancer.
@@ -54,7 +66,9 @@ func (c *AzureCluster) setDefaults() {
 func (c *AzureCluster) setNetworkSpecDefaults() {
 	c.setVnetDefaults()
 	c.setBastionDefaults()
+	c.setFirewallDefaults()
 	c.setSubnetDefaults()
+	c.setPrivateEndpointDefaults()
 	c.setVnetPeeringDefaults()
 	c.setAPIServerLBDefaults()
 	c.SetNodeOutboundLBDefaults()
@@ -101,6 +115,11 @@ func (c *AzureCluster) setSubnetDefaults() {
 	}
 	cpSubnet.SecurityGroup.SecurityGroupClass.setDefaults()
 
+	// The egress traffic of the control plane is routed to the Azure Firewall through a route table.
+	if c.Spec.NetworkSpec.Firewall != nil && cpSubnet.RouteTable.Name == "" {
+		cpSubnet.RouteTable.Name = generateControlPlaneRouteTableName(c.ObjectMeta.Name)
+	}
+
 	c.Spec.NetworkSpec.UpdateControlPlaneSubnet(cpSubnet)
 
 	var nodeSubnetFound bool
@@ -151,6 +170,16 @@ func (c *AzureCluster) setSubnetDefaults() {
 	}
 }
 
+func (c *AzureCluster) setPrivateEndpointDefaults() {
+	for i, subnet := range c.Spec.NetworkSpec.Subnets {
+		for j, privateEndpoint := range subnet.PrivateEndpoints {
+			if privateEndpoint.PrivateDNSZoneGroup != nil && privateEndpoint.PrivateDNSZoneGroup.Name == "" {
+				c.Spec.NetworkSpec.Subnets[i].PrivateEndpoints[j].PrivateDNSZoneGroup.Name = DefaultPrivateDNSZoneGroupName
+			}
+		}
+	}
+}
+
 func (c *AzureCluster) setVnetPeeringDefaults() {
 	for i, peering := range c.Spec.NetworkSpec.Vnet.Peerings {
 		if peering.ResourceGroup == "" {
@@ -198,7 +227,7 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 // SetNodeOutboundLBDefaults sets the default values for the NodeOutboundLB.
 func (c *AzureCluster) SetNodeOutboundLBDefaults() {
 	if c.Spec.NetworkSpec.NodeOutboundLB == nil {
-		if c.Spec.NetworkSpec.APIServerLB.Type == Internal {
+		if c.Spec.NetworkSpec.APIServerLB.Type == Internal || c.Spec.NetworkSpec.Firewall != nil {
 			return
 		}
 
@@ -251,8 +280,14 @@ func (c *AzureCluster) SetControlPlaneOutboundLBDefaults() {
 }
 
 // setOutboundLBFrontendIPs sets the frontend ips for the given load balancer.
-// The name of the frontend ip is generated using generatePublicIPName function.
+// The name of the frontend ip is generated using generatePublicIPName function,
+// and its settings are copied from the FrontendPublicIP of the load balancer.
 func (c *AzureCluster) setOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePublicIPName func(string) string) {
+	var publicIPClassSpec PublicIPClassSpec
+	if lb.FrontendPublicIP != nil {
+		publicIPClassSpec = *lb.FrontendPublicIP
+	}
+
 	switch *lb.FrontendIPsCount {
 	case 0:
 		lb.FrontendIPs = []FrontendIP{}
@@ -261,7 +296,8 @@ func (c *AzureCluster) setOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePu
 			{
 				Name: generateFrontendIPConfigName(lb.Name),
 				PublicIP: &PublicIPSpec{
-					Name: generatePublicIPName(c.ObjectMeta.Name),
+					Name:              generatePublicIPName(c.ObjectMeta.Name),
+					PublicIPClassSpec: *publicIPClassSpec.DeepCopy(),
 				},
 			},
 		}
@@ -271,7 +307,8 @@ func (c *AzureCluster) setOutboundLBFrontendIPs(lb *LoadBalancerSpec, generatePu
 			lb.FrontendIPs[i] = FrontendIP{
 				Name: withIndex(generateFrontendIPConfigName(lb.Name), i+1),
 				PublicIP: &PublicIPSpec{
-					Name: withIndex(generatePublicIPName(c.ObjectMeta.Name), i+1),
+					Name:              withIndex(generatePublicIPName(c.ObjectMeta.Name), i+1),
+					PublicIPClassSpec: *publicIPClassSpec.DeepCopy(),
 				},
 			}
 		}
@@ -297,6 +334,36 @@ func (c *AzureCluster) setBastionDefaults() {
 		if c.Spec.BastionSpec.AzureBastion.PublicIP.Name == "" {
 			c.Spec.BastionSpec.AzureBastion.PublicIP.Name = generateAzureBastionPublicIPName(c.ObjectMeta.Name)
 		}
+		// Ensure defaults for the host settings.
+		if c.Spec.BastionSpec.AzureBastion.Sku == "" {
+			c.Spec.BastionSpec.AzureBastion.Sku = DefaultAzureBastionSku
+		}
+		if c.Spec.BastionSpec.AzureBastion.ScaleUnits == nil {
+			c.Spec.BastionSpec.AzureBastion.ScaleUnits = pointer.Int32Ptr(DefaultAzureBastionScaleUnits)
+		}
+	}
+}
+
+func (c *AzureCluster) setFirewallDefaults() {
+	firewall := c.Spec.NetworkSpec.Firewall
+	// An existing firewall is referenced by its name and resource group and is not configured by CAPZ.
+	if firewall == nil || firewall.ResourceGroup != "" {
+		return
+	}
+	if firewall.Name == "" {
+		firewall.Name = generateAzureFirewallName(c.ObjectMeta.Name)
+	}
+	if len(firewall.SubnetCIDRBlocks) == 0 {
+		firewall.SubnetCIDRBlocks = []string{DefaultAzureFirewallSubnetCIDR}
+	}
+	if firewall.PublicIP.Name == "" {
+		firewall.PublicIP.Name = generateAzureFirewallPublicIPName(c.ObjectMeta.Name)
+	}
+	if firewall.Tier == "" {
+		firewall.Tier = DefaultAzureFirewallTier
+	}
+	if firewall.Policy.Name == "" {
+		firewall.Policy.Name = generateAzureFirewallPolicyName(c.ObjectMeta.Name)
 	}
 }
 
@@ -372,6 +439,21 @@ func generateAzureBastionPublicIPName(clusterName string) string {
 	return fmt.Sprintf("%s-azure-bastion-pip", clusterName)
 }
 
+// generateAzureFirewallName generates an azure firewall name.
+func generateAzureFirewallName(clusterName string) string {
+	return fmt.Sprintf("%s-firewall", clusterName)
+}
+
+// generateAzureFirewallPublicIPName generates an azure firewall public ip name.
+func generateAzureFirewallPublicIPName(clusterName string) string {
+	return fmt.Sprintf("%s-firewall-pip", clusterName)
+}
+
+// generateAzureFirewallPolicyName generates an azure firewall policy name.
+func generateAzureFirewallPolicyName(clusterName string) string {
+	return fmt.Sprintf("%s-firewall-policy", clusterName)
+}
+
 // generateControlPlaneSecurityGroupName generates a control plane security group name, based on the cluster name.
 func generateControlPlaneSecurityGroupName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "controlplane-nsg")
@@ -382,6 +464,11 @@ func generateNodeSecurityGroupName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "node-nsg")
 }
 
+// generateControlPlaneRouteTableName generates a control plane route table name, based on the cluster name.
+func generateControlPlaneRouteTableName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "controlplane-routetable")
+}
+
 // generateNodeRouteTableName generates a node route table name, based on the cluster name.
 func generateNodeRouteTableName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "node-routetable")
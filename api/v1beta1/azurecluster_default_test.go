@@ -243,6 +243,42 @@ func TestVnetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "encryption enabled",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					ResourceGroup: "cluster-test",
+					NetworkSpec: NetworkSpec{
+						Vnet: VnetSpec{
+							VnetClassSpec: VnetClassSpec{
+								Encryption: &VnetEncryption{},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					ResourceGroup: "cluster-test",
+					NetworkSpec: NetworkSpec{
+						Vnet: VnetSpec{
+							ResourceGroup: "cluster-test",
+							Name:          "cluster-test-vnet",
+							VnetClassSpec: VnetClassSpec{
+								CIDRBlocks: []string{DefaultVnetCIDR},
+								Encryption: &VnetEncryption{Enforcement: VnetEncryptionEnforcementAllowUnencrypted},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -705,6 +741,143 @@ func TestSubnetDefaults(t *testing.T) {
 	}
 }
 
+func TestPrivateEndpointDefaults(t *testing.T) {
+	cases := []struct {
+		name    string
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		{
+			name: "private endpoint without private DNS zone group",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, Name: "node-subnet"},
+								PrivateEndpoints: PrivateEndpoints{
+									{
+										Name:                 "my-storage-pe",
+										PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+										GroupIDs:             []string{"blob"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, Name: "node-subnet"},
+								PrivateEndpoints: PrivateEndpoints{
+									{
+										Name:                 "my-storage-pe",
+										PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+										GroupIDs:             []string{"blob"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "private endpoint with unnamed private DNS zone group",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, Name: "node-subnet"},
+								PrivateEndpoints: PrivateEndpoints{
+									{
+										Name:                 "my-storage-pe",
+										PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+										GroupIDs:             []string{"blob"},
+										PrivateDNSZoneGroup: &PrivateDNSZoneGroup{
+											PrivateDNSZoneIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"},
+										},
+									},
+									{
+										Name:                 "my-vault-pe",
+										PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.KeyVault/vaults/myvault",
+										GroupIDs:             []string{"vault"},
+										PrivateDNSZoneGroup: &PrivateDNSZoneGroup{
+											Name:              "my-zone-group",
+											PrivateDNSZoneIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.vaultcore.azure.net"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{Role: SubnetNode, Name: "node-subnet"},
+								PrivateEndpoints: PrivateEndpoints{
+									{
+										Name:                 "my-storage-pe",
+										PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+										GroupIDs:             []string{"blob"},
+										PrivateDNSZoneGroup: &PrivateDNSZoneGroup{
+											Name:              DefaultPrivateDNSZoneGroupName,
+											PrivateDNSZoneIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"},
+										},
+									},
+									{
+										Name:                 "my-vault-pe",
+										PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.KeyVault/vaults/myvault",
+										GroupIDs:             []string{"vault"},
+										PrivateDNSZoneGroup: &PrivateDNSZoneGroup{
+											Name:              "my-zone-group",
+											PrivateDNSZoneIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.vaultcore.azure.net"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cluster.setPrivateEndpointDefaults()
+			if !reflect.DeepEqual(tc.cluster, tc.output) {
+				expected, _ := json.MarshalIndent(tc.output, "", "\t")
+				actual, _ := json.MarshalIndent(tc.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
 func TestVnetPeeringDefaults(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -1599,6 +1772,47 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no lb with an azure firewall",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetNode,
+									Name: "node-subnet",
+								},
+							},
+						},
+						Firewall: &AzureFirewall{Name: "cluster-test-firewall"},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetNode,
+									Name: "node-subnet",
+								},
+							},
+						},
+						Firewall: &AzureFirewall{Name: "cluster-test-firewall"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -1731,6 +1945,77 @@ func TestControlPlaneOutboundLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "frontend public IPs allocated from a public IP prefix",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Internal}},
+						ControlPlaneOutboundLB: &LoadBalancerSpec{
+							FrontendIPsCount: to.Int32Ptr(2),
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								FrontendPublicIP: &PublicIPClassSpec{
+									PublicIPPrefixName: "my-prefix",
+									Zones:              []string{"1", "2", "3"},
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Internal,
+							},
+						},
+						ControlPlaneOutboundLB: &LoadBalancerSpec{
+							Name: "cluster-test-outbound-lb",
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "cluster-test-outbound-lb-frontEnd-1",
+									PublicIP: &PublicIPSpec{
+										Name: "pip-cluster-test-controlplane-outbound-1",
+										PublicIPClassSpec: PublicIPClassSpec{
+											PublicIPPrefixName: "my-prefix",
+											Zones:              []string{"1", "2", "3"},
+										},
+									},
+								},
+								{
+									Name: "cluster-test-outbound-lb-frontEnd-2",
+									PublicIP: &PublicIPSpec{
+										Name: "pip-cluster-test-controlplane-outbound-2",
+										PublicIPClassSpec: PublicIPClassSpec{
+											PublicIPPrefixName: "my-prefix",
+											Zones:              []string{"1", "2", "3"},
+										},
+									},
+								},
+							},
+							FrontendIPsCount: to.Int32Ptr(2),
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Public,
+								IdleTimeoutInMinutes: to.Int32Ptr(DefaultOutboundRuleIdleTimeoutInMinutes),
+								FrontendPublicIP: &PublicIPClassSpec{
+									PublicIPPrefixName: "my-prefix",
+									Zones:              []string{"1", "2", "3"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -1796,6 +2081,8 @@ func TestBastionDefault(t *testing.T) {
 							PublicIP: PublicIPSpec{
 								Name: "foo-azure-bastion-pip",
 							},
+							Sku:        DefaultAzureBastionSku,
+							ScaleUnits: to.Int32Ptr(DefaultAzureBastionScaleUnits),
 						},
 					},
 				},
@@ -1833,6 +2120,8 @@ func TestBastionDefault(t *testing.T) {
 							PublicIP: PublicIPSpec{
 								Name: "foo-azure-bastion-pip",
 							},
+							Sku:        DefaultAzureBastionSku,
+							ScaleUnits: to.Int32Ptr(DefaultAzureBastionScaleUnits),
 						},
 					},
 				},
@@ -1869,6 +2158,8 @@ func TestBastionDefault(t *testing.T) {
 							PublicIP: PublicIPSpec{
 								Name: "foo-azure-bastion-pip",
 							},
+							Sku:        DefaultAzureBastionSku,
+							ScaleUnits: to.Int32Ptr(DefaultAzureBastionScaleUnits),
 						},
 					},
 				},
@@ -1910,6 +2201,8 @@ func TestBastionDefault(t *testing.T) {
 							PublicIP: PublicIPSpec{
 								Name: "foo-azure-bastion-pip",
 							},
+							Sku:        DefaultAzureBastionSku,
+							ScaleUnits: to.Int32Ptr(DefaultAzureBastionScaleUnits),
 						},
 					},
 				},
@@ -1948,6 +2241,8 @@ func TestBastionDefault(t *testing.T) {
 							PublicIP: PublicIPSpec{
 								Name: "my-ultrafancy-pip-name",
 							},
+							Sku:        DefaultAzureBastionSku,
+							ScaleUnits: to.Int32Ptr(DefaultAzureBastionScaleUnits),
 						},
 					},
 				},
@@ -1968,3 +2263,98 @@ func TestBastionDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestFirewallDefault(t *testing.T) {
+	cases := map[string]struct {
+		cluster *AzureCluster
+		output  *AzureCluster
+	}{
+		"no firewall set": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{},
+			},
+		},
+		"azure firewall enabled with no settings": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Firewall: &AzureFirewall{},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Firewall: &AzureFirewall{
+							Name:             "foo-firewall",
+							SubnetCIDRBlocks: []string{DefaultAzureFirewallSubnetCIDR},
+							PublicIP: PublicIPSpec{
+								Name: "foo-firewall-pip",
+							},
+							Tier: DefaultAzureFirewallTier,
+							Policy: AzureFirewallPolicy{
+								Name: "foo-firewall-policy",
+							},
+						},
+					},
+				},
+			},
+		},
+		"existing azure firewall": {
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Firewall: &AzureFirewall{
+							Name:          "hub-firewall",
+							ResourceGroup: "hub-rg",
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Firewall: &AzureFirewall{
+							Name:          "hub-firewall",
+							ResourceGroup: "hub-rg",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c.cluster.setFirewallDefaults()
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -74,6 +75,11 @@ type AzureClusterStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// IdentityRefs are the AzureClusterIdentities referenced by the AzureCluster, on which the AzureCluster holds a
+	// finalizer. The finalizer is removed from the identities that are no longer referenced.
+	// +optional
+	IdentityRefs []corev1.ObjectReference `json:"identityRefs,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -21,7 +21,10 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"sort"
+	"strings"
 
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	valid "github.com/asaskevich/govalidator"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -39,8 +42,10 @@ const (
 	// obtained from https://docs.microsoft.com/en-us/rest/api/resources/resourcegroups/createorupdate#uri-parameters.
 	resourceGroupRegex = `^[-\w\._\(\)]+$`
 	// described in https://docs.microsoft.com/en-us/azure/azure-resource-manager/management/resource-name-rules.
-	subnetRegex       = `^[-\w\._]+$`
-	loadBalancerRegex = `^[-\w\._]+$`
+	subnetRegex                   = `^[-\w\._]+$`
+	loadBalancerRegex             = `^[-\w\._]+$`
+	applicationSecurityGroupRegex = `^[-\w\._]+$`
+	publicIPPrefixRegex           = `^[-\w\._]+$`
 	// MaxLoadBalancerOutboundIPs is the maximum number of outbound IPs in a Standard LoadBalancer frontend configuration.
 	MaxLoadBalancerOutboundIPs = 16
 	// MinLBIdleTimeoutInMinutes is the minimum number of minutes for the LB idle timeout.
@@ -51,6 +56,24 @@ const (
 	// https://docs.microsoft.com/en-us/azure/virtual-network/network-security-groups-overview#security-rules
 	minRulePriority = 100
 	maxRulePriority = 4096
+	// Azure Bastion hosts have between 2 and 50 scale units.
+	// https://docs.microsoft.com/en-us/azure/bastion/configuration-settings#instance
+	minBastionScaleUnits = 2
+	maxBastionScaleUnits = 50
+	// A private endpoint request message is restricted to 140 characters.
+	maxPrivateEndpointRequestMessageLength = 140
+	// The names of the load balancing rule and probe of the API server load balancer, which are created by CAPZ.
+	apiServerLBRuleName  = "LBRuleHTTPS"
+	apiServerLBProbeName = "TCPProbe"
+	// The lengths of the public IP prefixes that can be created.
+	// https://docs.microsoft.com/en-us/azure/virtual-network/ip-services/public-ip-address-prefix#limitations
+	minIPv4PublicIPPrefixLength = 21
+	maxIPv4PublicIPPrefixLength = 31
+	minIPv6PublicIPPrefixLength = 124
+	maxIPv6PublicIPPrefixLength = 127
+	// The subnet of an Azure Firewall must be at least a /26.
+	// https://docs.microsoft.com/en-us/azure/firewall/firewall-faq#why-does-azure-firewall-need-a--26-subnet-size
+	maxAzureFirewallSubnetPrefixLength = 26
 	// Must start with 'Microsoft.', then an alpha character, then can include alnum.
 	serviceEndpointServiceRegexPattern = `^Microsoft\.[a-zA-Z]{1,42}[a-zA-Z0-9]{0,42}$`
 	// Must start with an alpha character and then can include alnum OR be only *.
@@ -60,6 +83,8 @@ const (
 var (
 	serviceEndpointServiceRegex  = regexp.MustCompile(serviceEndpointServiceRegexPattern)
 	serviceEndpointLocationRegex = regexp.MustCompile(serviceEndpointLocationRegexPattern)
+	publicIPPrefixIDRegex        = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourcegroups/[^/]+/providers/microsoft\.network/publicipprefixes/[^/]+$`)
+	firewallFQDNRegex            = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?\.)+[a-zA-Z]{2,}$`)
 )
 
 // validateCluster validates a cluster.
@@ -85,6 +110,16 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 	}
 	allErrs = append(allErrs, validateNetworkSpec(c.Spec.NetworkSpec, oldNetworkSpec, field.NewPath("spec").Child("networkSpec"))...)
 
+	var oldAzureBastion *AzureBastion
+	if old != nil {
+		oldAzureBastion = old.Spec.BastionSpec.AzureBastion
+	}
+	allErrs = append(allErrs, validateAzureBastion(c.Spec.BastionSpec.AzureBastion, oldAzureBastion, field.NewPath("spec").Child("bastionSpec").Child("azureBastion"))...)
+	if azureBastion := c.Spec.BastionSpec.AzureBastion; azureBastion != nil {
+		allErrs = append(allErrs, validatePublicIP(azureBastion.PublicIP.PublicIPClassSpec, c.Spec.NetworkSpec.PublicIPPrefixes, false,
+			field.NewPath("spec").Child("bastionSpec").Child("azureBastion").Child("publicIP"))...)
+	}
+
 	var oldCloudProviderConfigOverrides *CloudProviderConfigOverrides
 	if old != nil {
 		oldCloudProviderConfigOverrides = old.Spec.CloudProviderConfigOverrides
@@ -127,7 +162,19 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 
 		allErrs = append(allErrs, validateVnetCIDR(networkSpec.Vnet.CIDRBlocks, fldPath.Child("cidrBlocks"))...)
 
-		allErrs = append(allErrs, validateSubnets(networkSpec.Subnets, networkSpec.Vnet, fldPath.Child("subnets"))...)
+		allErrs = append(allErrs, validateVnetClassSpec(networkSpec.Vnet.VnetClassSpec, fldPath.Child("vnet"))...)
+
+		allErrs = append(allErrs, validateSubnets(networkSpec.Subnets, old.Subnets, networkSpec.Vnet, fldPath.Child("subnets"))...)
+
+		allErrs = append(allErrs, validateApplicationSecurityGroups(networkSpec.ApplicationSecurityGroups, fldPath.Child("applicationSecurityGroups"))...)
+
+		for i, subnet := range networkSpec.Subnets {
+			allErrs = append(allErrs, validateSecurityRuleApplicationSecurityGroups(
+				subnet.SecurityGroup.SecurityRules,
+				networkSpec.ApplicationSecurityGroups,
+				fldPath.Child("subnets").Index(i).Child("securityGroup").Child("securityRules"),
+			)...)
+		}
 
 		allErrs = append(allErrs, validateVnetPeerings(networkSpec.Vnet.Peerings, fldPath.Child("peerings"))...)
 	}
@@ -157,6 +204,14 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 
 	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec.PrivateDNSZoneName, networkSpec.APIServerLB.Type, fldPath.Child("privateDNSZoneName"))...)
 
+	allErrs = append(allErrs, validatePrivateDNSZone(networkSpec.PrivateDNSZone, networkSpec.APIServerLB.Type, fldPath.Child("privateDNSZone"))...)
+
+	allErrs = append(allErrs, validatePublicIPPrefixes(networkSpec.PublicIPPrefixes, fldPath.Child("publicIPPrefixes"))...)
+
+	allErrs = append(allErrs, validateNetworkPublicIPs(networkSpec, fldPath)...)
+
+	allErrs = append(allErrs, validateAzureFirewall(networkSpec, old.Firewall, fldPath)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -173,9 +228,18 @@ func validateResourceGroup(resourceGroup string, fldPath *field.Path) *field.Err
 }
 
 // validateSubnets validates a list of Subnets.
-func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.ErrorList {
+func validateSubnets(subnets Subnets, old Subnets, vnet VnetSpec, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
+	oldSecurityRules := make(map[string]map[string]SecurityRule, len(old))
+	for _, subnet := range old {
+		oldSecurityRules[subnet.Name] = make(map[string]SecurityRule, len(subnet.SecurityGroup.SecurityRules))
+		for _, rule := range subnet.SecurityGroup.SecurityRules {
+			oldSecurityRules[subnet.Name][rule.Name] = rule
+		}
+	}
 	subnetNames := make(map[string]bool, len(subnets))
+	routeTableSubnets := make(map[string]int, len(subnets))
+	privateEndpointNames := make(map[string]bool)
 	requiredSubnetRoles := map[string]bool{
 		"control-plane": false,
 		"node":          false,
@@ -194,19 +258,40 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 				requiredSubnetRoles[role] = true
 			}
 		}
-		for _, rule := range subnet.SecurityGroup.SecurityRules {
-			if err := validateSecurityRule(
-				rule,
-				fldPath.Index(i).Child("securityGroup").Child("securityRules").Index(i),
-			); err != nil {
-				allErrs = append(allErrs, err)
+		for j, rule := range subnet.SecurityGroup.SecurityRules {
+			var oldRule *SecurityRule
+			if r, ok := oldSecurityRules[subnet.Name][rule.Name]; ok {
+				oldRule = &r
 			}
+			allErrs = append(allErrs, validateSecurityRule(
+				rule,
+				oldRule,
+				fldPath.Index(i).Child("securityGroup").Child("securityRules").Index(j),
+			)...)
 		}
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
 
 		if len(subnet.ServiceEndpoints) > 0 {
 			allErrs = append(allErrs, validateServiceEndpoints(subnet.ServiceEndpoints, fldPath.Index(i).Child("serviceEndpoints"))...)
 		}
+
+		if len(subnet.RouteTable.Routes) > 0 {
+			allErrs = append(allErrs, validateRoutes(subnet.RouteTable.Routes, fldPath.Index(i).Child("routeTable").Child("routes"))...)
+		}
+		if len(subnet.PrivateEndpoints) > 0 {
+			allErrs = append(allErrs, validatePrivateEndpoints(subnet.PrivateEndpoints, privateEndpointNames, fldPath.Index(i).Child("privateEndpoints"))...)
+		}
+		// A route table can be attached to several subnets, as long as they all declare the same routes.
+		if subnet.RouteTable.Name != "" {
+			if j, ok := routeTableSubnets[subnet.RouteTable.Name]; ok {
+				if !reflect.DeepEqual(subnets[j].RouteTable.Routes, subnet.RouteTable.Routes) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("routeTable").Child("routes"), subnet.RouteTable.Routes,
+						fmt.Sprintf("routes of route table %s must be the same in all the subnets it is attached to, they differ from subnet %s", subnet.RouteTable.Name, subnets[j].Name)))
+				}
+			} else {
+				routeTableSubnets[subnet.RouteTable.Name] = i
+			}
+		}
 	}
 	for k, v := range requiredSubnetRoles {
 		if !v {
@@ -270,17 +355,80 @@ func validateVnetCIDR(vnetCIDRBlocks []string, fldPath *field.Path) field.ErrorL
 	return allErrs
 }
 
+// validateVnetClassSpec validates the DDoS protection plan, DNS servers and flow timeout of a virtual network.
+func validateVnetClassSpec(vnet VnetClassSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if vnet.DDoSProtectionPlanID != "" {
+		resource, err := azureautorest.ParseResourceID(vnet.DDoSProtectionPlanID)
+		if err != nil || !strings.EqualFold(resource.Provider, "Microsoft.Network") || !strings.EqualFold(resource.ResourceType, "ddosProtectionPlans") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ddosProtectionPlanID"), vnet.DDoSProtectionPlanID, "must be the Azure resource ID of a DDoS protection plan"))
+		}
+	}
+
+	dnsServers := make(map[string]bool, len(vnet.DNSServers))
+	for i, dnsServer := range vnet.DNSServers {
+		if net.ParseIP(dnsServer) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsServers").Index(i), dnsServer, "must be a valid IP address"))
+		}
+		if dnsServers[dnsServer] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("dnsServers").Index(i), dnsServer))
+		}
+		dnsServers[dnsServer] = true
+	}
+
+	if vnet.FlowTimeoutInMinutes != nil && (*vnet.FlowTimeoutInMinutes < 4 || *vnet.FlowTimeoutInMinutes > 30) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("flowTimeoutInMinutes"), *vnet.FlowTimeoutInMinutes, "must be between 4 and 30"))
+	}
+
+	return allErrs
+}
+
 // validateVnetPeerings validates a list of virtual network peerings.
 func validateVnetPeerings(peerings VnetPeerings, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	vnetIdentifiers := make(map[string]bool, len(peerings))
+	usingRemoteGateways := false
 
-	for _, peering := range peerings {
+	for i, peering := range peerings {
 		vnetIdentifier := peering.ResourceGroup + "/" + peering.RemoteVnetName
+		if peering.SubscriptionID != "" {
+			vnetIdentifier = peering.SubscriptionID + "/" + vnetIdentifier
+		}
 		if _, ok := vnetIdentifiers[vnetIdentifier]; ok {
 			allErrs = append(allErrs, field.Duplicate(fldPath, vnetIdentifier))
 		}
 		vnetIdentifiers[vnetIdentifier] = true
+
+		if peering.IdentityRef != nil && peering.IdentityRef.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("identityRef", "name"), "name of the identity is required"))
+		}
+
+		forward, reverse := peering.ForwardPeeringProperties, peering.ReversePeeringProperties
+		if pointer.BoolDeref(forward.UseRemoteGateways, false) {
+			// The AzureCluster's virtual network can only use the gateway of one remote virtual network.
+			if usingRemoteGateways {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("forwardPeeringProperties", "useRemoteGateways"), "only one peering can use remote gateways"))
+			}
+			usingRemoteGateways = true
+		}
+		allErrs = append(allErrs, validateVnetPeeringGatewayTransit(forward, reverse, fldPath.Index(i).Child("forwardPeeringProperties"), fldPath.Index(i).Child("reversePeeringProperties"))...)
+		allErrs = append(allErrs, validateVnetPeeringGatewayTransit(reverse, forward, fldPath.Index(i).Child("reversePeeringProperties"), fldPath.Index(i).Child("forwardPeeringProperties"))...)
+	}
+	return allErrs
+}
+
+// validateVnetPeeringGatewayTransit validates that one direction of a peering only uses the gateway of the remote
+// virtual network when the opposite direction allows gateway transit.
+func validateVnetPeeringGatewayTransit(properties, opposite VnetPeeringProperties, fldPath, oppositeFldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !pointer.BoolDeref(properties.UseRemoteGateways, false) {
+		return allErrs
+	}
+	if pointer.BoolDeref(properties.AllowGatewayTransit, false) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("allowGatewayTransit"), "gateway transit cannot be allowed when using remote gateways"))
+	}
+	if !pointer.BoolDeref(opposite.AllowGatewayTransit, false) {
+		allErrs = append(allErrs, field.Invalid(oppositeFldPath.Child("allowGatewayTransit"), opposite.AllowGatewayTransit, "gateway transit must be allowed when the opposite peering uses remote gateways"))
 	}
 	return allErrs
 }
@@ -312,12 +460,372 @@ func validateInternalLBIPAddress(address string, cidrs []string, fldPath *field.
 }
 
 // validateSecurityRule validates a SecurityRule.
-func validateSecurityRule(rule SecurityRule, fldPath *field.Path) *field.Error {
+// The sources and destinations already set in the old rule are not validated again, so that a rule accepted by an
+// earlier version of CAPZ does not block the updates of the cluster, e.g. when a service tag is retired from Azure.
+func validateSecurityRule(rule SecurityRule, old *SecurityRule, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	oldPrefixes := make(map[string]bool)
+	if old != nil {
+		if old.Source != nil {
+			oldPrefixes[*old.Source] = true
+		}
+		if old.Destination != nil {
+			oldPrefixes[*old.Destination] = true
+		}
+		for _, prefix := range append(append([]string{}, old.Sources...), old.Destinations...) {
+			oldPrefixes[prefix] = true
+		}
+	}
+
 	if rule.Priority < minRulePriority || rule.Priority > maxRulePriority {
-		return field.Invalid(fldPath, rule.Priority, fmt.Sprintf("security rule priorities should be between %d and %d", minRulePriority, maxRulePriority))
+		allErrs = append(allErrs, field.Invalid(fldPath, rule.Priority, fmt.Sprintf("security rule priorities should be between %d and %d", minRulePriority, maxRulePriority)))
 	}
 
-	return nil
+	// Azure accepts a single address prefix, a list of address prefixes or a list of application security groups,
+	// both for the source and the destination of a rule.
+	if countSet(rule.Source != nil, len(rule.Sources) > 0, len(rule.SourceApplicationSecurityGroups) > 0) > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of source, sources and sourceApplicationSecurityGroups can be set"))
+	}
+	if countSet(rule.Destination != nil, len(rule.Destinations) > 0, len(rule.DestinationApplicationSecurityGroups) > 0) > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of destination, destinations and destinationApplicationSecurityGroups can be set"))
+	}
+
+	if rule.Source != nil && !oldPrefixes[*rule.Source] {
+		if err := validateSecurityRuleAddressPrefix(*rule.Source, fldPath.Child("source")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	for i, source := range rule.Sources {
+		if oldPrefixes[source] {
+			continue
+		}
+		if err := validateSecurityRuleAddressPrefix(source, fldPath.Child("sources").Index(i)); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	if rule.Destination != nil && !oldPrefixes[*rule.Destination] {
+		if err := validateSecurityRuleAddressPrefix(*rule.Destination, fldPath.Child("destination")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	for i, destination := range rule.Destinations {
+		if oldPrefixes[destination] {
+			continue
+		}
+		if err := validateSecurityRuleAddressPrefix(destination, fldPath.Child("destinations").Index(i)); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	return allErrs
+}
+
+// validateSecurityRuleAddressPrefix validates the source or destination address prefix of a security rule,
+// which is either '*', an IP address, a CIDR or a service tag.
+func validateSecurityRuleAddressPrefix(prefix string, fldPath *field.Path) *field.Error {
+	if prefix == "*" || net.ParseIP(prefix) != nil {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(prefix); err == nil {
+		return nil
+	}
+	if isServiceTag(prefix) {
+		return nil
+	}
+	return field.Invalid(fldPath, prefix, "must be '*', an IP address, a CIDR or a service tag")
+}
+
+// serviceTags are the Azure service tags that can be used as the source or destination of a security rule.
+// https://docs.microsoft.com/en-us/azure/virtual-network/service-tags-overview#available-service-tags
+var serviceTags = []string{
+	"ActionGroup",
+	"ApiManagement",
+	"AppConfiguration",
+	"AppService",
+	"AppServiceManagement",
+	"AzureActiveDirectory",
+	"AzureActiveDirectoryDomainServices",
+	"AzureAdvancedThreatProtection",
+	"AzureArcInfrastructure",
+	"AzureBackup",
+	"AzureBotService",
+	"AzureCloud",
+	"AzureCognitiveSearch",
+	"AzureConnectors",
+	"AzureContainerRegistry",
+	"AzureCosmosDB",
+	"AzureDatabricks",
+	"AzureDataExplorerManagement",
+	"AzureDataLake",
+	"AzureDevOps",
+	"AzureDevSpaces",
+	"AzureDigitalTwins",
+	"AzureEventGrid",
+	"AzureFrontDoor.Backend",
+	"AzureFrontDoor.FirstParty",
+	"AzureFrontDoor.Frontend",
+	"AzureInformationProtection",
+	"AzureIoTHub",
+	"AzureKeyVault",
+	"AzureLoadBalancer",
+	"AzureMachineLearning",
+	"AzureMonitor",
+	"AzureOpenDatasets",
+	"AzurePlatformDNS",
+	"AzurePlatformIMDS",
+	"AzurePlatformLKM",
+	"AzureResourceManager",
+	"AzureSignalR",
+	"AzureSiteRecovery",
+	"AzureTrafficManager",
+	"BatchNodeManagement",
+	"CognitiveServicesManagement",
+	"DataFactory",
+	"DataFactoryManagement",
+	"Dynamics365ForMarketingEmail",
+	"EventHub",
+	"GatewayManager",
+	"GuestAndHybridManagement",
+	"HDInsight",
+	"Internet",
+	"LogicApps",
+	"LogicAppsManagement",
+	"MicrosoftCloudAppSecurity",
+	"MicrosoftContainerRegistry",
+	"PowerQueryOnline",
+	"ServiceBus",
+	"ServiceFabric",
+	"Sql",
+	"SqlManagement",
+	"Storage",
+	"StorageSyncService",
+	"VirtualNetwork",
+	"WindowsAdminCenter",
+	"WindowsVirtualDesktop",
+}
+
+// isServiceTag returns true if tag is a known Azure service tag, optionally scoped to a region, e.g. "Storage.WestUS".
+func isServiceTag(tag string) bool {
+	for _, serviceTag := range serviceTags {
+		if strings.EqualFold(tag, serviceTag) {
+			return true
+		}
+		// Regional service tags are suffixed with the name of the region.
+		if len(tag) > len(serviceTag)+1 && strings.EqualFold(tag[:len(serviceTag)+1], serviceTag+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// countSet returns the number of conditions that are true.
+func countSet(conditions ...bool) int {
+	count := 0
+	for _, set := range conditions {
+		if set {
+			count++
+		}
+	}
+	return count
+}
+
+// validateApplicationSecurityGroups validates the application security groups of a cluster.
+func validateApplicationSecurityGroups(asgs ApplicationSecurityGroups, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]bool, len(asgs))
+
+	for i, asg := range asgs {
+		if success, _ := regexp.MatchString(applicationSecurityGroupRegex, asg.Name); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), asg.Name,
+				fmt.Sprintf("name of application security group doesn't match regex %s", applicationSecurityGroupRegex)))
+		}
+		if names[asg.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), asg.Name))
+		}
+		names[asg.Name] = true
+
+		if asg.Role != SubnetControlPlane && asg.Role != SubnetNode {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("role"), asg.Role, []string{string(SubnetControlPlane), string(SubnetNode)}))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSecurityRuleApplicationSecurityGroups validates that security rules only reference application security groups of the cluster.
+func validateSecurityRuleApplicationSecurityGroups(rules SecurityRules, asgs ApplicationSecurityGroups, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]bool, len(asgs))
+	for _, asg := range asgs {
+		names[asg.Name] = true
+	}
+
+	for i, rule := range rules {
+		for j, name := range rule.SourceApplicationSecurityGroups {
+			if !names[name] {
+				allErrs = append(allErrs, field.NotFound(fldPath.Index(i).Child("sourceApplicationSecurityGroups").Index(j), name))
+			}
+		}
+		for j, name := range rule.DestinationApplicationSecurityGroups {
+			if !names[name] {
+				allErrs = append(allErrs, field.NotFound(fldPath.Index(i).Child("destinationApplicationSecurityGroups").Index(j), name))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validatePublicIPPrefixes validates the public IP prefixes created for a cluster.
+func validatePublicIPPrefixes(prefixes PublicIPPrefixes, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := make(map[string]bool, len(prefixes))
+
+	for i, prefix := range prefixes {
+		if success, _ := regexp.MatchString(publicIPPrefixRegex, prefix.Name); !success {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), prefix.Name,
+				fmt.Sprintf("name of public IP prefix doesn't match regex %s", publicIPPrefixRegex)))
+		}
+		if names[prefix.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), prefix.Name))
+		}
+		names[prefix.Name] = true
+
+		minLength, maxLength := int32(minIPv4PublicIPPrefixLength), int32(maxIPv4PublicIPPrefixLength)
+		if prefix.IPVersion == IPVersionIPv6 {
+			minLength, maxLength = minIPv6PublicIPPrefixLength, maxIPv6PublicIPPrefixLength
+		}
+		if prefix.PrefixLength < minLength || prefix.PrefixLength > maxLength {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("prefixLength"), prefix.PrefixLength,
+				fmt.Sprintf("length of %s public IP prefix should be between %d and %d", ipVersionOrDefault(prefix.IPVersion), minLength, maxLength)))
+		}
+
+		if prefix.Tier == PublicIPTierGlobal && len(prefix.Zones) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("zones"),
+				fmt.Sprintf("%s public IP prefixes cannot be zonal", PublicIPTierGlobal)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateNetworkPublicIPs validates the public IP addresses of the load balancers and NAT gateways of a cluster.
+// Azure only supports IPv6 public IP addresses for the frontends of the outbound load balancers.
+func validateNetworkPublicIPs(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	lbs := []struct {
+		lb        *LoadBalancerSpec
+		allowIPv6 bool
+		fldPath   *field.Path
+	}{
+		{&networkSpec.APIServerLB, false, fldPath.Child("apiServerLB")},
+		{networkSpec.NodeOutboundLB, true, fldPath.Child("nodeOutboundLB")},
+		{networkSpec.ControlPlaneOutboundLB, true, fldPath.Child("controlPlaneOutboundLB")},
+	}
+	for _, lb := range lbs {
+		if lb.lb == nil {
+			continue
+		}
+		for i, frontendIP := range lb.lb.FrontendIPs {
+			if frontendIP.PublicIP != nil {
+				allErrs = append(allErrs, validatePublicIP(frontendIP.PublicIP.PublicIPClassSpec, networkSpec.PublicIPPrefixes, lb.allowIPv6,
+					lb.fldPath.Child("frontendIPs").Index(i).Child("publicIP"))...)
+			}
+		}
+	}
+
+	for i, subnet := range networkSpec.Subnets {
+		if subnet.IsNatGatewayEnabled() {
+			allErrs = append(allErrs, validatePublicIP(subnet.NatGateway.NatGatewayIP.PublicIPClassSpec, networkSpec.PublicIPPrefixes, false,
+				fldPath.Child("subnets").Index(i).Child("natGateway").Child("ip"))...)
+		}
+	}
+
+	if firewall := networkSpec.Firewall; firewall != nil && firewall.ResourceGroup == "" {
+		allErrs = append(allErrs, validatePublicIP(firewall.PublicIP.PublicIPClassSpec, networkSpec.PublicIPPrefixes, false,
+			fldPath.Child("firewall").Child("publicIP"))...)
+	}
+
+	return allErrs
+}
+
+// validatePublicIP validates the settings of a public IP address, which can be allocated from a public IP prefix
+// created for the cluster or from an existing public IP prefix.
+func validatePublicIP(ip PublicIPClassSpec, prefixes PublicIPPrefixes, allowIPv6 bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ip.PublicIPPrefixName != "" && ip.PublicIPPrefixID != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("publicIPPrefixID"), "publicIPPrefixID cannot be used with publicIPPrefixName"))
+	}
+
+	if ip.PublicIPPrefixName != "" {
+		var prefix *PublicIPPrefix
+		for i := range prefixes {
+			if prefixes[i].Name == ip.PublicIPPrefixName {
+				prefix = &prefixes[i]
+			}
+		}
+		switch {
+		case prefix == nil:
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("publicIPPrefixName"), ip.PublicIPPrefixName))
+		case ipVersionOrDefault(prefix.IPVersion) != ipVersionOrDefault(ip.IPVersion):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ipVersion"), ip.IPVersion,
+				fmt.Sprintf("IP version should match the %s IP version of public IP prefix %s", ipVersionOrDefault(prefix.IPVersion), prefix.Name)))
+		case publicIPTierOrDefault(prefix.Tier) != publicIPTierOrDefault(ip.Tier):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tier"), ip.Tier,
+				fmt.Sprintf("tier should match the %s tier of public IP prefix %s", publicIPTierOrDefault(prefix.Tier), prefix.Name)))
+		case len(ip.Zones) > 0 && !sameZones(ip.Zones, prefix.Zones):
+			// The public IP address defaults to the zones of the public IP prefix, which default to the failure domains of the cluster.
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones"), ip.Zones,
+				fmt.Sprintf("zones should be unset or match the zones %v of public IP prefix %s", prefix.Zones, prefix.Name)))
+		}
+	}
+
+	if ip.PublicIPPrefixID != "" && !publicIPPrefixIDRegex.MatchString(ip.PublicIPPrefixID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("publicIPPrefixID"), ip.PublicIPPrefixID,
+			fmt.Sprintf("resource ID must match %q", publicIPPrefixIDRegex.String())))
+	}
+
+	if !allowIPv6 && ip.IPVersion == IPVersionIPv6 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ipVersion"), "only IPv4 public IP addresses are supported"))
+	}
+
+	// The load balancers, NAT gateways, Azure Bastion hosts and firewalls of a cluster are regional resources.
+	if ip.Tier == PublicIPTierGlobal {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("tier"),
+			fmt.Sprintf("%s public IP addresses can only be used by cross-region load balancers", PublicIPTierGlobal)))
+	}
+
+	return allErrs
+}
+
+// sameZones returns true if both lists contain the same availability zones, in any order.
+func sameZones(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	return reflect.DeepEqual(sortedA, sortedB)
+}
+
+// ipVersionOrDefault returns the IP version, or IPv4 if it is not set.
+func ipVersionOrDefault(version IPVersion) IPVersion {
+	if version == "" {
+		return IPVersionIPv4
+	}
+	return version
+}
+
+// publicIPTierOrDefault returns the public IP tier, or Regional if it is not set.
+func publicIPTierOrDefault(tier PublicIPTier) PublicIPTier {
+	if tier == "" {
+		return PublicIPTierRegional
+	}
+	return tier
 }
 
 func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []string, fldPath *field.Path) field.ErrorList {
@@ -367,6 +875,8 @@ func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []stri
 		}
 	}
 
+	allErrs = append(allErrs, validateLoadBalancerFrontendIPNames(lb, fldPath)...)
+
 	return allErrs
 }
 
@@ -417,6 +927,8 @@ func validateNodeOutboundLB(lb *LoadBalancerSpec, old *LoadBalancerSpec, apiserv
 			fmt.Sprintf("Max front end ips allowed is %d", MaxLoadBalancerOutboundIPs)))
 	}
 
+	allErrs = append(allErrs, validateLoadBalancerFrontendIPNames(*lb, fldPath)...)
+
 	return allErrs
 }
 
@@ -438,6 +950,10 @@ func validateControlPlaneOutboundLB(lb *LoadBalancerSpec, apiserverLB LoadBalanc
 		}
 	}
 
+	if lb != nil {
+		allErrs = append(allErrs, validateLoadBalancerFrontendIPNames(*lb, fldPath)...)
+	}
+
 	return allErrs
 }
 
@@ -460,6 +976,116 @@ func validatePrivateDNSZoneName(privateDNSZoneName string, apiserverLBType LBTyp
 	return allErrs
 }
 
+// validatePrivateDNSZone validates the configuration of the private DNS zone.
+func validatePrivateDNSZone(zone *PrivateDNSZoneClassSpec, apiserverLBType LBType, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if zone == nil {
+		return allErrs
+	}
+
+	if apiserverLBType != Internal {
+		allErrs = append(allErrs, field.Invalid(fldPath, apiserverLBType,
+			"PrivateDNSZone is available only if APIServerLB.Type is Internal"))
+	}
+	if zone.ResourceGroup != "" {
+		if err := validateResourceGroup(zone.ResourceGroup, fldPath.Child("resourceGroup")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	} else if zone.SubscriptionID != "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resourceGroup"),
+			"resourceGroup of an existing private DNS zone is required when its subscriptionID is set"))
+	}
+	if zone.TTL != nil && *zone.TTL < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), *zone.TTL, "TTL must be at least 1 second"))
+	}
+
+	recordTypes := make(map[string]map[PrivateDNSRecordType]bool, len(zone.Records))
+	for _, record := range zone.Records {
+		if recordTypes[record.Name] == nil {
+			recordTypes[record.Name] = make(map[PrivateDNSRecordType]bool)
+		}
+		recordTypes[record.Name][record.Type] = true
+	}
+	seen := make(map[string]bool, len(zone.Records))
+	for i, record := range zone.Records {
+		key := fmt.Sprintf("%s %s", record.Type, record.Name)
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("records").Index(i), key))
+		}
+		seen[key] = true
+		// A CNAME record set cannot share its name with record sets of other types.
+		if record.Type == PrivateDNSRecordTypeCNAME && len(recordTypes[record.Name]) > 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("records").Index(i).Child("name"), record.Name,
+				"a CNAME record cannot have the same name as a record of another type"))
+		}
+
+		allErrs = append(allErrs, validatePrivateDNSRecord(record, fldPath.Child("records").Index(i))...)
+	}
+
+	return allErrs
+}
+
+// validatePrivateDNSRecord validates a record set of the private DNS zone.
+func validatePrivateDNSRecord(record PrivateDNSRecord, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// "@" is the apex of the zone.
+	if record.Name != "@" && !valid.IsDNSName(record.Name) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), record.Name,
+			"name of the record must be \"@\" or a DNS name relative to the zone"))
+	}
+	if record.TTL != nil && *record.TTL < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), *record.TTL, "TTL must be at least 1 second"))
+	}
+
+	switch record.Type {
+	case PrivateDNSRecordTypeA, PrivateDNSRecordTypeAAAA:
+		if len(record.IPs) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("ips"), fmt.Sprintf("%s records require at least one IP address", record.Type)))
+		}
+		for j, ip := range record.IPs {
+			parsed := net.ParseIP(ip)
+			if record.Type == PrivateDNSRecordTypeA && (parsed == nil || parsed.To4() == nil) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("ips").Index(j), ip, "must be an IPv4 address"))
+			}
+			if record.Type == PrivateDNSRecordTypeAAAA && (parsed == nil || parsed.To4() != nil) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("ips").Index(j), ip, "must be an IPv6 address"))
+			}
+		}
+	case PrivateDNSRecordTypeCNAME:
+		if record.CNAME == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("cname"), "CNAME records require a canonical name"))
+		} else if !valid.IsDNSName(record.CNAME) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cname"), record.CNAME, "canonical name must be a DNS name"))
+		}
+	case PrivateDNSRecordTypeSRV:
+		if len(record.SRVRecords) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("srvRecords"), "SRV records require at least one target"))
+		}
+		for j, srv := range record.SRVRecords {
+			if !valid.IsDNSName(srv.Target) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("srvRecords").Index(j).Child("target"), srv.Target, "target must be a DNS name"))
+			}
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), record.Type,
+			[]string{string(PrivateDNSRecordTypeA), string(PrivateDNSRecordTypeAAAA), string(PrivateDNSRecordTypeCNAME), string(PrivateDNSRecordTypeSRV)}))
+	}
+
+	// Only the values of the record type can be set.
+	if len(record.IPs) > 0 && record.Type != PrivateDNSRecordTypeA && record.Type != PrivateDNSRecordTypeAAAA {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ips"), fmt.Sprintf("IP addresses cannot be set for %s records", record.Type)))
+	}
+	if record.CNAME != "" && record.Type != PrivateDNSRecordTypeCNAME {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("cname"), fmt.Sprintf("canonical name cannot be set for %s records", record.Type)))
+	}
+	if len(record.SRVRecords) > 0 && record.Type != PrivateDNSRecordTypeSRV {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("srvRecords"), fmt.Sprintf("SRV records cannot be set for %s records", record.Type)))
+	}
+
+	return allErrs
+}
+
 // validateCloudProviderConfigOverrides validates CloudProviderConfigOverrides.
 func validateCloudProviderConfigOverrides(oldConfig, newConfig *CloudProviderConfigOverrides, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -503,6 +1129,14 @@ func validateClassSpecForAPIServerLB(lb LoadBalancerClassSpec, old *LoadBalancer
 			fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
 	}
 
+	// The frontend public IP of the API server load balancer is configured in its frontend IPs.
+	if lb.FrontendPublicIP != nil {
+		allErrs = append(allErrs, field.Forbidden(apiServerLBPath.Child("frontendPublicIP"),
+			"API Server load balancer public IP should be configured in its frontend IPs"))
+	}
+
+	allErrs = append(allErrs, validateLoadBalancerRules(lb, apiServerLBPath)...)
+
 	return allErrs
 }
 
@@ -536,6 +1170,8 @@ func validateClassSpecForNodeOutboundLB(lb *LoadBalancerClassSpec, old *LoadBala
 			fmt.Sprintf("Node outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
 	}
 
+	allErrs = append(allErrs, validateLoadBalancerRules(*lb, fldPath)...)
+
 	return allErrs
 }
 
@@ -557,6 +1193,88 @@ func validateClassSpecForControlPlaneOutboundLB(lb *LoadBalancerClassSpec, apise
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *lb.IdleTimeoutInMinutes,
 				fmt.Sprintf("Control plane outbound idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLoadBalancerOutboundIPs)))
 		}
+
+		allErrs = append(allErrs, validateLoadBalancerRules(*lb, fldPath)...)
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerRules validates the load balancing rules, probes and inbound NAT pools of a load balancer.
+func validateLoadBalancerRules(lb LoadBalancerClassSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	probes := make(map[string]bool, len(lb.Probes))
+	for i, probe := range lb.Probes {
+		probePath := fldPath.Child("probes").Index(i)
+		probes[probe.Name] = true
+		// The API server load balancer probe is created by CAPZ.
+		if probe.Name == apiServerLBProbeName {
+			allErrs = append(allErrs, field.Invalid(probePath.Child("name"), probe.Name, "name is reserved for the API server probe"))
+		}
+		switch probe.Protocol {
+		case ProbeProtocolHTTP, ProbeProtocolHTTPS:
+			if probe.RequestPath == "" {
+				allErrs = append(allErrs, field.Required(probePath.Child("requestPath"), fmt.Sprintf("requestPath is required for %s probes", probe.Protocol)))
+			}
+		default:
+			if probe.RequestPath != "" {
+				allErrs = append(allErrs, field.Forbidden(probePath.Child("requestPath"), fmt.Sprintf("requestPath is not allowed for %s probes", probe.Protocol)))
+			}
+		}
+	}
+
+	for i, rule := range lb.LoadBalancingRules {
+		rulePath := fldPath.Child("loadBalancingRules").Index(i)
+		// The API server load balancing rule is created by CAPZ.
+		if rule.Name == apiServerLBRuleName {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("name"), rule.Name, "name is reserved for the API server load balancing rule"))
+		}
+		if rule.ProbeName != "" && !probes[rule.ProbeName] {
+			allErrs = append(allErrs, field.NotFound(rulePath.Child("probeName"), rule.ProbeName))
+		}
+		allErrs = append(allErrs, validateLBRuleIdleTimeout(rule.IdleTimeoutInMinutes, rulePath.Child("idleTimeoutInMinutes"))...)
+	}
+
+	for i, pool := range lb.InboundNATPools {
+		poolPath := fldPath.Child("inboundNATPools").Index(i)
+		if pool.FrontendPortRangeEnd < pool.FrontendPortRangeStart {
+			allErrs = append(allErrs, field.Invalid(poolPath.Child("frontendPortRangeEnd"), pool.FrontendPortRangeEnd,
+				"frontendPortRangeEnd must be greater than or equal to frontendPortRangeStart"))
+		}
+		allErrs = append(allErrs, validateLBRuleIdleTimeout(pool.IdleTimeoutInMinutes, poolPath.Child("idleTimeoutInMinutes"))...)
+	}
+
+	return allErrs
+}
+
+// validateLBRuleIdleTimeout validates the idle timeout of a load balancing rule or an inbound NAT pool.
+func validateLBRuleIdleTimeout(idleTimeoutInMinutes *int32, fldPath *field.Path) field.ErrorList {
+	if idleTimeoutInMinutes != nil && (*idleTimeoutInMinutes < MinLBIdleTimeoutInMinutes || *idleTimeoutInMinutes > MaxLBIdleTimeoutInMinutes) {
+		return field.ErrorList{field.Invalid(fldPath, *idleTimeoutInMinutes,
+			fmt.Sprintf("idle timeout should be between %d and %d minutes", MinLBIdleTimeoutInMinutes, MaxLBIdleTimeoutInMinutes))}
+	}
+	return nil
+}
+
+// validateLoadBalancerFrontendIPNames validates that the load balancing rules and inbound NAT pools of a load balancer
+// reference its frontend IPs.
+func validateLoadBalancerFrontendIPNames(lb LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	frontendIPs := make(map[string]bool, len(lb.FrontendIPs))
+	for _, frontendIP := range lb.FrontendIPs {
+		frontendIPs[frontendIP.Name] = true
+	}
+	for i, rule := range lb.LoadBalancingRules {
+		if rule.FrontendIPName != "" && !frontendIPs[rule.FrontendIPName] {
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("loadBalancingRules").Index(i).Child("frontendIPName"), rule.FrontendIPName))
+		}
+	}
+	for i, pool := range lb.InboundNATPools {
+		if pool.FrontendIPName != "" && !frontendIPs[pool.FrontendIPName] {
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("inboundNATPools").Index(i).Child("frontendIPName"), pool.FrontendIPName))
+		}
 	}
 
 	return allErrs
@@ -598,6 +1316,237 @@ func validateServiceEndpoints(serviceEndpoints []ServiceEndpointSpec, fldPath *f
 	return allErrs
 }
 
+// validateAzureBastion validates the host settings of an AzureBastion.
+func validateAzureBastion(azureBastion *AzureBastion, old *AzureBastion, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if azureBastion == nil {
+		return allErrs
+	}
+
+	if azureBastion.Sku != StandardBastionHostSku {
+		standardOnlyFeatures := []struct {
+			name    string
+			enabled bool
+		}{
+			{"enableTunneling", azureBastion.EnableTunneling},
+			{"enableIPConnect", azureBastion.EnableIPConnect},
+			{"enableShareableLink", azureBastion.EnableShareableLink},
+			{"disableCopyPaste", azureBastion.DisableCopyPaste},
+		}
+		for _, feature := range standardOnlyFeatures {
+			if feature.enabled {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child(feature.name),
+					fmt.Sprintf("%s requires the %s SKU", feature.name, StandardBastionHostSku)))
+			}
+		}
+		if azureBastion.ScaleUnits != nil && *azureBastion.ScaleUnits != DefaultAzureBastionScaleUnits {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("scaleUnits"), *azureBastion.ScaleUnits,
+				fmt.Sprintf("more than %d scale units require the %s SKU", DefaultAzureBastionScaleUnits, StandardBastionHostSku)))
+		}
+	}
+
+	if azureBastion.ScaleUnits != nil && (*azureBastion.ScaleUnits < minBastionScaleUnits || *azureBastion.ScaleUnits > maxBastionScaleUnits) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("scaleUnits"), *azureBastion.ScaleUnits,
+			fmt.Sprintf("scale units should be between %d and %d", minBastionScaleUnits, maxBastionScaleUnits)))
+	}
+
+	// The Azure Bastion subnet cannot contain any other resource.
+	if len(azureBastion.Subnet.PrivateEndpoints) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnet").Child("privateEndpoints"),
+			"private endpoints are not supported in the azure bastion subnet"))
+	}
+
+	// Azure does not support downgrading a bastion host from the Standard to the Basic SKU.
+	if old != nil && old.Sku == StandardBastionHostSku && azureBastion.Sku != StandardBastionHostSku {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sku"),
+			fmt.Sprintf("cannot downgrade azure bastion from the %s SKU", StandardBastionHostSku)))
+	}
+
+	return allErrs
+}
+
+// validateAzureFirewall validates the Azure Firewall the egress traffic of the cluster is routed through.
+func validateAzureFirewall(networkSpec NetworkSpec, old *AzureFirewall, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	firewall := networkSpec.Firewall
+	if firewall == nil {
+		return allErrs
+	}
+	firewallPath := fldPath.Child("firewall")
+
+	// The responses of a public load balancer would be routed through the firewall and dropped.
+	if networkSpec.APIServerLB.Type != Internal {
+		allErrs = append(allErrs, field.Forbidden(firewallPath, "an Azure Firewall requires an internal API server load balancer"))
+	}
+
+	// The firewall replaces the other egress modes.
+	if networkSpec.NodeOutboundLB != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodeOutboundLB"), "node outbound load balancer cannot be used with an Azure Firewall"))
+	}
+	if networkSpec.ControlPlaneOutboundLB != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlaneOutboundLB"), "control plane outbound load balancer cannot be used with an Azure Firewall"))
+	}
+	for i, subnet := range networkSpec.Subnets {
+		if subnet.IsNatGatewayEnabled() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnets").Index(i).Child("natGateway"), "NAT gateways cannot be used with an Azure Firewall"))
+		}
+	}
+
+	if firewall.ResourceGroup != "" {
+		if err := validateResourceGroup(firewall.ResourceGroup, firewallPath.Child("resourceGroup")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if firewall.Name == "" {
+			allErrs = append(allErrs, field.Required(firewallPath.Child("name"), "name of the existing Azure Firewall is required"))
+		}
+		// An existing firewall and its policy are not configured by CAPZ.
+		managedOnlySettings := []struct {
+			name string
+			set  bool
+		}{
+			{"subnetCIDRBlocks", len(firewall.SubnetCIDRBlocks) > 0},
+			{"publicIP", !reflect.DeepEqual(firewall.PublicIP, PublicIPSpec{})},
+			{"tier", firewall.Tier != ""},
+			{"zones", len(firewall.Zones) > 0},
+			{"policy", !reflect.DeepEqual(firewall.Policy, AzureFirewallPolicy{})},
+		}
+		for _, setting := range managedOnlySettings {
+			if setting.set {
+				allErrs = append(allErrs, field.Forbidden(firewallPath.Child(setting.name),
+					fmt.Sprintf("%s cannot be set for an existing Azure Firewall", setting.name)))
+			}
+		}
+	} else {
+		allErrs = append(allErrs, validateSubnetCIDR(firewall.SubnetCIDRBlocks, networkSpec.Vnet.CIDRBlocks, firewallPath.Child("subnetCIDRBlocks"))...)
+		for i, cidr := range firewall.SubnetCIDRBlocks {
+			if _, subnet, err := net.ParseCIDR(cidr); err == nil {
+				if ones, _ := subnet.Mask.Size(); ones > maxAzureFirewallSubnetPrefixLength {
+					allErrs = append(allErrs, field.Invalid(firewallPath.Child("subnetCIDRBlocks").Index(i), cidr,
+						fmt.Sprintf("the Azure Firewall subnet should be at least a /%d", maxAzureFirewallSubnetPrefixLength)))
+				}
+			}
+		}
+		for i, fqdn := range firewall.Policy.AllowedFQDNs {
+			if !firewallFQDNRegex.MatchString(fqdn) {
+				allErrs = append(allErrs, field.Invalid(firewallPath.Child("policy").Child("allowedFQDNs").Index(i), fqdn,
+					"should be a fully qualified domain name, optionally starting with a \"*.\" wildcard"))
+			}
+		}
+	}
+
+	if old != nil {
+		if firewall.Name != old.Name {
+			allErrs = append(allErrs, field.Forbidden(firewallPath.Child("name"), "Azure Firewall name should not be modified after AzureCluster creation."))
+		}
+		if firewall.ResourceGroup != old.ResourceGroup {
+			allErrs = append(allErrs, field.Forbidden(firewallPath.Child("resourceGroup"), "Azure Firewall resource group should not be modified after AzureCluster creation."))
+		}
+		if !reflect.DeepEqual(firewall.SubnetCIDRBlocks, old.SubnetCIDRBlocks) {
+			allErrs = append(allErrs, field.Forbidden(firewallPath.Child("subnetCIDRBlocks"), "Azure Firewall subnet CIDR blocks should not be modified after AzureCluster creation."))
+		}
+		if firewall.Tier != old.Tier {
+			allErrs = append(allErrs, field.Forbidden(firewallPath.Child("tier"), "Azure Firewall tier should not be modified after AzureCluster creation."))
+		}
+		if !reflect.DeepEqual(firewall.Zones, old.Zones) {
+			allErrs = append(allErrs, field.Forbidden(firewallPath.Child("zones"), "Azure Firewall zones should not be modified after AzureCluster creation."))
+		}
+	}
+
+	return allErrs
+}
+
+// validateRoutes validates the user-defined routes of a route table.
+func validateRoutes(routes Routes, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	routeNames := make(map[string]bool, len(routes))
+	for i, route := range routes {
+		if route.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "name is required for all routes"))
+		} else {
+			if _, ok := routeNames[route.Name]; ok {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), route.Name))
+			}
+			routeNames[route.Name] = true
+		}
+
+		if _, _, err := net.ParseCIDR(route.AddressPrefix); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("addressPrefix"), route.AddressPrefix, "invalid CIDR format"))
+		}
+
+		switch route.NextHopType {
+		case RouteNextHopTypeVirtualAppliance:
+			if route.NextHopIPAddress == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("nextHopIPAddress"),
+					fmt.Sprintf("nextHopIPAddress is required when nextHopType is %s", RouteNextHopTypeVirtualAppliance)))
+			} else if net.ParseIP(route.NextHopIPAddress) == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("nextHopIPAddress"), route.NextHopIPAddress,
+					"nextHopIPAddress isn't a valid IPv4 or IPv6 address"))
+			}
+		case RouteNextHopTypeVirtualNetworkGateway, RouteNextHopTypeVnetLocal, RouteNextHopTypeInternet, RouteNextHopTypeNone:
+			if route.NextHopIPAddress != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("nextHopIPAddress"),
+					fmt.Sprintf("nextHopIPAddress is only allowed when nextHopType is %s", RouteNextHopTypeVirtualAppliance)))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("nextHopType"), route.NextHopType, []string{
+				string(RouteNextHopTypeVirtualNetworkGateway),
+				string(RouteNextHopTypeVnetLocal),
+				string(RouteNextHopTypeInternet),
+				string(RouteNextHopTypeVirtualAppliance),
+				string(RouteNextHopTypeNone),
+			}))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePrivateEndpoints validates the private endpoints of a subnet.
+// The names of the private endpoints must be unique across all the subnets, they are collected in privateEndpointNames.
+func validatePrivateEndpoints(privateEndpoints PrivateEndpoints, privateEndpointNames map[string]bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, pe := range privateEndpoints {
+		if pe.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "name is required for all private endpoints"))
+		} else {
+			if _, ok := privateEndpointNames[pe.Name]; ok {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), pe.Name))
+			}
+			privateEndpointNames[pe.Name] = true
+		}
+
+		if pe.PrivateLinkServiceID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("privateLinkServiceID"), "privateLinkServiceID is required for all private endpoints"))
+		} else if _, err := azureautorest.ParseResourceID(pe.PrivateLinkServiceID); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("privateLinkServiceID"), pe.PrivateLinkServiceID, "privateLinkServiceID must be an Azure resource ID"))
+		}
+
+		if pe.RequestMessage != "" && !pe.ManualApproval {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("requestMessage"), "requestMessage is only allowed when manualApproval is true"))
+		}
+		if len(pe.RequestMessage) > maxPrivateEndpointRequestMessageLength {
+			allErrs = append(allErrs, field.TooLong(fldPath.Index(i).Child("requestMessage"), pe.RequestMessage, maxPrivateEndpointRequestMessageLength))
+		}
+
+		if pe.PrivateDNSZoneGroup != nil {
+			zoneIDsPath := fldPath.Index(i).Child("privateDNSZoneGroup").Child("privateDNSZoneIDs")
+			if len(pe.PrivateDNSZoneGroup.PrivateDNSZoneIDs) == 0 {
+				allErrs = append(allErrs, field.Required(zoneIDsPath, "privateDNSZoneIDs are required for a private DNS zone group"))
+			}
+			for j, zoneID := range pe.PrivateDNSZoneGroup.PrivateDNSZoneIDs {
+				resource, err := azureautorest.ParseResourceID(zoneID)
+				if err != nil || !strings.EqualFold(resource.Provider, "Microsoft.Network") || !strings.EqualFold(resource.ResourceType, "privateDnsZones") {
+					allErrs = append(allErrs, field.Invalid(zoneIDsPath.Index(j), zoneID, "must be the Azure resource ID of a private DNS zone"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func validateServiceEndpointServiceName(serviceName string, fldPath *field.Path) *field.Error {
 	if success := serviceEndpointServiceRegex.MatchString(serviceName); !success {
 		return field.Invalid(fldPath, serviceName, fmt.Sprintf("service name of endpoint service doesn't match regex %s", serviceEndpointServiceRegexPattern))
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
@@ -409,6 +410,72 @@ func TestValidateVnetCIDR(t *testing.T) {
 	}
 }
 
+func TestValidateVnetClassSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		vnet    VnetClassSpec
+		wantErr bool
+	}{
+		{
+			name: "valid DDoS protection plan, encryption, DNS servers and flow timeout",
+			vnet: VnetClassSpec{
+				DDoSProtectionPlanID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/ddosProtectionPlans/my-plan",
+				Encryption:           &VnetEncryption{Enforcement: VnetEncryptionEnforcementDropUnencrypted},
+				DNSServers:           []string{"10.0.0.4", "10.0.0.5"},
+				FlowTimeoutInMinutes: pointer.Int32(10),
+			},
+			wantErr: false,
+		},
+		{
+			name: "DDoS protection plan ID is not a DDoS protection plan",
+			vnet: VnetClassSpec{
+				DDoSProtectionPlanID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip",
+			},
+			wantErr: true,
+		},
+		{
+			name: "DDoS protection plan ID is not a resource ID",
+			vnet: VnetClassSpec{
+				DDoSProtectionPlanID: "my-plan",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid DNS server",
+			vnet: VnetClassSpec{
+				DNSServers: []string{"10.0.0.4", "dns.example.com"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate DNS server",
+			vnet: VnetClassSpec{
+				DNSServers: []string{"10.0.0.4", "10.0.0.4"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "flow timeout too long",
+			vnet: VnetClassSpec{
+				FlowTimeoutInMinutes: pointer.Int32(31),
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			errs := validateVnetClassSpec(testCase.vnet, field.NewPath("spec").Child("networkSpec").Child("vnet"))
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestSubnetsValid(t *testing.T) {
 	g := NewWithT(t)
 
@@ -423,7 +490,7 @@ func TestSubnetsValid(t *testing.T) {
 	}
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
+		errs := validateSubnets(testCase.subnets, nil, createValidVnet(),
 			field.NewPath("spec").Child("networkSpec").Child("subnets"))
 		g.Expect(errs).To(BeNil())
 	})
@@ -445,7 +512,7 @@ func TestSubnetsInvalidSubnetName(t *testing.T) {
 	testCase.subnets[0].Name = "invalid-subnet-name-due-to-bracket)"
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
+		errs := validateSubnets(testCase.subnets, nil, createValidVnet(),
 			field.NewPath("spec").Child("networkSpec").Child("subnets"))
 		g.Expect(errs).To(HaveLen(1))
 		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
@@ -470,7 +537,7 @@ func TestSubnetsInvalidLackRequiredSubnet(t *testing.T) {
 	testCase.subnets[0].Role = "random-role"
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
+		errs := validateSubnets(testCase.subnets, nil, createValidVnet(),
 			field.NewPath("spec").Child("networkSpec").Child("subnets"))
 		g.Expect(errs).To(HaveLen(1))
 		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
@@ -496,7 +563,7 @@ func TestSubnetNamesNotUnique(t *testing.T) {
 	testCase.subnets[1].Name = "subnet-name"
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
+		errs := validateSubnets(testCase.subnets, nil, createValidVnet(),
 			field.NewPath("spec").Child("networkSpec").Child("subnets"))
 		g.Expect(errs).To(HaveLen(1))
 		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeDuplicate))
@@ -504,6 +571,38 @@ func TestSubnetNamesNotUnique(t *testing.T) {
 	})
 }
 
+func TestSubnetsSharedRouteTable(t *testing.T) {
+	g := NewWithT(t)
+
+	defaultRoute := Route{
+		Name:             "default",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopType:      RouteNextHopTypeVirtualAppliance,
+		NextHopIPAddress: "10.100.0.4",
+	}
+
+	t.Run("subnets - route table shared with the same routes", func(t *testing.T) {
+		subnets := createValidSubnets()
+		subnets[0].RouteTable = RouteTable{Name: "hub-route-table", Routes: Routes{defaultRoute}}
+		subnets[1].RouteTable = RouteTable{Name: "hub-route-table", Routes: Routes{defaultRoute}}
+		errs := validateSubnets(subnets, nil, createValidVnet(),
+			field.NewPath("spec").Child("networkSpec").Child("subnets"))
+		g.Expect(errs).To(BeNil())
+	})
+
+	t.Run("subnets - route table shared with different routes", func(t *testing.T) {
+		subnets := createValidSubnets()
+		subnets[0].RouteTable = RouteTable{Name: "hub-route-table", Routes: Routes{defaultRoute}}
+		subnets[1].RouteTable = RouteTable{Name: "hub-route-table"}
+		errs := validateSubnets(subnets, nil, createValidVnet(),
+			field.NewPath("spec").Child("networkSpec").Child("subnets"))
+		g.Expect(errs).To(HaveLen(1))
+		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets[1].routeTable.routes"))
+		g.Expect(errs[0].Detail).To(ContainSubstring("they differ from subnet control-plane-subnet"))
+	})
+}
+
 func TestSubnetNameValid(t *testing.T) {
 	g := NewWithT(t)
 
@@ -642,19 +741,605 @@ func TestValidateSecurityRule(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "security rule - valid deny rule with address prefixes and service tags",
+			validRule: SecurityRule{
+				Name:         "deny_internet",
+				Priority:     4000,
+				Action:       SecurityRuleAccessDeny,
+				Sources:      []string{"Internet", "Storage.WestUS", "10.0.0.0/16", "192.168.1.4"},
+				Destinations: []string{"*"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - valid rule with application security groups",
+			validRule: SecurityRule{
+				Name:                                 "allow_etcd",
+				Priority:                             1000,
+				SourceApplicationSecurityGroups:      []string{"control-plane-asg"},
+				DestinationApplicationSecurityGroups: []string{"control-plane-asg"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - invalid source",
+			validRule: SecurityRule{
+				Name:     "allow_apiserver",
+				Priority: 101,
+				Source:   pointer.StringPtr("NotAServiceTag"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - invalid destinations",
+			validRule: SecurityRule{
+				Name:         "allow_apiserver",
+				Priority:     101,
+				Destinations: []string{"10.0.0.0/16", "10.0.0.0/33"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - source and sources",
+			validRule: SecurityRule{
+				Name:     "allow_apiserver",
+				Priority: 101,
+				Source:   pointer.StringPtr("*"),
+				Sources:  []string{"10.0.0.0/16"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - destinations and destination application security groups",
+			validRule: SecurityRule{
+				Name:                                 "allow_apiserver",
+				Priority:                             101,
+				Destinations:                         []string{"10.0.0.0/16"},
+				DestinationApplicationSecurityGroups: []string{"control-plane-asg"},
+			},
+			wantErr: true,
+		},
 	}
 	for _, testCase := range tests {
 		testCase := testCase
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
-			err := validateSecurityRule(
+			errs := validateSecurityRule(
 				testCase.validRule,
+				nil,
+				field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("securityGroup").Child("securityRules").Index(0),
+			)
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateSecurityRuleUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		rule    SecurityRule
+		oldRule *SecurityRule
+		wantErr bool
+	}{
+		{
+			name: "unknown service tag already set in the old rule is accepted",
+			rule: SecurityRule{
+				Name:         "allow_retired",
+				Priority:     101,
+				Sources:      []string{"RetiredServiceTag", "10.0.0.0/16"},
+				Destinations: []string{"*"},
+			},
+			oldRule: &SecurityRule{
+				Name:         "allow_retired",
+				Priority:     100,
+				Sources:      []string{"RetiredServiceTag"},
+				Destinations: []string{"*"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown service tag already set as the single source of the old rule is accepted",
+			rule: SecurityRule{
+				Name:     "allow_retired",
+				Priority: 101,
+				Source:   pointer.StringPtr("RetiredServiceTag"),
+			},
+			oldRule: &SecurityRule{
+				Name:     "allow_retired",
+				Priority: 100,
+				Source:   pointer.StringPtr("RetiredServiceTag"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown service tag added to an existing rule is rejected",
+			rule: SecurityRule{
+				Name:         "allow_retired",
+				Priority:     100,
+				Sources:      []string{"10.0.0.0/16"},
+				Destinations: []string{"UnknownServiceTag"},
+			},
+			oldRule: &SecurityRule{
+				Name:     "allow_retired",
+				Priority: 100,
+				Sources:  []string{"10.0.0.0/16"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown service tag in a new rule is rejected",
+			rule: SecurityRule{
+				Name:     "allow_retired",
+				Priority: 100,
+				Sources:  []string{"RetiredServiceTag"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			errs := validateSecurityRule(
+				testCase.rule,
+				testCase.oldRule,
 				field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("securityGroup").Child("securityRules").Index(0),
 			)
 			if testCase.wantErr {
-				g.Expect(err).To(HaveOccurred())
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateApplicationSecurityGroups(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		asgs    ApplicationSecurityGroups
+		rules   SecurityRules
+		wantErr bool
+	}{
+		{
+			name: "valid application security groups referenced by security rules",
+			asgs: ApplicationSecurityGroups{
+				{Name: "control-plane-asg", Role: SubnetControlPlane},
+				{Name: "node-asg", Role: SubnetNode},
+			},
+			rules: SecurityRules{
+				{
+					Name:                                 "allow_etcd",
+					SourceApplicationSecurityGroups:      []string{"control-plane-asg"},
+					DestinationApplicationSecurityGroups: []string{"control-plane-asg"},
+				},
+				{
+					Name:                            "allow_kubelet",
+					SourceApplicationSecurityGroups: []string{"control-plane-asg"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid application security group name",
+			asgs: ApplicationSecurityGroups{
+				{Name: "control-plane asg", Role: SubnetControlPlane},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate application security group name",
+			asgs: ApplicationSecurityGroups{
+				{Name: "my-asg", Role: SubnetControlPlane},
+				{Name: "my-asg", Role: SubnetNode},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported application security group role",
+			asgs: ApplicationSecurityGroups{
+				{Name: "bastion-asg", Role: SubnetBastion},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule references an unknown application security group",
+			asgs: ApplicationSecurityGroups{
+				{Name: "control-plane-asg", Role: SubnetControlPlane},
+			},
+			rules: SecurityRules{
+				{
+					Name:                                 "allow_etcd",
+					SourceApplicationSecurityGroups:      []string{"node-asg"},
+					DestinationApplicationSecurityGroups: []string{"control-plane-asg"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			errs := validateApplicationSecurityGroups(testCase.asgs, field.NewPath("spec").Child("networkSpec").Child("applicationSecurityGroups"))
+			errs = append(errs, validateSecurityRuleApplicationSecurityGroups(
+				testCase.rules,
+				testCase.asgs,
+				field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("securityGroup").Child("securityRules"),
+			)...)
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
 			} else {
-				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePublicIPPrefixes(t *testing.T) {
+	g := NewWithT(t)
+
+	prefixes := PublicIPPrefixes{
+		{Name: "ipv4-prefix", PrefixLength: 28, Zones: []string{"1", "2", "3"}},
+		{Name: "ipv6-prefix", PrefixLength: 124, IPVersion: IPVersionIPv6},
+	}
+
+	tests := []struct {
+		name      string
+		prefixes  PublicIPPrefixes
+		ip        PublicIPClassSpec
+		allowIPv6 bool
+		wantErr   bool
+	}{
+		{
+			name:     "public IP allocated from a public IP prefix of the cluster",
+			prefixes: prefixes,
+			ip:       PublicIPClassSpec{PublicIPPrefixName: "ipv4-prefix", Zones: []string{"1", "2", "3"}},
+			wantErr:  false,
+		},
+		{
+			name:      "IPv6 public IP allocated from an IPv6 public IP prefix of the cluster",
+			prefixes:  prefixes,
+			ip:        PublicIPClassSpec{PublicIPPrefixName: "ipv6-prefix", IPVersion: IPVersionIPv6},
+			allowIPv6: true,
+			wantErr:   false,
+		},
+		{
+			name:    "public IP allocated from an existing public IP prefix",
+			ip:      PublicIPClassSpec{PublicIPPrefixID: "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Network/publicIPPrefixes/shared-prefix"},
+			wantErr: false,
+		},
+		{
+			name:     "public IP allocated from a public IP prefix of the cluster in the zones of the prefix",
+			prefixes: prefixes,
+			ip:       PublicIPClassSpec{PublicIPPrefixName: "ipv4-prefix"},
+			wantErr:  false,
+		},
+		{
+			name:     "public IP allocated from a public IP prefix of the cluster with its zones in another order",
+			prefixes: prefixes,
+			ip:       PublicIPClassSpec{PublicIPPrefixName: "ipv4-prefix", Zones: []string{"3", "1", "2"}},
+			wantErr:  false,
+		},
+		{
+			name:     "invalid public IP prefix name",
+			prefixes: PublicIPPrefixes{{Name: "my prefix", PrefixLength: 28}},
+			wantErr:  true,
+		},
+		{
+			name:     "duplicate public IP prefix name",
+			prefixes: PublicIPPrefixes{{Name: "my-prefix", PrefixLength: 28}, {Name: "my-prefix", PrefixLength: 30}},
+			wantErr:  true,
+		},
+		{
+			name:     "IPv4 public IP prefix length too short",
+			prefixes: PublicIPPrefixes{{Name: "my-prefix", PrefixLength: 20}},
+			wantErr:  true,
+		},
+		{
+			name:     "IPv6 public IP prefix length too short",
+			prefixes: PublicIPPrefixes{{Name: "my-prefix", PrefixLength: 28, IPVersion: IPVersionIPv6}},
+			wantErr:  true,
+		},
+		{
+			name:     "zonal global public IP prefix",
+			prefixes: PublicIPPrefixes{{Name: "my-prefix", PrefixLength: 28, Tier: PublicIPTierGlobal, Zones: []string{"1"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "public IP allocated from an unknown public IP prefix",
+			prefixes: prefixes,
+			ip:       PublicIPClassSpec{PublicIPPrefixName: "other-prefix"},
+			wantErr:  true,
+		},
+		{
+			name:     "public IP allocated from both a public IP prefix of the cluster and an existing public IP prefix",
+			prefixes: prefixes,
+			ip: PublicIPClassSpec{
+				PublicIPPrefixName: "ipv4-prefix",
+				PublicIPPrefixID:   "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Network/publicIPPrefixes/shared-prefix",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid public IP prefix ID",
+			ip:      PublicIPClassSpec{PublicIPPrefixID: "shared-prefix"},
+			wantErr: true,
+		},
+		{
+			name:      "IP version of public IP does not match its public IP prefix",
+			prefixes:  prefixes,
+			ip:        PublicIPClassSpec{PublicIPPrefixName: "ipv6-prefix"},
+			allowIPv6: true,
+			wantErr:   true,
+		},
+		{
+			name:     "tier of public IP does not match its public IP prefix",
+			prefixes: prefixes,
+			ip:       PublicIPClassSpec{PublicIPPrefixName: "ipv4-prefix", Tier: PublicIPTierGlobal},
+			wantErr:  true,
+		},
+		{
+			name:    "IPv6 public IP not allowed",
+			ip:      PublicIPClassSpec{IPVersion: IPVersionIPv6},
+			wantErr: true,
+		},
+		{
+			name:    "global public IP",
+			ip:      PublicIPClassSpec{Tier: PublicIPTierGlobal},
+			wantErr: true,
+		},
+		{
+			name:     "zones of public IP do not match its public IP prefix",
+			prefixes: prefixes,
+			ip:       PublicIPClassSpec{PublicIPPrefixName: "ipv4-prefix", Zones: []string{"1"}},
+			wantErr:  true,
+		},
+		{
+			name:     "zones of public IP set while its public IP prefix defaults to the failure domains",
+			prefixes: PublicIPPrefixes{{Name: "my-prefix", PrefixLength: 28}},
+			ip:       PublicIPClassSpec{PublicIPPrefixName: "my-prefix", Zones: []string{"1"}},
+			wantErr:  true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			errs := validatePublicIPPrefixes(testCase.prefixes, field.NewPath("spec").Child("networkSpec").Child("publicIPPrefixes"))
+			errs = append(errs, validatePublicIP(
+				testCase.ip,
+				testCase.prefixes,
+				testCase.allowIPv6,
+				field.NewPath("spec").Child("networkSpec").Child("nodeOutboundLB").Child("frontendIPs").Index(0).Child("publicIP"),
+			)...)
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateVnetPeerings(t *testing.T) {
+	g := NewWithT(t)
+
+	hubPeering := func(forward, reverse VnetPeeringProperties) VnetPeeringSpec {
+		return VnetPeeringSpec{
+			VnetPeeringClassSpec: VnetPeeringClassSpec{
+				ResourceGroup:            "hub-rg",
+				RemoteVnetName:           "hub-vnet",
+				SubscriptionID:           "hub-subscription",
+				ForwardPeeringProperties: forward,
+				ReversePeeringProperties: reverse,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		peerings VnetPeerings
+		wantErr  bool
+	}{
+		{
+			name: "valid peerings with the same virtual network name in different subscriptions",
+			peerings: VnetPeerings{
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{ResourceGroup: "my-rg", RemoteVnetName: "my-vnet"}},
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{ResourceGroup: "my-rg", RemoteVnetName: "my-vnet", SubscriptionID: "other-subscription"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate peerings",
+			peerings: VnetPeerings{
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{ResourceGroup: "my-rg", RemoteVnetName: "my-vnet", SubscriptionID: "other-subscription"}},
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{ResourceGroup: "my-rg", RemoteVnetName: "my-vnet", SubscriptionID: "other-subscription"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "identity reference without a name",
+			peerings: VnetPeerings{
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{ResourceGroup: "my-rg", RemoteVnetName: "my-vnet", IdentityRef: &corev1.ObjectReference{Namespace: "default"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid use of the gateway of a hub virtual network",
+			peerings: VnetPeerings{
+				hubPeering(
+					VnetPeeringProperties{UseRemoteGateways: pointer.BoolPtr(true), AllowForwardedTraffic: pointer.BoolPtr(true)},
+					VnetPeeringProperties{AllowGatewayTransit: pointer.BoolPtr(true), AllowForwardedTraffic: pointer.BoolPtr(true)},
+				),
+			},
+			wantErr: false,
+		},
+		{
+			name: "remote gateways used without gateway transit allowed on the opposite peering",
+			peerings: VnetPeerings{
+				hubPeering(
+					VnetPeeringProperties{UseRemoteGateways: pointer.BoolPtr(true)},
+					VnetPeeringProperties{},
+				),
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote gateways used and gateway transit allowed on the same peering",
+			peerings: VnetPeerings{
+				hubPeering(
+					VnetPeeringProperties{UseRemoteGateways: pointer.BoolPtr(true), AllowGatewayTransit: pointer.BoolPtr(true)},
+					VnetPeeringProperties{AllowGatewayTransit: pointer.BoolPtr(true)},
+				),
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote gateways used by more than one peering",
+			peerings: VnetPeerings{
+				hubPeering(
+					VnetPeeringProperties{UseRemoteGateways: pointer.BoolPtr(true)},
+					VnetPeeringProperties{AllowGatewayTransit: pointer.BoolPtr(true)},
+				),
+				{
+					VnetPeeringClassSpec: VnetPeeringClassSpec{
+						ResourceGroup:            "other-hub-rg",
+						RemoteVnetName:           "other-hub-vnet",
+						ForwardPeeringProperties: VnetPeeringProperties{UseRemoteGateways: pointer.BoolPtr(true)},
+						ReversePeeringProperties: VnetPeeringProperties{AllowGatewayTransit: pointer.BoolPtr(true)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			errs := validateVnetPeerings(testCase.peerings, field.NewPath("spec").Child("networkSpec").Child("vnet").Child("peerings"))
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateLoadBalancerRules(t *testing.T) {
+	g := NewWithT(t)
+
+	readyzProbe := LoadBalancerProbe{Name: "readyz", Protocol: ProbeProtocolHTTPS, Port: 8132, RequestPath: "/readyz"}
+
+	tests := []struct {
+		name    string
+		lb      LoadBalancerSpec
+		wantErr bool
+	}{
+		{
+			name: "valid rules, probes and inbound NAT pools",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{{Name: "ip-1"}, {Name: "ip-2"}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					LoadBalancingRules: LoadBalancingRules{
+						{Name: "konnectivity", FrontendPort: 8132, BackendPort: 8132, ProbeName: "readyz"},
+						{Name: "https", FrontendPort: 443, BackendPort: 30443, FrontendIPName: "ip-2", EnableTCPReset: pointer.BoolPtr(true), IdleTimeoutInMinutes: pointer.Int32Ptr(15)},
+					},
+					Probes: LoadBalancerProbes{readyzProbe, {Name: "tcp", Protocol: ProbeProtocolTCP, Port: 30443}},
+					InboundNATPools: InboundNATPools{
+						{Name: "ssh", FrontendPortRangeStart: 50000, FrontendPortRangeEnd: 50099, BackendPort: 22},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rule referencing an unknown probe",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					LoadBalancingRules: LoadBalancingRules{{Name: "konnectivity", FrontendPort: 8132, BackendPort: 8132, ProbeName: "readyz"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rule referencing an unknown frontend IP",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{{Name: "ip-1"}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					LoadBalancingRules: LoadBalancingRules{{Name: "konnectivity", FrontendPort: 8132, BackendPort: 8132, FrontendIPName: "ip-2"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rule with the name of the API server rule",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					LoadBalancingRules: LoadBalancingRules{{Name: "LBRuleHTTPS", FrontendPort: 443, BackendPort: 443}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rule with an idle timeout out of range",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					LoadBalancingRules: LoadBalancingRules{{Name: "https", FrontendPort: 443, BackendPort: 443, IdleTimeoutInMinutes: pointer.Int32Ptr(60)}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "HTTPS probe without a request path",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Probes: LoadBalancerProbes{{Name: "readyz", Protocol: ProbeProtocolHTTPS, Port: 8132}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TCP probe with a request path",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Probes: LoadBalancerProbes{{Name: "tcp", Protocol: ProbeProtocolTCP, Port: 8132, RequestPath: "/readyz"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "inbound NAT pool with an inverted port range",
+			lb: LoadBalancerSpec{
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					InboundNATPools: InboundNATPools{{Name: "ssh", FrontendPortRangeStart: 50099, FrontendPortRangeEnd: 50000, BackendPort: 22}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			fldPath := field.NewPath("spec").Child("networkSpec").Child("nodeOutboundLB")
+			errs := validateLoadBalancerRules(testCase.lb.LoadBalancerClassSpec, fldPath)
+			errs = append(errs, validateLoadBalancerFrontendIPNames(testCase.lb, fldPath)...)
+			if testCase.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
 			}
 		})
 	}
@@ -933,10 +1618,154 @@ func TestPrivateDNSZoneName(t *testing.T) {
 				},
 			},
 			expectedErr: field.Error{
-				Type:     "FieldValueInvalid",
-				Field:    "spec.networkSpec.privateDNSZoneName",
-				BadValue: "Public",
-				Detail:   "PrivateDNSZoneName is available only if APIServerLB.Type is Internal",
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZoneName",
+				BadValue: "Public",
+				Detail:   "PrivateDNSZoneName is available only if APIServerLB.Type is Internal",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := validatePrivateDNSZoneName(test.network.PrivateDNSZoneName, test.network.APIServerLB.Type, field.NewPath("spec", "networkSpec", "privateDNSZoneName"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePrivateDNSZone(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		zone        *PrivateDNSZoneClassSpec
+		lbType      LBType
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "no private DNS zone configuration",
+			lbType:  Public,
+			wantErr: false,
+		},
+		{
+			name: "existing zone in another subscription with records",
+			zone: &PrivateDNSZoneClassSpec{
+				ResourceGroup:       "dns-rg",
+				SubscriptionID:      "456",
+				TTL:                 pointer.Int64(60),
+				RegistrationEnabled: true,
+				Records: []PrivateDNSRecord{
+					{Name: "etcd", Type: PrivateDNSRecordTypeA, IPs: []string{"10.0.0.4", "10.0.0.5"}},
+					{Name: "etcd", Type: PrivateDNSRecordTypeAAAA, IPs: []string{"fd00::4"}},
+					{Name: "api", Type: PrivateDNSRecordTypeCNAME, CNAME: "apiserver.example.com", TTL: pointer.Int64(30)},
+					{Name: "_etcd-server-ssl._tcp", Type: PrivateDNSRecordTypeSRV, SRVRecords: []SRVRecord{{Priority: 0, Weight: 10, Port: 2380, Target: "etcd.example.com"}}},
+				},
+			},
+			lbType:  Internal,
+			wantErr: false,
+		},
+		{
+			name:   "private DNS zone with public API server load balancer",
+			zone:   &PrivateDNSZoneClassSpec{TTL: pointer.Int64(60)},
+			lbType: Public,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZone",
+				BadValue: "Public",
+				Detail:   "PrivateDNSZone is available only if APIServerLB.Type is Internal",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "subscription without resource group",
+			zone:   &PrivateDNSZoneClassSpec{SubscriptionID: "456"},
+			lbType: Internal,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "spec.networkSpec.privateDNSZone.resourceGroup",
+				Detail: "resourceGroup of an existing private DNS zone is required when its subscriptionID is set",
+			},
+			wantErr: true,
+		},
+		{
+			name: "A record with an IPv6 address",
+			zone: &PrivateDNSZoneClassSpec{
+				Records: []PrivateDNSRecord{{Name: "etcd", Type: PrivateDNSRecordTypeA, IPs: []string{"fd00::4"}}},
+			},
+			lbType: Internal,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZone.records[0].ips[0]",
+				BadValue: "fd00::4",
+				Detail:   "must be an IPv4 address",
+			},
+			wantErr: true,
+		},
+		{
+			name: "CNAME record with IP addresses",
+			zone: &PrivateDNSZoneClassSpec{
+				Records: []PrivateDNSRecord{{Name: "api", Type: PrivateDNSRecordTypeCNAME, CNAME: "apiserver.example.com", IPs: []string{"10.0.0.4"}}},
+			},
+			lbType: Internal,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.networkSpec.privateDNSZone.records[0].ips",
+				Detail: "IP addresses cannot be set for CNAME records",
+			},
+			wantErr: true,
+		},
+		{
+			name: "CNAME record with the name of another record",
+			zone: &PrivateDNSZoneClassSpec{
+				Records: []PrivateDNSRecord{
+					{Name: "api", Type: PrivateDNSRecordTypeA, IPs: []string{"10.0.0.4"}},
+					{Name: "api", Type: PrivateDNSRecordTypeCNAME, CNAME: "apiserver.example.com"},
+				},
+			},
+			lbType: Internal,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZone.records[1].name",
+				BadValue: "api",
+				Detail:   "a CNAME record cannot have the same name as a record of another type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate records",
+			zone: &PrivateDNSZoneClassSpec{
+				Records: []PrivateDNSRecord{
+					{Name: "etcd", Type: PrivateDNSRecordTypeA, IPs: []string{"10.0.0.4"}},
+					{Name: "etcd", Type: PrivateDNSRecordTypeA, IPs: []string{"10.0.0.5"}},
+				},
+			},
+			lbType: Internal,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "spec.networkSpec.privateDNSZone.records[1]",
+				BadValue: "A etcd",
+			},
+			wantErr: true,
+		},
+		{
+			name: "SRV record without targets",
+			zone: &PrivateDNSZoneClassSpec{
+				Records: []PrivateDNSRecord{{Name: "_etcd-server-ssl._tcp", Type: PrivateDNSRecordTypeSRV}},
+			},
+			lbType: Internal,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "spec.networkSpec.privateDNSZone.records[0].srvRecords",
+				Detail: "SRV records require at least one target",
 			},
 			wantErr: true,
 		},
@@ -946,7 +1775,7 @@ func TestPrivateDNSZoneName(t *testing.T) {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
-			err := validatePrivateDNSZoneName(test.network.PrivateDNSZoneName, test.network.APIServerLB.Type, field.NewPath("spec", "networkSpec", "privateDNSZoneName"))
+			err := validatePrivateDNSZone(test.zone, test.lbType, field.NewPath("spec", "networkSpec", "privateDNSZone"))
 			if test.wantErr {
 				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
 			} else {
@@ -1505,3 +2334,471 @@ func TestServiceEndpointsLackRequiredFieldLocations(t *testing.T) {
 		g.Expect(errs[0].Error()).To(ContainSubstring("locations are required for all service endpoints"))
 	})
 }
+
+func TestValidateRoutes(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		routes      Routes
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid routes",
+			routes: Routes{
+				{
+					Name:             "default",
+					AddressPrefix:    "0.0.0.0/0",
+					NextHopType:      RouteNextHopTypeVirtualAppliance,
+					NextHopIPAddress: "10.100.0.4",
+				},
+				{
+					Name:          "on-prem",
+					AddressPrefix: "192.168.0.0/16",
+					NextHopType:   RouteNextHopTypeVirtualNetworkGateway,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid address prefix",
+			routes: Routes{{
+				Name:          "on-prem",
+				AddressPrefix: "192.168.0.0",
+				NextHopType:   RouteNextHopTypeVirtualNetworkGateway,
+			}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].routeTable.routes[0].addressPrefix",
+				BadValue: "192.168.0.0",
+				Detail:   "invalid CIDR format",
+			},
+		},
+		{
+			name: "duplicate route names",
+			routes: Routes{
+				{
+					Name:          "on-prem",
+					AddressPrefix: "192.168.0.0/16",
+					NextHopType:   RouteNextHopTypeVirtualNetworkGateway,
+				},
+				{
+					Name:          "on-prem",
+					AddressPrefix: "172.16.0.0/12",
+					NextHopType:   RouteNextHopTypeVirtualNetworkGateway,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "subnets[0].routeTable.routes[1].name",
+				BadValue: "on-prem",
+			},
+		},
+		{
+			name: "virtual appliance without next hop IP address",
+			routes: Routes{{
+				Name:          "default",
+				AddressPrefix: "0.0.0.0/0",
+				NextHopType:   RouteNextHopTypeVirtualAppliance,
+			}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "subnets[0].routeTable.routes[0].nextHopIPAddress",
+				Detail: "nextHopIPAddress is required when nextHopType is VirtualAppliance",
+			},
+		},
+		{
+			name: "virtual appliance with an invalid next hop IP address",
+			routes: Routes{{
+				Name:             "default",
+				AddressPrefix:    "0.0.0.0/0",
+				NextHopType:      RouteNextHopTypeVirtualAppliance,
+				NextHopIPAddress: "10.100.0",
+			}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].routeTable.routes[0].nextHopIPAddress",
+				BadValue: "10.100.0",
+				Detail:   "nextHopIPAddress isn't a valid IPv4 or IPv6 address",
+			},
+		},
+		{
+			name: "next hop IP address with another next hop type",
+			routes: Routes{{
+				Name:             "default",
+				AddressPrefix:    "0.0.0.0/0",
+				NextHopType:      RouteNextHopTypeInternet,
+				NextHopIPAddress: "10.100.0.4",
+			}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "subnets[0].routeTable.routes[0].nextHopIPAddress",
+				Detail: "nextHopIPAddress is only allowed when nextHopType is VirtualAppliance",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateRoutes(testCase.routes, field.NewPath("subnets[0].routeTable.routes"))
+			if testCase.wantErr {
+				// Searches for expected error in list of thrown errors
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePrivateEndpoints(t *testing.T) {
+	g := NewWithT(t)
+
+	storageAccountID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage"
+	blobZoneID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"
+
+	tests := []struct {
+		name             string
+		privateEndpoints PrivateEndpoints
+		existingNames    map[string]bool
+		wantErr          bool
+		expectedErr      field.Error
+	}{
+		{
+			name: "valid private endpoints",
+			privateEndpoints: PrivateEndpoints{
+				{
+					Name:                 "my-storage-pe",
+					PrivateLinkServiceID: storageAccountID,
+					GroupIDs:             []string{"blob"},
+					PrivateDNSZoneGroup: &PrivateDNSZoneGroup{
+						Name:              "default",
+						PrivateDNSZoneIDs: []string{blobZoneID},
+					},
+				},
+				{
+					Name:                 "my-vault-pe",
+					PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.KeyVault/vaults/myvault",
+					GroupIDs:             []string{"vault"},
+					ManualApproval:       true,
+					RequestMessage:       "please approve",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "private endpoint name used in another subnet",
+			privateEndpoints: PrivateEndpoints{{
+				Name:                 "my-storage-pe",
+				PrivateLinkServiceID: storageAccountID,
+			}},
+			existingNames: map[string]bool{"my-storage-pe": true},
+			wantErr:       true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "subnets[0].privateEndpoints[0].name",
+				BadValue: "my-storage-pe",
+			},
+		},
+		{
+			name: "invalid private link service ID",
+			privateEndpoints: PrivateEndpoints{{
+				Name:                 "my-storage-pe",
+				PrivateLinkServiceID: "mystorage",
+			}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].privateEndpoints[0].privateLinkServiceID",
+				BadValue: "mystorage",
+				Detail:   "privateLinkServiceID must be an Azure resource ID",
+			},
+		},
+		{
+			name: "request message without manual approval",
+			privateEndpoints: PrivateEndpoints{{
+				Name:                 "my-storage-pe",
+				PrivateLinkServiceID: storageAccountID,
+				RequestMessage:       "please approve",
+			}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "subnets[0].privateEndpoints[0].requestMessage",
+				Detail: "requestMessage is only allowed when manualApproval is true",
+			},
+		},
+		{
+			name: "private DNS zone group with a resource that is not a private DNS zone",
+			privateEndpoints: PrivateEndpoints{{
+				Name:                 "my-storage-pe",
+				PrivateLinkServiceID: storageAccountID,
+				PrivateDNSZoneGroup: &PrivateDNSZoneGroup{
+					Name:              "default",
+					PrivateDNSZoneIDs: []string{storageAccountID},
+				},
+			}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[0].privateEndpoints[0].privateDNSZoneGroup.privateDNSZoneIDs[0]",
+				BadValue: storageAccountID,
+				Detail:   "must be the Azure resource ID of a private DNS zone",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			names := testCase.existingNames
+			if names == nil {
+				names = make(map[string]bool)
+			}
+			err := validatePrivateEndpoints(testCase.privateEndpoints, names, field.NewPath("subnets[0].privateEndpoints"))
+			if testCase.wantErr {
+				// Searches for expected error in list of thrown errors
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateAzureBastion(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name         string
+		azureBastion *AzureBastion
+		old          *AzureBastion
+		wantErr      bool
+		expectedErr  field.Error
+	}{
+		{
+			name:         "no azure bastion",
+			azureBastion: nil,
+			wantErr:      false,
+		},
+		{
+			name: "standard SKU with tunneling and scale units",
+			azureBastion: &AzureBastion{
+				Sku:             StandardBastionHostSku,
+				ScaleUnits:      pointer.Int32Ptr(10),
+				EnableTunneling: true,
+				EnableIPConnect: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "basic SKU with tunneling",
+			azureBastion: &AzureBastion{
+				Sku:             BasicBastionHostSku,
+				EnableTunneling: true,
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.bastionSpec.azureBastion.enableTunneling",
+				Detail: "enableTunneling requires the Standard SKU",
+			},
+		},
+		{
+			name: "basic SKU with more than 2 scale units",
+			azureBastion: &AzureBastion{
+				Sku:        BasicBastionHostSku,
+				ScaleUnits: pointer.Int32Ptr(3),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.bastionSpec.azureBastion.scaleUnits",
+				BadValue: int32(3),
+				Detail:   "more than 2 scale units require the Standard SKU",
+			},
+		},
+		{
+			name: "standard SKU with too many scale units",
+			azureBastion: &AzureBastion{
+				Sku:        StandardBastionHostSku,
+				ScaleUnits: pointer.Int32Ptr(51),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.bastionSpec.azureBastion.scaleUnits",
+				BadValue: int32(51),
+				Detail:   "scale units should be between 2 and 50",
+			},
+		},
+		{
+			name: "private endpoints in the azure bastion subnet",
+			azureBastion: &AzureBastion{
+				Subnet: SubnetSpec{
+					PrivateEndpoints: PrivateEndpoints{{Name: "my-pe"}},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.bastionSpec.azureBastion.subnet.privateEndpoints",
+				Detail: "private endpoints are not supported in the azure bastion subnet",
+			},
+		},
+		{
+			name:         "downgrade from the standard SKU",
+			azureBastion: &AzureBastion{Sku: BasicBastionHostSku},
+			old:          &AzureBastion{Sku: StandardBastionHostSku},
+			wantErr:      true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.bastionSpec.azureBastion.sku",
+				Detail: "cannot downgrade azure bastion from the Standard SKU",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateAzureBastion(testCase.azureBastion, testCase.old, field.NewPath("spec", "bastionSpec", "azureBastion"))
+			if testCase.wantErr {
+				// Searches for expected error in list of thrown errors
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateAzureFirewall(t *testing.T) {
+	g := NewWithT(t)
+
+	privateNetworkSpec := func(firewall *AzureFirewall) NetworkSpec {
+		networkSpec := createValidNetworkSpec()
+		networkSpec.Vnet = createValidVnet()
+		networkSpec.APIServerLB = createValidAPIServerInternalLB()
+		networkSpec.NodeOutboundLB = nil
+		networkSpec.Firewall = firewall
+		return networkSpec
+	}
+
+	tests := []struct {
+		name        string
+		networkSpec NetworkSpec
+		old         *AzureFirewall
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:        "no azure firewall",
+			networkSpec: createValidNetworkSpec(),
+			wantErr:     false,
+		},
+		{
+			name: "azure firewall with allowed FQDNs",
+			networkSpec: privateNetworkSpec(&AzureFirewall{
+				Name:             "my-firewall",
+				SubnetCIDRBlocks: []string{"10.255.255.0/26"},
+				Tier:             AzureFirewallTierPremium,
+				Policy: AzureFirewallPolicy{
+					AllowedFQDNs: []string{"*.example.com", "ghcr.io"},
+				},
+			}),
+			wantErr: false,
+		},
+		{
+			name:        "existing azure firewall",
+			networkSpec: privateNetworkSpec(&AzureFirewall{Name: "hub-firewall", ResourceGroup: "hub-rg"}),
+			wantErr:     false,
+		},
+		{
+			name: "azure firewall with public API server load balancer",
+			networkSpec: func() NetworkSpec {
+				networkSpec := createValidNetworkSpec()
+				networkSpec.Firewall = &AzureFirewall{Name: "my-firewall"}
+				return networkSpec
+			}(),
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.networkSpec.firewall",
+				Detail: "an Azure Firewall requires an internal API server load balancer",
+			},
+		},
+		{
+			name: "azure firewall with NAT gateway",
+			networkSpec: func() NetworkSpec {
+				networkSpec := privateNetworkSpec(&AzureFirewall{Name: "my-firewall"})
+				networkSpec.Subnets[1].NatGateway = NatGateway{NatGatewayClassSpec: NatGatewayClassSpec{Name: "my-natgw"}}
+				return networkSpec
+			}(),
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.networkSpec.subnets[1].natGateway",
+				Detail: "NAT gateways cannot be used with an Azure Firewall",
+			},
+		},
+		{
+			name:        "azure firewall subnet smaller than a /26",
+			networkSpec: privateNetworkSpec(&AzureFirewall{Name: "my-firewall", SubnetCIDRBlocks: []string{"10.255.255.0/27"}}),
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.firewall.subnetCIDRBlocks[0]",
+				BadValue: "10.255.255.0/27",
+				Detail:   "the Azure Firewall subnet should be at least a /26",
+			},
+		},
+		{
+			name: "invalid allowed FQDN",
+			networkSpec: privateNetworkSpec(&AzureFirewall{
+				Name:   "my-firewall",
+				Policy: AzureFirewallPolicy{AllowedFQDNs: []string{"https://example.com"}},
+			}),
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.firewall.policy.allowedFQDNs[0]",
+				BadValue: "https://example.com",
+				Detail:   `should be a fully qualified domain name, optionally starting with a "*." wildcard`,
+			},
+		},
+		{
+			name:        "existing azure firewall with a policy",
+			networkSpec: privateNetworkSpec(&AzureFirewall{Name: "hub-firewall", ResourceGroup: "hub-rg", Policy: AzureFirewallPolicy{Name: "my-policy"}}),
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.networkSpec.firewall.policy",
+				Detail: "policy cannot be set for an existing Azure Firewall",
+			},
+		},
+		{
+			name:        "azure firewall tier modified",
+			networkSpec: privateNetworkSpec(&AzureFirewall{Name: "my-firewall", Tier: AzureFirewallTierPremium}),
+			old:         &AzureFirewall{Name: "my-firewall", Tier: AzureFirewallTierStandard},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.networkSpec.firewall.tier",
+				Detail: "Azure Firewall tier should not be modified after AzureCluster creation.",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateAzureFirewall(testCase.networkSpec, testCase.old, field.NewPath("spec", "networkSpec"))
+			if testCase.wantErr {
+				// Searches for expected error in list of thrown errors
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
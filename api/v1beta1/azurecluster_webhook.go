@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -114,12 +115,46 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, err)
 	}
 
+	// The private DNS zone cannot be switched between a zone created by CAPZ and an existing zone.
+	var oldZone, zone PrivateDNSZoneClassSpec
+	if old.Spec.NetworkSpec.PrivateDNSZone != nil {
+		oldZone = *old.Spec.NetworkSpec.PrivateDNSZone
+	}
+	if c.Spec.NetworkSpec.PrivateDNSZone != nil {
+		zone = *c.Spec.NetworkSpec.PrivateDNSZone
+	}
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkSpec", "PrivateDNSZone", "ResourceGroup"),
+		oldZone.ResourceGroup,
+		zone.ResourceGroup); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkSpec", "PrivateDNSZone", "SubscriptionID"),
+		oldZone.SubscriptionID,
+		zone.SubscriptionID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	// Allow enabling azure bastion but avoid disabling it.
-	if old.Spec.BastionSpec.AzureBastion != nil && !reflect.DeepEqual(old.Spec.BastionSpec.AzureBastion, c.Spec.BastionSpec.AzureBastion) {
-		allErrs = append(allErrs,
-			field.Invalid(field.NewPath("spec", "BastionSpec", "AzureBastion"),
-				c.Spec.BastionSpec.AzureBastion, "azure bastion cannot be removed from a cluster"),
-		)
+	if old.Spec.BastionSpec.AzureBastion != nil {
+		if c.Spec.BastionSpec.AzureBastion == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "BastionSpec", "AzureBastion"),
+					c.Spec.BastionSpec.AzureBastion, "azure bastion cannot be removed from a cluster"),
+			)
+		} else {
+			// The SKU, scale units and features of the bastion host can be updated, but not the resources it is made of.
+			oldBastion, bastion := old.Spec.BastionSpec.AzureBastion, c.Spec.BastionSpec.AzureBastion
+			if bastion.Name != oldBastion.Name ||
+				!reflect.DeepEqual(bastion.Subnet, oldBastion.Subnet) ||
+				!reflect.DeepEqual(bastion.PublicIP, oldBastion.PublicIP) {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "BastionSpec", "AzureBastion"),
+						c.Spec.BastionSpec.AzureBastion, "azure bastion name, subnet and public IP are immutable"),
+				)
+			}
+		}
 	}
 
 	if err := webhookutils.ValidateImmutable(
@@ -129,6 +164,10 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, c.validateApplicationSecurityGroupsUpdate(old)...)
+
+	allErrs = append(allErrs, c.validatePublicIPPrefixesUpdate(old)...)
+
 	allErrs = append(allErrs, c.validateSubnetUpdate(old)...)
 
 	if len(allErrs) == 0 {
@@ -183,6 +222,101 @@ func (c *AzureCluster) validateSubnetUpdate(old *AzureCluster) field.ErrorList {
 		}
 	}
 
+	return append(allErrs, c.validatePrivateEndpointsUpdate(old)...)
+}
+
+// validatePrivateEndpointsUpdate validates that the existing private endpoints of a cluster are not moved to another subnet
+// and keep their custom network interface name, as both are immutable in Azure.
+func (c *AzureCluster) validatePrivateEndpointsUpdate(old *AzureCluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	oldSubnetNames := make(map[string]string)
+	oldPrivateEndpoints := make(map[string]PrivateEndpointSpec)
+	for _, subnet := range old.Spec.NetworkSpec.Subnets {
+		for _, privateEndpoint := range subnet.PrivateEndpoints {
+			oldSubnetNames[privateEndpoint.Name] = subnet.Name
+			oldPrivateEndpoints[privateEndpoint.Name] = privateEndpoint
+		}
+	}
+
+	for i, subnet := range c.Spec.NetworkSpec.Subnets {
+		for j, privateEndpoint := range subnet.PrivateEndpoints {
+			oldPrivateEndpoint, ok := oldPrivateEndpoints[privateEndpoint.Name]
+			if !ok {
+				continue
+			}
+			fldPath := field.NewPath("spec", "networkSpec", "subnets").Index(i).Child("privateEndpoints").Index(j)
+			if oldSubnetNames[privateEndpoint.Name] != subnet.Name {
+				allErrs = append(allErrs,
+					field.Forbidden(fldPath, fmt.Sprintf("private endpoint %s cannot be moved from subnet %s to another subnet", privateEndpoint.Name, oldSubnetNames[privateEndpoint.Name])),
+				)
+			}
+			if privateEndpoint.CustomNetworkInterfaceName != oldPrivateEndpoint.CustomNetworkInterfaceName {
+				allErrs = append(allErrs,
+					field.Invalid(fldPath.Child("customNetworkInterfaceName"), privateEndpoint.CustomNetworkInterfaceName, "field is immutable"),
+				)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// as the network interfaces of the machines that joined an application security group are not removed from it.
+// as the network interfaces of the machines are made members of the application security groups when they are created.
+func (c *AzureCluster) validateApplicationSecurityGroupsUpdate(old *AzureCluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	asgs := make(map[string]ApplicationSecurityGroup, len(c.Spec.NetworkSpec.ApplicationSecurityGroups))
+	for _, asg := range c.Spec.NetworkSpec.ApplicationSecurityGroups {
+		asgs[asg.Name] = asg
+	}
+	for i, oldASG := range old.Spec.NetworkSpec.ApplicationSecurityGroups {
+		asg, ok := asgs[oldASG.Name]
+		if !ok {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec", "networkSpec", "applicationSecurityGroups").Index(i),
+					"application security groups cannot be removed from a cluster"),
+			)
+			continue
+		}
+		if asg.Role != oldASG.Role {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "applicationSecurityGroups").Index(i).Child("role"),
+					asg.Role, "field is immutable"),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+// validatePublicIPPrefixesUpdate validates that the existing public IP prefixes of a cluster are neither modified nor removed,
+// as Azure does not support updating a public IP prefix and the public IP addresses of the cluster may be allocated from it.
+func (c *AzureCluster) validatePublicIPPrefixesUpdate(old *AzureCluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	prefixes := make(map[string]PublicIPPrefix, len(c.Spec.NetworkSpec.PublicIPPrefixes))
+	for _, prefix := range c.Spec.NetworkSpec.PublicIPPrefixes {
+		prefixes[prefix.Name] = prefix
+	}
+	for i, oldPrefix := range old.Spec.NetworkSpec.PublicIPPrefixes {
+		prefix, ok := prefixes[oldPrefix.Name]
+		if !ok {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec", "networkSpec", "publicIPPrefixes").Index(i),
+					"public IP prefixes cannot be removed from a cluster"),
+			)
+			continue
+		}
+		if !reflect.DeepEqual(prefix, oldPrefix) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "publicIPPrefixes").Index(i),
+					prefix, "field is immutable"),
+			)
+		}
+	}
+
 	return allErrs
 }
 
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -306,6 +307,287 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "azure bastion can be upgraded to the Standard SKU with tunneling",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "my-bastion", Sku: BasicBastionHostSku}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{
+					Name:            "my-bastion",
+					Sku:             StandardBastionHostSku,
+					ScaleUnits:      pointer.Int32Ptr(4),
+					EnableTunneling: true,
+				}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "azure bastion cannot be downgraded to the Basic SKU",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "my-bastion", Sku: StandardBastionHostSku}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "my-bastion", Sku: BasicBastionHostSku}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azure bastion name is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "my-bastion"}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "my-other-bastion"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "private DNS zone group of a private endpoint can be changed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].PrivateEndpoints = PrivateEndpoints{createValidPrivateEndpoint()}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				privateEndpoint := createValidPrivateEndpoint()
+				privateEndpoint.PrivateDNSZoneGroup = &PrivateDNSZoneGroup{
+					Name:              "default",
+					PrivateDNSZoneIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"},
+				}
+				cluster.Spec.NetworkSpec.Subnets[1].PrivateEndpoints = PrivateEndpoints{privateEndpoint}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "private link service of a private endpoint can be changed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].PrivateEndpoints = PrivateEndpoints{createValidPrivateEndpoint()}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				privateEndpoint := createValidPrivateEndpoint()
+				privateEndpoint.PrivateLinkServiceID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/otherstorage"
+				privateEndpoint.ManualApproval = true
+				cluster.Spec.NetworkSpec.Subnets[1].PrivateEndpoints = PrivateEndpoints{privateEndpoint}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "custom network interface name of a private endpoint is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].PrivateEndpoints = PrivateEndpoints{createValidPrivateEndpoint()}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				privateEndpoint := createValidPrivateEndpoint()
+				privateEndpoint.CustomNetworkInterfaceName = "my-pe-nic"
+				cluster.Spec.NetworkSpec.Subnets[1].PrivateEndpoints = PrivateEndpoints{privateEndpoint}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "private endpoint cannot be moved to another subnet",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].PrivateEndpoints = PrivateEndpoints{createValidPrivateEndpoint()}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[0].PrivateEndpoints = PrivateEndpoints{createValidPrivateEndpoint()}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "private endpoint can be removed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].PrivateEndpoints = PrivateEndpoints{createValidPrivateEndpoint()}
+				return cluster
+			}(),
+			cluster: createValidCluster(),
+			wantErr: false,
+		},
+		{
+			name: "application security groups can be added",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.ApplicationSecurityGroups = ApplicationSecurityGroups{
+					{Name: "control-plane-asg", Role: SubnetControlPlane},
+				}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.ApplicationSecurityGroups = ApplicationSecurityGroups{
+					{Name: "control-plane-asg", Role: SubnetControlPlane},
+					{Name: "node-asg", Role: SubnetNode},
+				}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "application security groups cannot be removed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.ApplicationSecurityGroups = ApplicationSecurityGroups{
+					{Name: "control-plane-asg", Role: SubnetControlPlane},
+				}
+				return cluster
+			}(),
+			cluster: createValidCluster(),
+			wantErr: true,
+		},
+		{
+			name: "application security group role is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.ApplicationSecurityGroups = ApplicationSecurityGroups{
+					{Name: "my-asg", Role: SubnetControlPlane},
+				}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.ApplicationSecurityGroups = ApplicationSecurityGroups{
+					{Name: "my-asg", Role: SubnetNode},
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "public IP prefixes can be added",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.PublicIPPrefixes = PublicIPPrefixes{
+					{Name: "my-prefix", PrefixLength: 28},
+				}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "public IP prefixes cannot be removed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.PublicIPPrefixes = PublicIPPrefixes{
+					{Name: "my-prefix", PrefixLength: 28},
+				}
+				return cluster
+			}(),
+			cluster: createValidCluster(),
+			wantErr: true,
+		},
+		{
+			name: "public IP prefix is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.PublicIPPrefixes = PublicIPPrefixes{
+					{Name: "my-prefix", PrefixLength: 28},
+				}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.PublicIPPrefixes = PublicIPPrefixes{
+					{Name: "my-prefix", PrefixLength: 29},
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "private DNS zone records and TTL can be updated",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerLB = createValidAPIServerInternalLB()
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.10.0.0/16"}
+				cluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks = []string{"10.10.1.0/24"}
+				cluster.Spec.NetworkSpec.PrivateDNSZone = &PrivateDNSZoneClassSpec{ResourceGroup: "dns-rg"}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerLB = createValidAPIServerInternalLB()
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.10.0.0/16"}
+				cluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks = []string{"10.10.1.0/24"}
+				cluster.Spec.NetworkSpec.PrivateDNSZone = &PrivateDNSZoneClassSpec{
+					ResourceGroup: "dns-rg",
+					TTL:           pointer.Int64(60),
+					Records: []PrivateDNSRecord{
+						{Name: "etcd", Type: PrivateDNSRecordTypeCNAME, CNAME: "apiserver.example.com"},
+					},
+				}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "private DNS zone resource group is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerLB = createValidAPIServerInternalLB()
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerLB = createValidAPIServerInternalLB()
+				cluster.Spec.NetworkSpec.PrivateDNSZone = &PrivateDNSZoneClassSpec{ResourceGroup: "dns-rg"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "private DNS zone subscription is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerLB = createValidAPIServerInternalLB()
+				cluster.Spec.NetworkSpec.PrivateDNSZone = &PrivateDNSZoneClassSpec{ResourceGroup: "dns-rg"}
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.APIServerLB = createValidAPIServerInternalLB()
+				cluster.Spec.NetworkSpec.PrivateDNSZone = &PrivateDNSZoneClassSpec{ResourceGroup: "dns-rg", SubscriptionID: "456"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azure bastion cannot be removed",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &AzureBastion{Name: "my-bastion"}
+				return cluster
+			}(),
+			cluster: createValidCluster(),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -320,3 +602,11 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func createValidPrivateEndpoint() PrivateEndpointSpec {
+	return PrivateEndpointSpec{
+		Name:                 "my-storage-pe",
+		PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+		GroupIDs:             []string{"blob"},
+	}
+}
@@ -45,6 +45,10 @@ func (c *AzureClusterTemplate) validateClusterTemplateSpec() field.ErrorList {
 		field.NewPath("spec").Child("template").Child("spec").
 			Child("networkSpec").Child("vnet").Child("cidrBlocks"))...)
 
+	allErrs = append(allErrs, validateVnetClassSpec(
+		c.Spec.Template.Spec.NetworkSpec.Vnet.VnetClassSpec,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("vnet"))...)
+
 	allErrs = append(allErrs, validateSubnetTemplates(
 		c.Spec.Template.Spec.NetworkSpec.Subnets,
 		c.Spec.Template.Spec.NetworkSpec.Vnet,
@@ -55,8 +59,42 @@ func (c *AzureClusterTemplate) validateClusterTemplateSpec() field.ErrorList {
 		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("apiServerLB"),
 	)...)
 
-	var oneSubnetWithoutNatGateway bool
 	networkSpec := c.Spec.Template.Spec.NetworkSpec
+	allErrs = append(allErrs, validateApplicationSecurityGroups(
+		networkSpec.ApplicationSecurityGroups,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("applicationSecurityGroups"),
+	)...)
+	for i, subnet := range networkSpec.Subnets {
+		allErrs = append(allErrs, validateSecurityRuleApplicationSecurityGroups(
+			subnet.SecurityGroup.SecurityRules,
+			networkSpec.ApplicationSecurityGroups,
+			field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("subnets").Index(i).Child("securityGroup").Child("securityRules"),
+		)...)
+	}
+
+	allErrs = append(allErrs, validatePublicIPPrefixes(
+		networkSpec.PublicIPPrefixes,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("publicIPPrefixes"),
+	)...)
+	outboundLBs := []struct {
+		name string
+		lb   *LoadBalancerClassSpec
+	}{
+		{"nodeOutboundLB", networkSpec.NodeOutboundLB},
+		{"controlPlaneOutboundLB", networkSpec.ControlPlaneOutboundLB},
+	}
+	for _, outboundLB := range outboundLBs {
+		if outboundLB.lb != nil && outboundLB.lb.FrontendPublicIP != nil {
+			allErrs = append(allErrs, validatePublicIP(
+				*outboundLB.lb.FrontendPublicIP,
+				networkSpec.PublicIPPrefixes,
+				true,
+				field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child(outboundLB.name).Child("frontendPublicIP"),
+			)...)
+		}
+	}
+
+	var oneSubnetWithoutNatGateway bool
 	for _, subnet := range networkSpec.Subnets {
 		if subnet.Role == SubnetNode && !subnet.IsNatGatewayEnabled() {
 			oneSubnetWithoutNatGateway = true
@@ -71,6 +109,12 @@ func (c *AzureClusterTemplate) validateClusterTemplateSpec() field.ErrorList {
 
 	allErrs = append(allErrs, c.validatePrivateDNSZoneName()...)
 
+	allErrs = append(allErrs, validatePrivateDNSZone(
+		networkSpec.PrivateDNSZone,
+		networkSpec.APIServerLB.Type,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("privateDNSZone"),
+	)...)
+
 	return allErrs
 }
 
@@ -96,12 +140,11 @@ func validateSubnetTemplates(subnets SubnetTemplatesSpec, vnet VnetTemplateSpec,
 			}
 		}
 		for j, rule := range subnet.SecurityGroup.SecurityRules {
-			if err := validateSecurityRule(
+			allErrs = append(allErrs, validateSecurityRule(
 				rule,
+				nil,
 				fld.Index(i).Child("securityGroup").Child("securityGroup").Child("securityRules").Index(j),
-			); err != nil {
-				allErrs = append(allErrs, err)
-			}
+			)...)
 		}
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fld.Index(i).Child("cidrBlocks"))...)
 	}
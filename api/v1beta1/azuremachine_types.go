@@ -123,9 +123,59 @@ type AzureMachineSpec struct {
 	// +optional
 	DNSServers []string `json:"dnsServers,omitempty"`
 
+	// NetworkInterfaces specifies a list of network interfaces attached to the VM. The first network interface is the
+	// primary one. If empty, a single network interface is created from SubnetName, AcceleratedNetworking,
+	// EnableIPForwarding and AllocatePublicIP, which must not be set when NetworkInterfaces is specified.
+	// +optional
+	NetworkInterfaces []NetworkInterface `json:"networkInterfaces,omitempty"`
+
 	// VMExtensions specifies a list of extensions to be added to the virtual machine.
 	// +optional
 	VMExtensions []VMExtension `json:"vmExtensions,omitempty"`
+
+	// Diagnostics specifies the diagnostics settings of the virtual machine.
+	// If omitted, boot diagnostics are stored in a storage account managed by Azure.
+	// +optional
+	Diagnostics *Diagnostics `json:"diagnostics,omitempty"`
+
+	// CapacityReservationGroupID is the Azure resource ID of the capacity reservation group the VM is allocated from,
+	// which guarantees capacity for the VM size. It cannot be combined with a Spot VM, a dedicated host group,
+	// a proximity placement group or ultra disks.
+	// +optional
+	CapacityReservationGroupID string `json:"capacityReservationGroupID,omitempty"`
+
+	// DedicatedHostGroupID is the Azure resource ID of the dedicated host group the VM is placed on. The host group must
+	// support automatic placement. It cannot be combined with a Spot VM.
+	// +optional
+	DedicatedHostGroupID string `json:"dedicatedHostGroupID,omitempty"`
+
+	// ProximityPlacementGroupID is the Azure resource ID of the proximity placement group the VM is placed in.
+	// +optional
+	ProximityPlacementGroupID string `json:"proximityPlacementGroupID,omitempty"`
+}
+
+// NetworkInterface defines a network interface of a VM.
+type NetworkInterface struct {
+	// SubnetName is the name of the subnet of the network interface.
+	SubnetName string `json:"subnetName"`
+
+	// AcceleratedNetworking enables or disables Azure accelerated networking on the network interface. If omitted, it
+	// will be set based on whether the requested VMSize supports accelerated networking.
+	// +optional
+	AcceleratedNetworking *bool `json:"acceleratedNetworking,omitempty"`
+
+	// EnableIPForwarding enables IP forwarding on the network interface. Default is false for disabled.
+	// +optional
+	EnableIPForwarding bool `json:"enableIPForwarding,omitempty"`
+
+	// PrivateIPAddress is the static private IP address of the network interface.
+	// If omitted, the private IP address is dynamically allocated from the subnet.
+	// +optional
+	PrivateIPAddress string `json:"privateIPAddress,omitempty"`
+
+	// AllocatePublicIP allocates a dynamic public IP to the network interface.
+	// +optional
+	AllocatePublicIP bool `json:"allocatePublicIP,omitempty"`
 }
 
 // SpotVMOptions defines the options relevant to running the Machine on Spot VMs.
@@ -19,11 +19,16 @@ package v1beta1
 import (
 	"encoding/base64"
 	"fmt"
+	"net"
+	"net/url"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/ssh"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 )
 
 // ValidateAzureMachineSpec check for validation errors of azuremachine.spec.
@@ -50,6 +55,22 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateNetworkInterfaces(spec, field.NewPath("networkInterfaces")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateDiagnostics(spec.Diagnostics, field.NewPath("diagnostics")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidatePlacement(spec.CapacityReservationGroupID, spec.DedicatedHostGroupID, spec.ProximityPlacementGroupID, spec.SpotVMOptions, spec.DataDisks); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateSecurityProfile(spec.SecurityProfile, spec.OSDisk, spec.DataDisks); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	return allErrs
 }
 
@@ -144,6 +165,47 @@ func ValidateDataDisks(dataDisks []DataDisk, fieldPath *field.Path) field.ErrorL
 	return allErrs
 }
 
+// ValidateNetworkInterfaces validates the network interfaces of an AzureMachineSpec.
+func ValidateNetworkInterfaces(spec AzureMachineSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(spec.NetworkInterfaces) == 0 {
+		return allErrs
+	}
+
+	// The single network interface fields of the spec are replaced by the network interfaces.
+	if spec.SubnetName != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("subnetName"), "cannot be set when networkInterfaces is specified"))
+	}
+	if spec.AcceleratedNetworking != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("acceleratedNetworking"), "cannot be set when networkInterfaces is specified"))
+	}
+	if spec.EnableIPForwarding {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("enableIPForwarding"), "cannot be set when networkInterfaces is specified"))
+	}
+	if spec.AllocatePublicIP {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("allocatePublicIP"), "cannot be set when networkInterfaces is specified"))
+	}
+
+	privateIPSet := make(map[string]struct{})
+	for i, nic := range spec.NetworkInterfaces {
+		if nic.SubnetName == "" {
+			allErrs = append(allErrs, field.Required(fieldPath.Index(i).Child("subnetName"), "the subnet name cannot be empty"))
+		}
+
+		if nic.PrivateIPAddress == "" {
+			continue
+		}
+		if net.ParseIP(nic.PrivateIPAddress) == nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("privateIPAddress"), nic.PrivateIPAddress, "must be a valid IP address"))
+		} else if _, ok := privateIPSet[nic.PrivateIPAddress]; ok {
+			allErrs = append(allErrs, field.Duplicate(fieldPath.Index(i).Child("privateIPAddress"), nic.PrivateIPAddress))
+		} else {
+			privateIPSet[nic.PrivateIPAddress] = struct{}{}
+		}
+	}
+	return allErrs
+}
+
 // ValidateOSDisk validates the OSDisk spec.
 func ValidateOSDisk(osDisk OSDisk, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -188,16 +250,26 @@ func validateManagedDisk(m *ManagedDiskParameters, fieldPath *field.Path, isOSDi
 	return allErrs
 }
 
-// ValidateDataDisksUpdate validates updates to Data disks.
+// ValidateDataDisksUpdate validates updates to Data disks. Data disks can be added and grown after machine creation,
+// but not removed, shrunk or otherwise modified.
 func ValidateDataDisksUpdate(oldDataDisks, newDataDisks []DataDisk, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	diskErrMsg := "adding/removing data disks after machine creation is not allowed"
+	diskErrMsg := "removing data disks after machine creation is not allowed"
+	sizeErrMsg := "shrinking data disks after machine creation is not allowed"
 	fieldErrMsg := "modifying data disk's fields after machine creation is not allowed"
 
-	if len(oldDataDisks) != len(newDataDisks) {
-		allErrs = append(allErrs, field.Invalid(fieldPath, newDataDisks, diskErrMsg))
-		return allErrs
+	newDisks := make(map[string]DataDisk)
+
+	for _, disk := range newDataDisks {
+		newDisks[disk.NameSuffix] = disk
+	}
+
+	for _, oldDisk := range oldDataDisks {
+		if _, ok := newDisks[oldDisk.NameSuffix]; !ok {
+			allErrs = append(allErrs, field.Invalid(fieldPath, newDataDisks, diskErrMsg))
+			return allErrs
+		}
 	}
 
 	oldDisks := make(map[string]DataDisk)
@@ -208,8 +280,8 @@ func ValidateDataDisksUpdate(oldDataDisks, newDataDisks []DataDisk, fieldPath *f
 
 	for i, newDisk := range newDataDisks {
 		if oldDisk, ok := oldDisks[newDisk.NameSuffix]; ok {
-			if newDisk.DiskSizeGB != oldDisk.DiskSizeGB {
-				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("diskSizeGB"), newDataDisks, fieldErrMsg))
+			if newDisk.DiskSizeGB < oldDisk.DiskSizeGB {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("diskSizeGB"), newDataDisks, sizeErrMsg))
 			}
 
 			allErrs = append(allErrs, validateManagedDisksUpdate(oldDisk.ManagedDisk, newDisk.ManagedDisk, fieldPath.Index(i).Child("managedDisk"))...)
@@ -223,8 +295,6 @@ func ValidateDataDisksUpdate(oldDataDisks, newDataDisks []DataDisk, fieldPath *f
 			if newDisk.CachingType != oldDisk.CachingType {
 				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("cachingType"), newDataDisks, fieldErrMsg))
 			}
-		} else {
-			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("nameSuffix"), newDataDisks, diskErrMsg))
 		}
 	}
 
@@ -293,3 +363,162 @@ func validateCachingType(cachingType string, fieldPath *field.Path, managedDisk
 	allErrs = append(allErrs, field.Invalid(cachingTypeChildPath, cachingType, fmt.Sprintf("allowed values are %v", compute.PossibleCachingTypesValues())))
 	return allErrs
 }
+
+// ValidateDiagnostics validates the diagnostics settings of a virtual machine.
+func ValidateDiagnostics(diagnostics *Diagnostics, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if diagnostics == nil || diagnostics.Boot == nil {
+		return allErrs
+	}
+
+	bootPath := fieldPath.Child("boot")
+	boot := diagnostics.Boot
+	switch boot.StorageAccountType {
+	case UserManagedDiagnosticsStorage:
+		if boot.UserManaged == nil {
+			allErrs = append(allErrs, field.Required(bootPath.Child("userManaged"),
+				fmt.Sprintf("userManaged must be specified when storageAccountType is '%s'", UserManagedDiagnosticsStorage)))
+			break
+		}
+		uri, err := url.Parse(boot.UserManaged.StorageAccountURI)
+		if err != nil || uri.Scheme != "https" || uri.Host == "" {
+			allErrs = append(allErrs, field.Invalid(bootPath.Child("userManaged", "storageAccountURI"), boot.UserManaged.StorageAccountURI,
+				"storageAccountURI must be the https URI of the blob endpoint of a storage account"))
+		}
+	case ManagedDiagnosticsStorage, DisabledDiagnosticsStorage:
+		if boot.UserManaged != nil {
+			allErrs = append(allErrs, field.Forbidden(bootPath.Child("userManaged"),
+				fmt.Sprintf("userManaged must not be specified when storageAccountType is '%s'", boot.StorageAccountType)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(bootPath.Child("storageAccountType"), boot.StorageAccountType,
+			[]string{string(ManagedDiagnosticsStorage), string(UserManagedDiagnosticsStorage), string(DisabledDiagnosticsStorage)}))
+	}
+
+	return allErrs
+}
+
+// ValidatePlacement validates the capacity reservation group, dedicated host group and proximity placement group of a
+// virtual machine, and that they are compatible with each other and with the Spot VM options and data disks.
+func ValidatePlacement(capacityReservationGroupID, dedicatedHostGroupID, proximityPlacementGroupID string, spotVMOptions *SpotVMOptions, dataDisks []DataDisk) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateComputeResourceID(capacityReservationGroupID, "capacityReservationGroups", "capacity reservation group", field.NewPath("capacityReservationGroupID"))...)
+	allErrs = append(allErrs, validateComputeResourceID(dedicatedHostGroupID, "hostGroups", "dedicated host group", field.NewPath("dedicatedHostGroupID"))...)
+	allErrs = append(allErrs, validateComputeResourceID(proximityPlacementGroupID, "proximityPlacementGroups", "proximity placement group", field.NewPath("proximityPlacementGroupID"))...)
+
+	if capacityReservationGroupID != "" {
+		fieldPath := field.NewPath("capacityReservationGroupID")
+		if spotVMOptions != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "capacity reservations are not supported for Spot VMs"))
+		}
+		if dedicatedHostGroupID != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "capacity reservations are not supported for VMs on dedicated hosts"))
+		}
+		if proximityPlacementGroupID != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "capacity reservations are not supported for VMs in a proximity placement group"))
+		}
+		for _, disk := range dataDisks {
+			if disk.ManagedDisk != nil && disk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) {
+				allErrs = append(allErrs, field.Forbidden(fieldPath, "capacity reservations are not supported for VMs with ultra disks"))
+				break
+			}
+		}
+	}
+
+	if dedicatedHostGroupID != "" && spotVMOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("dedicatedHostGroupID"), "dedicated hosts are not supported for Spot VMs"))
+	}
+
+	return allErrs
+}
+
+// ValidateSecurityProfile validates the security profile of a virtual machine, and that the security settings of its
+// managed disks are consistent with the security type.
+func ValidateSecurityProfile(securityProfile *SecurityProfile, osDisk OSDisk, dataDisks []DataDisk) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fieldPath := field.NewPath("securityProfile")
+	var securityType SecurityTypes
+	var uefiSettings *UefiSettings
+	var secureBootEnabled, vTpmEnabled, encryptionAtHost bool
+	if securityProfile != nil {
+		securityType = securityProfile.SecurityType
+		uefiSettings = securityProfile.UefiSettings
+		encryptionAtHost = pointer.BoolDeref(securityProfile.EncryptionAtHost, false)
+	}
+	if uefiSettings != nil {
+		secureBootEnabled = pointer.BoolDeref(uefiSettings.SecureBootEnabled, false)
+		vTpmEnabled = pointer.BoolDeref(uefiSettings.VTpmEnabled, false)
+	}
+
+	if uefiSettings != nil && securityType == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("securityType"), "securityType must be specified when uefiSettings is specified"))
+	}
+
+	var diskSecurityProfile *VMDiskSecurityProfile
+	if osDisk.ManagedDisk != nil {
+		diskSecurityProfile = osDisk.ManagedDisk.SecurityProfile
+	}
+	diskSecurityPath := field.NewPath("osDisk", "managedDisk", "securityProfile")
+
+	if securityType == SecurityTypesConfidentialVM {
+		if !vTpmEnabled {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "vTpmEnabled"), vTpmEnabled,
+				fmt.Sprintf("vTPM must be enabled when securityType is '%s'", SecurityTypesConfidentialVM)))
+		}
+		if diskSecurityProfile == nil || diskSecurityProfile.SecurityEncryptionType == "" {
+			allErrs = append(allErrs, field.Required(diskSecurityPath.Child("securityEncryptionType"),
+				fmt.Sprintf("securityEncryptionType must be specified when securityType is '%s'", SecurityTypesConfidentialVM)))
+		}
+	}
+
+	if diskSecurityProfile != nil {
+		if securityType != SecurityTypesConfidentialVM {
+			allErrs = append(allErrs, field.Forbidden(diskSecurityPath,
+				fmt.Sprintf("the OS disk security profile is only supported when securityType is '%s'", SecurityTypesConfidentialVM)))
+		}
+		if diskSecurityProfile.SecurityEncryptionType == SecurityEncryptionTypeDiskWithVMGuestState {
+			if !secureBootEnabled {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "secureBootEnabled"), secureBootEnabled,
+					fmt.Sprintf("secure boot must be enabled when securityEncryptionType is '%s'", SecurityEncryptionTypeDiskWithVMGuestState)))
+			}
+			if encryptionAtHost {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("encryptionAtHost"),
+					fmt.Sprintf("encryption at host is not supported when securityEncryptionType is '%s'", SecurityEncryptionTypeDiskWithVMGuestState)))
+			}
+		} else if diskSecurityProfile.DiskEncryptionSet != nil {
+			allErrs = append(allErrs, field.Forbidden(diskSecurityPath.Child("diskEncryptionSet"),
+				fmt.Sprintf("diskEncryptionSet is only supported when securityEncryptionType is '%s'", SecurityEncryptionTypeDiskWithVMGuestState)))
+		}
+		if osDisk.DiffDiskSettings != nil {
+			allErrs = append(allErrs, field.Forbidden(diskSecurityPath, "the OS disk security profile is not supported for ephemeral OS disks"))
+		}
+	}
+
+	for i, disk := range dataDisks {
+		if disk.ManagedDisk != nil && disk.ManagedDisk.SecurityProfile != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("dataDisks").Index(i).Child("managedDisk", "securityProfile"),
+				"the security profile is only supported on the OS disk"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateComputeResourceID validates that an optional ID is the Azure resource ID of a Microsoft.Compute resource of the given type.
+func validateComputeResourceID(id, resourceType, description string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if id == "" {
+		return allErrs
+	}
+
+	resource, err := azureautorest.ParseResourceID(id)
+	if err != nil || !strings.EqualFold(resource.Provider, "Microsoft.Compute") || !strings.EqualFold(resource.ResourceType, resourceType) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, id, fmt.Sprintf("must be the Azure resource ID of a %s", description)))
+	}
+
+	return allErrs
+}
@@ -444,6 +444,426 @@ func TestAzureMachine_ValidateDataDisks(t *testing.T) {
 	}
 }
 
+func TestAzureMachine_ValidateNetworkInterfaces(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name    string
+		spec    AzureMachineSpec
+		wantErr bool
+	}{
+		{
+			name:    "valid single network interface",
+			spec:    AzureMachineSpec{SubnetName: "node-subnet", AllocatePublicIP: true},
+			wantErr: false,
+		},
+		{
+			name: "valid network interfaces",
+			spec: AzureMachineSpec{
+				NetworkInterfaces: []NetworkInterface{
+					{SubnetName: "node-subnet", AcceleratedNetworking: to.BoolPtr(true), AllocatePublicIP: true},
+					{SubnetName: "replication-subnet", EnableIPForwarding: true, PrivateIPAddress: "10.1.0.4"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid network interfaces with subnet name",
+			spec: AzureMachineSpec{
+				SubnetName:        "node-subnet",
+				NetworkInterfaces: []NetworkInterface{{SubnetName: "node-subnet"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid network interfaces with public IP",
+			spec: AzureMachineSpec{
+				AllocatePublicIP:  true,
+				NetworkInterfaces: []NetworkInterface{{SubnetName: "node-subnet"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid network interface without subnet name",
+			spec: AzureMachineSpec{
+				NetworkInterfaces: []NetworkInterface{{SubnetName: "node-subnet"}, {}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid network interface private IP address",
+			spec: AzureMachineSpec{
+				NetworkInterfaces: []NetworkInterface{{SubnetName: "node-subnet", PrivateIPAddress: "10.1.0"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid duplicate network interface private IP address",
+			spec: AzureMachineSpec{
+				NetworkInterfaces: []NetworkInterface{
+					{SubnetName: "node-subnet", PrivateIPAddress: "10.1.0.4"},
+					{SubnetName: "replication-subnet", PrivateIPAddress: "10.1.0.4"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateNetworkInterfaces(test.spec, field.NewPath("networkInterfaces"))
+			if test.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateDiagnostics(t *testing.T) {
+	g := NewWithT(t)
+
+	testcases := []struct {
+		name        string
+		diagnostics *Diagnostics
+		wantErr     bool
+	}{
+		{
+			name:        "valid nil diagnostics",
+			diagnostics: nil,
+			wantErr:     false,
+		},
+		{
+			name:        "valid managed boot diagnostics",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: ManagedDiagnosticsStorage}},
+			wantErr:     false,
+		},
+		{
+			name:        "valid disabled boot diagnostics",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: DisabledDiagnosticsStorage}},
+			wantErr:     false,
+		},
+		{
+			name: "valid user-managed boot diagnostics",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{
+				StorageAccountType: UserManagedDiagnosticsStorage,
+				UserManaged:        &UserManagedBootDiagnostics{StorageAccountURI: "https://fake.blob.core.windows.net/"},
+			}},
+			wantErr: false,
+		},
+		{
+			name:        "invalid user-managed boot diagnostics without storage account",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: UserManagedDiagnosticsStorage}},
+			wantErr:     true,
+		},
+		{
+			name: "invalid user-managed boot diagnostics with http storage account URI",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{
+				StorageAccountType: UserManagedDiagnosticsStorage,
+				UserManaged:        &UserManagedBootDiagnostics{StorageAccountURI: "http://fake.blob.core.windows.net/"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid managed boot diagnostics with storage account",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{
+				StorageAccountType: ManagedDiagnosticsStorage,
+				UserManaged:        &UserManagedBootDiagnostics{StorageAccountURI: "https://fake.blob.core.windows.net/"},
+			}},
+			wantErr: true,
+		},
+		{
+			name:        "invalid boot diagnostics storage account type",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: "Unmanaged"}},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateDiagnostics(test.diagnostics, field.NewPath("diagnostics"))
+			if test.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidatePlacement(t *testing.T) {
+	g := NewWithT(t)
+
+	const (
+		capacityReservationGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg"
+		dedicatedHostGroupID       = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+		proximityPlacementGroupID  = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg"
+	)
+
+	testcases := []struct {
+		name                       string
+		capacityReservationGroupID string
+		dedicatedHostGroupID       string
+		proximityPlacementGroupID  string
+		spotVMOptions              *SpotVMOptions
+		dataDisks                  []DataDisk
+		wantErr                    bool
+	}{
+		{
+			name:    "valid without placement",
+			wantErr: false,
+		},
+		{
+			name:                       "valid capacity reservation group",
+			capacityReservationGroupID: capacityReservationGroupID,
+			wantErr:                    false,
+		},
+		{
+			name:                      "valid dedicated host group in a proximity placement group",
+			dedicatedHostGroupID:      dedicatedHostGroupID,
+			proximityPlacementGroupID: proximityPlacementGroupID,
+			wantErr:                   false,
+		},
+		{
+			name:                       "invalid capacity reservation group ID",
+			capacityReservationGroupID: "my-crg",
+			wantErr:                    true,
+		},
+		{
+			name:                 "invalid dedicated host group ID of another resource type",
+			dedicatedHostGroupID: proximityPlacementGroupID,
+			wantErr:              true,
+		},
+		{
+			name:                      "invalid proximity placement group ID of another resource provider",
+			proximityPlacementGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/proximityPlacementGroups/my-ppg",
+			wantErr:                   true,
+		},
+		{
+			name:                       "invalid capacity reservation group with a spot vm",
+			capacityReservationGroupID: capacityReservationGroupID,
+			spotVMOptions:              &SpotVMOptions{},
+			wantErr:                    true,
+		},
+		{
+			name:                       "invalid capacity reservation group with a dedicated host group",
+			capacityReservationGroupID: capacityReservationGroupID,
+			dedicatedHostGroupID:       dedicatedHostGroupID,
+			wantErr:                    true,
+		},
+		{
+			name:                       "invalid capacity reservation group with a proximity placement group",
+			capacityReservationGroupID: capacityReservationGroupID,
+			proximityPlacementGroupID:  proximityPlacementGroupID,
+			wantErr:                    true,
+		},
+		{
+			name:                       "invalid capacity reservation group with an ultra disk",
+			capacityReservationGroupID: capacityReservationGroupID,
+			dataDisks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					ManagedDisk: &ManagedDiskParameters{StorageAccountType: "UltraSSD_LRS"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:                 "invalid dedicated host group with a spot vm",
+			dedicatedHostGroupID: dedicatedHostGroupID,
+			spotVMOptions:        &SpotVMOptions{},
+			wantErr:              true,
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidatePlacement(test.capacityReservationGroupID, test.dedicatedHostGroupID, test.proximityPlacementGroupID, test.spotVMOptions, test.dataDisks)
+			if test.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateSecurityProfile(t *testing.T) {
+	g := NewWithT(t)
+
+	confidentialOSDisk := OSDisk{
+		OSType: "Linux",
+		ManagedDisk: &ManagedDiskParameters{
+			StorageAccountType: "Premium_LRS",
+			SecurityProfile: &VMDiskSecurityProfile{
+				SecurityEncryptionType: SecurityEncryptionTypeVMGuestStateOnly,
+			},
+		},
+	}
+	confidentialOSDiskWithGuestState := OSDisk{
+		OSType: "Linux",
+		ManagedDisk: &ManagedDiskParameters{
+			StorageAccountType: "Premium_LRS",
+			SecurityProfile: &VMDiskSecurityProfile{
+				SecurityEncryptionType: SecurityEncryptionTypeDiskWithVMGuestState,
+				DiskEncryptionSet:      &DiskEncryptionSetParameters{ID: "my-des"},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name            string
+		securityProfile *SecurityProfile
+		osDisk          OSDisk
+		dataDisks       []DataDisk
+		wantErr         bool
+	}{
+		{
+			name:    "valid without security profile",
+			osDisk:  generateValidOSDisk(),
+			wantErr: false,
+		},
+		{
+			name:            "valid encryption at host",
+			securityProfile: &SecurityProfile{EncryptionAtHost: to.BoolPtr(true)},
+			osDisk:          generateValidOSDisk(),
+			wantErr:         false,
+		},
+		{
+			name: "valid trusted launch",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesTrustedLaunch,
+				UefiSettings: &UefiSettings{SecureBootEnabled: to.BoolPtr(true), VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  generateValidOSDisk(),
+			wantErr: false,
+		},
+		{
+			name: "valid confidential vm",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDisk,
+			wantErr: false,
+		},
+		{
+			name: "valid confidential vm with disk and vm guest state encryption",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{SecureBootEnabled: to.BoolPtr(true), VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDiskWithGuestState,
+			wantErr: false,
+		},
+		{
+			name: "invalid uefi settings without security type",
+			securityProfile: &SecurityProfile{
+				UefiSettings: &UefiSettings{SecureBootEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  generateValidOSDisk(),
+			wantErr: true,
+		},
+		{
+			name: "invalid confidential vm without vTPM",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+			},
+			osDisk:  confidentialOSDisk,
+			wantErr: true,
+		},
+		{
+			name: "invalid confidential vm without OS disk security encryption type",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  generateValidOSDisk(),
+			wantErr: true,
+		},
+		{
+			name: "invalid OS disk security profile with trusted launch",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesTrustedLaunch,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDisk,
+			wantErr: true,
+		},
+		{
+			name: "invalid disk and vm guest state encryption without secure boot",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDiskWithGuestState,
+			wantErr: true,
+		},
+		{
+			name: "invalid disk and vm guest state encryption with encryption at host",
+			securityProfile: &SecurityProfile{
+				EncryptionAtHost: to.BoolPtr(true),
+				SecurityType:     SecurityTypesConfidentialVM,
+				UefiSettings:     &UefiSettings{SecureBootEnabled: to.BoolPtr(true), VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk:  confidentialOSDiskWithGuestState,
+			wantErr: true,
+		},
+		{
+			name: "invalid disk encryption set with vm guest state only encryption",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk: OSDisk{
+				OSType: "Linux",
+				ManagedDisk: &ManagedDiskParameters{
+					StorageAccountType: "Premium_LRS",
+					SecurityProfile: &VMDiskSecurityProfile{
+						SecurityEncryptionType: SecurityEncryptionTypeVMGuestStateOnly,
+						DiskEncryptionSet:      &DiskEncryptionSetParameters{ID: "my-des"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid data disk security profile",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{VTpmEnabled: to.BoolPtr(true)},
+			},
+			osDisk: confidentialOSDisk,
+			dataDisks: []DataDisk{
+				{
+					NameSuffix: "my_disk",
+					DiskSizeGB: 64,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						SecurityProfile: &VMDiskSecurityProfile{
+							SecurityEncryptionType: SecurityEncryptionTypeVMGuestStateOnly,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateSecurityProfile(test.securityProfile, test.osDisk, test.dataDisks)
+			if test.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestAzureMachine_ValidateSystemAssignedIdentity(t *testing.T) {
 	g := NewWithT(t)
 
@@ -612,7 +1032,7 @@ func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "data disks cannot be added after machine creation",
+			name: "data disks cannot be removed after machine creation",
 			disks: []DataDisk{
 				{
 					NameSuffix: "my_disk_1",
@@ -647,7 +1067,7 @@ func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "data disks cannot be removed after machine creation",
+			name: "data disks can be added after machine creation",
 			disks: []DataDisk{
 				{
 					NameSuffix: "my_disk_1",
@@ -679,6 +1099,58 @@ func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 					CachingType: string(compute.PossibleCachingTypesValues()[0]),
 				},
 			},
+			wantErr: false,
+		},
+		{
+			name: "data disks can be grown after machine creation",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 128,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			oldDisks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 64,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "data disks cannot be shrunk after machine creation",
+			disks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 64,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
+			oldDisks: []DataDisk{
+				{
+					NameSuffix: "my_disk_1",
+					DiskSizeGB: 128,
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Standard_LRS",
+					},
+					Lun:         to.Int32Ptr(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+				},
+			},
 			wantErr: true,
 		},
 	}
@@ -73,13 +73,6 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, err)
 	}
 
-	if err := webhookutils.ValidateImmutable(
-		field.NewPath("Spec", "UserAssignedIdentities"),
-		old.Spec.UserAssignedIdentities,
-		m.Spec.UserAssignedIdentities); err != nil {
-		allErrs = append(allErrs, err)
-	}
-
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "RoleAssignmentName"),
 		old.Spec.RoleAssignmentName,
@@ -94,12 +87,8 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, err)
 	}
 
-	if err := webhookutils.ValidateImmutable(
-		field.NewPath("Spec", "DataDisks"),
-		old.Spec.DataDisks,
-		m.Spec.DataDisks); err != nil {
-		allErrs = append(allErrs, err)
-	}
+	allErrs = append(allErrs, ValidateDataDisks(m.Spec.DataDisks, field.NewPath("Spec", "DataDisks"))...)
+	allErrs = append(allErrs, ValidateDataDisksUpdate(old.Spec.DataDisks, m.Spec.DataDisks, field.NewPath("Spec", "DataDisks"))...)
 
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "SSHPublicKey"),
@@ -129,6 +118,36 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object) error {
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkInterfaces"),
+		old.Spec.NetworkInterfaces,
+		m.Spec.NetworkInterfaces); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, ValidateDiagnostics(m.Spec.Diagnostics, field.NewPath("Spec", "Diagnostics"))...)
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "CapacityReservationGroupID"),
+		old.Spec.CapacityReservationGroupID,
+		m.Spec.CapacityReservationGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "DedicatedHostGroupID"),
+		old.Spec.DedicatedHostGroupID,
+		m.Spec.DedicatedHostGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "ProximityPlacementGroupID"),
+		old.Spec.ProximityPlacementGroupID,
+		m.Spec.ProximityPlacementGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "SpotVMOptions"),
 		old.Spec.SpotVMOptions,
@@ -119,6 +119,9 @@ func TestAzureMachine_ValidateCreate(t *testing.T) {
 func TestAzureMachine_ValidateUpdate(t *testing.T) {
 	g := NewWithT(t)
 
+	deallocatePolicy := SpotEvictionPolicyDeallocate
+	deletePolicy := SpotEvictionPolicyDelete
+
 	tests := []struct {
 		name       string
 		oldMachine *AzureMachine
@@ -190,7 +193,7 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "invalidTest: azuremachine.spec.UserAssignedIdentities is immutable",
+			name: "validTest: azuremachine.spec.UserAssignedIdentities is mutable",
 			oldMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
 					UserAssignedIdentities: []UserAssignedIdentity{
@@ -205,10 +208,10 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 					},
 				},
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "validTest: azuremachine.spec.UserAssignedIdentities is immutable",
+			name: "validTest: azuremachine.spec.UserAssignedIdentities is unchanged",
 			oldMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
 					UserAssignedIdentities: []UserAssignedIdentity{
@@ -290,12 +293,139 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "invalidTest: azuremachine.spec.DataDisks is immutable",
+			name: "invalidTest: azuremachine.spec.DataDisks cannot be shrunk",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
+						},
+					},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  64,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.DataDisks can be grown",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  64,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
+						},
+					},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "validTest: azuremachine.spec.DataDisks can be added",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
+						},
+					},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
+						},
+						{
+							NameSuffix:  "my_other_disk",
+							DiskSizeGB:  64,
+							Lun:         pointer.Int32(1),
+							CachingType: "None",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.DataDisks cannot be removed",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
+						},
+						{
+							NameSuffix:  "my_other_disk",
+							DiskSizeGB:  64,
+							Lun:         pointer.Int32(1),
+							CachingType: "None",
+						},
+					},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DataDisks: []DataDisk{
+						{
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: added azuremachine.spec.DataDisks must be valid",
 			oldMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
 					DataDisks: []DataDisk{
 						{
-							DiskSizeGB: 128,
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
 						},
 					},
 				},
@@ -304,7 +434,16 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 				Spec: AzureMachineSpec{
 					DataDisks: []DataDisk{
 						{
-							DiskSizeGB: 64,
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
+						},
+						{
+							NameSuffix:  "my_other_disk",
+							DiskSizeGB:  64,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
 						},
 					},
 				},
@@ -312,12 +451,15 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "validTest: azuremachine.spec.DataDisks is immutable",
+			name: "validTest: azuremachine.spec.DataDisks is unchanged",
 			oldMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
 					DataDisks: []DataDisk{
 						{
-							DiskSizeGB: 128,
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
 						},
 					},
 				},
@@ -326,7 +468,10 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 				Spec: AzureMachineSpec{
 					DataDisks: []DataDisk{
 						{
-							DiskSizeGB: 128,
+							NameSuffix:  "my_disk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "None",
 						},
 					},
 				},
@@ -446,37 +591,99 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "invalidTest: azuremachine.spec.SpotVMOptions is immutable",
+			name: "invalidTest: azuremachine.spec.NetworkInterfaces is immutable",
 			oldMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
-					SpotVMOptions: &SpotVMOptions{
-						MaxPrice: &resource.Quantity{Format: "vmoptions-0"},
-					},
+					NetworkInterfaces: []NetworkInterface{{SubnetName: "node-subnet"}},
 				},
 			},
 			newMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
-					SpotVMOptions: &SpotVMOptions{
-						MaxPrice: &resource.Quantity{Format: "vmoptions-1"},
-					},
+					NetworkInterfaces: []NetworkInterface{{SubnetName: "node-subnet"}, {SubnetName: "replication-subnet"}},
 				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "validTest: azuremachine.spec.SpotVMOptions is immutable",
+			name: "validTest: azuremachine.spec.NetworkInterfaces is immutable",
 			oldMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
-					SpotVMOptions: &SpotVMOptions{
-						MaxPrice: &resource.Quantity{Format: "vmoptions-1"},
-					},
+					NetworkInterfaces: []NetworkInterface{{SubnetName: "node-subnet"}, {SubnetName: "replication-subnet"}},
 				},
 			},
 			newMachine: &AzureMachine{
 				Spec: AzureMachineSpec{
-					SpotVMOptions: &SpotVMOptions{
-						MaxPrice: &resource.Quantity{Format: "vmoptions-1"},
-					},
+					NetworkInterfaces: []NetworkInterface{{SubnetName: "node-subnet"}, {SubnetName: "replication-subnet"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.SpotVMOptions.MaxPrice is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: &SpotVMOptions{MaxPrice: &resource.Quantity{Format: "vmoptions-0"}},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: &SpotVMOptions{MaxPrice: &resource.Quantity{Format: "vmoptions-1"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.SpotVMOptions.EvictionPolicy is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: &SpotVMOptions{EvictionPolicy: &deallocatePolicy},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: &SpotVMOptions{EvictionPolicy: &deletePolicy},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.SpotVMOptions cannot be removed",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: &SpotVMOptions{MaxPrice: &resource.Quantity{Format: "vmoptions-1"}},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: nil,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.SpotVMOptions cannot be added",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: nil,
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: &SpotVMOptions{MaxPrice: &resource.Quantity{Format: "vmoptions-1"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.SpotVMOptions is unchanged",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: &SpotVMOptions{MaxPrice: &resource.Quantity{Format: "vmoptions-1"}},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					SpotVMOptions: &SpotVMOptions{MaxPrice: &resource.Quantity{Format: "vmoptions-1"}},
 				},
 			},
 			wantErr: false,
@@ -509,6 +716,48 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalidTest: azuremachine.spec.CapacityReservationGroupID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-other-crg",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.DedicatedHostGroupID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DedicatedHostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					DedicatedHostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-other-host-group",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.ProximityPlacementGroupID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ProximityPlacementGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ProximityPlacementGroupID: "",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -638,3 +887,14 @@ func createMachineWithoutRoleAssignmentName() *AzureMachine {
 	}
 	return machine
 }
+
+func createMachineWithNetworkInterfaces(nics []NetworkInterface) *AzureMachine {
+	machine := &AzureMachine{
+		Spec: AzureMachineSpec{
+			SSHPublicKey:      validSSHPublicKey,
+			OSDisk:            validOSDisk,
+			NetworkInterfaces: nics,
+		},
+	}
+	return machine
+}
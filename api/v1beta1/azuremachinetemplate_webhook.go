@@ -34,6 +34,7 @@ import (
 const (
 	AzureMachineTemplateImmutableMsg          = "AzureMachineTemplate spec.template.spec field is immutable. Please create new resource instead. ref doc: https://cluster-api.sigs.k8s.io/tasks/updating-machine-templates.html"
 	AzureMachineTemplateRoleAssignmentNameMsg = "AzureMachineTemplate spec.template.spec.roleAssignmentName field can't be set"
+	AzureMachineTemplatePrivateIPAddressMsg   = "AzureMachineTemplate spec.template.spec.networkInterfaces privateIPAddress field can't be set"
 )
 
 // SetupWebhookWithManager sets up and registers the webhook with the manager.
@@ -64,6 +65,14 @@ func (r *AzureMachineTemplate) ValidateCreate(ctx context.Context, obj runtime.O
 		)
 	}
 
+	for i, nic := range spec.NetworkInterfaces {
+		if nic.PrivateIPAddress != "" {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "networkInterfaces").Index(i).Child("privateIPAddress"), t, AzureMachineTemplatePrivateIPAddressMsg),
+			)
+		}
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -138,6 +138,20 @@ func TestAzureMachineTemplate_ValidateCreate(t *testing.T) {
 			machineTemplate: createAzureMachineTemplateFromMachine(createMachineWithoutRoleAssignmentName()),
 			wantErr:         false,
 		},
+		{
+			name: "azuremachinetemplate with network interfaces",
+			machineTemplate: createAzureMachineTemplateFromMachine(
+				createMachineWithNetworkInterfaces([]NetworkInterface{{SubnetName: "node-subnet"}, {SubnetName: "replication-subnet"}}),
+			),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinetemplate with network interface private IP address",
+			machineTemplate: createAzureMachineTemplateFromMachine(
+				createMachineWithNetworkInterfaces([]NetworkInterface{{SubnetName: "node-subnet", PrivateIPAddress: "10.1.0.4"}}),
+			),
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -40,6 +40,10 @@ const (
 	VMDeletingReason = "VMDeleting"
 	// VMProvisionFailedReason used for failures during vm provisioning.
 	VMProvisionFailedReason = "VMProvisionFailed"
+	// SKURestrictedReason used when the VM size is not available in the location or zone for the subscription.
+	SKURestrictedReason = "SKURestricted"
+	// QuotaExceededReason used when creating the VM would exceed the vCPU quota of the subscription.
+	QuotaExceededReason = "QuotaExceeded"
 	// UserAssignedIdentityMissingReason used for failures when a user-assigned identity is missing.
 	UserAssignedIdentityMissingReason = "UserAssignedIdentityMissing"
 	// WaitingForClusterInfrastructureReason used when machine is waiting for cluster infrastructure to be ready before proceeding.
@@ -114,8 +118,16 @@ const (
 	PrivateDNSLinkReadyCondition clusterv1.ConditionType = "PrivateDNSLinkReady"
 	// PrivateDNSRecordReadyCondition means the private DNS records exist and are ready to be used.
 	PrivateDNSRecordReadyCondition clusterv1.ConditionType = "PrivateDNSRecordReady"
+	// PrivateEndpointsReadyCondition means the private endpoints exist and are ready to be used.
+	PrivateEndpointsReadyCondition clusterv1.ConditionType = "PrivateEndpointsReady"
+	// ApplicationSecurityGroupsReadyCondition means the application security groups exist and are ready to be used.
+	ApplicationSecurityGroupsReadyCondition clusterv1.ConditionType = "ApplicationSecurityGroupsReady"
+	// PublicIPPrefixesReadyCondition means the public IP prefixes exist and are ready to be used.
+	PublicIPPrefixesReadyCondition clusterv1.ConditionType = "PublicIPPrefixesReady"
 	// BastionHostReadyCondition means the bastion host exists and is ready to be used.
 	BastionHostReadyCondition clusterv1.ConditionType = "BastionHostReady"
+	// AzureFirewallReadyCondition means the Azure Firewall exists, is ready to be used and the egress traffic is routed through it.
+	AzureFirewallReadyCondition clusterv1.ConditionType = "AzureFirewallReady"
 	// InboundNATRulesReadyCondition means the inbound NAT rules exist and are ready to be used.
 	InboundNATRulesReadyCondition clusterv1.ConditionType = "InboundNATRulesReady"
 	// AvailabilitySetReadyCondition means the availability set exists and is ready to be used.
@@ -126,6 +138,12 @@ const (
 	DisksReadyCondition clusterv1.ConditionType = "DisksReady"
 	// NetworkInterfaceReadyCondition means the network interfaces exist and are ready to be used.
 	NetworkInterfaceReadyCondition clusterv1.ConditionType = "NetworkInterfacesReady"
+	// ResourcesInSyncCondition means the Azure resources match the spec. It is only set when the DriftDetection feature is enabled.
+	ResourcesInSyncCondition clusterv1.ConditionType = "ResourcesInSync"
+	// InfrastructureUpToDateCondition means no Azure operation is needed to reconcile the object. It is only set in dry-run mode.
+	InfrastructureUpToDateCondition clusterv1.ConditionType = "InfrastructureUpToDate"
+	// ThrottledCondition means Azure Resource Manager throttled the subscription and requests to it are paused.
+	ThrottledCondition clusterv1.ConditionType = "Throttled"
 
 	// CreatingReason means the resource is being created.
 	CreatingReason = "Creating"
@@ -139,4 +157,12 @@ const (
 	DeletionFailedReason = "DeletionFailed"
 	// UpdatingReason means the resource is being updated.
 	UpdatingReason = "Updating"
+	// DriftDetectedReason means some Azure resources were modified outside of the controller and differ from the spec.
+	DriftDetectedReason = "DriftDetected"
+	// ChangesPendingReason means Azure operations were planned in dry-run mode and are waiting to be performed.
+	ChangesPendingReason = "ChangesPending"
+	// PlannedReason means the operation on the resource was planned in dry-run mode but not performed.
+	PlannedReason = "Planned"
+	// SubscriptionThrottledReason means requests to the subscription are paused until its throttling window passes.
+	SubscriptionThrottledReason = "SubscriptionThrottled"
 )
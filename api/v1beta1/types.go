@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -86,6 +87,11 @@ type NetworkSpec struct {
 	// +optional
 	ControlPlaneOutboundLB *LoadBalancerSpec `json:"controlPlaneOutboundLB,omitempty"`
 
+	// Firewall is the configuration for the Azure Firewall the egress traffic of the control-plane and node subnets is routed through.
+	// It replaces the outbound load balancers and NAT gateways as the egress mode of the cluster.
+	// +optional
+	Firewall *AzureFirewall `json:"firewall,omitempty"`
+
 	NetworkClassSpec `json:",inline"`
 }
 
@@ -124,6 +130,50 @@ type VnetPeeringClassSpec struct {
 
 	// RemoteVnetName defines name of the remote virtual network.
 	RemoteVnetName string `json:"remoteVnetName"`
+
+	// SubscriptionID is the subscription ID of the remote virtual network.
+	// Defaults to the subscription ID of the AzureCluster.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// IdentityRef is a reference to an AzureClusterIdentity to be used when reconciling the peering from the remote
+	// virtual network to the AzureCluster's virtual network, e.g. when the remote virtual network is in another tenant.
+	// Defaults to the identity of the AzureCluster.
+	// +optional
+	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
+
+	// ForwardPeeringProperties specifies the properties of the peering from the AzureCluster's virtual network
+	// to the remote virtual network.
+	// +optional
+	ForwardPeeringProperties VnetPeeringProperties `json:"forwardPeeringProperties,omitempty"`
+
+	// ReversePeeringProperties specifies the properties of the peering from the remote virtual network
+	// to the AzureCluster's virtual network.
+	// +optional
+	ReversePeeringProperties VnetPeeringProperties `json:"reversePeeringProperties,omitempty"`
+}
+
+// VnetPeeringProperties specifies the properties of one direction of a virtual network peering.
+type VnetPeeringProperties struct {
+	// AllowForwardedTraffic specifies whether the traffic forwarded by the virtual machines of the local virtual network,
+	// i.e. that did not originate from them, is allowed into the remote virtual network.
+	// +optional
+	AllowForwardedTraffic *bool `json:"allowForwardedTraffic,omitempty"`
+
+	// AllowGatewayTransit specifies whether the remote virtual network can use the gateway of the local virtual network.
+	// +optional
+	AllowGatewayTransit *bool `json:"allowGatewayTransit,omitempty"`
+
+	// AllowVirtualNetworkAccess specifies whether the virtual machines of the local virtual network can access the
+	// virtual machines of the remote virtual network. Defaults to true in Azure.
+	// +optional
+	AllowVirtualNetworkAccess *bool `json:"allowVirtualNetworkAccess,omitempty"`
+
+	// UseRemoteGateways specifies whether the local virtual network uses the gateway of the remote virtual network,
+	// which requires AllowGatewayTransit on the opposite direction of the peering.
+	// Only one peering of a virtual network can use remote gateways, and not if the virtual network has a gateway.
+	// +optional
+	UseRemoteGateways *bool `json:"useRemoteGateways,omitempty"`
 }
 
 // VnetPeerings is a slice of VnetPeering.
@@ -162,8 +212,46 @@ type RouteTable struct {
 	// +optional
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
+	// Routes is a list of user-defined routes of the route table.
+	// +optional
+	Routes Routes `json:"routes,omitempty"`
+}
+
+// RouteNextHopType defines the type of Azure hop the traffic of a route should be sent to.
+type RouteNextHopType string
+
+const (
+	// RouteNextHopTypeVirtualNetworkGateway sends the traffic to the virtual network gateway.
+	RouteNextHopTypeVirtualNetworkGateway = RouteNextHopType("VirtualNetworkGateway")
+	// RouteNextHopTypeVnetLocal sends the traffic within the virtual network.
+	RouteNextHopTypeVnetLocal = RouteNextHopType("VnetLocal")
+	// RouteNextHopTypeInternet sends the traffic to the Internet.
+	RouteNextHopTypeInternet = RouteNextHopType("Internet")
+	// RouteNextHopTypeVirtualAppliance sends the traffic to a network virtual appliance, such as a firewall.
+	RouteNextHopTypeVirtualAppliance = RouteNextHopType("VirtualAppliance")
+	// RouteNextHopTypeNone drops the traffic.
+	RouteNextHopTypeNone = RouteNextHopType("None")
+)
+
+// Route defines an Azure user-defined route of a route table.
+type Route struct {
+	// Name is a unique name within the route table.
+	Name string `json:"name"`
+	// AddressPrefix is the destination CIDR to which the route applies.
+	AddressPrefix string `json:"addressPrefix"`
+	// NextHopType is the type of Azure hop the traffic should be sent to. "VirtualNetworkGateway", "VnetLocal", "Internet", "VirtualAppliance", or "None".
+	// +kubebuilder:validation:Enum=VirtualNetworkGateway;VnetLocal;Internet;VirtualAppliance;None
+	NextHopType RouteNextHopType `json:"nextHopType"`
+	// NextHopIPAddress is the IP address the traffic should be forwarded to. It is required, and only allowed, when NextHopType is "VirtualAppliance".
+	// +optional
+	NextHopIPAddress string `json:"nextHopIPAddress,omitempty"`
 }
 
+// Routes is a slice of Azure user-defined routes of a route table.
+// +listType=map
+// +listMapKey=name
+type Routes []Route
+
 // NatGateway defines an Azure NAT gateway.
 // NAT gateway resources are part of Vnet NAT and provide outbound Internet connectivity for subnets of a virtual network.
 type NatGateway struct {
@@ -182,6 +270,61 @@ type NatGatewayClassSpec struct {
 	Name string `json:"name"`
 }
 
+// AzureFirewall defines an Azure Firewall and its firewall policy.
+type AzureFirewall struct {
+	// Name is the name of the Azure Firewall. Defaults to "<cluster name>-firewall".
+	// +optional
+	Name string `json:"name,omitempty"`
+	// ResourceGroup is the resource group of an existing Azure Firewall to route the egress traffic through instead of creating one,
+	// e.g. the firewall of a hub virtual network peered with the cluster virtual network.
+	// CAPZ never modifies or deletes an existing firewall, and its policy must allow the traffic the cluster needs.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// PrivateIP is the private IP address of the Azure Firewall the egress traffic is routed to.
+	// READ-ONLY
+	// +optional
+	PrivateIP string `json:"privateIP,omitempty"`
+	// SubnetCIDRBlocks are the address prefixes of the AzureFirewallSubnet subnet of the firewall, of at least /26.
+	// Defaults to 10.255.255.0/26.
+	// +optional
+	SubnetCIDRBlocks []string `json:"subnetCIDRBlocks,omitempty"`
+	// PublicIP is the public IP address the egress traffic leaves the firewall from.
+	// +optional
+	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
+	// Tier is the tier of the Azure Firewall and its policy. "Standard" or "Premium". Defaults to "Standard".
+	// +kubebuilder:validation:Enum=Standard;Premium
+	// +optional
+	Tier AzureFirewallTier `json:"tier,omitempty"`
+	// Zones are the availability zones the firewall is deployed to.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+	// Policy is the configuration for the firewall policy created for the firewall.
+	// +optional
+	Policy AzureFirewallPolicy `json:"policy,omitempty"`
+}
+
+// AzureFirewallTier defines the tier of an Azure Firewall.
+type AzureFirewallTier string
+
+const (
+	// AzureFirewallTierStandard is the Standard tier of Azure Firewall.
+	AzureFirewallTierStandard AzureFirewallTier = "Standard"
+	// AzureFirewallTierPremium is the Premium tier of Azure Firewall, which supports TLS inspection and IDPS.
+	AzureFirewallTierPremium AzureFirewallTier = "Premium"
+)
+
+// AzureFirewallPolicy defines the firewall policy of an Azure Firewall.
+// The policy always allows the traffic the nodes need to bootstrap Kubernetes.
+type AzureFirewallPolicy struct {
+	// Name is the name of the firewall policy. Defaults to "<cluster name>-firewall-policy".
+	// +optional
+	Name string `json:"name,omitempty"`
+	// AllowedFQDNs are additional fully qualified domain names, optionally starting with a "*." wildcard,
+	// that the control-plane and node subnets are allowed to reach over HTTP and HTTPS.
+	// +optional
+	AllowedFQDNs []string `json:"allowedFQDNs,omitempty"`
+}
+
 // SecurityGroupProtocol defines the protocol type for a security group rule.
 type SecurityGroupProtocol string
 
@@ -207,6 +350,17 @@ const (
 	SecurityRuleDirectionOutbound = SecurityRuleDirection("Outbound")
 )
 
+// SecurityRuleAccess defines whether a security group rule allows or denies network traffic.
+type SecurityRuleAccess string
+
+const (
+	// SecurityRuleAccessAllow allows the network traffic matched by a security rule.
+	SecurityRuleAccessAllow = SecurityRuleAccess("Allow")
+
+	// SecurityRuleAccessDeny denies the network traffic matched by a security rule.
+	SecurityRuleAccessDeny = SecurityRuleAccess("Deny")
+)
+
 // SecurityRule defines an Azure security rule for security groups.
 type SecurityRule struct {
 	// Name is a unique name within the network security group.
@@ -234,6 +388,22 @@ type SecurityRule struct {
 	// Destination is the destination address prefix. CIDR or destination IP range. Asterix '*' can also be used to match all source IPs. Default tags such as 'VirtualNetwork', 'AzureLoadBalancer' and 'Internet' can also be used.
 	// +optional
 	Destination *string `json:"destination,omitempty"`
+	// Action specifies whether the network traffic matched by the rule is allowed or denied. "Allow" or "Deny". Defaults to "Allow".
+	// +kubebuilder:validation:Enum=Allow;Deny
+	// +optional
+	Action SecurityRuleAccess `json:"action,omitempty"`
+	// Sources specifies a list of CIDRs, IP addresses or service tags the network traffic originates from. Cannot be used with Source or SourceApplicationSecurityGroups.
+	// +optional
+	Sources []string `json:"sources,omitempty"`
+	// Destinations specifies a list of CIDRs, IP addresses or service tags the network traffic is sent to. Cannot be used with Destination or DestinationApplicationSecurityGroups.
+	// +optional
+	Destinations []string `json:"destinations,omitempty"`
+	// SourceApplicationSecurityGroups specifies the names of application security groups of the cluster the network traffic originates from. Cannot be used with Source or Sources.
+	// +optional
+	SourceApplicationSecurityGroups []string `json:"sourceApplicationSecurityGroups,omitempty"`
+	// DestinationApplicationSecurityGroups specifies the names of application security groups of the cluster the network traffic is sent to. Cannot be used with Destination or Destinations.
+	// +optional
+	DestinationApplicationSecurityGroups []string `json:"destinationApplicationSecurityGroups,omitempty"`
 }
 
 // SecurityRules is a slice of Azure security rules for security groups.
@@ -241,6 +411,21 @@ type SecurityRule struct {
 // +listMapKey=name
 type SecurityRules []SecurityRule
 
+// ApplicationSecurityGroup defines an Azure application security group created for the cluster.
+// The network interfaces of the machines with the given role are made members of the application security group.
+type ApplicationSecurityGroup struct {
+	// Name is the name of the application security group.
+	Name string `json:"name"`
+	// Role is the role of the machines whose network interfaces are members of the application security group. "control-plane" or "node".
+	// +kubebuilder:validation:Enum=control-plane;node
+	Role SubnetRole `json:"role"`
+}
+
+// ApplicationSecurityGroups is a slice of Azure application security groups.
+// +listType=map
+// +listMapKey=name
+type ApplicationSecurityGroups []ApplicationSecurityGroup
+
 // LoadBalancerSpec defines an Azure load balancer.
 type LoadBalancerSpec struct {
 	// ID is the Azure resource ID of the load balancer.
@@ -286,6 +471,138 @@ type FrontendIP struct {
 	FrontendIPClass `json:",inline"`
 }
 
+// LoadBalancerProtocol defines the transport protocol of a load balancing rule or an inbound NAT pool.
+type LoadBalancerProtocol string
+
+const (
+	// LoadBalancerProtocolTCP represents the TCP protocol.
+	LoadBalancerProtocolTCP = LoadBalancerProtocol("Tcp")
+	// LoadBalancerProtocolUDP represents the UDP protocol.
+	LoadBalancerProtocolUDP = LoadBalancerProtocol("Udp")
+	// LoadBalancerProtocolAll represents both the TCP and UDP protocols.
+	LoadBalancerProtocolAll = LoadBalancerProtocol("All")
+)
+
+// LoadBalancingRule defines an additional load balancing rule of a load balancer.
+type LoadBalancingRule struct {
+	// Name is a unique name within the load balancer.
+	Name string `json:"name"`
+	// Protocol is the transport protocol of the rule. "Tcp", "Udp" or "All". Defaults to "Tcp".
+	// +kubebuilder:validation:Enum=Tcp;Udp;All
+	// +optional
+	Protocol LoadBalancerProtocol `json:"protocol,omitempty"`
+	// FrontendPort is the port of the frontend IP on which the traffic is received.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65534
+	FrontendPort int32 `json:"frontendPort"`
+	// BackendPort is the port of the backend instances to which the traffic is forwarded.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	BackendPort int32 `json:"backendPort"`
+	// FrontendIPName is the name of the frontend IP that receives the traffic. Defaults to the first frontend IP of the load balancer.
+	// +optional
+	FrontendIPName string `json:"frontendIPName,omitempty"`
+	// ProbeName is the name of the probe, declared in the probes of the load balancer, that checks the health of the backend instances.
+	// +optional
+	ProbeName string `json:"probeName,omitempty"`
+	// EnableFloatingIP enables Direct Server Return, which is required for some SQL AlwaysOn scenarios.
+	// +optional
+	EnableFloatingIP *bool `json:"enableFloatingIP,omitempty"`
+	// EnableTCPReset sends bidirectional TCP resets when a TCP connection times out or is closed.
+	// +optional
+	EnableTCPReset *bool `json:"enableTCPReset,omitempty"`
+	// IdleTimeoutInMinutes specifies the timeout for the TCP idle connection. Defaults to the idle timeout of the load balancer.
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+}
+
+// LoadBalancingRules is a slice of load balancing rules.
+// +listType=map
+// +listMapKey=name
+type LoadBalancingRules []LoadBalancingRule
+
+// ProbeProtocol defines the protocol of a load balancer health probe.
+type ProbeProtocol string
+
+const (
+	// ProbeProtocolTCP probes the backend instances with a TCP connection.
+	ProbeProtocolTCP = ProbeProtocol("Tcp")
+	// ProbeProtocolHTTP probes the backend instances with an HTTP GET request.
+	ProbeProtocolHTTP = ProbeProtocol("Http")
+	// ProbeProtocolHTTPS probes the backend instances with an HTTPS GET request.
+	ProbeProtocolHTTPS = ProbeProtocol("Https")
+)
+
+// LoadBalancerProbe defines an additional health probe of a load balancer.
+type LoadBalancerProbe struct {
+	// Name is a unique name within the load balancer.
+	Name string `json:"name"`
+	// Protocol is the protocol of the probe. "Tcp", "Http" or "Https".
+	// +kubebuilder:validation:Enum=Tcp;Http;Https
+	Protocol ProbeProtocol `json:"protocol"`
+	// Port is the port of the backend instances that is probed.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// RequestPath is the URI requested by "Http" and "Https" probes. It is required for these protocols, and not allowed for "Tcp".
+	// +optional
+	RequestPath string `json:"requestPath,omitempty"`
+	// IntervalInSeconds is the interval between two probes. Defaults to 15.
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	IntervalInSeconds *int32 `json:"intervalInSeconds,omitempty"`
+	// NumberOfProbes is the number of consecutive failed probes after which a backend instance is considered unhealthy. Defaults to 4.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NumberOfProbes *int32 `json:"numberOfProbes,omitempty"`
+}
+
+// LoadBalancerProbes is a slice of load balancer health probes.
+// +listType=map
+// +listMapKey=name
+type LoadBalancerProbes []LoadBalancerProbe
+
+// InboundNATPool defines an inbound NAT pool of a load balancer.
+// Each port of the frontend port range is forwarded to the backend port of one instance of the virtual machine scale sets
+// that use the pool.
+type InboundNATPool struct {
+	// Name is a unique name within the load balancer.
+	Name string `json:"name"`
+	// Protocol is the transport protocol of the pool. "Tcp", "Udp" or "All". Defaults to "Tcp".
+	// +kubebuilder:validation:Enum=Tcp;Udp;All
+	// +optional
+	Protocol LoadBalancerProtocol `json:"protocol,omitempty"`
+	// FrontendPortRangeStart is the first port of the frontend port range.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65534
+	FrontendPortRangeStart int32 `json:"frontendPortRangeStart"`
+	// FrontendPortRangeEnd is the last port of the frontend port range.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	FrontendPortRangeEnd int32 `json:"frontendPortRangeEnd"`
+	// BackendPort is the port of the backend instances to which the traffic is forwarded.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	BackendPort int32 `json:"backendPort"`
+	// FrontendIPName is the name of the frontend IP that receives the traffic. Defaults to the first frontend IP of the load balancer.
+	// +optional
+	FrontendIPName string `json:"frontendIPName,omitempty"`
+	// EnableFloatingIP enables Direct Server Return, which is required for some SQL AlwaysOn scenarios.
+	// +optional
+	EnableFloatingIP *bool `json:"enableFloatingIP,omitempty"`
+	// EnableTCPReset sends bidirectional TCP resets when a TCP connection times out or is closed.
+	// +optional
+	EnableTCPReset *bool `json:"enableTCPReset,omitempty"`
+	// IdleTimeoutInMinutes specifies the timeout for the TCP idle connection. Defaults to the idle timeout of the load balancer.
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+}
+
+// InboundNATPools is a slice of inbound NAT pools.
+// +listType=map
+// +listMapKey=name
+type InboundNATPools []InboundNATPool
+
 // PublicIPSpec defines the inputs to create an Azure public IP address.
 type PublicIPSpec struct {
 	Name string `json:"name"`
@@ -293,8 +610,58 @@ type PublicIPSpec struct {
 	DNSName string `json:"dnsName,omitempty"`
 	// +optional
 	IPTags []IPTag `json:"ipTags,omitempty"`
+
+	PublicIPClassSpec `json:",inline"`
 }
 
+// PublicIPTier defines the tier of an Azure public IP address or public IP prefix.
+type PublicIPTier string
+
+const (
+	// PublicIPTierRegional is the tier of the public IP addresses and prefixes of a single region.
+	PublicIPTierRegional = PublicIPTier("Regional")
+	// PublicIPTierGlobal is the tier of the public IP addresses and prefixes that are anycast from multiple regions,
+	// which can only be used by cross-region load balancers.
+	PublicIPTierGlobal = PublicIPTier("Global")
+)
+
+// IPVersion defines the IP version of an Azure public IP address or public IP prefix.
+type IPVersion string
+
+const (
+	// IPVersionIPv4 is the IPv4 IP version.
+	IPVersionIPv4 = IPVersion("IPv4")
+	// IPVersionIPv6 is the IPv6 IP version.
+	IPVersionIPv6 = IPVersion("IPv6")
+)
+
+// PublicIPPrefix defines an Azure public IP prefix created for the cluster.
+// The public IP addresses of the cluster can be allocated from the public IP prefix by name.
+type PublicIPPrefix struct {
+	// Name is the name of the public IP prefix.
+	Name string `json:"name"`
+	// PrefixLength is the length of the public IP prefix, between 21 and 31 for IPv4 and between 124 and 127 for IPv6.
+	// For example, a public IP prefix of length 28 contains 16 IPv4 addresses.
+	PrefixLength int32 `json:"prefixLength"`
+	// Zones are the availability zones of the public IP prefix. Defaults to the failure domains of the cluster for Regional public IP prefixes.
+	// The public IP addresses allocated from the public IP prefix must be in the same zones.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+	// Tier is the tier of the public IP prefix, Regional or Global. Defaults to Regional.
+	// +kubebuilder:validation:Enum=Regional;Global
+	// +optional
+	Tier PublicIPTier `json:"tier,omitempty"`
+	// IPVersion is the IP version of the public IP prefix, IPv4 or IPv6. Defaults to IPv4.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +optional
+	IPVersion IPVersion `json:"ipVersion,omitempty"`
+}
+
+// PublicIPPrefixes is a slice of Azure public IP prefixes.
+// +listType=map
+// +listMapKey=name
+type PublicIPPrefixes []PublicIPPrefix
+
 // IPTag contains the IpTag associated with the object.
 type IPTag struct {
 	// Type specifies the IP tag type. Example: FirstPartyUsage.
@@ -572,6 +939,33 @@ type ManagedDiskParameters struct {
 	StorageAccountType string `json:"storageAccountType,omitempty"`
 	// +optional
 	DiskEncryptionSet *DiskEncryptionSetParameters `json:"diskEncryptionSet,omitempty"`
+	// SecurityProfile specifies the security profile for the managed disk. It is only supported
+	// on the OS disk of confidential virtual machines.
+	// +optional
+	SecurityProfile *VMDiskSecurityProfile `json:"securityProfile,omitempty"`
+}
+
+// SecurityEncryptionType defines the encryption type of a confidential virtual machine OS disk.
+type SecurityEncryptionType string
+
+const (
+	// SecurityEncryptionTypeVMGuestStateOnly encrypts only the VM guest state (the vTPM state) of the OS disk.
+	SecurityEncryptionTypeVMGuestStateOnly SecurityEncryptionType = "VMGuestStateOnly"
+	// SecurityEncryptionTypeDiskWithVMGuestState encrypts both the OS disk and the VM guest state.
+	SecurityEncryptionTypeDiskWithVMGuestState SecurityEncryptionType = "DiskWithVMGuestState"
+)
+
+// VMDiskSecurityProfile specifies the security profile settings for the managed disk of a confidential virtual machine.
+type VMDiskSecurityProfile struct {
+	// SecurityEncryptionType specifies the encryption type of the managed disk.
+	// It is required when the security type of the virtual machine is ConfidentialVM.
+	// +kubebuilder:validation:Enum=VMGuestStateOnly;DiskWithVMGuestState
+	// +optional
+	SecurityEncryptionType SecurityEncryptionType `json:"securityEncryptionType,omitempty"`
+	// DiskEncryptionSet specifies the customer managed disk encryption set used to encrypt the OS disk and the VM guest state.
+	// It can only be set when the SecurityEncryptionType is DiskWithVMGuestState.
+	// +optional
+	DiskEncryptionSet *DiskEncryptionSetParameters `json:"diskEncryptionSet,omitempty"`
 }
 
 // DiskEncryptionSetParameters defines disk encryption options.
@@ -622,9 +1016,62 @@ type SubnetSpec struct {
 	// +optional
 	NatGateway NatGateway `json:"natGateway,omitempty"`
 
+	// PrivateEndpoints defines a list of private endpoints that should be attached to this subnet.
+	// +optional
+	PrivateEndpoints PrivateEndpoints `json:"privateEndpoints,omitempty"`
+
 	SubnetClassSpec `json:",inline"`
 }
 
+// PrivateEndpointSpec configures an Azure Private Endpoint.
+type PrivateEndpointSpec struct {
+	// Name specifies the name of the private endpoint.
+	Name string `json:"name"`
+
+	// PrivateLinkServiceID is the Azure resource ID of the resource the private endpoint connects to,
+	// e.g. a storage account, a key vault or a container registry.
+	PrivateLinkServiceID string `json:"privateLinkServiceID"`
+
+	// GroupIDs specifies the sub-resources of the target resource the private endpoint connects to, e.g. "blob" for a storage account.
+	// +optional
+	GroupIDs []string `json:"groupIDs,omitempty"`
+
+	// ManualApproval specifies whether the connection to the target resource must be approved by its owner.
+	// It is needed when the cluster identity does not have permissions to approve the connection.
+	// +optional
+	ManualApproval bool `json:"manualApproval,omitempty"`
+
+	// RequestMessage is passed to the owner of the target resource when the connection requires a manual approval.
+	// +kubebuilder:validation:MaxLength=140
+	// +optional
+	RequestMessage string `json:"requestMessage,omitempty"`
+
+	// CustomNetworkInterfaceName specifies the name of the network interface attached to the private endpoint.
+	// By default, Azure generates a name.
+	// +optional
+	CustomNetworkInterfaceName string `json:"customNetworkInterfaceName,omitempty"`
+
+	// PrivateDNSZoneGroup registers the private endpoint in private DNS zones.
+	// +optional
+	PrivateDNSZoneGroup *PrivateDNSZoneGroup `json:"privateDNSZoneGroup,omitempty"`
+}
+
+// PrivateDNSZoneGroup defines the private DNS zones in which a private endpoint is registered.
+type PrivateDNSZoneGroup struct {
+	// Name specifies the name of the private DNS zone group.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// PrivateDNSZoneIDs are the Azure resource IDs of the private DNS zones, e.g. the "privatelink.blob.core.windows.net" zone.
+	// +kubebuilder:validation:MinItems=1
+	PrivateDNSZoneIDs []string `json:"privateDNSZoneIDs"`
+}
+
+// PrivateEndpoints is a slice of PrivateEndpointSpec.
+// +listType=map
+// +listMapKey=name
+type PrivateEndpoints []PrivateEndpointSpec
+
 // ServiceEndpointSpec configures an Azure Service Endpoint.
 type ServiceEndpointSpec struct {
 	Service string `json:"service"`
@@ -673,6 +1120,74 @@ type SecurityProfile struct {
 	// set. Default is disabled.
 	// +optional
 	EncryptionAtHost *bool `json:"encryptionAtHost,omitempty"`
+	// SecurityType specifies the security type of the virtual machine. Setting it to TrustedLaunch or
+	// ConfidentialVM requires a generation 2 image and a VM size that supports the security type.
+	// +kubebuilder:validation:Enum=TrustedLaunch;ConfidentialVM
+	// +optional
+	SecurityType SecurityTypes `json:"securityType,omitempty"`
+	// UefiSettings specifies the security settings like secure boot and vTPM used while creating the
+	// virtual machine. It requires SecurityType to be set.
+	// +optional
+	UefiSettings *UefiSettings `json:"uefiSettings,omitempty"`
+}
+
+// SecurityTypes represents the security type of a virtual machine.
+type SecurityTypes string
+
+const (
+	// SecurityTypesTrustedLaunch protects the virtual machine with secure boot and a virtual TPM.
+	SecurityTypesTrustedLaunch SecurityTypes = "TrustedLaunch"
+	// SecurityTypesConfidentialVM runs the virtual machine in a hardware-based trusted execution environment.
+	SecurityTypesConfidentialVM SecurityTypes = "ConfidentialVM"
+)
+
+// UefiSettings specifies the security settings like secure boot and vTPM used while creating the virtual machine.
+type UefiSettings struct {
+	// SecureBootEnabled specifies whether secure boot should be enabled on the virtual machine.
+	// +optional
+	SecureBootEnabled *bool `json:"secureBootEnabled,omitempty"`
+	// VTpmEnabled specifies whether vTPM should be enabled on the virtual machine.
+	// +optional
+	VTpmEnabled *bool `json:"vTpmEnabled,omitempty"`
+}
+
+// BootDiagnosticsStorageAccountType defines the storage account type of the boot diagnostics of a virtual machine.
+type BootDiagnosticsStorageAccountType string
+
+const (
+	// ManagedDiagnosticsStorage stores the boot diagnostics in a storage account managed by Azure.
+	ManagedDiagnosticsStorage BootDiagnosticsStorageAccountType = "Managed"
+	// UserManagedDiagnosticsStorage stores the boot diagnostics in a storage account provided by the user.
+	UserManagedDiagnosticsStorage BootDiagnosticsStorageAccountType = "UserManaged"
+	// DisabledDiagnosticsStorage disables the boot diagnostics.
+	DisabledDiagnosticsStorage BootDiagnosticsStorageAccountType = "Disabled"
+)
+
+// Diagnostics specifies the diagnostic settings of a virtual machine or virtual machine scale set.
+type Diagnostics struct {
+	// Boot configures the boot diagnostics, which capture the serial console log and a screenshot of the virtual
+	// machine. If omitted, boot diagnostics are stored in a storage account managed by Azure.
+	// +optional
+	Boot *BootDiagnostics `json:"boot,omitempty"`
+}
+
+// BootDiagnostics specifies the boot diagnostics settings of a virtual machine or virtual machine scale set.
+type BootDiagnostics struct {
+	// StorageAccountType determines whether the boot diagnostics are stored in a storage account managed by
+	// Azure (Managed) or by the user (UserManaged), or are disabled (Disabled).
+	// +kubebuilder:validation:Enum=Managed;UserManaged;Disabled
+	StorageAccountType BootDiagnosticsStorageAccountType `json:"storageAccountType"`
+
+	// UserManaged provides the storage account of the boot diagnostics when StorageAccountType is UserManaged.
+	// +optional
+	UserManaged *UserManagedBootDiagnostics `json:"userManaged,omitempty"`
+}
+
+// UserManagedBootDiagnostics specifies the storage account provided by the user to store the boot diagnostics.
+type UserManagedBootDiagnostics struct {
+	// StorageAccountURI is the URI of the blob endpoint of the storage account, e.g. https://mystorageaccount.blob.core.windows.net/.
+	// +kubebuilder:validation:MaxLength=1024
+	StorageAccountURI string `json:"storageAccountURI"`
 }
 
 // AddressRecord specifies a DNS record mapping a hostname to an IPV4 or IPv6 address.
@@ -681,6 +1196,60 @@ type AddressRecord struct {
 	IP       string
 }
 
+// PrivateDNSRecordType is the type of a record set of a private DNS zone.
+type PrivateDNSRecordType string
+
+const (
+	// PrivateDNSRecordTypeA is a record set of IPv4 addresses.
+	PrivateDNSRecordTypeA PrivateDNSRecordType = "A"
+	// PrivateDNSRecordTypeAAAA is a record set of IPv6 addresses.
+	PrivateDNSRecordTypeAAAA PrivateDNSRecordType = "AAAA"
+	// PrivateDNSRecordTypeCNAME is a record set aliasing another domain name.
+	PrivateDNSRecordTypeCNAME PrivateDNSRecordType = "CNAME"
+	// PrivateDNSRecordTypeSRV is a record set of service locations.
+	PrivateDNSRecordTypeSRV PrivateDNSRecordType = "SRV"
+)
+
+// PrivateDNSRecord defines a record set of a private DNS zone.
+type PrivateDNSRecord struct {
+	// Name is the name of the record set, relative to the zone, e.g. "etcd" or "_etcd-server-ssl._tcp".
+	Name string `json:"name"`
+	// Type is the type of the record set.
+	// +kubebuilder:validation:Enum=A;AAAA;CNAME;SRV
+	Type PrivateDNSRecordType `json:"type"`
+	// TTL is the time to live, in seconds, of the record set. Defaults to the TTL of the zone records.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// IPs are the IPv4 addresses of an A record set or the IPv6 addresses of an AAAA record set.
+	// +optional
+	IPs []string `json:"ips,omitempty"`
+	// CNAME is the canonical name of a CNAME record set.
+	// +optional
+	CNAME string `json:"cname,omitempty"`
+	// SRVRecords are the records of an SRV record set.
+	// +optional
+	SRVRecords []SRVRecord `json:"srvRecords,omitempty"`
+}
+
+// SRVRecord defines a record of an SRV record set.
+type SRVRecord struct {
+	// Priority is the priority of the target host, lower values are preferred.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Priority int32 `json:"priority"`
+	// Weight is the relative weight of the targets with the same priority.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Weight int32 `json:"weight"`
+	// Port is the port of the service on the target host.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Target is the domain name of the target host.
+	Target string `json:"target"`
+}
+
 // CloudProviderConfigOverrides represents the fields that can be overridden in azure cloud provider config.
 type CloudProviderConfigOverrides struct {
 	// +optional
@@ -778,8 +1347,41 @@ type AzureBastion struct {
 	Subnet SubnetSpec `json:"subnet,omitempty"`
 	// +optional
 	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
+	// Sku is the SKU of the Azure Bastion host. "Basic" or "Standard". Defaults to "Basic".
+	// A Standard host cannot be downgraded to Basic.
+	// +kubebuilder:validation:Enum=Basic;Standard
+	// +optional
+	Sku BastionHostSkuName `json:"sku,omitempty"`
+	// ScaleUnits is the number of instances of the Azure Bastion host, between 2 and 50. Defaults to 2.
+	// More than 2 scale units require the Standard SKU.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	ScaleUnits *int32 `json:"scaleUnits,omitempty"`
+	// EnableTunneling enables native client support, e.g. connecting with `az network bastion ssh`. Requires the Standard SKU.
+	// +optional
+	EnableTunneling bool `json:"enableTunneling,omitempty"`
+	// EnableIPConnect enables connecting to virtual machines by their private IP address. Requires the Standard SKU.
+	// +optional
+	EnableIPConnect bool `json:"enableIPConnect,omitempty"`
+	// EnableShareableLink enables connecting to virtual machines with a link, without access to the Azure portal. Requires the Standard SKU.
+	// +optional
+	EnableShareableLink bool `json:"enableShareableLink,omitempty"`
+	// DisableCopyPaste disables copy and paste in the web-based sessions of the Azure Bastion host. Requires the Standard SKU.
+	// +optional
+	DisableCopyPaste bool `json:"disableCopyPaste,omitempty"`
 }
 
+// BastionHostSkuName defines the SKU of an Azure Bastion host.
+type BastionHostSkuName string
+
+const (
+	// BasicBastionHostSku is the Basic SKU of Azure Bastion hosts.
+	BasicBastionHostSku BastionHostSkuName = "Basic"
+	// StandardBastionHostSku is the Standard SKU of Azure Bastion hosts, which supports scaling and advanced features.
+	StandardBastionHostSku BastionHostSkuName = "Standard"
+)
+
 // IsTerminalProvisioningState returns true if the ProvisioningState is a terminal state for an Azure resource.
 func IsTerminalProvisioningState(state ProvisioningState) bool {
 	return state == Failed || state == Succeeded
@@ -57,6 +57,20 @@ type NetworkClassSpec struct {
 	// PrivateDNSZoneName defines the zone name for the Azure Private DNS.
 	// +optional
 	PrivateDNSZoneName string `json:"privateDNSZoneName,omitempty"`
+
+	// PrivateDNSZone is the configuration of the private DNS zone of a cluster with an internal API server load balancer.
+	// +optional
+	PrivateDNSZone *PrivateDNSZoneClassSpec `json:"privateDNSZone,omitempty"`
+
+	// ApplicationSecurityGroups is the configuration for the application security groups of the cluster,
+	// which can be referenced by the security rules of the subnets.
+	// +optional
+	ApplicationSecurityGroups ApplicationSecurityGroups `json:"applicationSecurityGroups,omitempty"`
+
+	// PublicIPPrefixes is the configuration for the public IP prefixes created for the cluster,
+	// which the public IP addresses of the cluster can be allocated from.
+	// +optional
+	PublicIPPrefixes PublicIPPrefixes `json:"publicIPPrefixes,omitempty"`
 }
 
 // VnetClassSpec defines the VnetSpec properties that may be shared across several Azure clusters.
@@ -68,8 +82,47 @@ type VnetClassSpec struct {
 	// Tags is a collection of tags describing the resource.
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
+
+	// DDoSProtectionPlanID is the resource ID of an existing DDoS protection plan protecting the virtual network.
+	// +optional
+	DDoSProtectionPlanID string `json:"ddosProtectionPlanID,omitempty"`
+
+	// Encryption enables the encryption of the traffic between the virtual machines of the virtual network.
+	// +optional
+	Encryption *VnetEncryption `json:"encryption,omitempty"`
+
+	// DNSServers are the IP addresses of the custom DNS servers of the virtual network.
+	// The Azure-provided DNS is used if empty.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+
+	// FlowTimeoutInMinutes is the idle timeout, in minutes, of the flows of the virtual network, between 4 and 30.
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=30
+	// +optional
+	FlowTimeoutInMinutes *int32 `json:"flowTimeoutInMinutes,omitempty"`
+}
+
+// VnetEncryption defines the encryption of a virtual network.
+type VnetEncryption struct {
+	// Enforcement defines whether virtual machines that do not support encryption are allowed in the encrypted virtual network.
+	// "AllowUnencrypted" or "DropUnencrypted". Defaults to "AllowUnencrypted".
+	// +kubebuilder:validation:Enum=AllowUnencrypted;DropUnencrypted
+	// +kubebuilder:default=AllowUnencrypted
+	// +optional
+	Enforcement VnetEncryptionEnforcement `json:"enforcement,omitempty"`
 }
 
+// VnetEncryptionEnforcement defines how the unencrypted traffic of an encrypted virtual network is handled.
+type VnetEncryptionEnforcement string
+
+const (
+	// VnetEncryptionEnforcementAllowUnencrypted allows the virtual machines that do not support encryption in the virtual network.
+	VnetEncryptionEnforcementAllowUnencrypted VnetEncryptionEnforcement = "AllowUnencrypted"
+	// VnetEncryptionEnforcementDropUnencrypted drops the traffic of the virtual machines that do not support encryption.
+	VnetEncryptionEnforcementDropUnencrypted VnetEncryptionEnforcement = "DropUnencrypted"
+)
+
 // SubnetClassSpec defines the SubnetSpec properties that may be shared across several Azure clusters.
 type SubnetClassSpec struct {
 	// Name defines a name for the subnet resource.
@@ -97,6 +150,72 @@ type LoadBalancerClassSpec struct {
 	// IdleTimeoutInMinutes specifies the timeout for the TCP idle connection.
 	// +optional
 	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+	// LoadBalancingRules are additional rules that forward the traffic received on a frontend port to the backend pool of the load balancer.
+	// +optional
+	LoadBalancingRules LoadBalancingRules `json:"loadBalancingRules,omitempty"`
+	// Probes are additional health probes that can be referenced by the load balancing rules.
+	// +optional
+	Probes LoadBalancerProbes `json:"probes,omitempty"`
+	// InboundNATPools are pools of frontend ports that are each forwarded to a backend port of an instance of the
+	// virtual machine scale sets in the backend pool of the load balancer.
+	// +optional
+	InboundNATPools InboundNATPools `json:"inboundNATPools,omitempty"`
+	// FrontendPublicIP configures the public IP addresses generated for the frontends of an outbound load balancer,
+	// e.g. to allocate them from a public IP prefix.
+	// +optional
+	FrontendPublicIP *PublicIPClassSpec `json:"frontendPublicIP,omitempty"`
+}
+
+// PublicIPClassSpec defines the PublicIPSpec properties that may be shared across several public IP addresses.
+type PublicIPClassSpec struct {
+	// PublicIPPrefixName is the name of a public IP prefix of the cluster, declared in the PublicIPPrefixes of the network spec,
+	// to allocate the public IP address from. Cannot be used with PublicIPPrefixID.
+	// +optional
+	PublicIPPrefixName string `json:"publicIPPrefixName,omitempty"`
+	// PublicIPPrefixID is the Azure resource ID of an existing public IP prefix to allocate the public IP address from.
+	// Cannot be used with PublicIPPrefixName.
+	// +optional
+	PublicIPPrefixID string `json:"publicIPPrefixID,omitempty"`
+	// Zones are the availability zones of the public IP address. Defaults to the zones of the public IP prefix referenced by
+	// PublicIPPrefixName, and otherwise to the failure domains of the cluster. A public IP address allocated from a public IP
+	// prefix must be in the same zones as the prefix.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+	// Tier is the tier of the public IP address. Only Regional, the default, is supported since Global public IP addresses
+	// can only be used by cross-region load balancers, which are not created by CAPZ.
+	// +kubebuilder:validation:Enum=Regional;Global
+	// +optional
+	Tier PublicIPTier `json:"tier,omitempty"`
+	// IPVersion is the IP version of the public IP address, IPv4 or IPv6. Defaults to IPv4.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +optional
+	IPVersion IPVersion `json:"ipVersion,omitempty"`
+}
+
+// PrivateDNSZoneClassSpec defines the private DNS zone properties that may be shared across several Azure clusters.
+type PrivateDNSZoneClassSpec struct {
+	// ResourceGroup is the resource group of an existing private DNS zone to use instead of creating one in the cluster resource group.
+	// CAPZ only links the virtual networks of the cluster to an existing zone and creates the records of the cluster in it,
+	// it never modifies or deletes the zone itself.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// SubscriptionID is the subscription of the existing private DNS zone. Defaults to the subscription of the cluster.
+	// Requires ResourceGroup to be set.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+	// TTL is the time to live, in seconds, of the records created in the zone. Defaults to 300.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// RegistrationEnabled enables the auto-registration of the virtual machines of the cluster virtual network in the zone.
+	// +optional
+	RegistrationEnabled bool `json:"registrationEnabled,omitempty"`
+	// Records are additional records to create in the zone, besides the record of the API server.
+	// +listType=map
+	// +listMapKey=name
+	// +listMapKey=type
+	// +optional
+	Records []PrivateDNSRecord `json:"records,omitempty"`
 }
 
 // SecurityGroupClass defines the SecurityGroup properties that may be shared across several Azure clusters.
@@ -125,6 +244,9 @@ func (vc *VnetClassSpec) setDefaults() {
 	if len(vc.CIDRBlocks) == 0 {
 		vc.CIDRBlocks = []string{DefaultVnetCIDR}
 	}
+	if vc.Encryption != nil && vc.Encryption.Enforcement == "" {
+		vc.Encryption.Enforcement = VnetEncryptionEnforcementAllowUnencrypted
+	}
 }
 
 // setDefaults sets default values for SubnetClassSpec.
@@ -32,4 +32,32 @@ const (
 	// ReplicasManagedByAutoscalerAnnotation is set to true in the corresponding capi machine pool
 	// when an external autoscaler manages the node count of the associated machine pool.
 	ReplicasManagedByAutoscalerAnnotation = "cluster.x-k8s.io/replicas-managed-by-autoscaler"
+
+	// DriftModeAnnotation is the key for the AzureCluster and AzureMachine annotation which controls
+	// how drift between the spec and the Azure resources is handled when the DriftDetection feature is enabled.
+	// Drift is corrected by default. Set it to DriftModeReport to only report it.
+	DriftModeAnnotation = "sigs.k8s.io/cluster-api-provider-azure-drift-mode"
+
+	// DriftModeReport is the DriftModeAnnotation value to report drifted resources without updating them.
+	DriftModeReport = "report"
+
+	// DriftLastAppliedAnnotation is the key for the AzureCluster and AzureMachine annotation which holds a hash of
+	// the spec last applied to each Azure resource. It is used to tell out-of-band changes to a resource, which
+	// are reported as drift, from changes to its spec that have not been applied yet.
+	DriftLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-specs"
+
+	// DryRunAnnotation is the key for the AzureCluster and AzureMachine annotation which, when set to "true",
+	// makes the controllers plan the Azure operations needed to reconcile the object without performing them.
+	DryRunAnnotation = "sigs.k8s.io/cluster-api-provider-azure-dry-run"
+)
+
+const (
+	// PlannedCreate is the operation planned to create a resource that does not exist yet.
+	PlannedCreate = "create"
+	// PlannedUpdate is the operation planned to update an existing resource with a PUT request.
+	PlannedUpdate = "update"
+	// PlannedPatch is the operation planned to update an existing resource with a PATCH request.
+	PlannedPatch = "patch"
+	// PlannedDelete is the operation planned to delete an existing resource.
+	PlannedDelete = "delete"
 )
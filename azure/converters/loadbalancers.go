@@ -28,3 +28,12 @@ func SKUtoSDK(src infrav1.SKU) network.LoadBalancerSkuName {
 	}
 	return ""
 }
+
+// LoadBalancerProtocolToSDK converts infrav1.LoadBalancerProtocol into a network.TransportProtocol.
+// The protocol defaults to TCP when it is not set.
+func LoadBalancerProtocolToSDK(src infrav1.LoadBalancerProtocol) network.TransportProtocol {
+	if src == "" {
+		return network.TransportProtocolTCP
+	}
+	return network.TransportProtocol(src)
+}
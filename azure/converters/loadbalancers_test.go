@@ -52,3 +52,37 @@ func TestSKUtoSDK(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadBalancerProtocolToSDK(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol infrav1.LoadBalancerProtocol
+		want     network.TransportProtocol
+	}{
+		{
+			name:     "default",
+			protocol: "",
+			want:     network.TransportProtocolTCP,
+		},
+		{
+			name:     "udp",
+			protocol: infrav1.LoadBalancerProtocolUDP,
+			want:     network.TransportProtocolUDP,
+		},
+		{
+			name:     "all",
+			protocol: infrav1.LoadBalancerProtocolAll,
+			want:     network.TransportProtocolAll,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := LoadBalancerProtocolToSDK(tt.protocol)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LoadBalancerProtocolToSDK(%s) mismatch (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
@@ -20,10 +20,12 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
 // SecurityRuleToSDK converts a CAPZ security rule to an Azure network security rule.
-func SecurityRuleToSDK(rule infrav1.SecurityRule) network.SecurityRule {
+// The application security groups referenced by the rule are in the given subscription and resource group.
+func SecurityRuleToSDK(rule infrav1.SecurityRule, subscriptionID, resourceGroup string) network.SecurityRule {
 	secRule := network.SecurityRule{
 		Name: to.StringPtr(rule.Name),
 		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
@@ -37,6 +39,25 @@ func SecurityRuleToSDK(rule infrav1.SecurityRule) network.SecurityRule {
 		},
 	}
 
+	if rule.Action == infrav1.SecurityRuleAccessDeny {
+		secRule.Access = network.SecurityRuleAccessDeny
+	}
+
+	if len(rule.Sources) > 0 {
+		sources := rule.Sources
+		secRule.SourceAddressPrefixes = &sources
+	}
+	if len(rule.Destinations) > 0 {
+		destinations := rule.Destinations
+		secRule.DestinationAddressPrefixes = &destinations
+	}
+	if len(rule.SourceApplicationSecurityGroups) > 0 {
+		secRule.SourceApplicationSecurityGroups = applicationSecurityGroupsToSDK(rule.SourceApplicationSecurityGroups, subscriptionID, resourceGroup)
+	}
+	if len(rule.DestinationApplicationSecurityGroups) > 0 {
+		secRule.DestinationApplicationSecurityGroups = applicationSecurityGroupsToSDK(rule.DestinationApplicationSecurityGroups, subscriptionID, resourceGroup)
+	}
+
 	switch rule.Protocol {
 	case infrav1.SecurityGroupProtocolAll:
 		secRule.Protocol = network.SecurityRuleProtocolAsterisk
@@ -57,3 +78,14 @@ func SecurityRuleToSDK(rule infrav1.SecurityRule) network.SecurityRule {
 
 	return secRule
 }
+
+// applicationSecurityGroupsToSDK converts the names of application security groups to Azure application security group references.
+func applicationSecurityGroupsToSDK(names []string, subscriptionID, resourceGroup string) *[]network.ApplicationSecurityGroup {
+	asgs := make([]network.ApplicationSecurityGroup, 0, len(names))
+	for _, name := range names {
+		asgs = append(asgs, network.ApplicationSecurityGroup{
+			ID: to.StringPtr(azure.ApplicationSecurityGroupID(subscriptionID, resourceGroup, name)),
+		})
+	}
+	return &asgs
+}
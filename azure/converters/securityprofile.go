@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// SecurityProfileToSDK converts a CAPZ SecurityProfile to an Azure SDK SecurityProfile.
+func SecurityProfileToSDK(securityProfile *infrav1.SecurityProfile) *compute.SecurityProfile {
+	if securityProfile == nil {
+		return nil
+	}
+
+	sdkSecurityProfile := &compute.SecurityProfile{
+		EncryptionAtHost: securityProfile.EncryptionAtHost,
+		SecurityType:     compute.SecurityTypes(securityProfile.SecurityType),
+	}
+	if securityProfile.UefiSettings != nil {
+		sdkSecurityProfile.UefiSettings = &compute.UefiSettings{
+			SecureBootEnabled: securityProfile.UefiSettings.SecureBootEnabled,
+			VTpmEnabled:       securityProfile.UefiSettings.VTpmEnabled,
+		}
+	}
+	return sdkSecurityProfile
+}
+
+// DiskSecurityProfileToSDK converts a CAPZ VMDiskSecurityProfile to an Azure SDK VMDiskSecurityProfile.
+func DiskSecurityProfileToSDK(diskSecurityProfile *infrav1.VMDiskSecurityProfile) *compute.VMDiskSecurityProfile {
+	if diskSecurityProfile == nil {
+		return nil
+	}
+
+	sdkDiskSecurityProfile := &compute.VMDiskSecurityProfile{
+		SecurityEncryptionType: compute.SecurityEncryptionTypes(diskSecurityProfile.SecurityEncryptionType),
+	}
+	if diskSecurityProfile.DiskEncryptionSet != nil {
+		sdkDiskSecurityProfile.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(diskSecurityProfile.DiskEncryptionSet.ID)}
+	}
+	return sdkDiskSecurityProfile
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestSecurityProfileToSDK(t *testing.T) {
+	tests := []struct {
+		name            string
+		securityProfile *infrav1.SecurityProfile
+		want            *compute.SecurityProfile
+	}{
+		{
+			name:            "nil security profile",
+			securityProfile: nil,
+			want:            nil,
+		},
+		{
+			name:            "encryption at host",
+			securityProfile: &infrav1.SecurityProfile{EncryptionAtHost: to.BoolPtr(true)},
+			want:            &compute.SecurityProfile{EncryptionAtHost: to.BoolPtr(true)},
+		},
+		{
+			name: "trusted launch",
+			securityProfile: &infrav1.SecurityProfile{
+				SecurityType: infrav1.SecurityTypesTrustedLaunch,
+				UefiSettings: &infrav1.UefiSettings{
+					SecureBootEnabled: to.BoolPtr(true),
+					VTpmEnabled:       to.BoolPtr(true),
+				},
+			},
+			want: &compute.SecurityProfile{
+				SecurityType: compute.SecurityTypesTrustedLaunch,
+				UefiSettings: &compute.UefiSettings{
+					SecureBootEnabled: to.BoolPtr(true),
+					VTpmEnabled:       to.BoolPtr(true),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(SecurityProfileToSDK(tt.securityProfile)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestDiskSecurityProfileToSDK(t *testing.T) {
+	tests := []struct {
+		name                string
+		diskSecurityProfile *infrav1.VMDiskSecurityProfile
+		want                *compute.VMDiskSecurityProfile
+	}{
+		{
+			name:                "nil disk security profile",
+			diskSecurityProfile: nil,
+			want:                nil,
+		},
+		{
+			name: "vm guest state only",
+			diskSecurityProfile: &infrav1.VMDiskSecurityProfile{
+				SecurityEncryptionType: infrav1.SecurityEncryptionTypeVMGuestStateOnly,
+			},
+			want: &compute.VMDiskSecurityProfile{
+				SecurityEncryptionType: compute.SecurityEncryptionTypesVMGuestStateOnly,
+			},
+		},
+		{
+			name: "disk with vm guest state and disk encryption set",
+			diskSecurityProfile: &infrav1.VMDiskSecurityProfile{
+				SecurityEncryptionType: infrav1.SecurityEncryptionTypeDiskWithVMGuestState,
+				DiskEncryptionSet:      &infrav1.DiskEncryptionSetParameters{ID: "encryption-set-id"},
+			},
+			want: &compute.VMDiskSecurityProfile{
+				SecurityEncryptionType: compute.SecurityEncryptionTypesDiskWithVMGuestState,
+				DiskEncryptionSet:      &compute.DiskEncryptionSetParameters{ID: to.StringPtr("encryption-set-id")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(DiskSecurityProfileToSDK(tt.diskSecurityProfile)).To(Equal(tt.want))
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
@@ -67,6 +68,14 @@ const (
 	PrivateAPIServerHostname = "apiserver"
 )
 
+const (
+	// FirewallEgressRouteName is the name of the route sending the egress traffic of a subnet to the Azure Firewall of the cluster.
+	FirewallEgressRouteName = "azure-firewall-egress"
+	// FirewallRuleCollectionGroupName is the name of the rule collection group allowing the egress traffic of the cluster
+	// in its firewall policy.
+	FirewallRuleCollectionGroupName = "kubernetes-egress"
+)
+
 const (
 	// ControlPlaneNodeGroup will be used to create availability set for control plane machines.
 	ControlPlaneNodeGroup = "control-plane"
@@ -178,6 +187,11 @@ func GenerateNICName(machineName string) string {
 	return fmt.Sprintf("%s-nic", machineName)
 }
 
+// GenerateSecondaryNICName generates the name of a secondary network interface based on the name of a VM and the index of the network interface.
+func GenerateSecondaryNICName(machineName string, index int) string {
+	return fmt.Sprintf("%s-nic-%d", machineName, index)
+}
+
 // GeneratePublicNICName generates the name of a public network interface based on the name of a VM.
 func GeneratePublicNICName(machineName string) string {
 	return fmt.Sprintf("%s-public-nic", machineName)
@@ -276,6 +290,11 @@ func NATRuleID(subscriptionID, resourceGroup, loadBalancerName, natRuleName stri
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s/inboundNatRules/%s", subscriptionID, resourceGroup, loadBalancerName, natRuleName)
 }
 
+// InboundNATPoolID returns the azure resource ID for an inbound NAT pool.
+func InboundNATPoolID(subscriptionID, resourceGroup, loadBalancerName, natPoolName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s/inboundNatPools/%s", subscriptionID, resourceGroup, loadBalancerName, natPoolName)
+}
+
 // AvailabilitySetID returns the azure resource ID for a given availability set.
 func AvailabilitySetID(subscriptionID, resourceGroup, availabilitySetName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/availabilitySets/%s", subscriptionID, resourceGroup, availabilitySetName)
@@ -291,6 +310,26 @@ func VirtualNetworkLinkID(subscriptionID, resourceGroup, privateDNSZoneName, vir
 	return fmt.Sprintf("subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/privateDnsZones/%s/virtualNetworkLinks/%s", subscriptionID, resourceGroup, privateDNSZoneName, virtualNetworkLinkName)
 }
 
+// PrivateEndpointID returns the azure resource ID for a given private endpoint.
+func PrivateEndpointID(subscriptionID, resourceGroup, privateEndpointName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/privateEndpoints/%s", subscriptionID, resourceGroup, privateEndpointName)
+}
+
+// PublicIPPrefixID returns the azure resource ID for a given public IP prefix.
+func PublicIPPrefixID(subscriptionID, resourceGroup, publicIPPrefixName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPPrefixes/%s", subscriptionID, resourceGroup, publicIPPrefixName)
+}
+
+// ApplicationSecurityGroupID returns the azure resource ID for a given application security group.
+func ApplicationSecurityGroupID(subscriptionID, resourceGroup, applicationSecurityGroupName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationSecurityGroups/%s", subscriptionID, resourceGroup, applicationSecurityGroupName)
+}
+
+// FirewallPolicyID returns the azure resource ID for a given firewall policy.
+func FirewallPolicyID(subscriptionID, resourceGroup, firewallPolicyName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/firewallPolicies/%s", subscriptionID, resourceGroup, firewallPolicyName)
+}
+
 // GetBootstrappingVMExtension returns the CAPZ Bootstrapping VM extension.
 // The CAPZ Bootstrapping extension is a simple clone of https://github.com/Azure/custom-script-extension-linux for Linux or
 // https://docs.microsoft.com/en-us/azure/virtual-machines/extensions/custom-script-windows for Windows.
@@ -338,6 +377,8 @@ func SetAutoRestClientDefaults(c *autorest.Client, auth autorest.Authorizer) {
 	// The wrapped Sender should set the x-ms-correlation-request-id on the given
 	// request, then pass the new request to the underlying Sender.
 	c.Sender = autorest.DecorateSender(c.Sender, msCorrelationIDSendDecorator)
+	// Wrap the Sender to apply the client-side rate limits of the subscription and service the request is sent to.
+	c.Sender = autorest.DecorateSender(c.Sender, ratelimit.SendDecorator)
 	// The default number of retries is 3. This means the client will attempt to retry operation results like resource
 	// conflicts (HTTP 409). For a reconciling controller, this is undesirable behavior since if the controller runs
 	// into an error reconciling, the controller would be better off to end with an error and try again later.
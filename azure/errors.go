@@ -57,6 +57,23 @@ func (vde VMDeletedError) Error() string {
 	return fmt.Sprintf("VM with provider id %q has been deleted", vde.ProviderID)
 }
 
+// BootstrapFailedError is returned when a VM extension that bootstraps a virtual machine failed to provision.
+// It holds the last lines of the serial console log of the virtual machine to report them on the machine.
+type BootstrapFailedError struct {
+	Err                  error
+	SerialConsoleLogTail string
+}
+
+// Error returns the error string.
+func (bfe BootstrapFailedError) Error() string {
+	return bfe.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (bfe BootstrapFailedError) Unwrap() error {
+	return bfe.Err
+}
+
 // ReconcileError represents an error that is not automatically recoverable
 // errorType indicates what type of action is required to recover. It can take two values:
 // 1. `Transient` - Can be recovered through manual intervention, will be requeued after.
@@ -93,6 +110,11 @@ func (t ReconcileError) Error() string {
 	}
 }
 
+// Unwrap returns the underlying error.
+func (t ReconcileError) Unwrap() error {
+	return t.error
+}
+
 // IsTransient returns if the ReconcileError is recoverable.
 func (t ReconcileError) IsTransient() bool {
 	return t.errorType == TransientErrorType
@@ -153,3 +175,35 @@ func IsOperationNotDoneError(target error) bool {
 	}
 	return errors.As(target, &OperationNotDoneError{})
 }
+
+// OperationPlannedError is used to represent an operation that was planned in dry-run mode rather than performed.
+// It wraps an OperationNotDoneError, so that it is handled like an operation in progress: the resource is not ready.
+type OperationPlannedError struct {
+	Future *infrav1.Future
+}
+
+// NewOperationPlannedError returns a new OperationPlannedError for the operation described by a Future.
+func NewOperationPlannedError(future *infrav1.Future) OperationPlannedError {
+	return OperationPlannedError{
+		Future: future,
+	}
+}
+
+// Error returns the error represented as a string.
+func (ope OperationPlannedError) Error() string {
+	return fmt.Sprintf("operation type %s on Azure resource %s/%s is planned in dry-run mode", ope.Future.Type, ope.Future.ResourceGroup, ope.Future.Name)
+}
+
+// Unwrap returns the OperationNotDoneError of the planned operation.
+func (ope OperationPlannedError) Unwrap() error {
+	return NewOperationNotDoneError(ope.Future)
+}
+
+// IsOperationPlannedError returns true if the target is an OperationPlannedError.
+func IsOperationPlannedError(target error) bool {
+	reconcileErr := &ReconcileError{}
+	if errors.As(target, reconcileErr) {
+		return IsOperationPlannedError(reconcileErr.error)
+	}
+	return errors.As(target, &OperationPlannedError{})
+}
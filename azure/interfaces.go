@@ -59,6 +59,7 @@ type NetworkDescriber interface {
 	NodeSubnets() []infrav1.SubnetSpec
 	SetSubnet(infrav1.SubnetSpec)
 	IsIPv6Enabled() bool
+	ApplicationSecurityGroups() infrav1.ApplicationSecurityGroups
 	ControlPlaneRouteTable() infrav1.RouteTable
 	APIServerLB() *infrav1.LoadBalancerSpec
 	APIServerLBName() string
@@ -66,6 +67,7 @@ type NetworkDescriber interface {
 	IsAPIServerPrivate() bool
 	GetPrivateDNSZoneName() string
 	OutboundLBName(string) string
+	OutboundLBInboundNATPoolNames(string) []string
 	OutboundPoolName(string) string
 }
 
@@ -118,6 +120,43 @@ type ResourceSpecGetter interface {
 	Parameters(existing interface{}) (params interface{}, err error)
 }
 
+// ResourceSpecGetterWithPatch is a ResourceSpecGetter that can return parameters to update an existing
+// resource with a PATCH request instead of a PUT.
+type ResourceSpecGetterWithPatch interface {
+	ResourceSpecGetter
+	// PatchParameters takes the existing resource and returns the parameters of a PATCH request to update it.
+	// If no update is needed on the resource, PatchParameters should return nil.
+	PatchParameters(existing interface{}) (params interface{}, err error)
+}
+
+// ResourceSpecGetterWithDrift is a ResourceSpecGetter that can describe how an existing resource differs from the spec.
+type ResourceSpecGetterWithDrift interface {
+	ResourceSpecGetter
+	// Drift takes the existing resource and returns the fields that differ from the spec, e.g. "securityRules[allow_ssh].priority".
+	// If the existing resource matches the spec, Drift should return nil.
+	Drift(existing interface{}) (fields []string, err error)
+}
+
+// DriftRecorder is an interface used to record drift between specs and the live Azure resources.
+type DriftRecorder interface {
+	// RecordDrift records the fields of a resource that differ from its spec.
+	RecordDrift(serviceName, resourceName string, fields []string)
+	// DriftReportOnly returns true if drifted resources should be reported but not updated.
+	DriftReportOnly() bool
+	// LastAppliedSpecHash returns the hash of the spec last applied to a resource, or an empty string if none was recorded.
+	LastAppliedSpecHash(serviceName, resourceName string) string
+	// SetLastAppliedSpecHash records the hash of the spec applied to a resource.
+	SetLastAppliedSpecHash(serviceName, resourceName, hash string)
+}
+
+// OperationPlanner is an interface used to plan the Azure operations of a reconciliation instead of performing them.
+type OperationPlanner interface {
+	// DryRun returns true if Azure operations should be planned but not performed.
+	DryRun() bool
+	// PlanOperation records an operation, such as PlannedCreate, that would be performed on a resource.
+	PlanOperation(operation, serviceName, resourceGroupName, resourceName string)
+}
+
 // ResourceSpecGetterWithHeaders is a ResourceSpecGetter that can return custom headers to be added to API calls.
 type ResourceSpecGetterWithHeaders interface {
 	ResourceSpecGetter
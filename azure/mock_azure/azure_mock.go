@@ -361,6 +361,20 @@ func (mr *MockNetworkDescriberMockRecorder) APIServerLBPoolName(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIServerLBPoolName", reflect.TypeOf((*MockNetworkDescriber)(nil).APIServerLBPoolName), arg0)
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockNetworkDescriber) ApplicationSecurityGroups() v1beta1.ApplicationSecurityGroups {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].(v1beta1.ApplicationSecurityGroups)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockNetworkDescriberMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockNetworkDescriber)(nil).ApplicationSecurityGroups))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockNetworkDescriber) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -459,6 +473,20 @@ func (mr *MockNetworkDescriberMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockNetworkDescriber)(nil).NodeSubnets))
 }
 
+// OutboundLBInboundNATPoolNames mocks base method.
+func (m *MockNetworkDescriber) OutboundLBInboundNATPoolNames(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundLBInboundNATPoolNames", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OutboundLBInboundNATPoolNames indicates an expected call of OutboundLBInboundNATPoolNames.
+func (mr *MockNetworkDescriberMockRecorder) OutboundLBInboundNATPoolNames(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBInboundNATPoolNames", reflect.TypeOf((*MockNetworkDescriber)(nil).OutboundLBInboundNATPoolNames), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockNetworkDescriber) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -950,6 +978,20 @@ func (mr *MockClusterScoperMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockClusterScoper)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockClusterScoper) ApplicationSecurityGroups() v1beta1.ApplicationSecurityGroups {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].(v1beta1.ApplicationSecurityGroups)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockClusterScoperMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockClusterScoper)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockClusterScoper) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -1202,6 +1244,20 @@ func (mr *MockClusterScoperMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockClusterScoper)(nil).NodeSubnets))
 }
 
+// OutboundLBInboundNATPoolNames mocks base method.
+func (m *MockClusterScoper) OutboundLBInboundNATPoolNames(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundLBInboundNATPoolNames", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OutboundLBInboundNATPoolNames indicates an expected call of OutboundLBInboundNATPoolNames.
+func (mr *MockClusterScoperMockRecorder) OutboundLBInboundNATPoolNames(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBInboundNATPoolNames", reflect.TypeOf((*MockClusterScoper)(nil).OutboundLBInboundNATPoolNames), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockClusterScoper) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -1653,6 +1709,320 @@ func (mr *MockResourceSpecGetterMockRecorder) ResourceName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceName", reflect.TypeOf((*MockResourceSpecGetter)(nil).ResourceName))
 }
 
+// MockResourceSpecGetterWithPatch is a mock of ResourceSpecGetterWithPatch interface.
+type MockResourceSpecGetterWithPatch struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceSpecGetterWithPatchMockRecorder
+}
+
+// MockResourceSpecGetterWithPatchMockRecorder is the mock recorder for MockResourceSpecGetterWithPatch.
+type MockResourceSpecGetterWithPatchMockRecorder struct {
+	mock *MockResourceSpecGetterWithPatch
+}
+
+// NewMockResourceSpecGetterWithPatch creates a new mock instance.
+func NewMockResourceSpecGetterWithPatch(ctrl *gomock.Controller) *MockResourceSpecGetterWithPatch {
+	mock := &MockResourceSpecGetterWithPatch{ctrl: ctrl}
+	mock.recorder = &MockResourceSpecGetterWithPatchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceSpecGetterWithPatch) EXPECT() *MockResourceSpecGetterWithPatchMockRecorder {
+	return m.recorder
+}
+
+// OwnerResourceName mocks base method.
+func (m *MockResourceSpecGetterWithPatch) OwnerResourceName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnerResourceName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OwnerResourceName indicates an expected call of OwnerResourceName.
+func (mr *MockResourceSpecGetterWithPatchMockRecorder) OwnerResourceName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnerResourceName", reflect.TypeOf((*MockResourceSpecGetterWithPatch)(nil).OwnerResourceName))
+}
+
+// Parameters mocks base method.
+func (m *MockResourceSpecGetterWithPatch) Parameters(existing interface{}) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parameters", existing)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parameters indicates an expected call of Parameters.
+func (mr *MockResourceSpecGetterWithPatchMockRecorder) Parameters(existing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parameters", reflect.TypeOf((*MockResourceSpecGetterWithPatch)(nil).Parameters), existing)
+}
+
+// PatchParameters mocks base method.
+func (m *MockResourceSpecGetterWithPatch) PatchParameters(existing interface{}) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchParameters", existing)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchParameters indicates an expected call of PatchParameters.
+func (mr *MockResourceSpecGetterWithPatchMockRecorder) PatchParameters(existing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchParameters", reflect.TypeOf((*MockResourceSpecGetterWithPatch)(nil).PatchParameters), existing)
+}
+
+// ResourceGroupName mocks base method.
+func (m *MockResourceSpecGetterWithPatch) ResourceGroupName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceGroupName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResourceGroupName indicates an expected call of ResourceGroupName.
+func (mr *MockResourceSpecGetterWithPatchMockRecorder) ResourceGroupName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroupName", reflect.TypeOf((*MockResourceSpecGetterWithPatch)(nil).ResourceGroupName))
+}
+
+// ResourceName mocks base method.
+func (m *MockResourceSpecGetterWithPatch) ResourceName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResourceName indicates an expected call of ResourceName.
+func (mr *MockResourceSpecGetterWithPatchMockRecorder) ResourceName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceName", reflect.TypeOf((*MockResourceSpecGetterWithPatch)(nil).ResourceName))
+}
+
+// MockResourceSpecGetterWithDrift is a mock of ResourceSpecGetterWithDrift interface.
+type MockResourceSpecGetterWithDrift struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceSpecGetterWithDriftMockRecorder
+}
+
+// MockResourceSpecGetterWithDriftMockRecorder is the mock recorder for MockResourceSpecGetterWithDrift.
+type MockResourceSpecGetterWithDriftMockRecorder struct {
+	mock *MockResourceSpecGetterWithDrift
+}
+
+// NewMockResourceSpecGetterWithDrift creates a new mock instance.
+func NewMockResourceSpecGetterWithDrift(ctrl *gomock.Controller) *MockResourceSpecGetterWithDrift {
+	mock := &MockResourceSpecGetterWithDrift{ctrl: ctrl}
+	mock.recorder = &MockResourceSpecGetterWithDriftMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceSpecGetterWithDrift) EXPECT() *MockResourceSpecGetterWithDriftMockRecorder {
+	return m.recorder
+}
+
+// Drift mocks base method.
+func (m *MockResourceSpecGetterWithDrift) Drift(existing interface{}) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drift", existing)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Drift indicates an expected call of Drift.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) Drift(existing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drift", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).Drift), existing)
+}
+
+// OwnerResourceName mocks base method.
+func (m *MockResourceSpecGetterWithDrift) OwnerResourceName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnerResourceName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OwnerResourceName indicates an expected call of OwnerResourceName.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) OwnerResourceName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnerResourceName", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).OwnerResourceName))
+}
+
+// Parameters mocks base method.
+func (m *MockResourceSpecGetterWithDrift) Parameters(existing interface{}) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parameters", existing)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parameters indicates an expected call of Parameters.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) Parameters(existing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parameters", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).Parameters), existing)
+}
+
+// ResourceGroupName mocks base method.
+func (m *MockResourceSpecGetterWithDrift) ResourceGroupName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceGroupName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResourceGroupName indicates an expected call of ResourceGroupName.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) ResourceGroupName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroupName", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).ResourceGroupName))
+}
+
+// ResourceName mocks base method.
+func (m *MockResourceSpecGetterWithDrift) ResourceName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResourceName indicates an expected call of ResourceName.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) ResourceName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceName", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).ResourceName))
+}
+
+// MockDriftRecorder is a mock of DriftRecorder interface.
+type MockDriftRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriftRecorderMockRecorder
+}
+
+// MockDriftRecorderMockRecorder is the mock recorder for MockDriftRecorder.
+type MockDriftRecorderMockRecorder struct {
+	mock *MockDriftRecorder
+}
+
+// NewMockDriftRecorder creates a new mock instance.
+func NewMockDriftRecorder(ctrl *gomock.Controller) *MockDriftRecorder {
+	mock := &MockDriftRecorder{ctrl: ctrl}
+	mock.recorder = &MockDriftRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriftRecorder) EXPECT() *MockDriftRecorderMockRecorder {
+	return m.recorder
+}
+
+// DriftReportOnly mocks base method.
+func (m *MockDriftRecorder) DriftReportOnly() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftReportOnly")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DriftReportOnly indicates an expected call of DriftReportOnly.
+func (mr *MockDriftRecorderMockRecorder) DriftReportOnly() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftReportOnly", reflect.TypeOf((*MockDriftRecorder)(nil).DriftReportOnly))
+}
+
+// LastAppliedSpecHash mocks base method.
+func (m *MockDriftRecorder) LastAppliedSpecHash(serviceName, resourceName string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastAppliedSpecHash", serviceName, resourceName)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// LastAppliedSpecHash indicates an expected call of LastAppliedSpecHash.
+func (mr *MockDriftRecorderMockRecorder) LastAppliedSpecHash(serviceName, resourceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastAppliedSpecHash", reflect.TypeOf((*MockDriftRecorder)(nil).LastAppliedSpecHash), serviceName, resourceName)
+}
+
+// RecordDrift mocks base method.
+func (m *MockDriftRecorder) RecordDrift(serviceName, resourceName string, fields []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordDrift", serviceName, resourceName, fields)
+}
+
+// RecordDrift indicates an expected call of RecordDrift.
+func (mr *MockDriftRecorderMockRecorder) RecordDrift(serviceName, resourceName, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDrift", reflect.TypeOf((*MockDriftRecorder)(nil).RecordDrift), serviceName, resourceName, fields)
+}
+
+// SetLastAppliedSpecHash mocks base method.
+func (m *MockDriftRecorder) SetLastAppliedSpecHash(serviceName, resourceName, hash string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLastAppliedSpecHash", serviceName, resourceName, hash)
+}
+
+// SetLastAppliedSpecHash indicates an expected call of SetLastAppliedSpecHash.
+func (mr *MockDriftRecorderMockRecorder) SetLastAppliedSpecHash(serviceName, resourceName, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAppliedSpecHash", reflect.TypeOf((*MockDriftRecorder)(nil).SetLastAppliedSpecHash), serviceName, resourceName, hash)
+}
+
+// MockOperationPlanner is a mock of OperationPlanner interface.
+type MockOperationPlanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationPlannerMockRecorder
+}
+
+// MockOperationPlannerMockRecorder is the mock recorder for MockOperationPlanner.
+type MockOperationPlannerMockRecorder struct {
+	mock *MockOperationPlanner
+}
+
+// NewMockOperationPlanner creates a new mock instance.
+func NewMockOperationPlanner(ctrl *gomock.Controller) *MockOperationPlanner {
+	mock := &MockOperationPlanner{ctrl: ctrl}
+	mock.recorder = &MockOperationPlannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationPlanner) EXPECT() *MockOperationPlannerMockRecorder {
+	return m.recorder
+}
+
+// DryRun mocks base method.
+func (m *MockOperationPlanner) DryRun() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRun")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DryRun indicates an expected call of DryRun.
+func (mr *MockOperationPlannerMockRecorder) DryRun() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRun", reflect.TypeOf((*MockOperationPlanner)(nil).DryRun))
+}
+
+// PlanOperation mocks base method.
+func (m *MockOperationPlanner) PlanOperation(operation, serviceName, resourceGroupName, resourceName string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PlanOperation", operation, serviceName, resourceGroupName, resourceName)
+}
+
+// PlanOperation indicates an expected call of PlanOperation.
+func (mr *MockOperationPlannerMockRecorder) PlanOperation(operation, serviceName, resourceGroupName, resourceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanOperation", reflect.TypeOf((*MockOperationPlanner)(nil).PlanOperation), operation, serviceName, resourceGroupName, resourceName)
+}
+
 // MockResourceSpecGetterWithHeaders is a mock of ResourceSpecGetterWithHeaders interface.
 type MockResourceSpecGetterWithHeaders struct {
 	ctrl     *gomock.Controller
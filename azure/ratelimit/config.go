@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Limit is a token bucket limit on the rate of requests.
+type Limit struct {
+	// QPS is the average number of requests allowed per second. Zero disables the limit.
+	QPS float64 `json:"qps"`
+	// Burst is the maximum number of requests allowed at once. It defaults to QPS rounded up.
+	Burst int `json:"burst,omitempty"`
+}
+
+// Limits holds the limits of read and write requests.
+type Limits struct {
+	// Read limits GET and HEAD requests.
+	Read Limit `json:"read,omitempty"`
+	// Write limits all the other requests, e.g. PUT, PATCH and DELETE.
+	Write Limit `json:"write,omitempty"`
+}
+
+// Config configures the client-side rate limiting of Azure Resource Manager requests.
+type Config struct {
+	// Subscription limits apply to all the requests sent to a subscription.
+	Subscription Limits `json:"subscription,omitempty"`
+	// Services limits apply, in addition to the subscription limits, to the requests sent to a subscription for a
+	// resource type, e.g. Microsoft.Compute/virtualMachines or Microsoft.Network/virtualNetworks/subnets.
+	Services map[string]Limits `json:"services,omitempty"`
+}
+
+// LoadConfig reads a Config from a YAML or JSON file, such as a mounted ConfigMap.
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return config, errors.Wrapf(err, "failed to read rate limits config %s", path)
+	}
+	if err := yaml.UnmarshalStrict(raw, &config); err != nil {
+		return config, errors.Wrapf(err, "failed to parse rate limits config %s", path)
+	}
+	return config, config.Validate()
+}
+
+// Validate returns an error if a limit of the config is invalid.
+func (c Config) Validate() error {
+	if err := c.Subscription.validate("subscription"); err != nil {
+		return err
+	}
+	for service, limits := range c.Services {
+		if !strings.Contains(service, "/") {
+			return errors.Errorf("invalid rate limits for service %q: services must be resource types such as Microsoft.Compute/virtualMachines", service)
+		}
+		if err := limits.validate(service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Enabled returns true if the config limits any request.
+func (c Config) Enabled() bool {
+	if c.Subscription.enabled() {
+		return true
+	}
+	for _, limits := range c.Services {
+		if limits.enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+func (l Limits) validate(name string) error {
+	for kind, limit := range map[string]Limit{readKind: l.Read, writeKind: l.Write} {
+		if limit.QPS < 0 || limit.Burst < 0 {
+			return errors.Errorf("invalid %s rate limit for %s: qps and burst must not be negative", kind, name)
+		}
+	}
+	return nil
+}
+
+func (l Limits) enabled() bool {
+	return l.Read.QPS > 0 || l.Write.QPS > 0
+}
+
+// forKind returns the limit for read or write requests.
+func (l Limits) forKind(kind string) Limit {
+	if kind == readKind {
+		return l.Read
+	}
+	return l.Write
+}
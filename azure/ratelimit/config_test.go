@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cases := map[string]struct {
+		content     string
+		expected    Config
+		expectedErr string
+	}{
+		"subscription and service limits": {
+			content: `
+subscription:
+  read:
+    qps: 10
+    burst: 100
+  write:
+    qps: 1
+services:
+  Microsoft.Compute/virtualMachines:
+    write:
+      qps: 0.5
+      burst: 5
+`,
+			expected: Config{
+				Subscription: Limits{
+					Read:  Limit{QPS: 10, Burst: 100},
+					Write: Limit{QPS: 1},
+				},
+				Services: map[string]Limits{
+					"Microsoft.Compute/virtualMachines": {Write: Limit{QPS: 0.5, Burst: 5}},
+				},
+			},
+		},
+		"negative limit": {
+			content:     "subscription: {write: {qps: -1}}",
+			expectedErr: "invalid write rate limit for subscription",
+		},
+		"service is not a resource type": {
+			content:     "services: {virtualMachines: {write: {qps: 1}}}",
+			expectedErr: "invalid rate limits for service",
+		},
+		"unknown field": {
+			content:     "subscription: {write: {rate: 1}}",
+			expectedErr: "failed to parse rate limits config",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			g.Expect(os.WriteFile(path, []byte(tc.content), 0600)).To(Succeed())
+			config, err := LoadConfig(path)
+			if tc.expectedErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(config).To(Equal(tc.expected))
+			g.Expect(config.Enabled()).To(BeTrue())
+		})
+	}
+}
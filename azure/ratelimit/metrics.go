@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	delayedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_azure_rate_limiter_delayed_requests_total",
+			Help: "Total number of Azure Resource Manager requests delayed by the client-side rate limiter.",
+		},
+		[]string{"service", "kind"},
+	)
+
+	delaySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "capz_azure_rate_limiter_delay_seconds",
+			Help:    "Time Azure Resource Manager requests waited for the client-side rate limiter.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 4, 8),
+		},
+		[]string{"service", "kind"},
+	)
+
+	throttledResponses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_azure_throttled_responses_total",
+			Help: "Total number of Azure Resource Manager requests throttled by Azure with a 429 Too Many Requests response.",
+		},
+		[]string{"service", "kind"},
+	)
+
+	pausedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_azure_throttling_paused_requests_total",
+			Help: "Total number of Azure Resource Manager requests not sent because Azure throttled their subscription.",
+		},
+		[]string{"service", "kind"},
+	)
+
+	remainingRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capz_azure_subscription_remaining_requests",
+			Help: "Number of requests Azure Resource Manager last reported as remaining for a subscription before it is throttled.",
+		},
+		[]string{"subscription", "kind"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(delayedRequests, delaySeconds, throttledResponses, pausedRequests, remainingRequests)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit implements client-side rate limiting of Azure Resource Manager requests, to avoid exhausting
+// the request quotas of a subscription shared by many clusters, and pauses the requests to subscriptions throttled by Azure.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"golang.org/x/time/rate"
+)
+
+const (
+	readKind  = "read"
+	writeKind = "write"
+)
+
+// Limiter limits the rate of Azure Resource Manager requests per subscription and per service.
+type Limiter struct {
+	config Config
+	// services holds the limits of config.Services keyed by lowercase resource type.
+	services map[string]Limits
+
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+// NewLimiter returns a Limiter enforcing the limits of config.
+func NewLimiter(config Config) *Limiter {
+	services := make(map[string]Limits, len(config.Services))
+	for service, limits := range config.Services {
+		services[strings.ToLower(service)] = limits
+	}
+	return &Limiter{
+		config:   config,
+		services: services,
+		buckets:  map[string]*rate.Limiter{},
+	}
+}
+
+// Wait blocks until the request is allowed by the limits of its subscription and service, or ctx is done.
+func (l *Limiter) Wait(ctx context.Context, req *http.Request) error {
+	subscription, service := parsePath(req.URL.Path)
+	kind := requestKind(req.Method)
+
+	start := time.Now()
+	delayed := false
+	if subscription != "" {
+		if err := l.wait(ctx, &delayed, l.config.Subscription.forKind(kind), subscription, kind); err != nil {
+			return err
+		}
+		if limits, ok := l.services[service]; ok && service != "" {
+			if err := l.wait(ctx, &delayed, limits.forKind(kind), subscription, service, kind); err != nil {
+				return err
+			}
+		}
+	}
+	if delayed {
+		delayedRequests.WithLabelValues(service, kind).Inc()
+		delaySeconds.WithLabelValues(service, kind).Observe(time.Since(start).Seconds())
+	}
+	return nil
+}
+
+// wait waits for a token of the bucket identified by keys, and sets delayed if it had to wait.
+func (l *Limiter) wait(ctx context.Context, delayed *bool, limit Limit, keys ...string) error {
+	if limit.QPS <= 0 {
+		return nil
+	}
+	reservation := l.bucket(limit, keys...).Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	*delayed = true
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) bucket(limit Limit, keys ...string) *rate.Limiter {
+	key := strings.Join(keys, "/")
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		burst := limit.Burst
+		if burst == 0 {
+			burst = int(math.Ceil(limit.QPS))
+		}
+		bucket = rate.NewLimiter(rate.Limit(limit.QPS), burst)
+		l.buckets[key] = bucket
+	}
+	return bucket
+}
+
+// limiter is the Limiter used by SendDecorator. It is nil when rate limiting is disabled.
+var limiter atomic.Value
+
+// Configure sets the limits enforced by SendDecorator on the requests of all Azure clients.
+func Configure(config Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	limiter.Store(NewLimiter(config))
+	return nil
+}
+
+// SendDecorator rate limits the requests sent by an autorest client with the limits set by Configure,
+// and pauses the requests to subscriptions throttled by Azure until their throttling window passes.
+func SendDecorator(s autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		subscription, service := parsePath(req.URL.Path)
+		kind := requestKind(req.Method)
+		if retryAfter := throttles.Paused(subscription, kind); retryAfter > 0 {
+			pausedRequests.WithLabelValues(service, kind).Inc()
+			return throttledResponse(req, subscription, retryAfter), nil
+		}
+		if l, ok := limiter.Load().(*Limiter); ok {
+			if err := l.Wait(req.Context(), req); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := s.Do(req)
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			throttledResponses.WithLabelValues(service, kind).Inc()
+		}
+		throttles.Observe(req, resp)
+		return resp, err
+	})
+}
+
+// requestKind returns whether a request with the given method reads or writes resources.
+func requestKind(method string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		return readKind
+	}
+	return writeKind
+}
+
+// parsePath returns the lowercase subscription ID and resource type of an Azure Resource Manager request path.
+// For example, the resource type of /subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet
+// is microsoft.network/virtualnetworks/subnets. Requests on extension resources such as tags use the type of the
+// extension, e.g. microsoft.resources/tags.
+func parsePath(path string) (subscription, service string) {
+	segments := strings.FieldsFunc(strings.ToLower(path), func(c rune) bool { return c == '/' })
+	if len(segments) < 2 || segments[0] != "subscriptions" {
+		return "", ""
+	}
+	subscription = segments[1]
+
+	providers := -1
+	for i, segment := range segments {
+		if segment == "providers" && i+1 < len(segments) {
+			providers = i
+		}
+	}
+	if providers < 0 {
+		// Requests on resource groups, e.g. /subscriptions/123/resourceGroups/my-rg.
+		if len(segments) >= 3 {
+			return subscription, "microsoft.resources/" + segments[2]
+		}
+		return subscription, "microsoft.resources/subscriptions"
+	}
+
+	types := []string{segments[providers+1]}
+	for i := providers + 2; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+	return subscription, strings.Join(types, "/")
+}
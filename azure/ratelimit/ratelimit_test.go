@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestParsePath(t *testing.T) {
+	cases := []struct {
+		path         string
+		subscription string
+		service      string
+	}{
+		{
+			path:         "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+			subscription: "123",
+			service:      "microsoft.compute/virtualmachines",
+		},
+		{
+			path:         "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet",
+			subscription: "123",
+			service:      "microsoft.network/virtualnetworks/subnets",
+		},
+		{
+			path:         "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses",
+			subscription: "123",
+			service:      "microsoft.network/publicipaddresses",
+		},
+		{
+			path:         "/subscriptions/123/resourceGroups/my-rg",
+			subscription: "123",
+			service:      "microsoft.resources/resourcegroups",
+		},
+		{
+			path:         "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Resources/tags/default",
+			subscription: "123",
+			service:      "microsoft.resources/tags",
+		},
+		{
+			path:         "/subscriptions/123/providers/Microsoft.Compute/skus",
+			subscription: "123",
+			service:      "microsoft.compute/skus",
+		},
+		{
+			path: "/providers/Microsoft.Compute/operations",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.path, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			subscription, service := parsePath(tc.path)
+			g.Expect(subscription).To(Equal(tc.subscription))
+			g.Expect(service).To(Equal(tc.service))
+		})
+	}
+}
+
+func newRequest(t *testing.T, method, path string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.TODO(), method, "https://management.azure.com"+path, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func TestLimiterWait(t *testing.T) {
+	g := NewWithT(t)
+
+	const (
+		vm  = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"
+		pip = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-pip"
+	)
+	l := NewLimiter(Config{
+		Subscription: Limits{Write: Limit{QPS: 100, Burst: 2}},
+		Services: map[string]Limits{
+			"Microsoft.Compute/virtualMachines": {Write: Limit{QPS: 0.001, Burst: 1}},
+		},
+	})
+	ctx := context.TODO()
+
+	// The service limit only allows a single VM write.
+	g.Expect(l.Wait(ctx, newRequest(t, http.MethodPut, vm))).To(Succeed())
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	g.Expect(l.Wait(timeoutCtx, newRequest(t, http.MethodPut, vm))).To(MatchError(context.DeadlineExceeded))
+
+	// Reads are not limited, and writes to other services only wait for the subscription limit.
+	for i := 0; i < 10; i++ {
+		g.Expect(l.Wait(ctx, newRequest(t, http.MethodGet, vm))).To(Succeed())
+	}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		g.Expect(l.Wait(ctx, newRequest(t, http.MethodDelete, pip))).To(Succeed())
+	}
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+
+	// Other subscriptions have their own limits.
+	g.Expect(l.Wait(ctx, newRequest(t, http.MethodPut, "/subscriptions/456/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"))).To(Succeed())
+}
+
+func TestSendDecorator(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Configure(Config{Subscription: Limits{Read: Limit{QPS: -1}}})).NotTo(Succeed())
+	g.Expect(Configure(Config{})).To(Succeed())
+
+	throttles = NewTracker()
+	sent := 0
+	sender := autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		sent++
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"30"}}, Body: http.NoBody, Request: req}, nil
+	})
+	path := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-lb"
+	before := testutil.ToFloat64(throttledResponses.WithLabelValues("microsoft.network/loadbalancers", writeKind))
+
+	resp, err := autorest.DecorateSender(sender, SendDecorator).Do(newRequest(t, http.MethodPut, path))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+	g.Expect(testutil.ToFloat64(throttledResponses.WithLabelValues("microsoft.network/loadbalancers", writeKind))).To(Equal(before + 1))
+
+	// Writes to the throttled subscription are paused without being sent to Azure, while reads are still sent.
+	resp, err = autorest.DecorateSender(sender, SendDecorator).Do(newRequest(t, http.MethodDelete, path))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+	g.Expect(resp.Header.Get("Retry-After")).To(Equal("30"))
+	g.Expect(sent).To(Equal(1))
+	g.Expect(testutil.ToFloat64(throttledResponses.WithLabelValues("microsoft.network/loadbalancers", writeKind))).To(Equal(before + 1))
+	_, err = autorest.DecorateSender(sender, SendDecorator).Do(newRequest(t, http.MethodGet, path))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sent).To(Equal(2))
+}
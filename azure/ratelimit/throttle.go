@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// remainingReadsHeader is the number of read requests Azure Resource Manager still allows for the subscription.
+	remainingReadsHeader = "x-ms-ratelimit-remaining-subscription-reads"
+	// remainingWritesHeader is the number of write requests Azure Resource Manager still allows for the subscription.
+	remainingWritesHeader = "x-ms-ratelimit-remaining-subscription-writes"
+
+	// minThrottleBackoff is how long requests are first paused when a subscription is throttled without a Retry-After.
+	minThrottleBackoff = 5 * time.Second
+	// maxThrottleBackoff caps how long requests are paused when a subscription is repeatedly throttled without a Retry-After.
+	maxThrottleBackoff = 5 * time.Minute
+)
+
+// pause holds the throttling state of the read or write requests of a subscription.
+type pause struct {
+	// until is the time at which requests can be sent again.
+	until time.Time
+	// backoff is the last pause duration, doubled every time the subscription is throttled again without a Retry-After.
+	backoff time.Duration
+}
+
+// Tracker tracks the throttling of subscriptions by Azure Resource Manager from the responses to all requests,
+// so that requests to a throttled subscription are paused for every controller rather than each of them retrying.
+type Tracker struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	pauses map[string]*pause
+}
+
+// NewTracker returns a Tracker of subscription throttling.
+func NewTracker() *Tracker {
+	return &Tracker{
+		now:    time.Now,
+		pauses: map[string]*pause{},
+	}
+}
+
+// Paused returns how long requests of the given kind to a subscription remain paused, or zero if they can be sent.
+func (t *Tracker) Paused(subscription, kind string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.pauses[subscription+"/"+kind]
+	if !ok {
+		return 0
+	}
+	if remaining := p.until.Sub(t.now()); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// Observe updates the throttling state of the subscription of req from its response. Requests are paused for the
+// Retry-After of 429 Too Many Requests responses, and for an exponential backoff when Azure throttles the subscription
+// without a Retry-After or reports that no request of the kind remains.
+func (t *Tracker) Observe(req *http.Request, resp *http.Response) {
+	subscription, _ := parsePath(req.URL.Path)
+	if subscription == "" || resp == nil {
+		return
+	}
+	kind := requestKind(req.Method)
+
+	header := remainingReadsHeader
+	if kind == writeKind {
+		header = remainingWritesHeader
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get(header))
+	hasRemaining := err == nil
+	if hasRemaining {
+		remainingRequests.WithLabelValues(subscription, kind).Set(float64(remaining))
+	}
+
+	throttled := resp.StatusCode == http.StatusTooManyRequests
+	if !throttled && (!hasRemaining || remaining > 0) {
+		t.reset(subscription, kind)
+		return
+	}
+	retryAfter := time.Duration(0)
+	if throttled {
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), t.now())
+	}
+	t.pause(subscription, kind, retryAfter)
+}
+
+// pause pauses requests of the given kind to a subscription for retryAfter, or for the next backoff if it is zero.
+func (t *Tracker) pause(subscription, kind string, retryAfter time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := subscription + "/" + kind
+	p, ok := t.pauses[key]
+	if !ok {
+		p = &pause{}
+		t.pauses[key] = p
+	}
+	if retryAfter <= 0 {
+		retryAfter = minThrottleBackoff
+		if p.backoff > 0 {
+			retryAfter = 2 * p.backoff
+		}
+		if retryAfter > maxThrottleBackoff {
+			retryAfter = maxThrottleBackoff
+		}
+	}
+	p.backoff = retryAfter
+	if until := t.now().Add(retryAfter); until.After(p.until) {
+		p.until = until
+	}
+}
+
+// reset forgets the backoff of a subscription once its pause is over and Azure stopped throttling it.
+func (t *Tracker) reset(subscription, kind string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := subscription + "/" + kind
+	if p, ok := t.pauses[key]; ok && !p.until.After(t.now()) {
+		delete(t.pauses, key)
+	}
+}
+
+// parseRetryAfter returns the duration of a Retry-After header in seconds or HTTP date format, or zero if it is invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// throttledResponse returns the 429 Too Many Requests response to req while its subscription is paused for retryAfter.
+// Autorest clients wait for its Retry-After before retrying, and reconcilers requeue after it when their context is done first.
+func throttledResponse(req *http.Request, subscription string, retryAfter time.Duration) *http.Response {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	body := fmt.Sprintf(`{"error":{"code":"SubscriptionRequestsThrottled","message":"Requests to subscription %s are paused for %ds because Azure Resource Manager throttled it."}}`, subscription, seconds)
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests)),
+		StatusCode: http.StatusTooManyRequests,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+			"Retry-After":  []string{strconv.Itoa(seconds)},
+		},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// throttles is the Tracker used by SendDecorator.
+var throttles = NewTracker()
+
+// Throttled returns how long the read and write requests to a subscription are paused for because Azure Resource
+// Manager throttled it. Both durations are zero when the subscription is not throttled.
+func Throttled(subscriptionID string) (reads, writes time.Duration) {
+	subscription := strings.ToLower(subscriptionID)
+	return throttles.Paused(subscription, readKind), throttles.Paused(subscription, writeKind)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTrackerObserve(t *testing.T) {
+	const path = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"
+	now := time.Date(2022, time.October, 1, 12, 0, 0, 0, time.UTC)
+	response := func(statusCode int, header ...string) *http.Response {
+		resp := &http.Response{StatusCode: statusCode, Header: http.Header{}}
+		for i := 0; i+1 < len(header); i += 2 {
+			resp.Header.Set(header[i], header[i+1])
+		}
+		return resp
+	}
+
+	cases := []struct {
+		name      string
+		method    string
+		responses []*http.Response
+		reads     time.Duration
+		writes    time.Duration
+	}{
+		{
+			name:      "successful responses do not pause requests",
+			method:    http.MethodPut,
+			responses: []*http.Response{response(http.StatusOK, remainingWritesHeader, "1199")},
+		},
+		{
+			name:      "429 pauses requests of the same kind for Retry-After seconds",
+			method:    http.MethodPut,
+			responses: []*http.Response{response(http.StatusTooManyRequests, "Retry-After", "17")},
+			writes:    17 * time.Second,
+		},
+		{
+			name:      "429 pauses requests until the Retry-After date",
+			method:    http.MethodGet,
+			responses: []*http.Response{response(http.StatusTooManyRequests, "Retry-After", now.Add(time.Minute).Format(http.TimeFormat))},
+			reads:     time.Minute,
+		},
+		{
+			name:      "429 without Retry-After pauses requests for the minimum backoff",
+			method:    http.MethodDelete,
+			responses: []*http.Response{response(http.StatusTooManyRequests)},
+			writes:    minThrottleBackoff,
+		},
+		{
+			name:   "repeated throttling doubles the backoff",
+			method: http.MethodGet,
+			responses: []*http.Response{
+				response(http.StatusTooManyRequests),
+				response(http.StatusTooManyRequests),
+				response(http.StatusOK, remainingReadsHeader, "0"),
+			},
+			reads: 4 * minThrottleBackoff,
+		},
+		{
+			name:      "no remaining requests pauses requests",
+			method:    http.MethodPatch,
+			responses: []*http.Response{response(http.StatusCreated, remainingWritesHeader, "0")},
+			writes:    minThrottleBackoff,
+		},
+		{
+			name:   "the backoff is capped",
+			method: http.MethodPut,
+			responses: []*http.Response{
+				response(http.StatusTooManyRequests, "Retry-After", "200"),
+				response(http.StatusTooManyRequests),
+			},
+			writes: maxThrottleBackoff,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			tracker := NewTracker()
+			tracker.now = func() time.Time { return now }
+			req := newRequest(t, tc.method, path)
+			for _, resp := range tc.responses {
+				tracker.Observe(req, resp)
+			}
+			g.Expect(tracker.Paused("123", readKind)).To(Equal(tc.reads))
+			g.Expect(tracker.Paused("123", writeKind)).To(Equal(tc.writes))
+			g.Expect(tracker.Paused("456", readKind)).To(BeZero())
+			g.Expect(tracker.Paused("456", writeKind)).To(BeZero())
+		})
+	}
+}
+
+func TestTrackerReset(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Date(2022, time.October, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return now }
+	req := newRequest(t, http.MethodPut, "/subscriptions/123/resourceGroups/my-rg")
+
+	tracker.Observe(req, &http.Response{StatusCode: http.StatusTooManyRequests})
+	g.Expect(tracker.Paused("123", writeKind)).To(Equal(minThrottleBackoff))
+
+	// Once the pause is over, a successful response resets the backoff.
+	now = now.Add(minThrottleBackoff)
+	g.Expect(tracker.Paused("123", writeKind)).To(BeZero())
+	tracker.Observe(req, &http.Response{StatusCode: http.StatusOK})
+	tracker.Observe(req, &http.Response{StatusCode: http.StatusTooManyRequests})
+	g.Expect(tracker.Paused("123", writeKind)).To(Equal(minThrottleBackoff))
+}
@@ -133,6 +133,56 @@ func (c *AzureClients) setCredentialsWithProvider(ctx context.Context, subscript
 	return err
 }
 
+// withSubscriptionID returns a copy of the clients that uses the given subscription.
+func (c *AzureClients) withSubscriptionID(subscriptionID string) *AzureClients {
+	clients := *c
+	clients.Values = make(map[string]string, len(c.Values))
+	for k, v := range c.Values {
+		clients.Values[k] = v
+	}
+	clients.Values[auth.SubscriptionID] = subscriptionID
+	return &clients
+}
+
+// withAuxiliaryTenant returns a copy of the clients whose requests also carry a token of the given tenant in the
+// x-ms-authorization-auxiliary header, as Azure requires to link resources across tenants, e.g. to peer virtual networks.
+func (c *AzureClients) withAuxiliaryTenant(tenantID string) (*AzureClients, error) {
+	if tenantID == "" || strings.EqualFold(tenantID, c.TenantID()) {
+		return c, nil
+	}
+	if c.ClientSecret() == "" {
+		return nil, fmt.Errorf("a client secret is required to get a token of auxiliary tenant %s with client %s", tenantID, c.ClientID())
+	}
+
+	config := auth.NewClientCredentialsConfig(c.ClientID(), c.ClientSecret(), c.TenantID())
+	config.AuxTenants = []string{tenantID}
+	config.AADEndpoint = c.Environment.ActiveDirectoryEndpoint
+	config.Resource = c.ResourceManagerEndpoint
+	authorizer, err := config.Authorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	clients := *c
+	clients.Authorizer = authorizer
+	return &clients, nil
+}
+
+// clientsAuthorizer implements azure.Authorizer for AzureClients that are not the ones of a scope.
+type clientsAuthorizer struct {
+	*AzureClients
+}
+
+// BaseURI returns the Azure ResourceManagerEndpoint.
+func (a clientsAuthorizer) BaseURI() string {
+	return a.ResourceManagerEndpoint
+}
+
+// Authorizer returns the Azure client Authorizer.
+func (a clientsAuthorizer) Authorizer() autorest.Authorizer {
+	return a.AzureClients.Authorizer
+}
+
 func (c *AzureClients) getSettingsFromEnvironment(environmentName string) (s auth.EnvironmentSettings, err error) {
 	s = auth.EnvironmentSettings{
 		Values: map[string]string{},
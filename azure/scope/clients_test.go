@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
 	. "github.com/onsi/gomega"
 )
 
@@ -82,3 +84,48 @@ func TestGettingEnvironment(t *testing.T) {
 		})
 	}
 }
+
+func TestWithAuxiliaryTenant(t *testing.T) {
+	newClients := func(clientSecret string) *AzureClients {
+		return &AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Environment: azure.PublicCloud,
+				Values: map[string]string{
+					auth.TenantID:     "my-tenant",
+					auth.ClientID:     "my-client",
+					auth.ClientSecret: clientSecret,
+				},
+			},
+			Authorizer:              autorest.NullAuthorizer{},
+			ResourceManagerEndpoint: azure.PublicCloud.ResourceManagerEndpoint,
+		}
+	}
+
+	t.Run("clients of the same tenant are returned as is", func(t *testing.T) {
+		g := NewWithT(t)
+		c := newClients("")
+		for _, tenantID := range []string{"", "my-tenant", "MY-TENANT"} {
+			clients, err := c.withAuxiliaryTenant(tenantID)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(clients).To(BeIdenticalTo(c))
+		}
+	})
+
+	t.Run("clients without a client secret can't authorize another tenant", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := newClients("").withAuxiliaryTenant("other-tenant")
+		g.Expect(err).To(MatchError("a client secret is required to get a token of auxiliary tenant other-tenant with client my-client"))
+	})
+
+	t.Run("clients with a client secret authorize another tenant", func(t *testing.T) {
+		g := NewWithT(t)
+		c := newClients("my-secret")
+		clients, err := c.withAuxiliaryTenant("other-tenant")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(clients.TenantID()).To(Equal("my-tenant"))
+		g.Expect(c.Authorizer).To(Equal(autorest.NullAuthorizer{}))
+		authorizer, ok := clients.Authorizer.(*autorest.MultiTenantBearerAuthorizer)
+		g.Expect(ok).To(BeTrue())
+		g.Expect(authorizer.TokenProvider().AuxiliaryOAuthTokens()).To(HaveLen(1))
+	})
+}
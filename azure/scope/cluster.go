@@ -21,21 +21,28 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/net"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
@@ -112,6 +119,10 @@ type ClusterScope struct {
 	Client      client.Client
 	patchHelper *patch.Helper
 	cache       *ClusterCache
+	// mu guards writes to the AzureCluster made by services, which may be reconciled concurrently.
+	mu    sync.Mutex
+	drift driftReport
+	plan  operationPlan
 
 	AzureClients
 	Cluster      *clusterv1.Cluster
@@ -144,29 +155,35 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 		if s.ControlPlaneOutboundLB() != nil {
 			for _, ip := range s.ControlPlaneOutboundLB().FrontendIPs {
 				controlPlaneOutboundIPSpecs = append(controlPlaneOutboundIPSpecs, &publicips.PublicIPSpec{
-					Name:           ip.PublicIP.Name,
-					ResourceGroup:  s.ResourceGroup(),
-					ClusterName:    s.ClusterName(),
-					DNSName:        "",    // Set to default value
-					IsIPv6:         false, // Set to default value
-					Location:       s.Location(),
-					FailureDomains: s.FailureDomains(),
-					AdditionalTags: s.AdditionalTags(),
+					Name:             ip.PublicIP.Name,
+					ResourceGroup:    s.ResourceGroup(),
+					ClusterName:      s.ClusterName(),
+					DNSName:          "", // Set to default value
+					IsIPv6:           ip.PublicIP.IPVersion == infrav1.IPVersionIPv6,
+					Location:         s.Location(),
+					FailureDomains:   s.FailureDomains(),
+					Zones:            s.publicIPZones(ip.PublicIP.PublicIPClassSpec),
+					Tier:             ip.PublicIP.Tier,
+					PublicIPPrefixID: s.publicIPPrefixID(ip.PublicIP.PublicIPClassSpec),
+					AdditionalTags:   s.AdditionalTags(),
 				})
 			}
 		}
 	} else {
 		controlPlaneOutboundIPSpecs = []azure.ResourceSpecGetter{
 			&publicips.PublicIPSpec{
-				Name:           s.APIServerPublicIP().Name,
-				ResourceGroup:  s.ResourceGroup(),
-				DNSName:        s.APIServerPublicIP().DNSName,
-				IsIPv6:         false, // Currently azure requires an IPv4 lb rule to enable IPv6
-				ClusterName:    s.ClusterName(),
-				Location:       s.Location(),
-				FailureDomains: s.FailureDomains(),
-				AdditionalTags: s.AdditionalTags(),
-				IPTags:         s.APIServerPublicIP().IPTags,
+				Name:             s.APIServerPublicIP().Name,
+				ResourceGroup:    s.ResourceGroup(),
+				DNSName:          s.APIServerPublicIP().DNSName,
+				IsIPv6:           false, // Currently azure requires an IPv4 lb rule to enable IPv6
+				ClusterName:      s.ClusterName(),
+				Location:         s.Location(),
+				FailureDomains:   s.FailureDomains(),
+				Zones:            s.publicIPZones(s.APIServerPublicIP().PublicIPClassSpec),
+				Tier:             s.APIServerPublicIP().Tier,
+				AdditionalTags:   s.AdditionalTags(),
+				IPTags:           s.APIServerPublicIP().IPTags,
+				PublicIPPrefixID: s.publicIPPrefixID(s.APIServerPublicIP().PublicIPClassSpec),
 			},
 		}
 	}
@@ -176,14 +193,17 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	if s.NodeOutboundLB() != nil {
 		for _, ip := range s.NodeOutboundLB().FrontendIPs {
 			publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
-				Name:           ip.PublicIP.Name,
-				ResourceGroup:  s.ResourceGroup(),
-				ClusterName:    s.ClusterName(),
-				DNSName:        "",    // Set to default value
-				IsIPv6:         false, // Set to default value
-				Location:       s.Location(),
-				FailureDomains: s.FailureDomains(),
-				AdditionalTags: s.AdditionalTags(),
+				Name:             ip.PublicIP.Name,
+				ResourceGroup:    s.ResourceGroup(),
+				ClusterName:      s.ClusterName(),
+				DNSName:          "", // Set to default value
+				IsIPv6:           ip.PublicIP.IPVersion == infrav1.IPVersionIPv6,
+				Location:         s.Location(),
+				FailureDomains:   s.FailureDomains(),
+				Zones:            s.publicIPZones(ip.PublicIP.PublicIPClassSpec),
+				Tier:             ip.PublicIP.Tier,
+				PublicIPPrefixID: s.publicIPPrefixID(ip.PublicIP.PublicIPClassSpec),
+				AdditionalTags:   s.AdditionalTags(),
 			})
 		}
 	}
@@ -193,15 +213,18 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	for _, subnet := range s.NodeSubnets() {
 		if subnet.IsNatGatewayEnabled() {
 			nodeNatGatewayIPSpecs = append(nodeNatGatewayIPSpecs, &publicips.PublicIPSpec{
-				Name:           subnet.NatGateway.NatGatewayIP.Name,
-				ResourceGroup:  s.ResourceGroup(),
-				DNSName:        subnet.NatGateway.NatGatewayIP.DNSName,
-				IsIPv6:         false, // Public IP is IPv4 by default
-				ClusterName:    s.ClusterName(),
-				Location:       s.Location(),
-				FailureDomains: s.FailureDomains(),
-				AdditionalTags: s.AdditionalTags(),
-				IPTags:         subnet.NatGateway.NatGatewayIP.IPTags,
+				Name:             subnet.NatGateway.NatGatewayIP.Name,
+				ResourceGroup:    s.ResourceGroup(),
+				DNSName:          subnet.NatGateway.NatGatewayIP.DNSName,
+				IsIPv6:           false, // Public IP is IPv4 by default
+				ClusterName:      s.ClusterName(),
+				Location:         s.Location(),
+				FailureDomains:   s.FailureDomains(),
+				Zones:            s.publicIPZones(subnet.NatGateway.NatGatewayIP.PublicIPClassSpec),
+				Tier:             subnet.NatGateway.NatGatewayIP.Tier,
+				AdditionalTags:   s.AdditionalTags(),
+				IPTags:           subnet.NatGateway.NatGatewayIP.IPTags,
+				PublicIPPrefixID: s.publicIPPrefixID(subnet.NatGateway.NatGatewayIP.PublicIPClassSpec),
 			})
 		}
 		publicIPSpecs = append(publicIPSpecs, nodeNatGatewayIPSpecs...)
@@ -210,20 +233,85 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	if azureBastion := s.AzureBastion(); azureBastion != nil {
 		// public IP for Azure Bastion.
 		azureBastionPublicIP := &publicips.PublicIPSpec{
-			Name:           azureBastion.PublicIP.Name,
+			Name:             azureBastion.PublicIP.Name,
+			ResourceGroup:    s.ResourceGroup(),
+			DNSName:          azureBastion.PublicIP.DNSName,
+			IsIPv6:           false, // Public IP is IPv4 by default
+			ClusterName:      s.ClusterName(),
+			Location:         s.Location(),
+			FailureDomains:   s.FailureDomains(),
+			Zones:            s.publicIPZones(azureBastion.PublicIP.PublicIPClassSpec),
+			Tier:             azureBastion.PublicIP.Tier,
+			AdditionalTags:   s.AdditionalTags(),
+			IPTags:           azureBastion.PublicIP.IPTags,
+			PublicIPPrefixID: s.publicIPPrefixID(azureBastion.PublicIP.PublicIPClassSpec),
+		}
+		publicIPSpecs = append(publicIPSpecs, azureBastionPublicIP)
+	}
+
+	if s.isAzureFirewallManaged() {
+		// public IP for the Azure Firewall.
+		firewallPublicIP := s.AzureFirewall().PublicIP
+		publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+			Name:             firewallPublicIP.Name,
+			ResourceGroup:    s.ResourceGroup(),
+			DNSName:          firewallPublicIP.DNSName,
+			IsIPv6:           false, // Azure Firewall only supports IPv4 public IPs
+			ClusterName:      s.ClusterName(),
+			Location:         s.Location(),
+			FailureDomains:   s.FailureDomains(),
+			Zones:            s.publicIPZones(firewallPublicIP.PublicIPClassSpec),
+			Tier:             firewallPublicIP.Tier,
+			AdditionalTags:   s.AdditionalTags(),
+			IPTags:           firewallPublicIP.IPTags,
+			PublicIPPrefixID: s.publicIPPrefixID(firewallPublicIP.PublicIPClassSpec),
+		})
+	}
+
+	return publicIPSpecs
+}
+
+// publicIPPrefixID returns the ID of the public IP prefix a public IP is allocated from, if any.
+func (s *ClusterScope) publicIPPrefixID(ip infrav1.PublicIPClassSpec) string {
+	if ip.PublicIPPrefixName != "" {
+		return azure.PublicIPPrefixID(s.SubscriptionID(), s.ResourceGroup(), ip.PublicIPPrefixName)
+	}
+	return ip.PublicIPPrefixID
+}
+
+// publicIPZones returns the availability zones of a public IP address, which default to the zones of the public IP prefix
+// of the cluster it is allocated from. The public IP specs default empty zones to the failure domains of the cluster.
+func (s *ClusterScope) publicIPZones(ip infrav1.PublicIPClassSpec) []string {
+	if len(ip.Zones) > 0 || ip.PublicIPPrefixName == "" {
+		return ip.Zones
+	}
+	for _, prefix := range s.AzureCluster.Spec.NetworkSpec.PublicIPPrefixes {
+		if prefix.Name == ip.PublicIPPrefixName {
+			return prefix.Zones
+		}
+	}
+	return nil
+}
+
+// PublicIPPrefixSpecs returns the public IP prefix specs.
+func (s *ClusterScope) PublicIPPrefixSpecs() []azure.ResourceSpecGetter {
+	specs := make([]azure.ResourceSpecGetter, len(s.AzureCluster.Spec.NetworkSpec.PublicIPPrefixes))
+	for i, prefix := range s.AzureCluster.Spec.NetworkSpec.PublicIPPrefixes {
+		specs[i] = &publicipprefixes.PublicIPPrefixSpec{
+			Name:           prefix.Name,
 			ResourceGroup:  s.ResourceGroup(),
-			DNSName:        azureBastion.PublicIP.DNSName,
-			IsIPv6:         false, // Public IP is IPv4 by default
-			ClusterName:    s.ClusterName(),
 			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			PrefixLength:   prefix.PrefixLength,
 			FailureDomains: s.FailureDomains(),
+			Zones:          prefix.Zones,
+			Tier:           prefix.Tier,
+			IsIPv6:         prefix.IPVersion == infrav1.IPVersionIPv6,
 			AdditionalTags: s.AdditionalTags(),
-			IPTags:         azureBastion.PublicIP.IPTags,
 		}
-		publicIPSpecs = append(publicIPSpecs, azureBastionPublicIP)
 	}
 
-	return publicIPSpecs
+	return specs
 }
 
 // LBSpecs returns the load balancer specs.
@@ -246,6 +334,9 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			Role:                 infrav1.APIServerRole,
 			BackendPoolName:      s.APIServerLBPoolName(s.APIServerLB().Name),
 			IdleTimeoutInMinutes: s.APIServerLB().IdleTimeoutInMinutes,
+			LoadBalancingRules:   s.APIServerLB().LoadBalancingRules,
+			Probes:               s.APIServerLB().Probes,
+			InboundNATPools:      s.APIServerLB().InboundNATPools,
 			AdditionalTags:       s.AdditionalTags(),
 		},
 	}
@@ -265,6 +356,9 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			SKU:                  s.NodeOutboundLB().SKU,
 			BackendPoolName:      s.OutboundPoolName(s.NodeOutboundLB().Name),
 			IdleTimeoutInMinutes: s.NodeOutboundLB().IdleTimeoutInMinutes,
+			LoadBalancingRules:   s.NodeOutboundLB().LoadBalancingRules,
+			Probes:               s.NodeOutboundLB().Probes,
+			InboundNATPools:      s.NodeOutboundLB().InboundNATPools,
 			Role:                 infrav1.NodeOutboundRole,
 			AdditionalTags:       s.AdditionalTags(),
 		})
@@ -285,6 +379,9 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			SKU:                  s.ControlPlaneOutboundLB().SKU,
 			BackendPoolName:      s.OutboundPoolName(azure.GenerateControlPlaneOutboundLBName(s.ClusterName())),
 			IdleTimeoutInMinutes: s.NodeOutboundLB().IdleTimeoutInMinutes,
+			LoadBalancingRules:   s.ControlPlaneOutboundLB().LoadBalancingRules,
+			Probes:               s.ControlPlaneOutboundLB().Probes,
+			InboundNATPools:      s.ControlPlaneOutboundLB().InboundNATPools,
 			Role:                 infrav1.ControlPlaneOutboundRole,
 			AdditionalTags:       s.AdditionalTags(),
 		})
@@ -294,18 +391,26 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 }
 
 // RouteTableSpecs returns the subnet route tables.
+// A route table attached to several subnets is only returned once, the webhook ensures they all declare the same routes.
 func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
+	routeTableSet := make(map[string]struct{})
 	var specs []azure.ResourceSpecGetter
 	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
-		if subnet.RouteTable.Name != "" {
-			specs = append(specs, &routetables.RouteTableSpec{
-				Name:           subnet.RouteTable.Name,
-				Location:       s.Location(),
-				ResourceGroup:  s.ResourceGroup(),
-				ClusterName:    s.ClusterName(),
-				AdditionalTags: s.AdditionalTags(),
-			})
+		if subnet.RouteTable.Name == "" {
+			continue
+		}
+		if _, ok := routeTableSet[subnet.RouteTable.Name]; ok {
+			continue
 		}
+		routeTableSet[subnet.RouteTable.Name] = struct{}{}
+		specs = append(specs, &routetables.RouteTableSpec{
+			Name:           subnet.RouteTable.Name,
+			Location:       s.Location(),
+			ResourceGroup:  s.ResourceGroup(),
+			ClusterName:    s.ClusterName(),
+			Routes:         subnet.RouteTable.Routes,
+			AdditionalTags: s.AdditionalTags(),
+		})
 	}
 
 	return specs
@@ -346,6 +451,7 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 		nsgspecs[i] = &securitygroups.NSGSpec{
 			Name:           subnet.SecurityGroup.Name,
 			SecurityRules:  subnet.SecurityGroup.SecurityRules,
+			SubscriptionID: s.SubscriptionID(),
 			ResourceGroup:  s.ResourceGroup(),
 			Location:       s.Location(),
 			ClusterName:    s.ClusterName(),
@@ -356,6 +462,22 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 	return nsgspecs
 }
 
+// ApplicationSecurityGroupSpecs returns the application security group specs.
+func (s *ClusterScope) ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter {
+	specs := make([]azure.ResourceSpecGetter, len(s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups))
+	for i, asg := range s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups {
+		specs[i] = &applicationsecuritygroups.ApplicationSecurityGroupSpec{
+			Name:           asg.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+		}
+	}
+
+	return specs
+}
+
 // SubnetSpecs returns the subnets specs.
 func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
@@ -400,9 +522,66 @@ func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 		})
 	}
 
+	if s.isAzureFirewallManaged() {
+		// The firewall subnet must not have a security group nor a route table.
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:              infrav1.DefaultAzureFirewallSubnetName,
+			ResourceGroup:     s.ResourceGroup(),
+			SubscriptionID:    s.SubscriptionID(),
+			CIDRs:             s.AzureFirewall().SubnetCIDRBlocks,
+			VNetName:          s.Vnet().Name,
+			VNetResourceGroup: s.Vnet().ResourceGroup,
+			IsVNetManaged:     s.IsVnetManaged(),
+		})
+	}
+
 	return subnetSpecs
 }
 
+// PrivateEndpointSpecs returns the private endpoint specs of all the subnets.
+func (s *ClusterScope) PrivateEndpointSpecs() []azure.ResourceSpecGetter {
+	var specs []azure.ResourceSpecGetter
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		for _, privateEndpoint := range subnet.PrivateEndpoints {
+			specs = append(specs, &privateendpoints.PrivateEndpointSpec{
+				Name:                       privateEndpoint.Name,
+				ResourceGroup:              s.ResourceGroup(),
+				Location:                   s.Location(),
+				ClusterName:                s.ClusterName(),
+				SubnetID:                   azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, subnet.Name),
+				PrivateLinkServiceID:       privateEndpoint.PrivateLinkServiceID,
+				GroupIDs:                   privateEndpoint.GroupIDs,
+				ManualApproval:             privateEndpoint.ManualApproval,
+				RequestMessage:             privateEndpoint.RequestMessage,
+				CustomNetworkInterfaceName: privateEndpoint.CustomNetworkInterfaceName,
+				AdditionalTags:             s.AdditionalTags(),
+			})
+		}
+	}
+
+	return specs
+}
+
+// PrivateDNSZoneGroupSpecs returns the private DNS zone group specs of the private endpoints of all the subnets.
+func (s *ClusterScope) PrivateDNSZoneGroupSpecs() []azure.ResourceSpecGetter {
+	var specs []azure.ResourceSpecGetter
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		for _, privateEndpoint := range subnet.PrivateEndpoints {
+			if privateEndpoint.PrivateDNSZoneGroup == nil {
+				continue
+			}
+			specs = append(specs, &privateendpoints.PrivateDNSZoneGroupSpec{
+				Name:                privateEndpoint.PrivateDNSZoneGroup.Name,
+				PrivateEndpointName: privateEndpoint.Name,
+				ResourceGroup:       s.ResourceGroup(),
+				PrivateDNSZoneIDs:   privateEndpoint.PrivateDNSZoneGroup.PrivateDNSZoneIDs,
+			})
+		}
+	}
+
+	return specs
+}
+
 // GroupSpec returns the resource group spec.
 func (s *ClusterScope) GroupSpec() azure.ResourceSpecGetter {
 	return &groups.GroupSpec{
@@ -417,21 +596,37 @@ func (s *ClusterScope) GroupSpec() azure.ResourceSpecGetter {
 func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	peeringSpecs := make([]azure.ResourceSpecGetter, 2*len(s.Vnet().Peerings))
 	for i, peering := range s.Vnet().Peerings {
+		remoteSubscriptionID := peering.SubscriptionID
+		if remoteSubscriptionID == "" {
+			remoteSubscriptionID = s.SubscriptionID()
+		}
 		forwardPeering := &vnetpeerings.VnetPeeringSpec{
-			PeeringName:         azure.GenerateVnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
-			SourceVnetName:      s.Vnet().Name,
-			SourceResourceGroup: s.Vnet().ResourceGroup,
-			RemoteVnetName:      peering.RemoteVnetName,
-			RemoteResourceGroup: peering.ResourceGroup,
-			SubscriptionID:      s.SubscriptionID(),
+			PeeringName:               azure.GenerateVnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
+			SourceVnetName:            s.Vnet().Name,
+			SourceResourceGroup:       s.Vnet().ResourceGroup,
+			RemoteVnetName:            peering.RemoteVnetName,
+			RemoteResourceGroup:       peering.ResourceGroup,
+			SubscriptionID:            remoteSubscriptionID,
+			SourceSubscriptionID:      s.SubscriptionID(),
+			RemoteIdentityRef:         peering.IdentityRef,
+			AllowForwardedTraffic:     peering.ForwardPeeringProperties.AllowForwardedTraffic,
+			AllowGatewayTransit:       peering.ForwardPeeringProperties.AllowGatewayTransit,
+			AllowVirtualNetworkAccess: peering.ForwardPeeringProperties.AllowVirtualNetworkAccess,
+			UseRemoteGateways:         peering.ForwardPeeringProperties.UseRemoteGateways,
 		}
 		reversePeering := &vnetpeerings.VnetPeeringSpec{
-			PeeringName:         azure.GenerateVnetPeeringName(peering.RemoteVnetName, s.Vnet().Name),
-			SourceVnetName:      peering.RemoteVnetName,
-			SourceResourceGroup: peering.ResourceGroup,
-			RemoteVnetName:      s.Vnet().Name,
-			RemoteResourceGroup: s.Vnet().ResourceGroup,
-			SubscriptionID:      s.SubscriptionID(),
+			PeeringName:               azure.GenerateVnetPeeringName(peering.RemoteVnetName, s.Vnet().Name),
+			SourceVnetName:            peering.RemoteVnetName,
+			SourceResourceGroup:       peering.ResourceGroup,
+			RemoteVnetName:            s.Vnet().Name,
+			RemoteResourceGroup:       s.Vnet().ResourceGroup,
+			SubscriptionID:            s.SubscriptionID(),
+			SourceSubscriptionID:      remoteSubscriptionID,
+			SourceIdentityRef:         peering.IdentityRef,
+			AllowForwardedTraffic:     peering.ReversePeeringProperties.AllowForwardedTraffic,
+			AllowGatewayTransit:       peering.ReversePeeringProperties.AllowGatewayTransit,
+			AllowVirtualNetworkAccess: peering.ReversePeeringProperties.AllowVirtualNetworkAccess,
+			UseRemoteGateways:         peering.ReversePeeringProperties.UseRemoteGateways,
 		}
 		peeringSpecs[i*2] = forwardPeering
 		peeringSpecs[i*2+1] = reversePeering
@@ -440,6 +635,51 @@ func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	return peeringSpecs
 }
 
+// VnetPeeringAuthorizer returns the authorizer used to manage the virtual network peerings of a remote virtual network
+// in the given subscription, with either the identity of the cluster or the referenced AzureClusterIdentity.
+// When the virtual network on the other side of the peering, whose identity is referenced by remoteIdentityRef, is in
+// another tenant, the requests also carry a token of that tenant as Azure requires to peer across tenants.
+func (s *ClusterScope) VnetPeeringAuthorizer(ctx context.Context, subscriptionID string, identityRef, remoteIdentityRef *corev1.ObjectReference) (azure.Authorizer, error) {
+	remoteTenantID := s.TenantID()
+	if remoteIdentityRef != nil {
+		credentialsProvider, err := s.vnetPeeringCredentialsProvider(ctx, remoteIdentityRef)
+		if err != nil {
+			return nil, err
+		}
+		remoteTenantID = credentialsProvider.GetTenantID()
+	}
+
+	clients := s.AzureClients.withSubscriptionID(subscriptionID)
+	if identityRef != nil {
+		credentialsProvider, err := s.vnetPeeringCredentialsProvider(ctx, identityRef)
+		if err != nil {
+			return nil, err
+		}
+		clients = &AzureClients{}
+		if err := clients.setCredentialsWithProvider(ctx, subscriptionID, s.AzureCluster.Spec.AzureEnvironment, credentialsProvider); err != nil {
+			return nil, errors.Wrap(err, "failed to configure azure settings and credentials for Identity")
+		}
+	}
+
+	clients, err := clients.withAuxiliaryTenant(remoteTenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to authorize the virtual network peering across tenants")
+	}
+	return clientsAuthorizer{clients}, nil
+}
+
+// vnetPeeringCredentialsProvider returns the credentials provider of an AzureClusterIdentity referenced by a peering.
+func (s *ClusterScope) vnetPeeringCredentialsProvider(ctx context.Context, identityRef *corev1.ObjectReference) (*AzureClusterCredentialsProvider, error) {
+	credentialsProvider, err := newAzureClusterCredentialsProviderFromRef(ctx, s.Client, s.AzureCluster, identityRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init credentials provider")
+	}
+	if !IsClusterNamespaceAllowed(ctx, s.Client, credentialsProvider.Identity.Spec.AllowedNamespaces, s.Namespace()) {
+		return nil, errors.Errorf("AzureClusterIdentity %s list of allowed namespaces doesn't include current cluster namespace", identityRef.Name)
+	}
+	return credentialsProvider, nil
+}
+
 // VNetSpec returns the virtual network spec.
 func (s *ClusterScope) VNetSpec() azure.ResourceSpecGetter {
 	return &virtualnetworks.VNetSpec{
@@ -449,29 +689,48 @@ func (s *ClusterScope) VNetSpec() azure.ResourceSpecGetter {
 		Location:       s.Location(),
 		ClusterName:    s.ClusterName(),
 		AdditionalTags: s.AdditionalTags(),
+
+		DDoSProtectionPlanID: s.Vnet().DDoSProtectionPlanID,
+		Encryption:           s.Vnet().Encryption,
+		DNSServers:           s.Vnet().DNSServers,
+		FlowTimeoutInMinutes: s.Vnet().FlowTimeoutInMinutes,
 	}
 }
 
 // PrivateDNSSpec returns the private dns zone spec.
 func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linkSpec, recordSpec []azure.ResourceSpecGetter) {
 	if s.IsAPIServerPrivate() {
+		zoneConfig := s.AzureCluster.Spec.NetworkSpec.PrivateDNSZone
+		if zoneConfig == nil {
+			zoneConfig = &infrav1.PrivateDNSZoneClassSpec{}
+		}
+
 		zone := privatedns.ZoneSpec{
 			Name:           s.GetPrivateDNSZoneName(),
 			ResourceGroup:  s.ResourceGroup(),
+			SubscriptionID: s.SubscriptionID(),
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
 		}
+		if zoneConfig.ResourceGroup != "" {
+			zone.ResourceGroup = zoneConfig.ResourceGroup
+			zone.Existing = true
+		}
+		if zoneConfig.SubscriptionID != "" {
+			zone.SubscriptionID = zoneConfig.SubscriptionID
+		}
 
 		links := make([]azure.ResourceSpecGetter, 1+len(s.Vnet().Peerings))
 		links[0] = privatedns.LinkSpec{
-			Name:              azure.GenerateVNetLinkName(s.Vnet().Name),
-			ZoneName:          s.GetPrivateDNSZoneName(),
-			SubscriptionID:    s.SubscriptionID(),
-			VNetResourceGroup: s.Vnet().ResourceGroup,
-			VNetName:          s.Vnet().Name,
-			ResourceGroup:     s.ResourceGroup(),
-			ClusterName:       s.ClusterName(),
-			AdditionalTags:    s.AdditionalTags(),
+			Name:                azure.GenerateVNetLinkName(s.Vnet().Name),
+			ZoneName:            s.GetPrivateDNSZoneName(),
+			SubscriptionID:      s.SubscriptionID(),
+			VNetResourceGroup:   s.Vnet().ResourceGroup,
+			VNetName:            s.Vnet().Name,
+			ResourceGroup:       zone.ResourceGroup,
+			ClusterName:         s.ClusterName(),
+			RegistrationEnabled: zoneConfig.RegistrationEnabled,
+			AdditionalTags:      s.AdditionalTags(),
 		}
 		for i, peering := range s.Vnet().Peerings {
 			links[i+1] = privatedns.LinkSpec{
@@ -480,20 +739,42 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 				SubscriptionID:    s.SubscriptionID(),
 				VNetResourceGroup: peering.ResourceGroup,
 				VNetName:          peering.RemoteVnetName,
-				ResourceGroup:     s.ResourceGroup(),
+				ResourceGroup:     zone.ResourceGroup,
 				ClusterName:       s.ClusterName(),
 				AdditionalTags:    s.AdditionalTags(),
 			}
 		}
 
-		records := make([]azure.ResourceSpecGetter, 1)
+		var ttl int64
+		if zoneConfig.TTL != nil {
+			ttl = *zoneConfig.TTL
+		}
+		apiServerRecordType := infrav1.PrivateDNSRecordTypeA
+		if net.IsIPv6String(s.APIServerPrivateIP()) {
+			apiServerRecordType = infrav1.PrivateDNSRecordTypeAAAA
+		}
+		records := make([]azure.ResourceSpecGetter, 1+len(zoneConfig.Records))
 		records[0] = privatedns.RecordSpec{
-			Record: infrav1.AddressRecord{
-				Hostname: azure.PrivateAPIServerHostname,
-				IP:       s.APIServerPrivateIP(),
+			Record: infrav1.PrivateDNSRecord{
+				Name: azure.PrivateAPIServerHostname,
+				Type: apiServerRecordType,
+				IPs:  []string{s.APIServerPrivateIP()},
 			},
+			TTL:           ttl,
 			ZoneName:      s.GetPrivateDNSZoneName(),
-			ResourceGroup: s.ResourceGroup(),
+			ResourceGroup: zone.ResourceGroup,
+			ClusterName:   s.ClusterName(),
+			Existing:      zone.Existing,
+		}
+		for i, record := range zoneConfig.Records {
+			records[i+1] = privatedns.RecordSpec{
+				Record:        record,
+				TTL:           ttl,
+				ZoneName:      s.GetPrivateDNSZoneName(),
+				ResourceGroup: zone.ResourceGroup,
+				ClusterName:   s.ClusterName(),
+				Existing:      zone.Existing,
+			}
 		}
 
 		return zone, links, records
@@ -502,6 +783,12 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 	return nil, nil, nil
 }
 
+// PrivateDNSZoneAuthorizer returns the authorizer used to manage the links and records of an existing private DNS zone
+// in the given subscription.
+func (s *ClusterScope) PrivateDNSZoneAuthorizer(subscriptionID string) azure.Authorizer {
+	return clientsAuthorizer{s.AzureClients.withSubscriptionID(subscriptionID)}
+}
+
 // IsAzureBastionEnabled returns true if the azure bastion is enabled.
 func (s *ClusterScope) IsAzureBastionEnabled() bool {
 	return s.AzureCluster.Spec.BastionSpec.AzureBastion != nil
@@ -525,23 +812,154 @@ func (s *ClusterScope) AzureBastionSpec() azure.ResourceSpecGetter {
 			ClusterName:   s.ClusterName(),
 			SubnetID:      subnetID,
 			PublicIPID:    publicIPID,
+			Sku:           s.AzureBastion().Sku,
+			ScaleUnits:    s.AzureBastion().ScaleUnits,
+
+			EnableTunneling:     s.AzureBastion().EnableTunneling,
+			EnableIPConnect:     s.AzureBastion().EnableIPConnect,
+			EnableShareableLink: s.AzureBastion().EnableShareableLink,
+			DisableCopyPaste:    s.AzureBastion().DisableCopyPaste,
 		}
 	}
 
 	return nil
 }
 
-// Vnet returns the cluster Vnet.
+// AzureFirewall returns the cluster Azure Firewall.
+func (s *ClusterScope) AzureFirewall() *infrav1.AzureFirewall {
+	return s.AzureCluster.Spec.NetworkSpec.Firewall
+}
+
+// isAzureFirewallManaged returns true if the cluster has an Azure Firewall created by CAPZ rather than an existing one.
+func (s *ClusterScope) isAzureFirewallManaged() bool {
+	return s.AzureFirewall() != nil && s.AzureFirewall().ResourceGroup == ""
+}
+
+// AzureFirewallSpecs returns the specs of the firewall policy, its rule collection group and the Azure Firewall.
+// Only the firewall spec is returned for an existing firewall.
+func (s *ClusterScope) AzureFirewallSpecs() (policySpec, ruleCollectionGroupSpec, firewallSpec azure.ResourceSpecGetter) {
+	firewall := s.AzureFirewall()
+	if firewall == nil {
+		return nil, nil, nil
+	}
+	if !s.isAzureFirewallManaged() {
+		return nil, nil, &azurefirewalls.FirewallSpec{
+			Name:          firewall.Name,
+			ResourceGroup: firewall.ResourceGroup,
+			Existing:      true,
+		}
+	}
+
+	policySpec = &azurefirewalls.PolicySpec{
+		Name:           firewall.Policy.Name,
+		ResourceGroup:  s.ResourceGroup(),
+		Location:       s.Location(),
+		ClusterName:    s.ClusterName(),
+		Tier:           firewall.Tier,
+		AdditionalTags: s.AdditionalTags(),
+	}
+	ruleCollectionGroupSpec = &azurefirewalls.RuleCollectionGroupSpec{
+		Name:            azure.FirewallRuleCollectionGroupName,
+		PolicyName:      firewall.Policy.Name,
+		ResourceGroup:   s.ResourceGroup(),
+		SourceAddresses: s.Vnet().CIDRBlocks,
+		AzureFQDNs:      s.azureEndpointFQDNs(),
+		AllowedFQDNs:    firewall.Policy.AllowedFQDNs,
+	}
+	firewallSpec = &azurefirewalls.FirewallSpec{
+		Name:           firewall.Name,
+		ResourceGroup:  s.ResourceGroup(),
+		Location:       s.Location(),
+		ClusterName:    s.ClusterName(),
+		SubnetID:       azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, infrav1.DefaultAzureFirewallSubnetName),
+		PublicIPID:     azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), firewall.PublicIP.Name),
+		PolicyID:       azure.FirewallPolicyID(s.SubscriptionID(), s.ResourceGroup(), firewall.Policy.Name),
+		Tier:           firewall.Tier,
+		Zones:          firewall.Zones,
+		AdditionalTags: s.AdditionalTags(),
+	}
+	return policySpec, ruleCollectionGroupSpec, firewallSpec
+}
+
+// azureEndpointFQDNs returns the FQDNs of the Azure Resource Manager and Azure Active Directory endpoints
+// of the cloud of the cluster, which the nodes reach to bootstrap.
+func (s *ClusterScope) azureEndpointFQDNs() []string {
+	var fqdns []string
+	for _, endpoint := range []string{s.AzureClients.Environment.ResourceManagerEndpoint, s.AzureClients.Environment.ActiveDirectoryEndpoint} {
+		if u, err := url.Parse(endpoint); err == nil && u.Hostname() != "" {
+			fqdns = append(fqdns, u.Hostname())
+		}
+	}
+	return fqdns
+}
+
+// AzureFirewallRouteSpecs returns the specs of the routes sending the egress traffic of the control plane and node subnets
+// to the Azure Firewall. A route table attached to several subnets gets a single route.
+func (s *ClusterScope) AzureFirewallRouteSpecs() []azure.ResourceSpecGetter {
+	firewall := s.AzureFirewall()
+	if firewall == nil {
+		return nil
+	}
+
+	routeTableSet := make(map[string]struct{})
+	var specs []azure.ResourceSpecGetter
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		if subnet.Role != infrav1.SubnetControlPlane && subnet.Role != infrav1.SubnetNode {
+			continue
+		}
+		if subnet.RouteTable.Name == "" {
+			continue
+		}
+		if _, ok := routeTableSet[subnet.RouteTable.Name]; ok {
+			continue
+		}
+		routeTableSet[subnet.RouteTable.Name] = struct{}{}
+		specs = append(specs, &azurefirewalls.RouteSpec{
+			Name:              azure.FirewallEgressRouteName,
+			RouteTableName:    subnet.RouteTable.Name,
+			ResourceGroup:     s.ResourceGroup(),
+			FirewallPrivateIP: firewall.PrivateIP,
+		})
+	}
+
+	return specs
+}
+
+// SetAzureFirewallPrivateIP sets the private IP address of the Azure Firewall the egress traffic is routed to.
+func (s *ClusterScope) SetAzureFirewallPrivateIP(privateIP string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.AzureCluster.Spec.NetworkSpec.Firewall != nil {
+		s.AzureCluster.Spec.NetworkSpec.Firewall.PrivateIP = privateIP
+	}
+}
+
+// Vnet returns a copy of the cluster Vnet.
 func (s *ClusterScope) Vnet() *infrav1.VnetSpec {
-	return &s.AzureCluster.Spec.NetworkSpec.Vnet
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.AzureCluster.Spec.NetworkSpec.Vnet.DeepCopy()
+}
+
+// UpdateVnet updates the ID, tags and CIDR blocks of the cluster Vnet.
+func (s *ClusterScope) UpdateVnet(id string, tags infrav1.Tags, cidrBlocks []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vnet := &s.AzureCluster.Spec.NetworkSpec.Vnet
+	vnet.ID = id
+	vnet.Tags = tags
+	vnet.CIDRBlocks = cidrBlocks
 }
 
 // IsVnetManaged returns true if the vnet is managed.
 func (s *ClusterScope) IsVnetManaged() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.cache.isVnetManaged != nil {
 		return to.Bool(s.cache.isVnetManaged)
 	}
-	isVnetManaged := s.Vnet().ID == "" || s.Vnet().Tags.HasOwned(s.ClusterName())
+	vnet := s.AzureCluster.Spec.NetworkSpec.Vnet
+	isVnetManaged := vnet.ID == "" || vnet.Tags.HasOwned(s.ClusterName())
 	s.cache.isVnetManaged = to.BoolPtr(isVnetManaged)
 	return isVnetManaged
 }
@@ -556,9 +974,16 @@ func (s *ClusterScope) IsIPv6Enabled() bool {
 	return false
 }
 
-// Subnets returns the cluster subnets.
+// ApplicationSecurityGroups returns the cluster application security groups.
+func (s *ClusterScope) ApplicationSecurityGroups() infrav1.ApplicationSecurityGroups {
+	return s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups
+}
+
+// Subnets returns a copy of the cluster subnets.
 func (s *ClusterScope) Subnets() infrav1.Subnets {
-	return s.AzureCluster.Spec.NetworkSpec.Subnets
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.AzureCluster.Spec.NetworkSpec.Subnets.DeepCopy()
 }
 
 // ControlPlaneSubnet returns the cluster control plane subnet.
@@ -579,11 +1004,17 @@ func (s *ClusterScope) NodeSubnets() []infrav1.SubnetSpec {
 	return subnets
 }
 
-// Subnet returns the subnet with the provided name.
+// Subnet returns a copy of the subnet with the provided name.
 func (s *ClusterScope) Subnet(name string) infrav1.SubnetSpec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subnet(name)
+}
+
+func (s *ClusterScope) subnet(name string) infrav1.SubnetSpec {
 	for _, sn := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		if sn.Name == name {
-			return sn
+			return *sn.DeepCopy()
 		}
 	}
 
@@ -592,6 +1023,12 @@ func (s *ClusterScope) Subnet(name string) infrav1.SubnetSpec {
 
 // SetSubnet sets the subnet spec for the subnet with the same name.
 func (s *ClusterScope) SetSubnet(subnetSpec infrav1.SubnetSpec) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setSubnet(subnetSpec)
+}
+
+func (s *ClusterScope) setSubnet(subnetSpec infrav1.SubnetSpec) {
 	for i, sn := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		if sn.Name == subnetSpec.Name {
 			s.AzureCluster.Spec.NetworkSpec.Subnets[i] = subnetSpec
@@ -602,26 +1039,32 @@ func (s *ClusterScope) SetSubnet(subnetSpec infrav1.SubnetSpec) {
 
 // SetNatGatewayIDInSubnets sets the NAT Gateway ID in the subnets with the same name.
 func (s *ClusterScope) SetNatGatewayIDInSubnets(name string, id string) {
-	for _, subnet := range s.Subnets() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets.DeepCopy() {
 		if subnet.NatGateway.Name == name {
 			subnet.NatGateway.ID = id
-			s.SetSubnet(subnet)
+			s.setSubnet(subnet)
 		}
 	}
 }
 
 // UpdateSubnetCIDRs updates the subnet CIDRs for the subnet with the same name.
 func (s *ClusterScope) UpdateSubnetCIDRs(name string, cidrBlocks []string) {
-	subnetSpecInfra := s.Subnet(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subnetSpecInfra := s.subnet(name)
 	subnetSpecInfra.CIDRBlocks = cidrBlocks
-	s.SetSubnet(subnetSpecInfra)
+	s.setSubnet(subnetSpecInfra)
 }
 
 // UpdateSubnetID updates the subnet ID for the subnet with the same name.
 func (s *ClusterScope) UpdateSubnetID(name string, id string) {
-	subnetSpecInfra := s.Subnet(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subnetSpecInfra := s.subnet(name)
 	subnetSpecInfra.ID = id
-	s.SetSubnet(subnetSpecInfra)
+	s.setSubnet(subnetSpecInfra)
 }
 
 // ControlPlaneRouteTable returns the cluster controlplane routetable.
@@ -673,6 +1116,13 @@ func (s *ClusterScope) GetPrivateDNSZoneName() string {
 	return azure.GeneratePrivateDNSZoneName(s.ClusterName())
 }
 
+// HasExistingPrivateDNSZone returns true if the private API server uses an existing private DNS zone
+// that is not in the resource group of the cluster.
+func (s *ClusterScope) HasExistingPrivateDNSZone() bool {
+	zoneConfig := s.AzureCluster.Spec.NetworkSpec.PrivateDNSZone
+	return s.IsAPIServerPrivate() && zoneConfig != nil && zoneConfig.ResourceGroup != ""
+}
+
 // APIServerLBPoolName returns the API Server LB backend pool name.
 func (s *ClusterScope) APIServerLBPoolName(loadBalancerName string) string {
 	return azure.GenerateBackendAddressPoolName(loadBalancerName)
@@ -695,6 +1145,27 @@ func (s *ClusterScope) OutboundLBName(role string) string {
 	return s.APIServerLBName()
 }
 
+// OutboundLBInboundNATPoolNames returns the names of the inbound NAT pools of the outbound LB of the given role.
+func (s *ClusterScope) OutboundLBInboundNATPoolNames(role string) []string {
+	var lb *infrav1.LoadBalancerSpec
+	switch {
+	case role == infrav1.Node:
+		lb = s.NodeOutboundLB()
+	case s.IsAPIServerPrivate():
+		lb = s.ControlPlaneOutboundLB()
+	default:
+		lb = s.APIServerLB()
+	}
+	if lb == nil {
+		return nil
+	}
+	names := make([]string, 0, len(lb.InboundNATPools))
+	for _, pool := range lb.InboundNATPools {
+		names = append(names, pool.Name)
+	}
+	return names
+}
+
 // OutboundPoolName returns the outbound LB backend pool name.
 func (s *ClusterScope) OutboundPoolName(loadBalancerName string) string {
 	if loadBalancerName == "" {
@@ -767,6 +1238,7 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.ClusterScope.PatchObject")
 	defer done()
 
+	setThrottledCondition(s.AzureCluster, s.SubscriptionID())
 	conditions.SetSummary(s.AzureCluster)
 
 	return s.patchHelper.Patch(
@@ -788,6 +1260,7 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			infrav1.PrivateDNSZoneReadyCondition,
 			infrav1.PrivateDNSLinkReadyCondition,
 			infrav1.PrivateDNSRecordReadyCondition,
+			infrav1.AzureFirewallReadyCondition,
 		}})
 }
 
@@ -912,24 +1385,34 @@ func (s *ClusterScope) SetDNSName() {
 // SetLongRunningOperationState will set the future on the AzureCluster status to allow the resource to continue
 // in the next reconciliation.
 func (s *ClusterScope) SetLongRunningOperationState(future *infrav1.Future) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	futures.Set(s.AzureCluster, future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureCluster status.
 func (s *ClusterScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return futures.Get(s.AzureCluster, name, service, futureType)
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureCluster status.
 func (s *ClusterScope) DeleteLongRunningOperationState(name, service, futureType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	futures.Delete(s.AzureCluster, name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureCluster status after a DELETE operation.
 func (s *ClusterScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	switch {
 	case err == nil:
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
+	case azure.IsOperationPlannedError(err):
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.PlannedReason, clusterv1.ConditionSeverityInfo, "%s deletion planned in dry-run mode", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
 	default:
@@ -939,9 +1422,13 @@ func (s *ClusterScope) UpdateDeleteStatus(condition clusterv1.ConditionType, ser
 
 // UpdatePutStatus updates a condition on the AzureCluster status after a PUT operation.
 func (s *ClusterScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	switch {
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
+	case azure.IsOperationPlannedError(err):
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.PlannedReason, clusterv1.ConditionSeverityInfo, "%s creation or update planned in dry-run mode", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
 	default:
@@ -951,9 +1438,13 @@ func (s *ClusterScope) UpdatePutStatus(condition clusterv1.ConditionType, servic
 
 // UpdatePatchStatus updates a condition on the AzureCluster status after a PATCH operation.
 func (s *ClusterScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	switch {
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
+	case azure.IsOperationPlannedError(err):
+		conditions.MarkFalse(s.AzureCluster, condition, infrav1.PlannedReason, clusterv1.ConditionSeverityInfo, "%s update planned in dry-run mode", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
 	default:
@@ -964,7 +1455,9 @@ func (s *ClusterScope) UpdatePatchStatus(condition clusterv1.ConditionType, serv
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (s *ClusterScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
 	out := map[string]interface{}{}
+	s.mu.Lock()
 	jsonAnnotation := s.AzureCluster.GetAnnotations()[annotation]
+	s.mu.Unlock()
 	if jsonAnnotation == "" {
 		return out, nil
 	}
@@ -990,12 +1483,79 @@ func (s *ClusterScope) UpdateAnnotationJSON(annotation string, content map[strin
 
 // SetAnnotation sets a key value annotation on the AzureCluster.
 func (s *ClusterScope) SetAnnotation(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.AzureCluster.Annotations == nil {
 		s.AzureCluster.Annotations = map[string]string{}
 	}
 	s.AzureCluster.Annotations[key] = value
 }
 
+// RecordDrift records the fields of an Azure resource that differ from its spec.
+func (s *ClusterScope) RecordDrift(serviceName, resourceName string, fields []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.drift == nil {
+		s.drift = driftReport{}
+	}
+	s.drift.record(serviceName, resourceName, fields)
+}
+
+// DriftReportOnly returns true if drifted resources should be reported but not updated.
+func (s *ClusterScope) DriftReportOnly() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.AzureCluster.GetAnnotations()[azure.DriftModeAnnotation] == azure.DriftModeReport
+}
+
+// LastAppliedSpecHash returns the hash of the spec last applied to an Azure resource, or an empty string if none was recorded.
+func (s *ClusterScope) LastAppliedSpecHash(serviceName, resourceName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return lastAppliedSpecHashes(s.AzureCluster)[driftKey(serviceName, resourceName)]
+}
+
+// SetLastAppliedSpecHash records the hash of the spec applied to an Azure resource.
+func (s *ClusterScope) SetLastAppliedSpecHash(serviceName, resourceName, hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	setLastAppliedSpecHash(s.AzureCluster, serviceName, resourceName, hash)
+}
+
+// UpdateDriftStatus sets the ResourcesInSync condition on the AzureCluster from the drift recorded during reconciliation.
+// It returns the description of the drift, or an empty string if all resources match the spec.
+func (s *ClusterScope) UpdateDriftStatus() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return setDriftCondition(s.AzureCluster, s.drift)
+}
+
+// DryRun returns true if the Azure operations needed to reconcile the AzureCluster should be planned but not performed.
+func (s *ClusterScope) DryRun() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.AzureCluster.GetAnnotations()[azure.DryRunAnnotation] == "true"
+}
+
+// PlanOperation records an Azure operation that would be performed in dry-run mode.
+func (s *ClusterScope) PlanOperation(operation, serviceName, resourceGroupName, resourceName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.plan == nil {
+		s.plan = operationPlan{}
+	}
+	s.plan.record(operation, serviceName, resourceGroupName, resourceName)
+}
+
+// UpdatePlanStatus sets the InfrastructureUpToDate condition on the AzureCluster from the operations planned in dry-run mode.
+// It returns the description of the planned operations, or an empty string if there are none.
+func (s *ClusterScope) UpdatePlanStatus() string {
+	dryRun := s.DryRun()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return setPlanCondition(s.AzureCluster, dryRun, s.plan)
+}
+
 // TagsSpecs returns the tag specs for the AzureCluster.
 func (s *ClusterScope) TagsSpecs() []azure.TagsSpec {
 	return []azure.TagsSpec{
@@ -21,23 +21,31 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -741,6 +749,89 @@ func TestPublicIPSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Azure cluster with outbound public IPs allocated from public IP prefixes",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "cluster.x-k8s.io/v1beta1",
+							Kind:       "Cluster",
+							Name:       "my-cluster",
+						},
+					},
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "centralIndia",
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						NetworkClassSpec: infrav1.NetworkClassSpec{
+							PublicIPPrefixes: infrav1.PublicIPPrefixes{
+								{Name: "my-cluster-outbound-prefix", PrefixLength: 28, Zones: []string{"1"}},
+							},
+						},
+						ControlPlaneOutboundLB: &infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "my-frontend-ip",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "pip-my-cluster-controlplane-outbound",
+										PublicIPClassSpec: infrav1.PublicIPClassSpec{
+											PublicIPPrefixName: "my-cluster-outbound-prefix",
+										},
+									},
+								},
+							},
+						},
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "my-node-frontend-ip",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "pip-my-cluster-node-outbound",
+										PublicIPClassSpec: infrav1.PublicIPClassSpec{
+											PublicIPPrefixID: "/subscriptions/456/resourceGroups/shared-rg/providers/Microsoft.Network/publicIPPrefixes/shared-prefix",
+											IPVersion:        infrav1.IPVersionIPv6,
+										},
+									},
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Internal,
+							},
+						},
+					},
+				},
+			},
+			expectedPublicIPSpec: []azure.ResourceSpecGetter{
+				&publicips.PublicIPSpec{
+					Name:             "pip-my-cluster-controlplane-outbound",
+					ResourceGroup:    "my-rg",
+					ClusterName:      "my-cluster",
+					Location:         "centralIndia",
+					FailureDomains:   []string{},
+					Zones:            []string{"1"},
+					PublicIPPrefixID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-cluster-outbound-prefix",
+					AdditionalTags:   infrav1.Tags{},
+				},
+				&publicips.PublicIPSpec{
+					Name:             "pip-my-cluster-node-outbound",
+					ResourceGroup:    "my-rg",
+					ClusterName:      "my-cluster",
+					IsIPv6:           true,
+					Location:         "centralIndia",
+					FailureDomains:   []string{},
+					PublicIPPrefixID: "/subscriptions/456/resourceGroups/shared-rg/providers/Microsoft.Network/publicIPPrefixes/shared-prefix",
+					AdditionalTags:   infrav1.Tags{},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -778,14 +869,20 @@ func TestPublicIPSpecs(t *testing.T) {
 }
 
 func TestRouteTableSpecs(t *testing.T) {
+	fakeHubRoute := infrav1.Route{
+		Name:             "default",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+		NextHopIPAddress: "10.100.0.4",
+	}
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -799,7 +896,7 @@ func TestRouteTableSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified route tables if present",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -848,6 +945,59 @@ func TestRouteTableSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "returns a route table shared by several subnets once with its routes",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetControlPlane,
+										Name: "cp-subnet",
+									},
+									RouteTable: infrav1.RouteTable{
+										Name:   "hub-route-table",
+										Routes: infrav1.Routes{fakeHubRoute},
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+										Name: "node-subnet",
+									},
+									RouteTable: infrav1.RouteTable{
+										Name:   "hub-route-table",
+										Routes: infrav1.Routes{fakeHubRoute},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:           "hub-route-table",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					Routes:         infrav1.Routes{fakeHubRoute},
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -864,12 +1014,12 @@ func TestRouteTableSpecs(t *testing.T) {
 func TestNatGatewaySpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -883,7 +1033,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -943,7 +1093,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present and ignores duplicate",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1021,7 +1171,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present and ignores control plane nat gateway",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1112,12 +1262,12 @@ func TestNatGatewaySpecs(t *testing.T) {
 func TestNSGSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns empty if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1130,7 +1280,7 @@ func TestNSGSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified security groups if present",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1193,12 +1343,12 @@ func TestNSGSpecs(t *testing.T) {
 func TestSubnetSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns empty if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1212,7 +1362,7 @@ func TestSubnetSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified subnet spec",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1289,7 +1439,7 @@ func TestSubnetSpecs(t *testing.T) {
 
 		{
 			name: "returns specified subnet spec and bastion spec if enabled",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1414,15 +1564,337 @@ func TestSubnetSpecs(t *testing.T) {
 	}
 }
 
+func TestPrivateEndpointSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "centralIndia",
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						Name:          "fake-vnet-1",
+						ResourceGroup: "my-rg-vnet",
+					},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{
+								Role: infrav1.SubnetControlPlane,
+								Name: "fake-subnet-cp",
+							},
+						},
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{
+								Role: infrav1.SubnetNode,
+								Name: "fake-subnet-1",
+							},
+							PrivateEndpoints: infrav1.PrivateEndpoints{
+								{
+									Name:                 "fake-storage-pe",
+									PrivateLinkServiceID: "fake-storage-account-id",
+									GroupIDs:             []string{"blob"},
+									PrivateDNSZoneGroup: &infrav1.PrivateDNSZoneGroup{
+										Name:              "default",
+										PrivateDNSZoneIDs: []string{"fake-private-dns-zone-id"},
+									},
+								},
+								{
+									Name:                       "fake-vault-pe",
+									PrivateLinkServiceID:       "fake-vault-id",
+									GroupIDs:                   []string{"vault"},
+									ManualApproval:             true,
+									RequestMessage:             "please approve",
+									CustomNetworkInterfaceName: "fake-vault-pe-nic",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		cache: &ClusterCache{},
+	}
+
+	g.Expect(clusterScope.PrivateEndpointSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&privateendpoints.PrivateEndpointSpec{
+			Name:                 "fake-storage-pe",
+			ResourceGroup:        "my-rg",
+			Location:             "centralIndia",
+			ClusterName:          "my-cluster",
+			SubnetID:             "/subscriptions/123/resourceGroups/my-rg-vnet/providers/Microsoft.Network/virtualNetworks/fake-vnet-1/subnets/fake-subnet-1",
+			PrivateLinkServiceID: "fake-storage-account-id",
+			GroupIDs:             []string{"blob"},
+			AdditionalTags:       infrav1.Tags{},
+		},
+		&privateendpoints.PrivateEndpointSpec{
+			Name:                       "fake-vault-pe",
+			ResourceGroup:              "my-rg",
+			Location:                   "centralIndia",
+			ClusterName:                "my-cluster",
+			SubnetID:                   "/subscriptions/123/resourceGroups/my-rg-vnet/providers/Microsoft.Network/virtualNetworks/fake-vnet-1/subnets/fake-subnet-1",
+			PrivateLinkServiceID:       "fake-vault-id",
+			GroupIDs:                   []string{"vault"},
+			ManualApproval:             true,
+			RequestMessage:             "please approve",
+			CustomNetworkInterfaceName: "fake-vault-pe-nic",
+			AdditionalTags:             infrav1.Tags{},
+		},
+	}))
+	g.Expect(clusterScope.PrivateDNSZoneGroupSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&privateendpoints.PrivateDNSZoneGroupSpec{
+			Name:                "default",
+			PrivateEndpointName: "fake-storage-pe",
+			ResourceGroup:       "my-rg",
+			PrivateDNSZoneIDs:   []string{"fake-private-dns-zone-id"},
+		},
+	}))
+}
+
+func TestApplicationSecurityGroupSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location:       "centralIndia",
+					AdditionalTags: infrav1.Tags{"foo": "bar"},
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					NetworkClassSpec: infrav1.NetworkClassSpec{
+						ApplicationSecurityGroups: infrav1.ApplicationSecurityGroups{
+							{Name: "control-plane-asg", Role: infrav1.SubnetControlPlane},
+							{Name: "node-asg", Role: infrav1.SubnetNode},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.ApplicationSecurityGroupSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&applicationsecuritygroups.ApplicationSecurityGroupSpec{
+			Name:           "control-plane-asg",
+			ResourceGroup:  "my-rg",
+			Location:       "centralIndia",
+			ClusterName:    "my-cluster",
+			AdditionalTags: infrav1.Tags{"foo": "bar"},
+		},
+		&applicationsecuritygroups.ApplicationSecurityGroupSpec{
+			Name:           "node-asg",
+			ResourceGroup:  "my-rg",
+			Location:       "centralIndia",
+			ClusterName:    "my-cluster",
+			AdditionalTags: infrav1.Tags{"foo": "bar"},
+		},
+	}))
+}
+
+func TestPublicIPPrefixSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location:       "centralIndia",
+					AdditionalTags: infrav1.Tags{"foo": "bar"},
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					NetworkClassSpec: infrav1.NetworkClassSpec{
+						PublicIPPrefixes: infrav1.PublicIPPrefixes{
+							{Name: "outbound-prefix", PrefixLength: 30, Zones: []string{"1", "2"}},
+							{Name: "global-prefix", PrefixLength: 127, Tier: infrav1.PublicIPTierGlobal, IPVersion: infrav1.IPVersionIPv6},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.PublicIPPrefixSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&publicipprefixes.PublicIPPrefixSpec{
+			Name:           "outbound-prefix",
+			ResourceGroup:  "my-rg",
+			Location:       "centralIndia",
+			ClusterName:    "my-cluster",
+			PrefixLength:   30,
+			FailureDomains: []string{},
+			Zones:          []string{"1", "2"},
+			AdditionalTags: infrav1.Tags{"foo": "bar"},
+		},
+		&publicipprefixes.PublicIPPrefixSpec{
+			Name:           "global-prefix",
+			ResourceGroup:  "my-rg",
+			Location:       "centralIndia",
+			ClusterName:    "my-cluster",
+			PrefixLength:   127,
+			FailureDomains: []string{},
+			Tier:           infrav1.PublicIPTierGlobal,
+			IsIPv6:         true,
+			AdditionalTags: infrav1.Tags{"foo": "bar"},
+		},
+	}))
+}
+
+func TestVnetPeeringSpecs(t *testing.T) {
+	g := NewWithT(t)
+
+	identityRef := &corev1.ObjectReference{Name: "hub-identity", Namespace: "default", Kind: "AzureClusterIdentity"}
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+		},
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						Name:          "my-vnet",
+						ResourceGroup: "my-rg",
+						Peerings: infrav1.VnetPeerings{
+							{
+								VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+									ResourceGroup:  "spoke-rg",
+									RemoteVnetName: "spoke-vnet",
+								},
+							},
+							{
+								VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+									ResourceGroup:  "hub-rg",
+									RemoteVnetName: "hub-vnet",
+									SubscriptionID: "456",
+									IdentityRef:    identityRef,
+									ForwardPeeringProperties: infrav1.VnetPeeringProperties{
+										AllowForwardedTraffic: to.BoolPtr(true),
+										UseRemoteGateways:     to.BoolPtr(true),
+									},
+									ReversePeeringProperties: infrav1.VnetPeeringProperties{
+										AllowGatewayTransit: to.BoolPtr(true),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterScope.VnetPeeringSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&vnetpeerings.VnetPeeringSpec{
+			PeeringName:          "my-vnet-To-spoke-vnet",
+			SourceVnetName:       "my-vnet",
+			SourceResourceGroup:  "my-rg",
+			RemoteVnetName:       "spoke-vnet",
+			RemoteResourceGroup:  "spoke-rg",
+			SubscriptionID:       "123",
+			SourceSubscriptionID: "123",
+		},
+		&vnetpeerings.VnetPeeringSpec{
+			PeeringName:          "spoke-vnet-To-my-vnet",
+			SourceVnetName:       "spoke-vnet",
+			SourceResourceGroup:  "spoke-rg",
+			RemoteVnetName:       "my-vnet",
+			RemoteResourceGroup:  "my-rg",
+			SubscriptionID:       "123",
+			SourceSubscriptionID: "123",
+		},
+		&vnetpeerings.VnetPeeringSpec{
+			PeeringName:           "my-vnet-To-hub-vnet",
+			SourceVnetName:        "my-vnet",
+			SourceResourceGroup:   "my-rg",
+			RemoteVnetName:        "hub-vnet",
+			RemoteResourceGroup:   "hub-rg",
+			SubscriptionID:        "456",
+			SourceSubscriptionID:  "123",
+			RemoteIdentityRef:     identityRef,
+			AllowForwardedTraffic: to.BoolPtr(true),
+			UseRemoteGateways:     to.BoolPtr(true),
+		},
+		&vnetpeerings.VnetPeeringSpec{
+			PeeringName:          "hub-vnet-To-my-vnet",
+			SourceVnetName:       "hub-vnet",
+			SourceResourceGroup:  "hub-rg",
+			RemoteVnetName:       "my-vnet",
+			RemoteResourceGroup:  "my-rg",
+			SubscriptionID:       "123",
+			SourceSubscriptionID: "456",
+			SourceIdentityRef:    identityRef,
+			AllowGatewayTransit:  to.BoolPtr(true),
+		},
+	}))
+}
+
+func TestVnetPeeringAuthorizerWithClusterIdentity(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+					auth.TenantID:       "my-tenant",
+				},
+			},
+			ResourceManagerEndpoint: "https://management.azure.com/",
+		},
+		AzureCluster: &infrav1.AzureCluster{},
+	}
+
+	authorizer, err := clusterScope.VnetPeeringAuthorizer(context.TODO(), "456", nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(authorizer.SubscriptionID()).To(Equal("456"))
+	g.Expect(authorizer.TenantID()).To(Equal("my-tenant"))
+	g.Expect(authorizer.BaseURI()).To(Equal("https://management.azure.com/"))
+	g.Expect(clusterScope.SubscriptionID()).To(Equal("123"))
+}
+
 func TestIsVnetManaged(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         bool
 	}{
 		{
 			name: "VNET ID is empty",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1443,7 +1915,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Wrong tags",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1467,7 +1939,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has owning tags",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1491,7 +1963,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has cached value of false",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{},
 				},
@@ -1503,7 +1975,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has cached value of true",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{},
 				},
@@ -1533,12 +2005,12 @@ func TestIsVnetManaged(t *testing.T) {
 func TestAzureBastionSpec(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1551,7 +2023,7 @@ func TestAzureBastionSpec(t *testing.T) {
 		},
 		{
 			name: "returns bastion spec if enabled",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1568,7 +2040,10 @@ func TestAzureBastionSpec(t *testing.T) {
 					Spec: infrav1.AzureClusterSpec{
 						BastionSpec: infrav1.BastionSpec{
 							AzureBastion: &infrav1.AzureBastion{
-								Name: "fake-azure-bastion-1",
+								Name:            "fake-azure-bastion-1",
+								Sku:             infrav1.StandardBastionHostSku,
+								ScaleUnits:      to.Int32Ptr(4),
+								EnableTunneling: true,
 								Subnet: infrav1.SubnetSpec{
 									SubnetClassSpec: infrav1.SubnetClassSpec{
 										Role:       infrav1.SubnetBastion,
@@ -1647,6 +2122,9 @@ func TestAzureBastionSpec(t *testing.T) {
 					"virtualNetworks/%s/subnets/%s", "123", "my-rg", "fake-vnet-1", "fake-bastion-subnet-1"),
 				PublicIPID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
 					"publicIPAddresses/%s", "123", "my-rg", "fake-public-ip-1"),
+				Sku:             infrav1.StandardBastionHostSku,
+				ScaleUnits:      to.Int32Ptr(4),
+				EnableTunneling: true,
 			},
 		},
 	}
@@ -1662,6 +2140,216 @@ func TestAzureBastionSpec(t *testing.T) {
 	}
 }
 
+func TestAzureFirewallSpecs(t *testing.T) {
+	tests := []struct {
+		name                        string
+		firewall                    *infrav1.AzureFirewall
+		wantPolicySpec              azure.ResourceSpecGetter
+		wantRuleCollectionGroupSpec azure.ResourceSpecGetter
+		wantFirewallSpec            azure.ResourceSpecGetter
+	}{
+		{
+			name:     "returns nil if no firewall is specified",
+			firewall: nil,
+		},
+		{
+			name: "returns policy, rule collection group and firewall specs for a managed firewall",
+			firewall: &infrav1.AzureFirewall{
+				Name:             "my-cluster-firewall",
+				SubnetCIDRBlocks: []string{"10.255.255.0/26"},
+				PublicIP:         infrav1.PublicIPSpec{Name: "my-cluster-firewall-pip"},
+				Tier:             infrav1.AzureFirewallTierPremium,
+				Zones:            []string{"1", "2"},
+				Policy: infrav1.AzureFirewallPolicy{
+					Name:         "my-cluster-firewall-policy",
+					AllowedFQDNs: []string{"example.com"},
+				},
+			},
+			wantPolicySpec: &azurefirewalls.PolicySpec{
+				Name:           "my-cluster-firewall-policy",
+				ResourceGroup:  "my-rg",
+				Location:       "westeurope",
+				ClusterName:    "my-cluster",
+				Tier:           infrav1.AzureFirewallTierPremium,
+				AdditionalTags: infrav1.Tags{},
+			},
+			wantRuleCollectionGroupSpec: &azurefirewalls.RuleCollectionGroupSpec{
+				Name:            "kubernetes-egress",
+				PolicyName:      "my-cluster-firewall-policy",
+				ResourceGroup:   "my-rg",
+				SourceAddresses: []string{"10.0.0.0/8"},
+				AzureFQDNs:      []string{"management.azure.com", "login.microsoftonline.com"},
+				AllowedFQDNs:    []string{"example.com"},
+			},
+			wantFirewallSpec: &azurefirewalls.FirewallSpec{
+				Name:           "my-cluster-firewall",
+				ResourceGroup:  "my-rg",
+				Location:       "westeurope",
+				ClusterName:    "my-cluster",
+				SubnetID:       "/subscriptions/123/resourceGroups/my-vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/AzureFirewallSubnet",
+				PublicIPID:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-cluster-firewall-pip",
+				PolicyID:       "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/firewallPolicies/my-cluster-firewall-policy",
+				Tier:           infrav1.AzureFirewallTierPremium,
+				Zones:          []string{"1", "2"},
+				AdditionalTags: infrav1.Tags{},
+			},
+		},
+		{
+			name: "returns only the firewall spec for an existing firewall",
+			firewall: &infrav1.AzureFirewall{
+				Name:          "hub-firewall",
+				ResourceGroup: "hub-rg",
+			},
+			wantFirewallSpec: &azurefirewalls.FirewallSpec{
+				Name:          "hub-firewall",
+				ResourceGroup: "hub-rg",
+				Existing:      true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			clusterScope := &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Environment: azureautorest.PublicCloud,
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "westeurope",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "my-vnet",
+								ResourceGroup: "my-vnet-rg",
+								VnetClassSpec: infrav1.VnetClassSpec{
+									CIDRBlocks: []string{"10.0.0.0/8"},
+								},
+							},
+							Firewall: tt.firewall,
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			}
+			policySpec, ruleCollectionGroupSpec, firewallSpec := clusterScope.AzureFirewallSpecs()
+			g.Expect(reflect.DeepEqual(policySpec, tt.wantPolicySpec)).To(BeTrue(), "policy spec %s", specToString(policySpec))
+			g.Expect(reflect.DeepEqual(ruleCollectionGroupSpec, tt.wantRuleCollectionGroupSpec)).To(BeTrue(), "rule collection group spec %s", specToString(ruleCollectionGroupSpec))
+			g.Expect(reflect.DeepEqual(firewallSpec, tt.wantFirewallSpec)).To(BeTrue(), "firewall spec %s", specToString(firewallSpec))
+		})
+	}
+}
+
+func TestAzureFirewallRouteSpecs(t *testing.T) {
+	g := NewWithT(t)
+	clusterScope := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Firewall: &infrav1.AzureFirewall{
+						Name:      "my-cluster-firewall",
+						PrivateIP: "10.255.255.4",
+					},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp-subnet", Role: infrav1.SubnetControlPlane},
+							RouteTable:      infrav1.RouteTable{Name: "cp-routetable"},
+						},
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet-1", Role: infrav1.SubnetNode},
+							RouteTable:      infrav1.RouteTable{Name: "node-routetable"},
+						},
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet-2", Role: infrav1.SubnetNode},
+							RouteTable:      infrav1.RouteTable{Name: "node-routetable"},
+						},
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet-3", Role: infrav1.SubnetNode},
+						},
+					},
+				},
+			},
+		},
+	}
+	g.Expect(clusterScope.AzureFirewallRouteSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&azurefirewalls.RouteSpec{
+			Name:              "azure-firewall-egress",
+			RouteTableName:    "cp-routetable",
+			ResourceGroup:     "my-rg",
+			FirewallPrivateIP: "10.255.255.4",
+		},
+		&azurefirewalls.RouteSpec{
+			Name:              "azure-firewall-egress",
+			RouteTableName:    "node-routetable",
+			ResourceGroup:     "my-rg",
+			FirewallPrivateIP: "10.255.255.4",
+		},
+	}))
+}
+
+func TestClusterScope_ConcurrentNetworkAccess(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		cache: &ClusterCache{},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{Name: "my-vnet"},
+					Subnets: infrav1.Subnets{
+						{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp-subnet", Role: infrav1.SubnetControlPlane}},
+						{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet", Role: infrav1.SubnetNode, CIDRBlocks: []string{"10.1.0.0/16"}}},
+					},
+				},
+			},
+		},
+	}
+
+	// The getters return copies, which can be modified without changing the spec.
+	clusterScope.Vnet().Name = "other-vnet"
+	clusterScope.Subnets()[0].Name = "other-subnet"
+	clusterScope.Subnet("node-subnet").CIDRBlocks[0] = "10.2.0.0/16"
+	g.Expect(clusterScope.AzureCluster.Spec.NetworkSpec.Vnet.Name).To(Equal("my-vnet"))
+	g.Expect(clusterScope.AzureCluster.Spec.NetworkSpec.Subnets[0].Name).To(Equal("cp-subnet"))
+	g.Expect(clusterScope.AzureCluster.Spec.NetworkSpec.Subnets[1].CIDRBlocks).To(Equal([]string{"10.1.0.0/16"}))
+
+	// Services reconciled concurrently read and write the network spec, which is detected by the race detector if unguarded.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("subnet-id-%d", i)
+			clusterScope.UpdateSubnetID("node-subnet", id)
+			clusterScope.UpdateSubnetCIDRs("cp-subnet", []string{fmt.Sprintf("10.%d.0.0/16", i)})
+			clusterScope.SetNatGatewayIDInSubnets("", id)
+			clusterScope.UpdateVnet(id, nil, []string{"10.0.0.0/8"})
+			_ = clusterScope.Subnets()
+			_ = clusterScope.Subnet("node-subnet")
+			_ = clusterScope.Vnet()
+		}(i)
+	}
+	wg.Wait()
+	g.Expect(clusterScope.Subnet("node-subnet").ID).To(HavePrefix("subnet-id-"))
+	g.Expect(clusterScope.Vnet().CIDRBlocks).To(Equal([]string{"10.0.0.0/8"}))
+}
+
 func TestSubnet(t *testing.T) {
 	tests := []struct {
 		clusterName             string
@@ -2198,6 +2886,59 @@ func TestOutboundPoolName(t *testing.T) {
 	}
 }
 
+func TestOutboundLBInboundNATPoolNames(t *testing.T) {
+	natPools := infrav1.InboundNATPools{{Name: "ssh"}, {Name: "rdp"}}
+	tests := []struct {
+		name        string
+		role        string
+		networkSpec infrav1.NetworkSpec
+		expectNames []string
+	}{
+		{
+			name: "node outbound load balancer with inbound NAT pools",
+			role: infrav1.Node,
+			networkSpec: infrav1.NetworkSpec{
+				NodeOutboundLB: &infrav1.LoadBalancerSpec{
+					LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{InboundNATPools: natPools},
+				},
+			},
+			expectNames: []string{"ssh", "rdp"},
+		},
+		{
+			name:        "no node outbound load balancer",
+			role:        infrav1.Node,
+			networkSpec: infrav1.NetworkSpec{},
+			expectNames: nil,
+		},
+		{
+			name: "public API server load balancer of the control plane",
+			role: infrav1.ControlPlane,
+			networkSpec: infrav1.NetworkSpec{
+				APIServerLB: infrav1.LoadBalancerSpec{
+					LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Public, InboundNATPools: natPools},
+				},
+			},
+			expectNames: []string{"ssh", "rdp"},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{NetworkSpec: tc.networkSpec},
+				},
+			}
+			if tc.expectNames == nil {
+				g.Expect(clusterScope.OutboundLBInboundNATPoolNames(tc.role)).To(BeEmpty())
+			} else {
+				g.Expect(clusterScope.OutboundLBInboundNATPoolNames(tc.role)).To(Equal(tc.expectNames))
+			}
+		})
+	}
+}
+
 func TestGenerateFQDN(t *testing.T) {
 	tests := []struct {
 		clusterName    string
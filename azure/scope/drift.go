@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// driftReport holds the fields of each Azure resource that differ from the spec, keyed by service and resource name.
+type driftReport map[string][]string
+
+// record adds the drifted fields of a resource to the report.
+func (d driftReport) record(serviceName, resourceName string, fields []string) {
+	key := driftKey(serviceName, resourceName)
+	d[key] = append(d[key], fields...)
+}
+
+// driftKey returns the key identifying a resource in drift reports and last-applied spec hashes.
+func driftKey(serviceName, resourceName string) string {
+	return fmt.Sprintf("%s/%s", serviceName, resourceName)
+}
+
+// String returns a stable, human-readable description of the report.
+func (d driftReport) String() string {
+	keys := make([]string, 0, len(d))
+	for key := range d {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resources := make([]string, 0, len(keys))
+	for _, key := range keys {
+		resources = append(resources, fmt.Sprintf("%s: %s", key, strings.Join(d[key], ", ")))
+	}
+	return strings.Join(resources, "; ")
+}
+
+// setDriftCondition sets the ResourcesInSync condition on obj from the report, and returns the description of the drift, if any.
+func setDriftCondition(obj conditions.Setter, report driftReport) string {
+	if len(report) == 0 {
+		conditions.MarkTrue(obj, infrav1.ResourcesInSyncCondition)
+		return ""
+	}
+	drift := report.String()
+	conditions.MarkFalse(obj, infrav1.ResourcesInSyncCondition, infrav1.DriftDetectedReason, clusterv1.ConditionSeverityWarning, "%s", drift)
+	return drift
+}
+
+// lastAppliedSpecHashes returns the hashes of the specs last applied to each resource, from the annotations of obj.
+// A malformed annotation is treated as empty, so that it is overwritten by the next applied spec.
+func lastAppliedSpecHashes(obj metav1.Object) map[string]string {
+	hashes := map[string]string{}
+	annotation := obj.GetAnnotations()[azure.DriftLastAppliedAnnotation]
+	if annotation == "" {
+		return hashes
+	}
+	if err := json.Unmarshal([]byte(annotation), &hashes); err != nil {
+		return map[string]string{}
+	}
+	return hashes
+}
+
+// setLastAppliedSpecHash records the hash of the spec applied to a resource in the annotations of obj.
+func setLastAppliedSpecHash(obj metav1.Object, serviceName, resourceName, hash string) {
+	hashes := lastAppliedSpecHashes(obj)
+	key := driftKey(serviceName, resourceName)
+	if hashes[key] == hash {
+		return
+	}
+	hashes[key] = hash
+	// A map of strings always marshals successfully.
+	b, _ := json.Marshal(hashes)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[azure.DriftLastAppliedAnnotation] = string(b)
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestClusterScopeDrift(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{azure.DriftModeAnnotation: azure.DriftModeReport},
+			},
+		},
+	}
+	g.Expect(s.DriftReportOnly()).To(BeTrue())
+
+	g.Expect(s.UpdateDriftStatus()).To(BeEmpty())
+	g.Expect(conditions.IsTrue(s.AzureCluster, infrav1.ResourcesInSyncCondition)).To(BeTrue())
+
+	s.RecordDrift("securitygroups", "node-nsg", []string{"securityRules[allow_ssh].priority"})
+	s.RecordDrift("loadbalancers", "public-lb", []string{"probes[TCPProbe]", "loadBalancingRules[LBRuleHTTPS]"})
+	drift := s.UpdateDriftStatus()
+	g.Expect(drift).To(Equal("loadbalancers/public-lb: probes[TCPProbe], loadBalancingRules[LBRuleHTTPS]; securitygroups/node-nsg: securityRules[allow_ssh].priority"))
+	g.Expect(conditions.IsFalse(s.AzureCluster, infrav1.ResourcesInSyncCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(s.AzureCluster, infrav1.ResourcesInSyncCondition)).To(Equal(infrav1.DriftDetectedReason))
+	g.Expect(conditions.GetMessage(s.AzureCluster, infrav1.ResourcesInSyncCondition)).To(Equal(drift))
+}
+
+func TestMachineScopeDrift(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &MachineScope{
+		AzureMachine: &infrav1.AzureMachine{},
+	}
+	g.Expect(s.DriftReportOnly()).To(BeFalse())
+
+	s.RecordDrift("virtualmachine", "my-vm", []string{"tags[foo]"})
+	g.Expect(s.UpdateDriftStatus()).To(Equal("virtualmachine/my-vm: tags[foo]"))
+	g.Expect(conditions.Get(s.AzureMachine, infrav1.ResourcesInSyncCondition).Status).To(Equal(corev1.ConditionFalse))
+}
+
+func TestLastAppliedSpecHash(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{},
+	}
+	g.Expect(s.LastAppliedSpecHash("securitygroups", "node-nsg")).To(BeEmpty())
+
+	s.SetLastAppliedSpecHash("securitygroups", "node-nsg", "foo")
+	s.SetLastAppliedSpecHash("routetables", "node-routetable", "bar")
+	g.Expect(s.LastAppliedSpecHash("securitygroups", "node-nsg")).To(Equal("foo"))
+	g.Expect(s.LastAppliedSpecHash("routetables", "node-routetable")).To(Equal("bar"))
+	g.Expect(s.AzureCluster.Annotations[azure.DriftLastAppliedAnnotation]).To(Equal(`{"routetables/node-routetable":"bar","securitygroups/node-nsg":"foo"}`))
+
+	// A malformed annotation is overwritten.
+	m := &MachineScope{
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{azure.DriftLastAppliedAnnotation: "not json"},
+			},
+		},
+	}
+	g.Expect(m.LastAppliedSpecHash("virtualmachine", "my-vm")).To(BeEmpty())
+	m.SetLastAppliedSpecHash("virtualmachine", "my-vm", "foo")
+	g.Expect(m.AzureMachine.Annotations[azure.DriftLastAppliedAnnotation]).To(Equal(`{"virtualmachine/my-vm":"foo"}`))
+}
@@ -75,8 +75,12 @@ func NewAzureClusterCredentialsProvider(ctx context.Context, kubeClient client.C
 	if azureCluster.Spec.IdentityRef == nil {
 		return nil, errors.New("failed to generate new AzureClusterCredentialsProvider from empty identityName")
 	}
+	return newAzureClusterCredentialsProviderFromRef(ctx, kubeClient, azureCluster, azureCluster.Spec.IdentityRef)
+}
 
-	ref := azureCluster.Spec.IdentityRef
+// newAzureClusterCredentialsProviderFromRef creates a new AzureClusterCredentialsProvider for the AzureClusterIdentity referenced by ref,
+// which may differ from the identity of the AzureCluster, e.g. for a virtual network peering.
+func newAzureClusterCredentialsProviderFromRef(ctx context.Context, kubeClient client.Client, azureCluster *infrav1.AzureCluster, ref *corev1.ObjectReference) (*AzureClusterCredentialsProvider, error) {
 	// if the namespace isn't specified then assume it's in the same namespace as the AzureCluster
 	namespace := ref.Namespace
 	if namespace == "" {
@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
@@ -97,6 +98,10 @@ type MachineScope struct {
 	Machine      *clusterv1.Machine
 	AzureMachine *infrav1.AzureMachine
 	cache        *MachineCache
+	// mu guards writes to the AzureMachine made by services, which may be reconciled concurrently.
+	mu    sync.Mutex
+	drift driftReport
+	plan  operationPlan
 }
 
 // MachineCache stores common machine information so we don't have to hit the API multiple times within the same reconcile loop.
@@ -104,6 +109,7 @@ type MachineCache struct {
 	BootstrapData      string
 	VMImage            *infrav1.Image
 	VMSKU              resourceskus.SKU
+	VMSizesInFamily    []string
 	availabilitySetSKU resourceskus.SKU
 }
 
@@ -136,6 +142,24 @@ func (m *MachineScope) InitMachineCache(ctx context.Context) error {
 			return errors.Wrapf(err, "failed to get VM SKU %s in compute api", m.AzureMachine.Spec.VMSize)
 		}
 
+		if zone := m.AvailabilityZone(); zone != "" {
+			if err := m.cache.VMSKU.CheckRestrictions(m.Location(), zone); err != nil {
+				return azure.WithTerminalError(err)
+			}
+		}
+
+		if err := m.checkVMQuota(ctx, skuCache); err != nil {
+			return err
+		}
+
+		// The sizes of the VM SKU family are only needed to resize an existing VM in place.
+		if m.ProviderID() != "" {
+			m.cache.VMSizesInFamily, err = skuCache.GetVMSizesInFamily(ctx, to.String(m.cache.VMSKU.Family))
+			if err != nil {
+				return errors.Wrapf(err, "failed to get VM sizes in family of VM SKU %s", m.AzureMachine.Spec.VMSize)
+			}
+		}
+
 		m.cache.availabilitySetSKU, err = skuCache.Get(ctx, string(compute.AvailabilitySetSkuTypesAligned), resourceskus.AvailabilitySets)
 		if err != nil {
 			return errors.Wrapf(err, "failed to get availability set SKU %s in compute api", string(compute.AvailabilitySetSkuTypesAligned))
@@ -145,31 +169,55 @@ func (m *MachineScope) InitMachineCache(ctx context.Context) error {
 	return nil
 }
 
+// checkVMQuota verifies there is enough vCPU quota left to create the VM. It is skipped once the VM
+// exists or is being created, as the VM then already counts towards the current usage.
+func (m *MachineScope) checkVMQuota(ctx context.Context, skuCache *resourceskus.Cache) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "azure.MachineScope.checkVMQuota")
+	defer done()
+
+	if m.ProviderID() != "" || m.GetLongRunningOperationState(m.Name(), virtualmachines.ServiceName, infrav1.PutFuture) != nil {
+		return nil
+	}
+
+	err := skuCache.CheckQuota(ctx, m.cache.VMSKU, 1, m.AzureMachine.Spec.SpotVMOptions != nil)
+	if err != nil && !resourceskus.IsQuotaExceeded(err) {
+		// Quota checks are best effort, let Azure reject the VM if we cannot get the current usage.
+		log.V(2).Info("unable to check compute quota, continuing", "error", err.Error())
+		return nil
+	}
+	return err
+}
+
 // VMSpec returns the VM spec.
 func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 	spec := &virtualmachines.VMSpec{
-		Name:                   m.Name(),
-		Location:               m.Location(),
-		ResourceGroup:          m.ResourceGroup(),
-		ClusterName:            m.ClusterName(),
-		Role:                   m.Role(),
-		NICIDs:                 m.NICIDs(),
-		SSHKeyData:             m.AzureMachine.Spec.SSHPublicKey,
-		Size:                   m.AzureMachine.Spec.VMSize,
-		OSDisk:                 m.AzureMachine.Spec.OSDisk,
-		DataDisks:              m.AzureMachine.Spec.DataDisks,
-		AvailabilitySetID:      m.AvailabilitySetID(),
-		Zone:                   m.AvailabilityZone(),
-		Identity:               m.AzureMachine.Spec.Identity,
-		UserAssignedIdentities: m.AzureMachine.Spec.UserAssignedIdentities,
-		SpotVMOptions:          m.AzureMachine.Spec.SpotVMOptions,
-		SecurityProfile:        m.AzureMachine.Spec.SecurityProfile,
-		AdditionalTags:         m.AdditionalTags(),
-		AdditionalCapabilities: m.AzureMachine.Spec.AdditionalCapabilities,
-		ProviderID:             m.ProviderID(),
+		Name:                       m.Name(),
+		Location:                   m.Location(),
+		ResourceGroup:              m.ResourceGroup(),
+		ClusterName:                m.ClusterName(),
+		Role:                       m.Role(),
+		NICIDs:                     m.NICIDs(),
+		SSHKeyData:                 m.AzureMachine.Spec.SSHPublicKey,
+		Size:                       m.AzureMachine.Spec.VMSize,
+		OSDisk:                     m.AzureMachine.Spec.OSDisk,
+		DataDisks:                  m.AzureMachine.Spec.DataDisks,
+		AvailabilitySetID:          m.AvailabilitySetID(),
+		Zone:                       m.AvailabilityZone(),
+		CapacityReservationGroupID: m.AzureMachine.Spec.CapacityReservationGroupID,
+		DedicatedHostGroupID:       m.AzureMachine.Spec.DedicatedHostGroupID,
+		ProximityPlacementGroupID:  m.AzureMachine.Spec.ProximityPlacementGroupID,
+		Identity:                   m.AzureMachine.Spec.Identity,
+		UserAssignedIdentities:     m.AzureMachine.Spec.UserAssignedIdentities,
+		SpotVMOptions:              m.AzureMachine.Spec.SpotVMOptions,
+		SecurityProfile:            m.AzureMachine.Spec.SecurityProfile,
+		AdditionalTags:             m.AdditionalTags(),
+		AdditionalCapabilities:     m.AzureMachine.Spec.AdditionalCapabilities,
+		Diagnostics:                m.AzureMachine.Spec.Diagnostics,
+		ProviderID:                 m.ProviderID(),
 	}
 	if m.cache != nil {
 		spec.SKU = m.cache.VMSKU
+		spec.VMSizesInFamily = m.cache.VMSizesInFamily
 		spec.Image = m.cache.VMImage
 		spec.BootstrapData = m.cache.BootstrapData
 	}
@@ -190,9 +238,12 @@ func (m *MachineScope) TagsSpecs() []azure.TagsSpec {
 // PublicIPSpecs returns the public IP specs.
 func (m *MachineScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
-	if m.AzureMachine.Spec.AllocatePublicIP {
+	for i, nic := range m.networkInterfaces() {
+		if !nic.AllocatePublicIP {
+			continue
+		}
 		specs = append(specs, &publicips.PublicIPSpec{
-			Name:           azure.GenerateNodePublicIPName(m.Name()),
+			Name:           m.publicIPName(i),
 			ResourceGroup:  m.ResourceGroup(),
 			ClusterName:    m.ClusterName(),
 			DNSName:        "",    // Set to default value
@@ -227,51 +278,101 @@ func (m *MachineScope) InboundNatSpecs() []azure.ResourceSpecGetter {
 }
 
 // NICSpecs returns the network interface specs.
+// The first network interface is the primary one, which is the only one attached to the load balancers.
 func (m *MachineScope) NICSpecs() []azure.ResourceSpecGetter {
-	spec := &networkinterfaces.NICSpec{
-		Name:                  azure.GenerateNICName(m.Name()),
-		ResourceGroup:         m.ResourceGroup(),
-		Location:              m.Location(),
-		SubscriptionID:        m.SubscriptionID(),
-		MachineName:           m.Name(),
-		VNetName:              m.Vnet().Name,
-		VNetResourceGroup:     m.Vnet().ResourceGroup,
-		SubnetName:            m.AzureMachine.Spec.SubnetName,
-		AcceleratedNetworking: m.AzureMachine.Spec.AcceleratedNetworking,
-		DNSServers:            m.AzureMachine.Spec.DNSServers,
-		IPv6Enabled:           m.IsIPv6Enabled(),
-		EnableIPForwarding:    m.AzureMachine.Spec.EnableIPForwarding,
-		AdditionalTags:        m.AdditionalTags(),
-		ClusterName:           m.ClusterName(),
-	}
+	nics := m.networkInterfaces()
+	specs := make([]azure.ResourceSpecGetter, len(nics))
+	for i, nic := range nics {
+		spec := &networkinterfaces.NICSpec{
+			Name:                  m.nicName(i),
+			ResourceGroup:         m.ResourceGroup(),
+			Location:              m.Location(),
+			SubscriptionID:        m.SubscriptionID(),
+			MachineName:           m.Name(),
+			VNetName:              m.Vnet().Name,
+			VNetResourceGroup:     m.Vnet().ResourceGroup,
+			SubnetName:            nic.SubnetName,
+			StaticIPAddress:       nic.PrivateIPAddress,
+			AcceleratedNetworking: nic.AcceleratedNetworking,
+			DNSServers:            m.AzureMachine.Spec.DNSServers,
+			EnableIPForwarding:    nic.EnableIPForwarding,
+			AdditionalTags:        m.AdditionalTags(),
+			ClusterName:           m.ClusterName(),
+		}
 
-	if m.Role() == infrav1.ControlPlane {
-		spec.PublicLBName = m.OutboundLBName(m.Role())
-		spec.PublicLBAddressPoolName = m.OutboundPoolName(m.OutboundLBName(m.Role()))
-		if m.IsAPIServerPrivate() {
-			spec.InternalLBName = m.APIServerLBName()
-			spec.InternalLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
-		} else {
-			spec.PublicLBNATRuleName = m.Name()
-			spec.PublicLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
+		if i == 0 {
+			spec.IPv6Enabled = m.IsIPv6Enabled()
+
+			if m.Role() == infrav1.ControlPlane {
+				spec.PublicLBName = m.OutboundLBName(m.Role())
+				spec.PublicLBAddressPoolName = m.OutboundPoolName(m.OutboundLBName(m.Role()))
+				if m.IsAPIServerPrivate() {
+					spec.InternalLBName = m.APIServerLBName()
+					spec.InternalLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
+				} else {
+					spec.PublicLBNATRuleName = m.Name()
+					spec.PublicLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
+				}
+			}
+
+			// If NAT gateway is not enabled and node has no public IP, then the NIC needs to reference the LB to get outbound traffic.
+			if m.Role() == infrav1.Node && !m.Subnet().IsNatGatewayEnabled() && !nic.AllocatePublicIP {
+				spec.PublicLBName = m.OutboundLBName(m.Role())
+				spec.PublicLBAddressPoolName = m.OutboundPoolName(m.OutboundLBName(m.Role()))
+			}
 		}
-	}
 
-	// If NAT gateway is not enabled and node has no public IP, then the NIC needs to reference the LB to get outbound traffic.
-	if m.Role() == infrav1.Node && !m.Subnet().IsNatGatewayEnabled() && !m.AzureMachine.Spec.AllocatePublicIP {
-		spec.PublicLBName = m.OutboundLBName(m.Role())
-		spec.PublicLBAddressPoolName = m.OutboundPoolName(m.OutboundLBName(m.Role()))
+		if m.Role() == infrav1.Node && nic.AllocatePublicIP {
+			spec.PublicIPName = m.publicIPName(i)
+		}
+
+		// The network interface joins the application security groups of the machine role.
+		for _, asg := range m.ApplicationSecurityGroups() {
+			if string(asg.Role) == m.Role() {
+				spec.ApplicationSecurityGroups = append(spec.ApplicationSecurityGroups, asg.Name)
+			}
+		}
+
+		if m.cache != nil {
+			spec.SKU = &m.cache.VMSKU
+		}
+
+		specs[i] = spec
 	}
 
-	if m.Role() == infrav1.Node && m.AzureMachine.Spec.AllocatePublicIP {
-		spec.PublicIPName = azure.GenerateNodePublicIPName(m.Name())
+	return specs
+}
+
+// networkInterfaces returns the network interfaces of the machine. When none are specified, the single network interface
+// is built from the subnet name, accelerated networking, IP forwarding and public IP settings of the spec.
+func (m *MachineScope) networkInterfaces() []infrav1.NetworkInterface {
+	if len(m.AzureMachine.Spec.NetworkInterfaces) > 0 {
+		return m.AzureMachine.Spec.NetworkInterfaces
+	}
+	return []infrav1.NetworkInterface{
+		{
+			SubnetName:            m.AzureMachine.Spec.SubnetName,
+			AcceleratedNetworking: m.AzureMachine.Spec.AcceleratedNetworking,
+			EnableIPForwarding:    m.AzureMachine.Spec.EnableIPForwarding,
+			AllocatePublicIP:      m.AzureMachine.Spec.AllocatePublicIP,
+		},
 	}
+}
 
-	if m.cache != nil {
-		spec.SKU = &m.cache.VMSKU
+// nicName returns the name of the network interface at the given index.
+func (m *MachineScope) nicName(index int) string {
+	if index == 0 {
+		return azure.GenerateNICName(m.Name())
 	}
+	return azure.GenerateSecondaryNICName(m.Name(), index)
+}
 
-	return []azure.ResourceSpecGetter{spec}
+// publicIPName returns the name of the public IP of the network interface at the given index.
+func (m *MachineScope) publicIPName(index int) string {
+	if index == 0 {
+		return azure.GenerateNodePublicIPName(m.Name())
+	}
+	return azure.GenerateNodePublicIPName(m.nicName(index))
 }
 
 // NICIDs returns the NIC resource IDs.
@@ -361,10 +462,11 @@ func (m *MachineScope) VMExtensionSpecs() []azure.ResourceSpecGetter {
 	return extensionSpecs
 }
 
-// Subnet returns the machine's subnet.
+// Subnet returns the subnet of the machine's primary network interface.
 func (m *MachineScope) Subnet() infrav1.SubnetSpec {
+	subnetName := m.networkInterfaces()[0].SubnetName
 	for _, subnet := range m.Subnets() {
-		if subnet.Name == m.AzureMachine.Spec.SubnetName {
+		if subnet.Name == subnetName {
 			return subnet
 		}
 	}
@@ -466,6 +568,12 @@ func (m *MachineScope) AvailabilitySet() (string, bool) {
 		return "", false
 	}
 
+	// capacity reservations and dedicated hosts do not support availability sets, and an availability set created here
+	// would not be in the machine's proximity placement group.
+	if m.hasPlacementGroup() {
+		return "", false
+	}
+
 	if m.IsControlPlane() {
 		return azure.GenerateAvailabilitySetName(m.ClusterName(), azure.ControlPlaneNodeGroup), true
 	}
@@ -483,6 +591,16 @@ func (m *MachineScope) AvailabilitySet() (string, bool) {
 	return "", false
 }
 
+// hasPlacementGroup returns true if the machine is placed in a capacity reservation group, a dedicated host group or a
+// proximity placement group.
+func (m *MachineScope) hasPlacementGroup() bool {
+	if m.AzureMachine == nil {
+		return false
+	}
+	spec := m.AzureMachine.Spec
+	return spec.CapacityReservationGroupID != "" || spec.DedicatedHostGroupID != "" || spec.ProximityPlacementGroupID != ""
+}
+
 // AvailabilitySetID returns the availability set for this machine, or "" if there is no availability set.
 func (m *MachineScope) AvailabilitySetID() string {
 	var asID string
@@ -560,16 +678,85 @@ func (m *MachineScope) SetBootstrapConditions(ctx context.Context, provisioningS
 
 // SetAnnotation sets a key value annotation on the AzureMachine.
 func (m *MachineScope) SetAnnotation(key, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.AzureMachine.Annotations == nil {
 		m.AzureMachine.Annotations = map[string]string{}
 	}
 	m.AzureMachine.Annotations[key] = value
 }
 
+// RecordDrift records the fields of an Azure resource that differ from its spec.
+func (m *MachineScope) RecordDrift(serviceName, resourceName string, fields []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.drift == nil {
+		m.drift = driftReport{}
+	}
+	m.drift.record(serviceName, resourceName, fields)
+}
+
+// DriftReportOnly returns true if drifted resources should be reported but not updated.
+func (m *MachineScope) DriftReportOnly() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.AzureMachine.GetAnnotations()[azure.DriftModeAnnotation] == azure.DriftModeReport
+}
+
+// LastAppliedSpecHash returns the hash of the spec last applied to an Azure resource, or an empty string if none was recorded.
+func (m *MachineScope) LastAppliedSpecHash(serviceName, resourceName string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return lastAppliedSpecHashes(m.AzureMachine)[driftKey(serviceName, resourceName)]
+}
+
+// SetLastAppliedSpecHash records the hash of the spec applied to an Azure resource.
+func (m *MachineScope) SetLastAppliedSpecHash(serviceName, resourceName, hash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	setLastAppliedSpecHash(m.AzureMachine, serviceName, resourceName, hash)
+}
+
+// UpdateDriftStatus sets the ResourcesInSync condition on the AzureMachine from the drift recorded during reconciliation.
+// It returns the description of the drift, or an empty string if all resources match the spec.
+func (m *MachineScope) UpdateDriftStatus() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return setDriftCondition(m.AzureMachine, m.drift)
+}
+
+// DryRun returns true if the Azure operations needed to reconcile the AzureMachine should be planned but not performed.
+func (m *MachineScope) DryRun() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.AzureMachine.GetAnnotations()[azure.DryRunAnnotation] == "true"
+}
+
+// PlanOperation records an Azure operation that would be performed in dry-run mode.
+func (m *MachineScope) PlanOperation(operation, serviceName, resourceGroupName, resourceName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.plan == nil {
+		m.plan = operationPlan{}
+	}
+	m.plan.record(operation, serviceName, resourceGroupName, resourceName)
+}
+
+// UpdatePlanStatus sets the InfrastructureUpToDate condition on the AzureMachine from the operations planned in dry-run mode.
+// It returns the description of the planned operations, or an empty string if there are none.
+func (m *MachineScope) UpdatePlanStatus() string {
+	dryRun := m.DryRun()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return setPlanCondition(m.AzureMachine, dryRun, m.plan)
+}
+
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (m *MachineScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
 	out := map[string]interface{}{}
+	m.mu.Lock()
 	jsonAnnotation := m.AzureMachine.GetAnnotations()[annotation]
+	m.mu.Unlock()
 	if jsonAnnotation == "" {
 		return out, nil
 	}
@@ -600,6 +787,7 @@ func (m *MachineScope) SetAddresses(addrs []corev1.NodeAddress) {
 
 // PatchObject persists the machine spec and status.
 func (m *MachineScope) PatchObject(ctx context.Context) error {
+	setThrottledCondition(m.AzureMachine, m.SubscriptionID())
 	conditions.SetSummary(m.AzureMachine)
 
 	return m.patchHelper.Patch(
@@ -664,23 +852,25 @@ func (m *MachineScope) GetVMImage(ctx context.Context) (*infrav1.Image, error) {
 	}
 
 	svc := virtualmachineimages.New(m)
+	// A VM with a security type can only boot a generation 2 image.
+	gen2 := m.AzureMachine.Spec.SecurityProfile != nil && m.AzureMachine.Spec.SecurityProfile.SecurityType != ""
 
 	if m.AzureMachine.Spec.OSDisk.OSType == azure.WindowsOS {
 		runtime := m.AzureMachine.Annotations["runtime"]
 		windowsServerVersion := m.AzureMachine.Annotations["windowsServerVersion"]
-		log.Info("No image specified for machine, using default Windows Image", "machine", m.AzureMachine.GetName(), "runtime", runtime, "windowsServerVersion", windowsServerVersion)
-		return svc.GetDefaultWindowsImage(ctx, m.Location(), to.String(m.Machine.Spec.Version), runtime, windowsServerVersion)
+		log.Info("No image specified for machine, using default Windows Image", "machine", m.AzureMachine.GetName(), "runtime", runtime, "windowsServerVersion", windowsServerVersion, "gen2", gen2)
+		return svc.GetDefaultWindowsImage(ctx, m.Location(), to.String(m.Machine.Spec.Version), runtime, windowsServerVersion, gen2)
 	}
 
-	log.Info("No image specified for machine, using default Linux Image", "machine", m.AzureMachine.GetName())
-	return svc.GetDefaultUbuntuImage(ctx, m.Location(), to.String(m.Machine.Spec.Version))
+	log.Info("No image specified for machine, using default Linux Image", "machine", m.AzureMachine.GetName(), "gen2", gen2)
+	return svc.GetDefaultUbuntuImage(ctx, m.Location(), to.String(m.Machine.Spec.Version), gen2)
 }
 
 // SetSubnetName defaults the AzureMachine subnet name to the name of one the subnets with the machine role when there is only one of them.
 // Note: this logic exists only for purposes of ensuring backwards compatibility for old clusters created without the `subnetName` field being
 // set, and should be removed in the future when this field is no longer optional.
 func (m *MachineScope) SetSubnetName() error {
-	if m.AzureMachine.Spec.SubnetName == "" {
+	if m.AzureMachine.Spec.SubnetName == "" && len(m.AzureMachine.Spec.NetworkInterfaces) == 0 {
 		subnetName := ""
 		subnets := m.Subnets()
 		var subnetCount int
@@ -703,24 +893,34 @@ func (m *MachineScope) SetSubnetName() error {
 // SetLongRunningOperationState will set the future on the AzureMachine status to allow the resource to continue
 // in the next reconciliation.
 func (m *MachineScope) SetLongRunningOperationState(future *infrav1.Future) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	futures.Set(m.AzureMachine, future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureMachine status.
 func (m *MachineScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return futures.Get(m.AzureMachine, name, service, futureType)
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureMachine status.
 func (m *MachineScope) DeleteLongRunningOperationState(name, service, futureType string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	futures.Delete(m.AzureMachine, name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureMachine status after a DELETE operation.
 func (m *MachineScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	switch {
 	case err == nil:
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
+	case azure.IsOperationPlannedError(err):
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.PlannedReason, clusterv1.ConditionSeverityInfo, "%s deletion planned in dry-run mode", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.DeletingReason, clusterv1.ConditionSeverityInfo, "%s deleting", service)
 	default:
@@ -730,9 +930,13 @@ func (m *MachineScope) UpdateDeleteStatus(condition clusterv1.ConditionType, ser
 
 // UpdatePutStatus updates a condition on the AzureMachine status after a PUT operation.
 func (m *MachineScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	switch {
 	case err == nil:
 		conditions.MarkTrue(m.AzureMachine, condition)
+	case azure.IsOperationPlannedError(err):
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.PlannedReason, clusterv1.ConditionSeverityInfo, "%s creation or update planned in dry-run mode", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.CreatingReason, clusterv1.ConditionSeverityInfo, "%s creating or updating", service)
 	default:
@@ -742,9 +946,13 @@ func (m *MachineScope) UpdatePutStatus(condition clusterv1.ConditionType, servic
 
 // UpdatePatchStatus updates a condition on the AzureMachine status after a PATCH operation.
 func (m *MachineScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	switch {
 	case err == nil:
 		conditions.MarkTrue(m.AzureMachine, condition)
+	case azure.IsOperationPlannedError(err):
+		conditions.MarkFalse(m.AzureMachine, condition, infrav1.PlannedReason, clusterv1.ConditionSeverityInfo, "%s update planned in dry-run mode", service)
 	case azure.IsOperationNotDoneError(err):
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.UpdatingReason, clusterv1.ConditionSeverityInfo, "%s updating", service)
 	default:
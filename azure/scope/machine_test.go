@@ -18,9 +18,12 @@ package scope
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 
+	"github.com/Azure/go-autorest/autorest"
 	autorestazure "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -32,6 +35,8 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
@@ -41,19 +46,21 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestMachineScope_Name(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         string
 		testLength   bool
 	}{
 		{
 			name: "if provider ID exists, use it",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-with-a-long-name",
@@ -70,7 +77,7 @@ func TestMachineScope_Name(t *testing.T) {
 		},
 		{
 			name: "linux can be any length",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-with-really-really-long-name",
@@ -86,7 +93,7 @@ func TestMachineScope_Name(t *testing.T) {
 		},
 		{
 			name: "Windows name with long MachineName and short cluster name",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
 						ObjectMeta: metav1.ObjectMeta{
@@ -112,7 +119,7 @@ func TestMachineScope_Name(t *testing.T) {
 		},
 		{
 			name: "Windows name with long MachineName and long cluster name",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
 						ObjectMeta: metav1.ObjectMeta{
@@ -154,12 +161,12 @@ func TestMachineScope_Name(t *testing.T) {
 func TestMachineScope_GetVMID(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         string
 	}{
 		{
 			name: "returns the vm name from provider ID",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "not-this-name",
@@ -173,7 +180,7 @@ func TestMachineScope_GetVMID(t *testing.T) {
 		},
 		{
 			name: "returns empty if provider ID is invalid",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -199,12 +206,12 @@ func TestMachineScope_GetVMID(t *testing.T) {
 func TestMachineScope_ProviderID(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         string
 	}{
 		{
 			name: "returns the entire provider ID",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "not-this-name",
@@ -218,7 +225,7 @@ func TestMachineScope_ProviderID(t *testing.T) {
 		},
 		{
 			name: "returns empty if provider ID is invalid",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -244,12 +251,12 @@ func TestMachineScope_ProviderID(t *testing.T) {
 func TestMachineScope_PublicIPSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns nil if AllocatePublicIP is false",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -263,7 +270,7 @@ func TestMachineScope_PublicIPSpecs(t *testing.T) {
 		},
 		{
 			name: "appends to PublicIPSpec for node if AllocatePublicIP is true",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -337,15 +344,67 @@ func TestMachineScope_PublicIPSpecs(t *testing.T) {
 	}
 }
 
+// publicIPsMachineScope is a MachineScope with several public IPs, which are reconciled concurrently.
+type publicIPsMachineScope struct {
+	*MachineScope
+	specs []azure.ResourceSpecGetter
+}
+
+func (m *publicIPsMachineScope) PublicIPSpecs() []azure.ResourceSpecGetter {
+	return m.specs
+}
+
+// TestMachineScope_ConcurrentPublicIPs checks that the status writes of concurrently reconciled public IPs are
+// serialized, it is meant to be run with -race.
+func TestMachineScope_ConcurrentPublicIPs(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	machineScope := &MachineScope{
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "machine-name",
+			},
+		},
+	}
+	scope := &publicIPsMachineScope{MachineScope: machineScope}
+	for i := 0; i < 4; i++ {
+		scope.specs = append(scope.specs, &publicips.PublicIPSpec{
+			Name:          fmt.Sprintf("pip-machine-name-%d", i),
+			ResourceGroup: "my-rg",
+			ClusterName:   "my-cluster",
+			Location:      "centralIndia",
+		})
+	}
+
+	creator := mock_async.NewMockCreator(mockCtrl)
+	creator.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&publicips.PublicIPSpec{})).
+		Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found")).
+		Times(len(scope.specs))
+	creator.EXPECT().CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&publicips.PublicIPSpec{}), gomock.Any()).
+		Return(nil, &autorestazure.Future{}, context.DeadlineExceeded).
+		Times(len(scope.specs))
+
+	s := &publicips.Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, creator, nil),
+	}
+	err := s.Reconcile(context.TODO())
+	g.Expect(azure.IsOperationNotDoneError(err)).To(BeTrue())
+	g.Expect(machineScope.AzureMachine.Status.LongRunningOperationStates).To(HaveLen(len(scope.specs)))
+	g.Expect(conditions.IsFalse(machineScope.AzureMachine, infrav1.PublicIPsReadyCondition)).To(BeTrue())
+}
+
 func TestMachineScope_InboundNatSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns empty when infra is not control plane",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -357,7 +416,7 @@ func TestMachineScope_InboundNatSpecs(t *testing.T) {
 		},
 		{
 			name: "returns InboundNatSpec when infra is control plane",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Labels: map[string]string{
@@ -422,12 +481,12 @@ func TestMachineScope_InboundNatSpecs(t *testing.T) {
 func TestMachineScope_RoleAssignmentSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns empty if VM identity is system assigned",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -439,7 +498,7 @@ func TestMachineScope_RoleAssignmentSpecs(t *testing.T) {
 		},
 		{
 			name: "returns RoleAssignmentSpec if VM identity is not system assigned",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -493,12 +552,12 @@ func TestMachineScope_RoleAssignmentSpecs(t *testing.T) {
 func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "If OS type is Linux and cloud is AzurePublicCloud, it returns ExtensionSpec",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -546,7 +605,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 		},
 		{
 			name: "If OS type is Linux and cloud is not AzurePublicCloud, it returns empty",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -580,7 +639,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 		},
 		{
 			name: "If OS type is Windows and cloud is AzurePublicCloud, it returns ExtensionSpec",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -628,7 +687,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 		},
 		{
 			name: "If OS type is Windows and cloud is not AzurePublicCloud, it returns empty",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -662,7 +721,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 		},
 		{
 			name: "If OS type is not Linux or Windows and cloud is AzurePublicCloud, it returns empty",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -696,7 +755,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 		},
 		{
 			name: "If OS type is not Windows or Linux and cloud is not AzurePublicCloud, it returns empty",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -730,7 +789,7 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 		},
 		{
 			name: "If a custom VM extension is specified, it returns the custom VM extension",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -818,12 +877,12 @@ func TestMachineScope_VMExtensionSpecs(t *testing.T) {
 func TestMachineScope_Subnet(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         infrav1.SubnetSpec
 	}{
 		{
 			name: "returns empty if no subnet is found at cluster scope",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -846,7 +905,7 @@ func TestMachineScope_Subnet(t *testing.T) {
 		},
 		{
 			name: "returns the machine subnet name if the same is present in the cluster scope",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -884,7 +943,7 @@ func TestMachineScope_Subnet(t *testing.T) {
 		},
 		{
 			name: "returns empty if machine subnet name is not present in the cluster scope",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -916,12 +975,12 @@ func TestMachineScope_Subnet(t *testing.T) {
 func TestMachineScope_AvailabilityZone(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         string
 	}{
 		{
 			name: "returns empty if no failure domain is present",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					Spec: clusterv1.MachineSpec{},
 				},
@@ -936,7 +995,7 @@ func TestMachineScope_AvailabilityZone(t *testing.T) {
 		},
 		{
 			name: "returns failure domain from the machine spec",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					Spec: clusterv1.MachineSpec{
 						FailureDomain: pointer.String("dummy-failure-domain-from-machine-spec"),
@@ -955,7 +1014,7 @@ func TestMachineScope_AvailabilityZone(t *testing.T) {
 		},
 		{
 			name: "returns failure domain from the azuremachine spec",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					Spec: clusterv1.MachineSpec{},
 				},
@@ -983,12 +1042,12 @@ func TestMachineScope_AvailabilityZone(t *testing.T) {
 func TestMachineScope_Namespace(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         string
 	}{
 		{
 			name: "returns azure machine namespace",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "machine-name",
@@ -1000,7 +1059,7 @@ func TestMachineScope_Namespace(t *testing.T) {
 		},
 		{
 			name: "returns azure machine namespace as empty if namespace is no specified",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1022,12 +1081,12 @@ func TestMachineScope_Namespace(t *testing.T) {
 func TestMachineScope_IsControlPlane(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         bool
 	}{
 		{
 			name: "returns false when machine is not control plane",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1039,7 +1098,7 @@ func TestMachineScope_IsControlPlane(t *testing.T) {
 		},
 		{
 			name: "returns true when machine is control plane",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Labels: map[string]string{
@@ -1068,12 +1127,12 @@ func TestMachineScope_IsControlPlane(t *testing.T) {
 func TestMachineScope_Role(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         string
 	}{
 		{
 			name: "returns node when machine is worker",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{},
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1085,7 +1144,7 @@ func TestMachineScope_Role(t *testing.T) {
 		},
 		{
 			name: "returns control-plane when machine is control plane",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Labels: map[string]string{
@@ -1114,13 +1173,13 @@ func TestMachineScope_Role(t *testing.T) {
 func TestMachineScope_AvailabilitySet(t *testing.T) {
 	tests := []struct {
 		name                         string
-		machineScope                 MachineScope
+		machineScope                 *MachineScope
 		wantAvailabilitySetName      string
 		wantAvailabilitySetExistence bool
 	}{
 		{
 			name: "returns empty and false if availability set is not enabled",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
 						ObjectMeta: metav1.ObjectMeta{
@@ -1142,7 +1201,7 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 		},
 		{
 			name: "returns AvailabilitySet name and true if availability set is enabled and machine is control plane",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
@@ -1165,9 +1224,39 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 			wantAvailabilitySetName:      "cluster_control-plane-as",
 			wantAvailabilitySetExistence: true,
 		},
+		{
+			name: "returns empty and false if machine is in a proximity placement group",
+			machineScope: &MachineScope{
+
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Status: infrav1.AzureClusterStatus{},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							clusterv1.MachineControlPlaneLabelName: "",
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						ProximityPlacementGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg",
+					},
+				},
+			},
+			wantAvailabilitySetName:      "",
+			wantAvailabilitySetExistence: false,
+		},
 		{
 			name: "returns AvailabilitySet name and true if AvailabilitySet is enabled for worker machine which is part of machine deployment",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
@@ -1192,7 +1281,7 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 		},
 		{
 			name: "returns AvailabilitySet name and true if AvailabilitySet is enabled for worker machine which is part of machine set",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
@@ -1217,7 +1306,7 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 		},
 		{
 			name: "returns AvailabilitySet name and true if AvailabilitySet is enabled for worker machine and machine deployment name takes precedence over machine set name",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
@@ -1243,7 +1332,7 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 		},
 		{
 			name: "returns empty and false if AvailabilitySet is enabled but worker machine is not part of machine deployment or machine set",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
@@ -1281,12 +1370,12 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 func TestMachineScope_VMState(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         infrav1.ProvisioningState
 	}{
 		{
 			name: "returns the VMState if present in AzureMachine status",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1303,7 +1392,7 @@ func TestMachineScope_VMState(t *testing.T) {
 		},
 		{
 			name: "returns empty if VMState is not present in AzureMachine status",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1336,13 +1425,13 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         *infrav1.Image
 		expectedErr  string
 	}{
 		{
 			name: "returns AzureMachine image is found if present in the AzureMachine spec",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				AzureMachine: &infrav1.AzureMachine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1361,7 +1450,7 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 		},
 		{
 			name: "if no image is specified and os specified is windows with version below 1.22, returns windows dockershim image",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1383,14 +1472,14 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 				ClusterScoper: clusterMock,
 			},
 			want: func() *infrav1.Image {
-				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.20.1", "dockershim", "")
+				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.20.1", "dockershim", "", false)
 				return image
 			}(),
 			expectedErr: "",
 		},
 		{
 			name: "if no image is specified and os specified is windows with version is 1.22+ with no annotation, returns windows containerd image",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1412,14 +1501,14 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 				ClusterScoper: clusterMock,
 			},
 			want: func() *infrav1.Image {
-				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.22.1", "containerd", "")
+				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.22.1", "containerd", "", false)
 				return image
 			}(),
 			expectedErr: "",
 		},
 		{
 			name: "if no image is specified and os specified is windows with version is 1.22+ with annotation dockershim, returns windows dockershim image",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1444,14 +1533,14 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 				ClusterScoper: clusterMock,
 			},
 			want: func() *infrav1.Image {
-				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.22.1", "dockershim", "")
+				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.22.1", "dockershim", "", false)
 				return image
 			}(),
 			expectedErr: "",
 		},
 		{
 			name: "if no image is specified and os specified is windows with version is less and 1.22 with annotation dockershim, returns windows dockershim image",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1476,14 +1565,14 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 				ClusterScoper: clusterMock,
 			},
 			want: func() *infrav1.Image {
-				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.21.1", "dockershim", "")
+				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.21.1", "dockershim", "", false)
 				return image
 			}(),
 			expectedErr: "",
 		},
 		{
 			name: "if no image is specified and os specified is windows with version is less and 1.22 with annotation containerd, returns error",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1512,7 +1601,7 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 		},
 		{
 			name: "if no image is specified and os specified is windows with windowsServerVersion annotation set to 2019, retrurns 2019 image",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1537,14 +1626,14 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 				ClusterScoper: clusterMock,
 			},
 			want: func() *infrav1.Image {
-				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.23.3", "", "windows-2019")
+				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.23.3", "", "windows-2019", false)
 				return image
 			}(),
 			expectedErr: "",
 		},
 		{
 			name: "if no image is specified and os specified is windows with windowsServerVersion annotation set to 2022, retrurns 2022 image",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1569,14 +1658,14 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 				ClusterScoper: clusterMock,
 			},
 			want: func() *infrav1.Image {
-				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.23.3", "", "windows-2022")
+				image, _ := svc.GetDefaultWindowsImage(context.TODO(), "", "1.23.3", "", "windows-2022", false)
 				return image
 			}(),
 			expectedErr: "",
 		},
 		{
 			name: "if no image and OS is specified, returns linux image",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				Machine: &clusterv1.Machine{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "machine-name",
@@ -1593,7 +1682,7 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 				ClusterScoper: clusterMock,
 			},
 			want: func() *infrav1.Image {
-				image, _ := svc.GetDefaultUbuntuImage(context.TODO(), "", "1.20.1")
+				image, _ := svc.GetDefaultUbuntuImage(context.TODO(), "", "1.20.1", false)
 				return image
 			}(),
 			expectedErr: "",
@@ -1615,12 +1704,12 @@ func TestMachineScope_GetVMImage(t *testing.T) {
 func TestMachineScope_NICSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "Node Machine with no NAT gateway and no public IP address",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
@@ -1718,9 +1807,239 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Node Machine with multiple network interfaces",
+			machineScope: &MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+									},
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "replication",
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: to.StringPtr("azure://compute/virtual-machines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{
+							{
+								SubnetName:            "subnet1",
+								AcceleratedNetworking: to.BoolPtr(true),
+							},
+							{
+								SubnetName:         "replication",
+								EnableIPForwarding: true,
+								PrivateIPAddress:   "10.1.0.4",
+								AllocatePublicIP:   true,
+							},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                    "machine-name-nic",
+					ResourceGroup:           "my-rg",
+					Location:                "westus",
+					SubscriptionID:          "123",
+					MachineName:             "machine-name",
+					SubnetName:              "subnet1",
+					VNetName:                "vnet1",
+					VNetResourceGroup:       "rg1",
+					PublicLBName:            "outbound-lb",
+					PublicLBAddressPoolName: "outbound-lb-outboundBackendPool",
+					AcceleratedNetworking:   to.BoolPtr(true),
+					ClusterName:             "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+				&networkinterfaces.NICSpec{
+					Name:               "machine-name-nic-1",
+					ResourceGroup:      "my-rg",
+					Location:           "westus",
+					SubscriptionID:     "123",
+					MachineName:        "machine-name",
+					SubnetName:         "replication",
+					VNetName:           "vnet1",
+					VNetResourceGroup:  "rg1",
+					StaticIPAddress:    "10.1.0.4",
+					PublicIPName:       "pip-machine-name-nic-1",
+					EnableIPForwarding: true,
+					ClusterName:        "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+			},
+		},
+		{
+			name: "Node Machine joins the application security groups of the node role",
+			machineScope: &MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+								},
+								NetworkClassSpec: infrav1.NetworkClassSpec{
+									ApplicationSecurityGroups: infrav1.ApplicationSecurityGroups{
+										{Name: "control-plane-asg", Role: infrav1.SubnetControlPlane},
+										{Name: "node-asg", Role: infrav1.SubnetNode},
+									},
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: to.StringPtr("azure://compute/virtual-machines/machine-name"),
+						SubnetName: "subnet1",
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{
+							// clusterv1.MachineControlPlaneLabelName: "true",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                      "machine-name-nic",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "outbound-lb",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     nil,
+					DNSServers:                nil,
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ApplicationSecurityGroups: []string{"node-asg"},
+					ClusterName:               "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+			},
+		},
 		{
 			name: "Node Machine with no NAT gateway and no public IP address and SKU is in machine cache",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
@@ -1827,7 +2146,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 		},
 		{
 			name: "Node Machine with NAT gateway",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
@@ -1932,7 +2251,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 		},
 		{
 			name: "Node Machine with public IP address",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
@@ -2033,7 +2352,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 		},
 		{
 			name: "Control Plane Machine with private LB",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
@@ -2139,7 +2458,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 		},
 		{
 			name: "Control Plane Machine with public LB",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
@@ -2242,7 +2561,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 		},
 		{
 			name: "Control Plane Machine with public LB and Custom DNS Servers",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					AzureClients: AzureClients{
 						EnvironmentSettings: auth.EnvironmentSettings{
@@ -2358,12 +2677,12 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 func TestDiskSpecs(t *testing.T) {
 	testcases := []struct {
 		name         string
-		machineScope MachineScope
+		machineScope *MachineScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "only os disk",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
 						ObjectMeta: metav1.ObjectMeta{
@@ -2405,7 +2724,7 @@ func TestDiskSpecs(t *testing.T) {
 		},
 		{
 			name: "os and data disks",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
 						ObjectMeta: metav1.ObjectMeta{
@@ -2455,7 +2774,7 @@ func TestDiskSpecs(t *testing.T) {
 			},
 		}, {
 			name: "os and multiple data disks",
-			machineScope: MachineScope{
+			machineScope: &MachineScope{
 				ClusterScoper: &ClusterScope{
 					Cluster: &clusterv1.Cluster{
 						ObjectMeta: metav1.ObjectMeta{
@@ -120,6 +120,7 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		VNetResourceGroup:            m.Vnet().ResourceGroup,
 		PublicLBName:                 m.OutboundLBName(infrav1.Node),
 		PublicLBAddressPoolName:      azure.GenerateOutboundBackendAddressPoolName(m.OutboundLBName(infrav1.Node)),
+		PublicLBInboundNATPoolNames:  m.OutboundLBInboundNATPoolNames(infrav1.Node),
 		AcceleratedNetworking:        m.AzureMachinePool.Spec.Template.AcceleratedNetworking,
 		Identity:                     m.AzureMachinePool.Spec.Identity,
 		UserAssignedIdentities:       m.AzureMachinePool.Spec.UserAssignedIdentities,
@@ -127,6 +128,10 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		SpotVMOptions:                m.AzureMachinePool.Spec.Template.SpotVMOptions,
 		FailureDomains:               m.MachinePool.Spec.FailureDomains,
 		TerminateNotificationTimeout: m.AzureMachinePool.Spec.Template.TerminateNotificationTimeout,
+		Diagnostics:                  m.AzureMachinePool.Spec.Template.Diagnostics,
+		CapacityReservationGroupID:   m.AzureMachinePool.Spec.Template.CapacityReservationGroupID,
+		DedicatedHostGroupID:         m.AzureMachinePool.Spec.Template.DedicatedHostGroupID,
+		ProximityPlacementGroupID:    m.AzureMachinePool.Spec.Template.ProximityPlacementGroupID,
 	}
 }
 
@@ -493,6 +498,7 @@ func (m *MachinePoolScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolScope.PatchObject")
 	defer done()
 
+	setThrottledCondition(m.AzureMachinePool, m.SubscriptionID())
 	conditions.SetSummary(m.AzureMachinePool)
 	return m.patchHelper.Patch(
 		ctx,
@@ -559,6 +565,9 @@ func (m *MachinePoolScope) GetVMImage(ctx context.Context) (*infrav1.Image, erro
 	}
 
 	svc := virtualmachineimages.New(m)
+	// A VM with a security type can only boot a generation 2 image.
+	securityProfile := m.AzureMachinePool.Spec.Template.SecurityProfile
+	gen2 := securityProfile != nil && securityProfile.SecurityType != ""
 
 	var (
 		err          error
@@ -568,9 +577,9 @@ func (m *MachinePoolScope) GetVMImage(ctx context.Context) (*infrav1.Image, erro
 		runtime := m.AzureMachinePool.Annotations["runtime"]
 		windowsServerVersion := m.AzureMachinePool.Annotations["windowsServerVersion"]
 		log.V(4).Info("No image specified for machine, using default Windows Image", "machine", m.MachinePool.GetName(), "runtime", runtime, "windowsServerVersion", windowsServerVersion)
-		defaultImage, err = svc.GetDefaultWindowsImage(ctx, m.Location(), to.String(m.MachinePool.Spec.Template.Spec.Version), runtime, windowsServerVersion)
+		defaultImage, err = svc.GetDefaultWindowsImage(ctx, m.Location(), to.String(m.MachinePool.Spec.Template.Spec.Version), runtime, windowsServerVersion, gen2)
 	} else {
-		defaultImage, err = svc.GetDefaultUbuntuImage(ctx, m.Location(), to.String(m.MachinePool.Spec.Template.Spec.Version))
+		defaultImage, err = svc.GetDefaultUbuntuImage(ctx, m.Location(), to.String(m.MachinePool.Spec.Template.Spec.Version), gen2)
 	}
 
 	if err != nil {
@@ -295,6 +295,12 @@ func (s *ManagedControlPlaneScope) SetSubnet(_ infrav1.SubnetSpec) {
 	// no-op
 }
 
+// UpdateVnet updates the ID, tags and CIDR blocks of the cluster Vnet.
+// This is not used when using a managed control plane.
+func (s *ManagedControlPlaneScope) UpdateVnet(_ string, _ infrav1.Tags, _ []string) {
+	// no-op
+}
+
 // UpdateSubnetCIDRs updates the subnet CIDRs for the subnet with the same name.
 // This is not used when using a managed control plane.
 func (s *ManagedControlPlaneScope) UpdateSubnetCIDRs(_ string, _ []string) {
@@ -343,6 +349,12 @@ func (s *ManagedControlPlaneScope) IsIPv6Enabled() bool {
 	return false
 }
 
+// ApplicationSecurityGroups returns the cluster application security groups.
+// Application security groups are not supported when using a managed control plane.
+func (s *ManagedControlPlaneScope) ApplicationSecurityGroups() infrav1.ApplicationSecurityGroups {
+	return nil
+}
+
 // IsVnetManaged returns true if the vnet is managed.
 func (s *ManagedControlPlaneScope) IsVnetManaged() bool {
 	if s.cache.isVnetManaged != nil {
@@ -388,6 +400,11 @@ func (s *ManagedControlPlaneScope) OutboundLBName(_ string) string {
 	return "kubernetes"
 }
 
+// OutboundLBInboundNATPoolNames returns nil as AKS manages the inbound NAT pools of its load balancer.
+func (s *ManagedControlPlaneScope) OutboundLBInboundNATPoolNames(_ string) []string {
+	return nil
+}
+
 // OutboundPoolName returns the outbound LB backend pool name.
 func (s *ManagedControlPlaneScope) OutboundPoolName(_ string) string {
 	return "aksOutboundBackendPool" // hard-coded in aks
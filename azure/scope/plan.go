@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// operationPlan holds the Azure operations planned in dry-run mode, e.g. "create publicips/my-rg/my-pip".
+type operationPlan map[string]struct{}
+
+// record adds an operation on a resource to the plan.
+func (p operationPlan) record(operation, serviceName, resourceGroupName, resourceName string) {
+	resource := fmt.Sprintf("%s/%s", serviceName, resourceName)
+	if resourceGroupName != "" {
+		resource = fmt.Sprintf("%s/%s/%s", serviceName, resourceGroupName, resourceName)
+	}
+	p[fmt.Sprintf("%s %s", operation, resource)] = struct{}{}
+}
+
+// String returns a stable, human-readable description of the plan.
+func (p operationPlan) String() string {
+	operations := make([]string, 0, len(p))
+	for operation := range p {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	return strings.Join(operations, "; ")
+}
+
+// setPlanCondition sets the InfrastructureUpToDate condition on obj from the plan when dryRun is true, and removes it otherwise.
+// It returns the description of the planned operations, if any.
+func setPlanCondition(obj conditions.Setter, dryRun bool, plan operationPlan) string {
+	if !dryRun {
+		conditions.Delete(obj, infrav1.InfrastructureUpToDateCondition)
+		return ""
+	}
+	if len(plan) == 0 {
+		conditions.MarkTrue(obj, infrav1.InfrastructureUpToDateCondition)
+		return ""
+	}
+	operations := plan.String()
+	conditions.MarkFalse(obj, infrav1.InfrastructureUpToDateCondition, infrav1.ChangesPendingReason, clusterv1.ConditionSeverityInfo, "%s", operations)
+	return operations
+}
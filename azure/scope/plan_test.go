@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestClusterScopePlan(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{azure.DryRunAnnotation: "true"},
+			},
+		},
+	}
+	g.Expect(s.DryRun()).To(BeTrue())
+
+	g.Expect(s.UpdatePlanStatus()).To(BeEmpty())
+	g.Expect(conditions.IsTrue(s.AzureCluster, infrav1.InfrastructureUpToDateCondition)).To(BeTrue())
+
+	s.PlanOperation(azure.PlannedUpdate, "subnets", "my-rg", "node-subnet")
+	s.PlanOperation(azure.PlannedCreate, "natgateways", "my-rg", "node-natgw")
+	s.PlanOperation(azure.PlannedCreate, "natgateways", "my-rg", "node-natgw")
+	s.PlanOperation(azure.PlannedPatch, "tags", "", "subscriptions/123/resourceGroups/my-rg")
+	operations := s.UpdatePlanStatus()
+	g.Expect(operations).To(Equal("create natgateways/my-rg/node-natgw; patch tags/subscriptions/123/resourceGroups/my-rg; update subnets/my-rg/node-subnet"))
+	g.Expect(conditions.IsFalse(s.AzureCluster, infrav1.InfrastructureUpToDateCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(s.AzureCluster, infrav1.InfrastructureUpToDateCondition)).To(Equal(infrav1.ChangesPendingReason))
+	g.Expect(conditions.GetMessage(s.AzureCluster, infrav1.InfrastructureUpToDateCondition)).To(Equal(operations))
+
+	// The condition is removed once the AzureCluster leaves dry-run mode.
+	s.AzureCluster.Annotations = nil
+	g.Expect(s.DryRun()).To(BeFalse())
+	g.Expect(s.UpdatePlanStatus()).To(BeEmpty())
+	g.Expect(conditions.Has(s.AzureCluster, infrav1.InfrastructureUpToDateCondition)).To(BeFalse())
+}
+
+func TestMachineScopePlan(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &MachineScope{
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{azure.DryRunAnnotation: "true"},
+			},
+		},
+	}
+	g.Expect(s.DryRun()).To(BeTrue())
+
+	s.PlanOperation(azure.PlannedDelete, "virtualmachine", "my-rg", "my-vm")
+	g.Expect(s.UpdatePlanStatus()).To(Equal("delete virtualmachine/my-rg/my-vm"))
+	g.Expect(conditions.IsFalse(s.AzureMachine, infrav1.InfrastructureUpToDateCondition)).To(BeTrue())
+}
+
+func TestClusterScopePlannedStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{},
+	}
+	planned := azure.NewOperationPlannedError(&infrav1.Future{Type: infrav1.PutFuture, ResourceGroup: "my-rg", Name: "my-vnet"})
+
+	s.UpdatePutStatus(infrav1.VNetReadyCondition, "virtualnetwork", planned)
+	s.UpdatePatchStatus(infrav1.SubnetsReadyCondition, "subnets", planned)
+	s.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, "natgateways", planned)
+	for _, condition := range []clusterv1.ConditionType{infrav1.VNetReadyCondition, infrav1.SubnetsReadyCondition, infrav1.NATGatewaysReadyCondition} {
+		g.Expect(conditions.IsFalse(s.AzureCluster, condition)).To(BeTrue())
+		g.Expect(conditions.GetReason(s.AzureCluster, condition)).To(Equal(infrav1.PlannedReason))
+	}
+}
+
+func TestMachineScopeDryRunConditions(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	machineScope := &MachineScope{
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "machine-name",
+				Annotations: map[string]string{azure.DryRunAnnotation: "true"},
+			},
+		},
+	}
+	scope := &publicIPsMachineScope{MachineScope: machineScope}
+	for i := 0; i < 2; i++ {
+		scope.specs = append(scope.specs, &publicips.PublicIPSpec{
+			Name:          fmt.Sprintf("pip-machine-name-%d", i),
+			ResourceGroup: "my-rg",
+			ClusterName:   "my-cluster",
+			Location:      "centralIndia",
+		})
+	}
+
+	// The public IPs do not exist yet, so their creation is planned but not performed.
+	creator := mock_async.NewMockCreator(mockCtrl)
+	creator.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&publicips.PublicIPSpec{})).
+		Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found")).
+		Times(len(scope.specs))
+
+	s := &publicips.Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, creator, nil),
+	}
+	err := s.Reconcile(context.TODO())
+	g.Expect(azure.IsOperationPlannedError(err)).To(BeTrue())
+	g.Expect(conditions.GetReason(machineScope.AzureMachine, infrav1.PublicIPsReadyCondition)).To(Equal(infrav1.PlannedReason))
+	g.Expect(machineScope.UpdatePlanStatus()).To(Equal("create publicips/my-rg/pip-machine-name-0; create publicips/my-rg/pip-machine-name-1"))
+
+	for _, condition := range machineScope.AzureMachine.GetConditions() {
+		g.Expect(condition.Status).NotTo(Equal(corev1.ConditionTrue), "condition %s must not be true in dry-run mode", condition.Type)
+	}
+}
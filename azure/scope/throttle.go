@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/ratelimit"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// setThrottledCondition marks the Throttled condition on obj as true while requests to the subscription are paused
+// because Azure Resource Manager throttled it, and removes the condition otherwise.
+func setThrottledCondition(obj conditions.Setter, subscriptionID string) {
+	reads, writes := ratelimit.Throttled(subscriptionID)
+	// Report when the pause ends rather than how long it lasts, so the message does not change on every reconciliation.
+	now := time.Now()
+	var paused []string
+	if reads > 0 {
+		paused = append(paused, fmt.Sprintf("read requests are paused until %s", now.Add(reads).UTC().Format(time.RFC3339)))
+	}
+	if writes > 0 {
+		paused = append(paused, fmt.Sprintf("write requests are paused until %s", now.Add(writes).UTC().Format(time.RFC3339)))
+	}
+	if len(paused) == 0 {
+		conditions.Delete(obj, infrav1.ThrottledCondition)
+		return
+	}
+	conditions.Set(obj, &clusterv1.Condition{
+		Type:    infrav1.ThrottledCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  infrav1.SubscriptionThrottledReason,
+		Message: fmt.Sprintf("Azure Resource Manager throttled subscription %s: %s", subscriptionID, strings.Join(paused, ", ")),
+	})
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/ratelimit"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestSetThrottledCondition(t *testing.T) {
+	g := NewWithT(t)
+
+	const subscriptionID = "throttled-subscription"
+	cluster := &infrav1.AzureCluster{}
+	conditions.MarkTrue(cluster, infrav1.ThrottledCondition)
+	setThrottledCondition(cluster, subscriptionID)
+	g.Expect(conditions.Has(cluster, infrav1.ThrottledCondition)).To(BeFalse())
+
+	// Throttle the subscription's writes with a 429 response.
+	sender := autorest.DecorateSender(autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"60"}}, Body: http.NoBody, Request: req}, nil
+	}), ratelimit.SendDecorator)
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPut, "https://management.azure.com/subscriptions/"+subscriptionID+"/resourceGroups/my-rg", http.NoBody)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = sender.Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	setThrottledCondition(cluster, subscriptionID)
+	g.Expect(conditions.IsTrue(cluster, infrav1.ThrottledCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(cluster, infrav1.ThrottledCondition)).To(Equal(infrav1.SubscriptionThrottledReason))
+	g.Expect(conditions.GetMessage(cluster, infrav1.ThrottledCondition)).To(HavePrefix("Azure Resource Manager throttled subscription throttled-subscription: write requests are paused until "))
+}
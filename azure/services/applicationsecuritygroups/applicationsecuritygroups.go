@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "applicationsecuritygroups"
+
+// ApplicationSecurityGroupScope defines the scope interface for an application security group service.
+type ApplicationSecurityGroupScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	azure.ClusterDescriber
+	ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope ApplicationSecurityGroupScope
+	async.Reconciler
+	async.TagsGetter
+}
+
+// New creates a new service.
+func New(scope ApplicationSecurityGroupScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		TagsGetter: tags.NewClient(scope),
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the application security groups.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.ApplicationSecurityGroupSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We reconcile the application security groups concurrently, each one independently of the result of the others.
+	// If multiple errors occur, we return the most pressing one.
+	_, result := async.CreateOrUpdateResources(ctx, s.Reconciler, specs, ServiceName)
+
+	s.Scope.UpdatePutStatus(infrav1.ApplicationSecurityGroupsReadyCondition, ServiceName, result)
+	return result
+}
+
+// Delete deletes the application security groups managed by CAPZ.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.ApplicationSecurityGroupSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var managedSpecs []azure.ResourceSpecGetter
+	for _, asgSpec := range specs {
+		managed, err := s.isApplicationSecurityGroupManaged(ctx, asgSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrap(err, "could not get application security group management state")
+		}
+
+		if !managed {
+			log.V(2).Info("Skipping application security group deletion for unmanaged application security group", "application security group", asgSpec.ResourceName())
+			continue
+		}
+		managedSpecs = append(managedSpecs, asgSpec)
+	}
+
+	if len(managedSpecs) == 0 {
+		return nil
+	}
+
+	// We delete the managed application security groups concurrently, each one independently of the result of the others.
+	// If multiple errors occur, we return the most pressing one.
+	log.V(2).Info("deleting application security groups", "count", len(managedSpecs))
+	result := async.DeleteResources(ctx, s.Reconciler, managedSpecs, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.ApplicationSecurityGroupsReadyCondition, ServiceName, result)
+
+	return result
+}
+
+// isApplicationSecurityGroupManaged returns true if the application security group has an owned tag with the cluster name as value,
+// meaning that the application security group's lifecycle is managed.
+func (s *Service) isApplicationSecurityGroupManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
+	scope := azure.ApplicationSecurityGroupID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName())
+	result, err := s.TagsGetter.GetAtScope(ctx, scope)
+	if err != nil {
+		return false, err
+	}
+
+	tagsMap := make(map[string]*string)
+	if result.Properties != nil && result.Properties.Tags != nil {
+		tagsMap = result.Properties.Tags
+	}
+
+	tags := converters.MapToTags(tagsMap)
+	return tags.HasOwned(s.Scope.ClusterName()), nil
+}
+
+// IsManaged returns always returns true as application security groups are managed on a one-by-one basis.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
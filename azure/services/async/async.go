@@ -18,6 +18,8 @@ package async
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -29,14 +31,16 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// Service is an implementation of the Reconciler interface. It handles asynchronous creation and deletion of resources.
+// Service is an implementation of the Reconciler interface. It handles asynchronous creation, update and deletion of resources.
 type Service struct {
 	Scope FutureScope
 	Creator
+	Updater
 	Deleter
 }
 
@@ -49,6 +53,17 @@ func New(scope FutureScope, createClient Creator, deleteClient Deleter) *Service
 	}
 }
 
+// NewWithUpdater creates a new async service which updates existing resources with PATCH requests
+// when their spec implements azure.ResourceSpecGetterWithPatch.
+func NewWithUpdater(scope FutureScope, createClient Creator, updateClient Updater, deleteClient Deleter) *Service {
+	return &Service{
+		Scope:   scope,
+		Creator: createClient,
+		Updater: updateClient,
+		Deleter: deleteClient,
+	}
+}
+
 // processOngoingOperation is a helper function that will process an ongoing operation to check if it is done.
 // If it is not done, it will return a transient error.
 func processOngoingOperation(ctx context.Context, scope FutureScope, client FutureHandler, resourceName string, serviceName string, futureType string) (result interface{}, err error) {
@@ -112,6 +127,12 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 		return processOngoingOperation(ctx, s.Scope, s.Creator, resourceName, serviceName, futureType)
 	}
 
+	patchSpec, canPatch := spec.(azure.ResourceSpecGetterWithPatch)
+	canPatch = canPatch && s.Updater != nil
+	if canPatch && s.Scope.GetLongRunningOperationState(resourceName, serviceName, infrav1.PatchFuture) != nil {
+		return processOngoingOperation(ctx, s.Scope, s.Updater, resourceName, serviceName, infrav1.PatchFuture)
+	}
+
 	// Get the resource if it already exists, and use it to construct the desired resource parameters.
 	var existingResource interface{}
 	if existing, err := s.Creator.Get(ctx, spec); err != nil && !azure.ResourceNotFound(err) {
@@ -122,6 +143,16 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 		log.V(2).Info("successfully got existing resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	}
 
+	if existingResource != nil && s.reportDrift(ctx, spec, existingResource, serviceName) {
+		// The resource was modified outside of the controller and should only be reported, leave it untouched.
+		return existingResource, nil
+	}
+
+	// Update the existing resource with a PATCH if possible, to avoid overwriting fields managed by other components.
+	if existingResource != nil && canPatch {
+		return s.patchResource(ctx, patchSpec, existingResource, serviceName)
+	}
+
 	// Construct parameters using the resource spec and information from the existing resource, if there is one.
 	parameters, err := spec.Parameters(existingResource)
 	if err != nil {
@@ -129,29 +160,255 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 	} else if parameters == nil {
 		// Nothing to do, don't create or update the resource and return the existing resource.
 		log.V(2).Info("resource up to date", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+		s.recordAppliedSpec(ctx, spec, serviceName)
 		return existingResource, nil
 	}
 
 	// Create or update the resource with the desired parameters.
 	logMessageVerbPrefix := "creat"
+	operation := azure.PlannedCreate
 	if existingResource != nil {
 		logMessageVerbPrefix = "updat"
+		operation = azure.PlannedUpdate
+	}
+	if s.planOperation(ctx, operation, spec, serviceName) {
+		return existingResource, newOperationPlannedError(spec, futureType, serviceName)
 	}
 	log.V(2).Info(fmt.Sprintf("%sing resource", logMessageVerbPrefix), "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	result, sdkFuture, err := s.Creator.CreateOrUpdateAsync(ctx, spec, parameters)
-	errWrapped := errors.Wrapf(err, fmt.Sprintf("failed to %se resource %s/%s (service: %s)", logMessageVerbPrefix, rgName, resourceName, serviceName))
 	if sdkFuture != nil {
 		future, err := converters.SDKToFuture(sdkFuture, infrav1.PutFuture, serviceName, resourceName, rgName)
 		if err != nil {
+			return nil, errors.Wrapf(err, "failed to %se resource %s/%s (service: %s)", logMessageVerbPrefix, rgName, resourceName, serviceName)
+		}
+		s.Scope.SetLongRunningOperationState(future)
+		return nil, azure.WithTransientError(azure.NewOperationNotDoneError(future), getRequeueAfterFromFuture(sdkFuture))
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to %se resource %s/%s (service: %s)", logMessageVerbPrefix, rgName, resourceName, serviceName)
+	}
+
+	log.V(2).Info(fmt.Sprintf("successfully %sed resource", logMessageVerbPrefix), "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	s.recordAppliedSpec(ctx, spec, serviceName)
+	return result, nil
+}
+
+// UpdateResource implements the logic for updating an existing resource asynchronously with a PATCH request.
+// The spec must implement azure.ResourceSpecGetterWithPatch and the service must have an Updater.
+func (s *Service) UpdateResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (result interface{}, err error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.UpdateResource")
+	defer done()
+
+	resourceName := spec.ResourceName()
+	rgName := spec.ResourceGroupName()
+	futureType := infrav1.PatchFuture
+
+	if s.Updater == nil {
+		return nil, errors.Errorf("failed to update resource %s/%s (service: %s): service does not support updates", rgName, resourceName, serviceName)
+	}
+	patchSpec, ok := spec.(azure.ResourceSpecGetterWithPatch)
+	if !ok {
+		return nil, errors.Errorf("failed to update resource %s/%s (service: %s): %T is not a azure.ResourceSpecGetterWithPatch", rgName, resourceName, serviceName, spec)
+	}
+
+	// Check if there is an ongoing long running operation.
+	future := s.Scope.GetLongRunningOperationState(resourceName, serviceName, futureType)
+	if future != nil {
+		return processOngoingOperation(ctx, s.Scope, s.Updater, resourceName, serviceName, futureType)
+	}
+
+	// Get the existing resource to construct the patch parameters.
+	existingResource, err := s.Updater.Get(ctx, spec)
+	if err != nil {
+		errWrapped := errors.Wrapf(err, "failed to get existing resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		if azure.ResourceNotFound(err) {
 			return nil, errWrapped
 		}
+		return nil, azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
+	}
+	log.V(2).Info("successfully got existing resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+
+	if s.reportDrift(ctx, spec, existingResource, serviceName) {
+		// The resource was modified outside of the controller and should only be reported, leave it untouched.
+		return existingResource, nil
+	}
+
+	return s.patchResource(ctx, patchSpec, existingResource, serviceName)
+}
+
+// reportDrift records the differences between the spec and the existing resource when the DriftDetection feature is enabled
+// and both the spec and the scope support it. Differences are only reported as drift when the spec is the one last applied
+// to the resource, so that changes to the spec that have not been applied yet are not mistaken for out-of-band changes.
+// It returns true if the resource has drifted and must not be updated.
+func (s *Service) reportDrift(ctx context.Context, spec azure.ResourceSpecGetter, existingResource interface{}, serviceName string) bool {
+	_, log, done := tele.StartSpanWithLogger(ctx, "async.Service.reportDrift")
+	defer done()
+
+	driftSpec, recorder, ok := s.driftDetection(spec)
+	if !ok {
+		return false
+	}
+
+	hash, err := specHash(spec)
+	if err != nil {
+		// Failing to detect drift should not prevent the resource from being reconciled.
+		log.Error(err, "failed to detect drift", "service", serviceName, "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName())
+		return false
+	}
+	if recorder.LastAppliedSpecHash(serviceName, spec.ResourceName()) != hash {
+		log.V(2).Info("spec has not been applied to the resource yet, skipping drift detection", "service", serviceName, "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName())
+		return false
+	}
+
+	fields, err := driftSpec.Drift(existingResource)
+	if err != nil {
+		// Failing to detect drift should not prevent the resource from being reconciled.
+		log.Error(err, "failed to detect drift", "service", serviceName, "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName())
+		return false
+	}
+	if len(fields) == 0 {
+		return false
+	}
+
+	log.V(2).Info("resource has drifted from its spec", "service", serviceName, "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName(), "fields", fields)
+	recorder.RecordDrift(serviceName, spec.ResourceName(), fields)
+	return recorder.DriftReportOnly()
+}
+
+// recordAppliedSpec records the hash of a spec once the resource is up to date with it, when drift can be detected on the resource.
+func (s *Service) recordAppliedSpec(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) {
+	_, log, done := tele.StartSpanWithLogger(ctx, "async.Service.recordAppliedSpec")
+	defer done()
+
+	_, recorder, ok := s.driftDetection(spec)
+	if !ok {
+		return
+	}
+
+	hash, err := specHash(spec)
+	if err != nil {
+		log.Error(err, "failed to record applied spec", "service", serviceName, "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName())
+		return
+	}
+	recorder.SetLastAppliedSpecHash(serviceName, spec.ResourceName(), hash)
+}
+
+// driftDetection returns the spec and the scope as drift detection interfaces when the DriftDetection feature
+// is enabled and both support it.
+func (s *Service) driftDetection(spec azure.ResourceSpecGetter) (azure.ResourceSpecGetterWithDrift, azure.DriftRecorder, bool) {
+	if !feature.Gates.Enabled(feature.DriftDetection) {
+		return nil, nil, false
+	}
+	driftSpec, ok := spec.(azure.ResourceSpecGetterWithDrift)
+	if !ok {
+		return nil, nil, false
+	}
+	recorder, ok := s.Scope.(azure.DriftRecorder)
+	if !ok {
+		return nil, nil, false
+	}
+	return driftSpec, recorder, true
+}
+
+// specHash returns a hash of the spec, which changes whenever the spec does.
+func specHash(spec azure.ResourceSpecGetter) (string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal spec")
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// planOperation records the operation in the plan of the scope when the scope is in dry-run mode.
+// It returns true if the operation must be planned rather than performed.
+func (s *Service) planOperation(ctx context.Context, operation string, spec azure.ResourceSpecGetter, serviceName string) bool {
+	_, log, done := tele.StartSpanWithLogger(ctx, "async.Service.planOperation")
+	defer done()
+
+	planner, ok := s.Scope.(azure.OperationPlanner)
+	if !ok || !planner.DryRun() {
+		return false
+	}
+
+	log.V(2).Info("planning operation on resource", "operation", operation, "service", serviceName, "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName())
+	planner.PlanOperation(operation, serviceName, spec.ResourceGroupName(), spec.ResourceName())
+	return true
+}
+
+// planDelete records the deletion of the resource in the plan of the scope when the scope is in dry-run mode.
+// Resources that are known not to exist are left out of the plan. It returns true if the deletion must not be performed,
+// along with an OperationPlannedError if the deletion was planned.
+func (s *Service) planDelete(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (bool, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.planDelete")
+	defer done()
+
+	planner, ok := s.Scope.(azure.OperationPlanner)
+	if !ok || !planner.DryRun() {
+		return false, nil
+	}
+
+	if getter, ok := s.Deleter.(Getter); ok {
+		if _, err := getter.Get(ctx, spec); azure.ResourceNotFound(err) {
+			log.V(2).Info("resource already deleted, nothing to plan", "service", serviceName, "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName())
+			return true, nil
+		}
+	}
+
+	log.V(2).Info("planning operation on resource", "operation", azure.PlannedDelete, "service", serviceName, "resource", spec.ResourceName(), "resourceGroup", spec.ResourceGroupName())
+	planner.PlanOperation(azure.PlannedDelete, serviceName, spec.ResourceGroupName(), spec.ResourceName())
+	return true, newOperationPlannedError(spec, infrav1.DeleteFuture, serviceName)
+}
+
+// newOperationPlannedError returns the error reported for an operation on the resource that was planned in dry-run mode.
+func newOperationPlannedError(spec azure.ResourceSpecGetter, futureType, serviceName string) error {
+	return azure.NewOperationPlannedError(&infrav1.Future{
+		Type:          futureType,
+		ServiceName:   serviceName,
+		Name:          spec.ResourceName(),
+		ResourceGroup: spec.ResourceGroupName(),
+	})
+}
+
+// patchResource updates an existing resource with the PATCH parameters of the spec.
+func (s *Service) patchResource(ctx context.Context, spec azure.ResourceSpecGetterWithPatch, existingResource interface{}, serviceName string) (result interface{}, err error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.patchResource")
+	defer done()
+
+	resourceName := spec.ResourceName()
+	rgName := spec.ResourceGroupName()
+
+	parameters, err := spec.PatchParameters(existingResource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get desired patch parameters for resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	} else if parameters == nil {
+		// Nothing to do, don't update the resource and return the existing resource.
+		log.V(2).Info("resource up to date", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+		s.recordAppliedSpec(ctx, spec, serviceName)
+		return existingResource, nil
+	}
+
+	if s.planOperation(ctx, azure.PlannedPatch, spec, serviceName) {
+		return existingResource, newOperationPlannedError(spec, infrav1.PatchFuture, serviceName)
+	}
+
+	log.V(2).Info("patching resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	result, sdkFuture, err := s.Updater.UpdateAsync(ctx, spec, parameters)
+	if sdkFuture != nil {
+		future, err := converters.SDKToFuture(sdkFuture, infrav1.PatchFuture, serviceName, resourceName, rgName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to patch resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		}
 		s.Scope.SetLongRunningOperationState(future)
 		return nil, azure.WithTransientError(azure.NewOperationNotDoneError(future), getRequeueAfterFromFuture(sdkFuture))
 	} else if err != nil {
+		errWrapped := errors.Wrapf(err, "failed to patch resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		if azure.ResourceConflict(err) {
+			return nil, azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
+		}
 		return nil, errWrapped
 	}
 
-	log.V(2).Info(fmt.Sprintf("successfully %sed resource", logMessageVerbPrefix), "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	log.V(2).Info("successfully patched resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	s.recordAppliedSpec(ctx, spec, serviceName)
 	return result, nil
 }
 
@@ -171,6 +428,10 @@ func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGet
 		return err
 	}
 
+	if planned, err := s.planDelete(ctx, spec, serviceName); planned {
+		return err
+	}
+
 	// No long running operation is active, so delete the resource.
 	log.V(2).Info("deleting resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	sdkFuture, err := s.Deleter.DeleteAsync(ctx, spec)
@@ -28,9 +28,12 @@ import (
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 )
@@ -43,6 +46,13 @@ var (
 		ResourceGroup: "test-group",
 		Data:          "eyJtZXRob2QiOiJQVVQiLCJwb2xsaW5nTWV0aG9kIjoiTG9jYXRpb24iLCJscm9TdGF0ZSI6IkluUHJvZ3Jlc3MifQ==",
 	}
+	validPatchFuture = infrav1.Future{
+		Type:          infrav1.PatchFuture,
+		ServiceName:   "test-service",
+		Name:          "test-resource",
+		ResourceGroup: "test-group",
+		Data:          "eyJtZXRob2QiOiJQQVRDSCIsInBvbGxpbmdNZXRob2QiOiJMb2NhdGlvbiIsImxyb1N0YXRlIjoiSW5Qcm9ncmVzcyJ9",
+	}
 	validDeleteFuture = infrav1.Future{
 		Type:          infrav1.DeleteFuture,
 		ServiceName:   "test-service",
@@ -61,9 +71,19 @@ var (
 	fakeResourceParameters = resources.GenericResource{}
 	fakeInternalError      = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
 	fakeNotFoundError      = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+	fakeConflictError      = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusConflict}, "Conflict")
 	errCtxExceeded         = errors.New("ctx exceeded")
 )
 
+// unmarshalableFuture is a future which cannot be stored in the status of a resource.
+type unmarshalableFuture struct {
+	azureautorest.Future
+}
+
+func (f *unmarshalableFuture) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("cannot marshal future")
+}
+
 // TestProcessOngoingOperation tests the processOngoingOperation function.
 func TestProcessOngoingOperation(t *testing.T) {
 	testcases := []struct {
@@ -316,6 +336,19 @@ func TestCreateOrUpdateResource(t *testing.T) {
 				s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
 			},
 		},
+		{
+			name:          "create async future cannot be stored",
+			expectedError: "failed to update resource test-group/test-resource (service: test-service): failed to marshal async future: cannot marshal future",
+			serviceName:   "test-service",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+				r.Parameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return(nil, &unmarshalableFuture{}, nil)
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -345,6 +378,331 @@ func TestCreateOrUpdateResource(t *testing.T) {
 	}
 }
 
+// fakeSpecHash is the hash of the mock specs with drift.
+var fakeSpecHash, _ = specHash(&mock_azure.MockResourceSpecGetterWithDrift{})
+
+// driftScope is a FutureScope that can record drift.
+type driftScope struct {
+	*mock_async.MockFutureScope
+	*mock_azure.MockDriftRecorder
+}
+
+// TestCreateOrUpdateResourceWithDrift tests the CreateOrUpdateResource function with a spec that can report drift.
+func TestCreateOrUpdateResourceWithDrift(t *testing.T) {
+	testcases := []struct {
+		name           string
+		featureEnabled bool
+		expectedResult interface{}
+		expect         func(s *mock_async.MockFutureScopeMockRecorder, d *mock_azure.MockDriftRecorderMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterWithDriftMockRecorder)
+	}{
+		{
+			name:           "drift is not detected when the feature is disabled",
+			featureEnabled: false,
+			expectedResult: "test-resource",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_azure.MockDriftRecorderMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterWithDriftMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{})).Return(&fakeExistingResource, nil)
+				r.Parameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{}), &fakeResourceParameters).Return("test-resource", nil, nil)
+			},
+		},
+		{
+			name:           "drift is recorded and corrected",
+			featureEnabled: true,
+			expectedResult: "test-resource",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_azure.MockDriftRecorderMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterWithDriftMockRecorder) {
+				r.ResourceName().Return("test-resource").AnyTimes()
+				r.ResourceGroupName().Return("test-group").AnyTimes()
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{})).Return(&fakeExistingResource, nil)
+				d.LastAppliedSpecHash("test-service", "test-resource").Return(fakeSpecHash)
+				r.Drift(&fakeExistingResource).Return([]string{"tags[foo]"}, nil)
+				d.RecordDrift("test-service", "test-resource", []string{"tags[foo]"})
+				d.DriftReportOnly().Return(false)
+				r.Parameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{}), &fakeResourceParameters).Return("test-resource", nil, nil)
+				d.SetLastAppliedSpecHash("test-service", "test-resource", fakeSpecHash)
+			},
+		},
+		{
+			name:           "drift is only reported in report mode",
+			featureEnabled: true,
+			expectedResult: &fakeExistingResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_azure.MockDriftRecorderMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterWithDriftMockRecorder) {
+				r.ResourceName().Return("test-resource").AnyTimes()
+				r.ResourceGroupName().Return("test-group").AnyTimes()
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{})).Return(&fakeExistingResource, nil)
+				d.LastAppliedSpecHash("test-service", "test-resource").Return(fakeSpecHash)
+				r.Drift(&fakeExistingResource).Return([]string{"tags[foo]"}, nil)
+				d.RecordDrift("test-service", "test-resource", []string{"tags[foo]"})
+				d.DriftReportOnly().Return(true)
+			},
+		},
+		{
+			name:           "failing to detect drift does not block the update",
+			featureEnabled: true,
+			expectedResult: "test-resource",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_azure.MockDriftRecorderMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterWithDriftMockRecorder) {
+				r.ResourceName().Return("test-resource").AnyTimes()
+				r.ResourceGroupName().Return("test-group").AnyTimes()
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{})).Return(&fakeExistingResource, nil)
+				d.LastAppliedSpecHash("test-service", "test-resource").Return(fakeSpecHash)
+				r.Drift(&fakeExistingResource).Return(nil, errors.New("foo"))
+				r.Parameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{}), &fakeResourceParameters).Return("test-resource", nil, nil)
+				d.SetLastAppliedSpecHash("test-service", "test-resource", fakeSpecHash)
+			},
+		},
+		{
+			name:           "differences are not reported as drift when the spec changed since it was last applied",
+			featureEnabled: true,
+			expectedResult: "test-resource",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_azure.MockDriftRecorderMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterWithDriftMockRecorder) {
+				r.ResourceName().Return("test-resource").AnyTimes()
+				r.ResourceGroupName().Return("test-group").AnyTimes()
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{})).Return(&fakeExistingResource, nil)
+				d.LastAppliedSpecHash("test-service", "test-resource").Return("previous-spec-hash")
+				r.Parameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{}), &fakeResourceParameters).Return("test-resource", nil, nil)
+				d.SetLastAppliedSpecHash("test-service", "test-resource", fakeSpecHash)
+			},
+		},
+		{
+			name:           "spec is recorded as applied when the resource is up to date",
+			featureEnabled: true,
+			expectedResult: &fakeExistingResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_azure.MockDriftRecorderMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterWithDriftMockRecorder) {
+				r.ResourceName().Return("test-resource").AnyTimes()
+				r.ResourceGroupName().Return("test-group").AnyTimes()
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithDrift{})).Return(&fakeExistingResource, nil)
+				d.LastAppliedSpecHash("test-service", "test-resource").Return("")
+				r.Parameters(&fakeExistingResource).Return(nil, nil)
+				d.SetLastAppliedSpecHash("test-service", "test-resource", fakeSpecHash)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.DriftDetection, tc.featureEnabled)()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			recorderMock := mock_azure.NewMockDriftRecorder(mockCtrl)
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetterWithDrift(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), recorderMock.EXPECT(), creatorMock.EXPECT(), specMock.EXPECT())
+
+			s := New(driftScope{scopeMock, recorderMock}, creatorMock, nil)
+			result, err := s.CreateOrUpdateResource(context.TODO(), specMock, "test-service")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result).To(Equal(tc.expectedResult))
+		})
+	}
+}
+
+// TestCreateOrUpdateResourceWithPatch tests the CreateOrUpdateResource function with a spec that supports PATCH.
+func TestCreateOrUpdateResourceWithPatch(t *testing.T) {
+	testcases := []struct {
+		name           string
+		expectedError  string
+		expectedResult interface{}
+		expect         func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder)
+	}{
+		{
+			name:           "resource does not exist and is created with a PUT",
+			expectedResult: "test-resource",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{})).Return(nil, fakeNotFoundError)
+				r.Parameters(nil).Return(&fakeResourceParameters, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{}), &fakeResourceParameters).Return("test-resource", nil, nil)
+			},
+		},
+		{
+			name:           "existing resource is updated with a PATCH",
+			expectedResult: "test-resource",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource").Times(2)
+				r.ResourceGroupName().Return("test-group").Times(2)
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{})).Return(&fakeExistingResource, nil)
+				r.PatchParameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				u.UpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{}), &fakeResourceParameters).Return("test-resource", nil, nil)
+			},
+		},
+		{
+			name:          "patch operation is already in progress",
+			expectedError: "operation type PATCH on Azure resource test-group/test-resource is not done. Object will be requeued after 15s",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Times(2).Return(&validPatchFuture)
+				u.IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(false, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			updaterMock := mock_async.NewMockUpdater(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetterWithPatch(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), creatorMock.EXPECT(), updaterMock.EXPECT(), specMock.EXPECT())
+
+			s := NewWithUpdater(scopeMock, creatorMock, updaterMock, nil)
+			result, err := s.CreateOrUpdateResource(context.TODO(), specMock, "test-service")
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal(tc.expectedResult))
+			}
+		})
+	}
+}
+
+// TestUpdateResource tests the UpdateResource function.
+func TestUpdateResource(t *testing.T) {
+	testcases := []struct {
+		name           string
+		expectedError  string
+		expectedResult interface{}
+		expect         func(s *mock_async.MockFutureScopeMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder)
+	}{
+		{
+			name:          "patch operation is already in progress",
+			expectedError: "operation type PATCH on Azure resource test-group/test-resource is not done. Object will be requeued after 15s",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Times(2).Return(&validPatchFuture)
+				u.IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(false, nil)
+			},
+		},
+		{
+			name:           "patch async returns success",
+			expectedResult: "test-resource",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource").Times(2)
+				r.ResourceGroupName().Return("test-group").Times(2)
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Return(nil)
+				u.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{})).Return(&fakeExistingResource, nil)
+				r.PatchParameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				u.UpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{}), &fakeResourceParameters).Return("test-resource", nil, nil)
+			},
+		},
+		{
+			name:          "resource does not exist",
+			expectedError: "failed to get existing resource test-group/test-resource (service: test-service)",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Return(nil)
+				u.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{})).Return(nil, fakeNotFoundError)
+			},
+		},
+		{
+			name:           "patch parameters returns nil",
+			expectedResult: &fakeExistingResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource").Times(2)
+				r.ResourceGroupName().Return("test-group").Times(2)
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Return(nil)
+				u.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{})).Return(&fakeExistingResource, nil)
+				r.PatchParameters(&fakeExistingResource).Return(nil, nil)
+			},
+		},
+		{
+			name:          "patch async conflicts with another operation",
+			expectedError: "failed to patch resource test-group/test-resource (service: test-service)",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource").Times(2)
+				r.ResourceGroupName().Return("test-group").Times(2)
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Return(nil)
+				u.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{})).Return(&fakeExistingResource, nil)
+				r.PatchParameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				u.UpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{}), &fakeResourceParameters).Return(nil, nil, fakeConflictError)
+			},
+		},
+		{
+			name:          "patch async future cannot be stored",
+			expectedError: "failed to patch resource test-group/test-resource (service: test-service): failed to marshal async future: cannot marshal future",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource").Times(2)
+				r.ResourceGroupName().Return("test-group").Times(2)
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Return(nil)
+				u.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{})).Return(&fakeExistingResource, nil)
+				r.PatchParameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				u.UpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{}), &fakeResourceParameters).Return(nil, &unmarshalableFuture{}, nil)
+			},
+		},
+		{
+			name:          "patch async exits before completing",
+			expectedError: "operation type PATCH on Azure resource test-group/test-resource is not done. Object will be requeued after 15s",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, u *mock_async.MockUpdaterMockRecorder, r *mock_azure.MockResourceSpecGetterWithPatchMockRecorder) {
+				r.ResourceName().Return("test-resource").Times(2)
+				r.ResourceGroupName().Return("test-group").Times(2)
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PatchFuture).Return(nil)
+				u.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{})).Return(&fakeExistingResource, nil)
+				r.PatchParameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				u.UpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetterWithPatch{}), &fakeResourceParameters).Return(nil, &azureautorest.Future{}, errCtxExceeded)
+				s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			updaterMock := mock_async.NewMockUpdater(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetterWithPatch(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), updaterMock.EXPECT(), specMock.EXPECT())
+
+			s := NewWithUpdater(scopeMock, nil, updaterMock, nil)
+			result, err := s.UpdateResource(context.TODO(), specMock, "test-service")
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(result).To(Equal(tc.expectedResult))
+			}
+		})
+	}
+}
+
 // TestDeleteResource tests the DeleteResource function.
 func TestDeleteResource(t *testing.T) {
 	testcases := []struct {
@@ -437,6 +795,170 @@ func TestDeleteResource(t *testing.T) {
 	}
 }
 
+// planScope is a FutureScope that can plan operations.
+type planScope struct {
+	*mock_async.MockFutureScope
+	*mock_azure.MockOperationPlanner
+}
+
+// TestCreateOrUpdateResourceDryRun tests the CreateOrUpdateResource function with a scope in dry-run mode.
+func TestCreateOrUpdateResourceDryRun(t *testing.T) {
+	testcases := []struct {
+		name           string
+		expectedResult interface{}
+		expectedError  string
+		expect         func(s *mock_async.MockFutureScopeMockRecorder, p *mock_azure.MockOperationPlannerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:           "create is planned",
+			expectedResult: nil,
+			expectedError:  "operation type PUT on Azure resource test-group/test-resource is planned in dry-run mode",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, p *mock_azure.MockOperationPlannerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource").AnyTimes()
+				r.ResourceGroupName().Return("test-group").AnyTimes()
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+				r.Parameters(nil).Return(&fakeResourceParameters, nil)
+				p.DryRun().Return(true)
+				p.PlanOperation(azure.PlannedCreate, "test-service", "test-group", "test-resource")
+			},
+		},
+		{
+			name:           "update is planned",
+			expectedResult: &fakeExistingResource,
+			expectedError:  "operation type PUT on Azure resource test-group/test-resource is planned in dry-run mode",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, p *mock_azure.MockOperationPlannerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource").AnyTimes()
+				r.ResourceGroupName().Return("test-group").AnyTimes()
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+				r.Parameters(&fakeExistingResource).Return(&fakeResourceParameters, nil)
+				p.DryRun().Return(true)
+				p.PlanOperation(azure.PlannedUpdate, "test-service", "test-group", "test-resource")
+			},
+		},
+		{
+			name:           "nothing is planned for an up to date resource",
+			expectedResult: &fakeExistingResource,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, p *mock_azure.MockOperationPlannerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource").AnyTimes()
+				r.ResourceGroupName().Return("test-group").AnyTimes()
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+				r.Parameters(&fakeExistingResource).Return(nil, nil)
+			},
+		},
+		{
+			name:           "resource is created when not in dry-run mode",
+			expectedResult: "test-resource",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, p *mock_azure.MockOperationPlannerMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource").AnyTimes()
+				r.ResourceGroupName().Return("test-group").AnyTimes()
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+				r.Parameters(nil).Return(&fakeResourceParameters, nil)
+				p.DryRun().Return(false)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return("test-resource", nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			plannerMock := mock_azure.NewMockOperationPlanner(mockCtrl)
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), plannerMock.EXPECT(), creatorMock.EXPECT(), specMock.EXPECT())
+
+			s := New(planScope{scopeMock, plannerMock}, creatorMock, nil)
+			result, err := s.CreateOrUpdateResource(context.TODO(), specMock, "test-service")
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				g.Expect(azure.IsOperationPlannedError(err)).To(BeTrue())
+				// A planned operation is handled like an operation in progress.
+				g.Expect(azure.IsOperationNotDoneError(err)).To(BeTrue())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			if tc.expectedResult == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tc.expectedResult))
+			}
+		})
+	}
+}
+
+// getterDeleter is a Deleter that can also get the resource to delete.
+type getterDeleter struct {
+	*mock_async.MockGetter
+	*mock_async.MockDeleter
+}
+
+// TestDeleteResourceDryRun tests the DeleteResource function with a scope in dry-run mode.
+func TestDeleteResourceDryRun(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(p *mock_azure.MockOperationPlannerMockRecorder, g *mock_async.MockGetterMockRecorder)
+	}{
+		{
+			name:          "delete is planned",
+			expectedError: "operation type DELETE on Azure resource test-group/test-resource is planned in dry-run mode",
+			expect: func(p *mock_azure.MockOperationPlannerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				p.DryRun().Return(true)
+				g.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+				p.PlanOperation(azure.PlannedDelete, "test-service", "test-group", "test-resource")
+			},
+		},
+		{
+			name: "delete is not planned for a resource that does not exist",
+			expect: func(p *mock_azure.MockOperationPlannerMockRecorder, g *mock_async.MockGetterMockRecorder) {
+				p.DryRun().Return(true)
+				g.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			plannerMock := mock_azure.NewMockOperationPlanner(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			deleterMock := mock_async.NewMockDeleter(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			specMock.EXPECT().ResourceName().Return("test-resource").AnyTimes()
+			specMock.EXPECT().ResourceGroupName().Return("test-group").AnyTimes()
+			scopeMock.EXPECT().GetLongRunningOperationState("test-resource", "test-service", infrav1.DeleteFuture).Return(nil)
+			tc.expect(plannerMock.EXPECT(), getterMock.EXPECT())
+
+			s := New(planScope{scopeMock, plannerMock}, nil, getterDeleter{getterMock, deleterMock})
+			err := s.DeleteResource(context.TODO(), specMock, "test-service")
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				g.Expect(azure.IsOperationPlannedError(err)).To(BeTrue())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestGetRetryAfterFromError(t *testing.T) {
 	cases := []struct {
 		name                   string
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// CreateOrUpdateResources creates or updates all the resources described by specs, running at most
+// reconciler.DefaultMaxConcurrentAzureOperations operations concurrently. Each operation stores its own
+// future, so the scope behind r must be safe for concurrent use.
+// The results are returned in the same order as specs, along with the most pressing error (see MostPressingError).
+func CreateOrUpdateResources(ctx context.Context, r Reconciler, specs []azure.ResourceSpecGetter, serviceName string) ([]interface{}, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "async.CreateOrUpdateResources")
+	defer done()
+
+	results := make([]interface{}, len(specs))
+	errs := forEachSpec(specs, func(i int, spec azure.ResourceSpecGetter) error {
+		var err error
+		results[i], err = r.CreateOrUpdateResource(ctx, spec, serviceName)
+		return err
+	})
+	return results, MostPressingError(errs)
+}
+
+// DeleteResources deletes all the resources described by specs, running at most
+// reconciler.DefaultMaxConcurrentAzureOperations operations concurrently. Each operation stores its own
+// future, so the scope behind r must be safe for concurrent use.
+// It returns the most pressing error (see MostPressingError).
+func DeleteResources(ctx context.Context, r Reconciler, specs []azure.ResourceSpecGetter, serviceName string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "async.DeleteResources")
+	defer done()
+
+	errs := forEachSpec(specs, func(_ int, spec azure.ResourceSpecGetter) error {
+		return r.DeleteResource(ctx, spec, serviceName)
+	})
+	return MostPressingError(errs)
+}
+
+// MostPressingError returns the error that should be surfaced out of the errors of several independent operations.
+// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. operation failed)
+// -> operationNotDoneError (i.e. operation in progress) -> operationPlannedError (i.e. operation planned in dry-run mode)
+// -> no error (i.e. operation done).
+// Among errors of the same precedence, the first one wins.
+func MostPressingError(errs []error) error {
+	var result error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if result == nil || errorPrecedence(err) > errorPrecedence(result) {
+			result = err
+		}
+	}
+	return result
+}
+
+// errorPrecedence ranks a non-nil error for MostPressingError.
+func errorPrecedence(err error) int {
+	switch {
+	case azure.IsOperationPlannedError(err):
+		return 0
+	case azure.IsOperationNotDoneError(err):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// forEachSpec calls fn for every spec using a bounded number of workers and returns the errors in the same order as specs.
+func forEachSpec(specs []azure.ResourceSpecGetter, fn func(i int, spec azure.ResourceSpecGetter) error) []error {
+	errs := make([]error, len(specs))
+	sem := make(chan struct{}, reconciler.DefaultMaxConcurrentAzureOperations)
+	var wg sync.WaitGroup
+	for i, spec := range specs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, spec azure.ResourceSpecGetter) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i, spec)
+		}(i, spec)
+	}
+	wg.Wait()
+	return errs
+}
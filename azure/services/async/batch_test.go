@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+func TestCreateOrUpdateResources(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	r := mock_async.NewMockReconciler(mockCtrl)
+
+	specs := make([]azure.ResourceSpecGetter, 2*reconciler.DefaultMaxConcurrentAzureOperations)
+	for i := range specs {
+		specs[i] = mock_azure.NewMockResourceSpecGetter(mockCtrl)
+	}
+
+	var inFlight, maxInFlight int32
+	r.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), gomock.Any(), "test-service").DoAndReturn(
+		func(_ context.Context, spec azure.ResourceSpecGetter, _ string) (interface{}, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				prev := atomic.LoadInt32(&maxInFlight)
+				if current <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return spec, nil
+		}).Times(len(specs))
+
+	results, err := CreateOrUpdateResources(context.TODO(), r, specs, "test-service")
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := range specs {
+		g.Expect(results[i]).To(BeIdenticalTo(specs[i]))
+	}
+	g.Expect(maxInFlight).To(BeNumerically(">", 1))
+	g.Expect(maxInFlight).To(BeNumerically("<=", reconciler.DefaultMaxConcurrentAzureOperations))
+}
+
+func TestDeleteResources(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	r := mock_async.NewMockReconciler(mockCtrl)
+
+	done := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+	inProgress := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+	failed := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+	r.EXPECT().DeleteResource(gomockinternal.AContext(), done, "test-service").Return(nil)
+	r.EXPECT().DeleteResource(gomockinternal.AContext(), inProgress, "test-service").Return(azure.WithTransientError(azure.NewOperationNotDoneError(&validDeleteFuture), 15*time.Second))
+	r.EXPECT().DeleteResource(gomockinternal.AContext(), failed, "test-service").Return(errors.New("foo"))
+
+	err := DeleteResources(context.TODO(), r, []azure.ResourceSpecGetter{done, inProgress, failed}, "test-service")
+	g.Expect(err).To(MatchError("foo"))
+}
+
+func TestMostPressingError(t *testing.T) {
+	notDone := azure.WithTransientError(azure.NewOperationNotDoneError(&infrav1.Future{}), 15*time.Second)
+	otherNotDone := azure.WithTransientError(azure.NewOperationNotDoneError(&infrav1.Future{Name: "other"}), 15*time.Second)
+	failed := errors.New("foo")
+	otherFailed := errors.New("bar")
+	planned := azure.NewOperationPlannedError(&infrav1.Future{Type: infrav1.PutFuture})
+
+	cases := map[string]struct {
+		errs []error
+		want error
+	}{
+		"no errors": {
+			errs: []error{nil, nil},
+			want: nil,
+		},
+		"operation not done": {
+			errs: []error{nil, notDone, otherNotDone},
+			want: notDone,
+		},
+		"failure takes precedence over operation not done": {
+			errs: []error{notDone, failed, nil},
+			want: failed,
+		},
+		"operation not done takes precedence over operation planned": {
+			errs: []error{planned, notDone},
+			want: notDone,
+		},
+		"failure takes precedence over operation planned": {
+			errs: []error{failed, planned},
+			want: failed,
+		},
+		"operation planned": {
+			errs: []error{nil, planned},
+			want: planned,
+		},
+		"first failure wins": {
+			errs: []error{otherFailed, notDone, failed},
+			want: otherFailed,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			err := MostPressingError(tc.errs)
+			if tc.want == nil {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(Equal(tc.want))
+			}
+		})
+	}
+}
@@ -54,6 +54,13 @@ type Creator interface {
 	CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error)
 }
 
+// Updater is a client that can update an existing resource asynchronously with a PATCH request.
+type Updater interface {
+	FutureHandler
+	Getter
+	UpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error)
+}
+
 // Deleter is a client that can delete a resource asynchronously.
 type Deleter interface {
 	FutureHandler
@@ -63,5 +70,6 @@ type Deleter interface {
 // Reconciler is a generic interface used to perform asynchronous reconciliation of Azure resources.
 type Reconciler interface {
 	CreateOrUpdateResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (result interface{}, err error)
+	UpdateResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (result interface{}, err error)
 	DeleteResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (err error)
 }
@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "azurefirewalls"
+
+// firewallNotReadyRequeue is how long to wait before checking again whether a firewall that is not ready yet is ready.
+const firewallNotReadyRequeue = 30 * time.Second
+
+// Scope defines the scope interface for an Azure Firewall service.
+type Scope interface {
+	azure.ClusterDescriber
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	AzureFirewallSpecs() (policySpec, ruleCollectionGroupSpec, firewallSpec azure.ResourceSpecGetter)
+	AzureFirewallRouteSpecs() []azure.ResourceSpecGetter
+	SetAzureFirewallPrivateIP(privateIP string)
+	IsVnetManaged() bool
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope                         Scope
+	policyReconciler              async.Reconciler
+	ruleCollectionGroupReconciler async.Reconciler
+	firewallReconciler            async.Reconciler
+	routeReconciler               async.Reconciler
+}
+
+// New creates a new Azure Firewall service.
+func New(scope Scope) *Service {
+	policiesClient := newFirewallPoliciesClient(scope)
+	ruleCollectionGroupsClient := newRuleCollectionGroupsClient(scope)
+	firewallsClient := newAzureFirewallsClient(scope)
+	routesClient := newRoutesClient(scope)
+	return &Service{
+		Scope:                         scope,
+		policyReconciler:              async.New(scope, policiesClient, policiesClient),
+		ruleCollectionGroupReconciler: async.New(scope, ruleCollectionGroupsClient, ruleCollectionGroupsClient),
+		firewallReconciler:            async.New(scope, firewallsClient, firewallsClient),
+		routeReconciler:               async.New(scope, routesClient, routesClient),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile creates or updates the firewall policy and the Azure Firewall, and routes the egress traffic
+// of the cluster subnets through the firewall.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	policySpec, ruleCollectionGroupSpec, firewallSpec := s.Scope.AzureFirewallSpecs()
+	if firewallSpec == nil {
+		return nil
+	}
+
+	err := s.reconcileFirewall(ctx, policySpec, ruleCollectionGroupSpec, firewallSpec)
+	s.Scope.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, ServiceName, err)
+	return err
+}
+
+func (s *Service) reconcileFirewall(ctx context.Context, policySpec, ruleCollectionGroupSpec, firewallSpec azure.ResourceSpecGetter) error {
+	if !isExistingFirewall(firewallSpec) {
+		if _, err := s.policyReconciler.CreateOrUpdateResource(ctx, policySpec, ServiceName); err != nil {
+			return err
+		}
+		if _, err := s.ruleCollectionGroupReconciler.CreateOrUpdateResource(ctx, ruleCollectionGroupSpec, ServiceName); err != nil {
+			return err
+		}
+	}
+
+	result, err := s.firewallReconciler.CreateOrUpdateResource(ctx, firewallSpec, ServiceName)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		// the firewall is not created in dry-run mode, so there is no private IP to route the egress traffic to yet.
+		return nil
+	}
+	firewall, ok := result.(network.AzureFirewall)
+	if !ok {
+		return errors.Errorf("%T is not a network.AzureFirewall", result)
+	}
+	if firewall.AzureFirewallPropertiesFormat == nil || firewall.ProvisioningState != network.ProvisioningStateSucceeded {
+		return azure.WithTransientError(errors.Errorf("Azure Firewall %s is not ready yet", firewallSpec.ResourceName()), firewallNotReadyRequeue)
+	}
+	privateIP := PrivateIP(firewall)
+	if privateIP == "" {
+		return azure.WithTransientError(errors.Errorf("Azure Firewall %s has no private IP address yet", firewallSpec.ResourceName()), firewallNotReadyRequeue)
+	}
+	s.Scope.SetAzureFirewallPrivateIP(privateIP)
+
+	// The route tables of an unmanaged vnet are not managed by CAPZ, routing its egress traffic is up to the user.
+	if !s.Scope.IsVnetManaged() {
+		return nil
+	}
+	for _, routeSpec := range s.Scope.AzureFirewallRouteSpecs() {
+		if _, err := s.routeReconciler.CreateOrUpdateResource(ctx, routeSpec, ServiceName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the routes through the Azure Firewall, the Azure Firewall and its firewall policy.
+// An existing Azure Firewall is never deleted.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	policySpec, _, firewallSpec := s.Scope.AzureFirewallSpecs()
+	if firewallSpec == nil {
+		return nil
+	}
+
+	err := s.deleteFirewall(ctx, policySpec, firewallSpec)
+	s.Scope.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, ServiceName, err)
+	return err
+}
+
+func (s *Service) deleteFirewall(ctx context.Context, policySpec, firewallSpec azure.ResourceSpecGetter) error {
+	if s.Scope.IsVnetManaged() {
+		for _, routeSpec := range s.Scope.AzureFirewallRouteSpecs() {
+			if err := s.routeReconciler.DeleteResource(ctx, routeSpec, ServiceName); err != nil {
+				return err
+			}
+		}
+	}
+
+	if isExistingFirewall(firewallSpec) {
+		return nil
+	}
+
+	if err := s.firewallReconciler.DeleteResource(ctx, firewallSpec, ServiceName); err != nil {
+		return err
+	}
+	// deleting the firewall policy also deletes its rule collection groups.
+	return s.policyReconciler.DeleteResource(ctx, policySpec, ServiceName)
+}
+
+// IsManaged returns true if the Azure Firewall is created by CAPZ rather than an existing firewall.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	_, _, firewallSpec := s.Scope.AzureFirewallSpecs()
+	if firewallSpec == nil {
+		return false, errors.Errorf("no Azure Firewall spec available")
+	}
+	return !isExistingFirewall(firewallSpec), nil
+}
+
+// isExistingFirewall returns true if the Azure Firewall is an existing firewall that is not created by CAPZ.
+func isExistingFirewall(firewallSpec azure.ResourceSpecGetter) bool {
+	spec, ok := firewallSpec.(*FirewallSpec)
+	return ok && spec.Existing
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls/mock_azurefirewalls"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakePolicySpec = &PolicySpec{
+		Name:          "my-cluster-firewall-policy",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+	fakeRuleCollectionGroupSpec = &RuleCollectionGroupSpec{
+		Name:            "kubernetes-egress",
+		PolicyName:      "my-cluster-firewall-policy",
+		ResourceGroup:   "my-rg",
+		SourceAddresses: []string{"10.0.0.0/8"},
+	}
+	fakeFirewallSpec = &FirewallSpec{
+		Name:          "my-cluster-firewall",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+	fakeExistingFirewallSpec = &FirewallSpec{
+		Name:          "hub-firewall",
+		ResourceGroup: "hub-rg",
+		Existing:      true,
+	}
+	fakeRouteSpec = &RouteSpec{
+		Name:              "azure-firewall-egress",
+		RouteTableName:    "my-node-routetable",
+		ResourceGroup:     "my-rg",
+		FirewallPrivateIP: "10.255.255.4",
+	}
+	fakeFirewall = network.AzureFirewall{
+		AzureFirewallPropertiesFormat: &network.AzureFirewallPropertiesFormat{
+			ProvisioningState: network.ProvisioningStateSucceeded,
+			IPConfigurations: &[]network.AzureFirewallIPConfiguration{
+				{
+					AzureFirewallIPConfigurationPropertiesFormat: &network.AzureFirewallIPConfigurationPropertiesFormat{
+						PrivateIPAddress: to.StringPtr("10.255.255.4"),
+					},
+				},
+			},
+		},
+	}
+	fakeUpdatingFirewall = network.AzureFirewall{
+		AzureFirewallPropertiesFormat: &network.AzureFirewallPropertiesFormat{
+			ProvisioningState: network.ProvisioningStateUpdating,
+		},
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcileAzureFirewall(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, groupReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no firewall",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, groupReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(nil, nil, nil)
+			},
+		},
+		{
+			name:          "create the firewall policy, the firewall and the routes",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, groupReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(fakePolicySpec, fakeRuleCollectionGroupSpec, fakeFirewallSpec)
+				policyReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakePolicySpec, ServiceName).Return(nil, nil)
+				groupReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakeRuleCollectionGroupSpec, ServiceName).Return(nil, nil)
+				firewallReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakeFirewallSpec, ServiceName).Return(fakeFirewall, nil)
+				s.SetAzureFirewallPrivateIP("10.255.255.4")
+				s.IsVnetManaged().Return(true)
+				s.AzureFirewallRouteSpecs().Return([]azure.ResourceSpecGetter{fakeRouteSpec})
+				routeReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakeRouteSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "route through an existing firewall",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, groupReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(nil, nil, fakeExistingFirewallSpec)
+				firewallReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakeExistingFirewallSpec, ServiceName).Return(fakeFirewall, nil)
+				s.SetAzureFirewallPrivateIP("10.255.255.4")
+				s.IsVnetManaged().Return(true)
+				s.AzureFirewallRouteSpecs().Return([]azure.ResourceSpecGetter{fakeRouteSpec})
+				routeReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakeRouteSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "do not route the egress traffic of an unmanaged vnet",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, groupReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(fakePolicySpec, fakeRuleCollectionGroupSpec, fakeFirewallSpec)
+				policyReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakePolicySpec, ServiceName).Return(nil, nil)
+				groupReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakeRuleCollectionGroupSpec, ServiceName).Return(nil, nil)
+				firewallReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakeFirewallSpec, ServiceName).Return(fakeFirewall, nil)
+				s.SetAzureFirewallPrivateIP("10.255.255.4")
+				s.IsVnetManaged().Return(false)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "firewall is not ready yet",
+			expectedError: "Azure Firewall my-cluster-firewall is not ready yet. Object will be requeued after 30s",
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, groupReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(fakePolicySpec, fakeRuleCollectionGroupSpec, fakeFirewallSpec)
+				policyReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakePolicySpec, ServiceName).Return(nil, nil)
+				groupReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakeRuleCollectionGroupSpec, ServiceName).Return(nil, nil)
+				firewallReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakeFirewallSpec, ServiceName).Return(fakeUpdatingFirewall, nil)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, ServiceName, gomock.Any())
+			},
+		},
+		{
+			name:          "fail to create the firewall policy",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, groupReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(fakePolicySpec, fakeRuleCollectionGroupSpec, fakeFirewallSpec)
+				policyReconciler.CreateOrUpdateResource(gomockinternal.AContext(), fakePolicySpec, ServiceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_azurefirewalls.NewMockScope(mockCtrl)
+			policyMock := mock_async.NewMockReconciler(mockCtrl)
+			groupMock := mock_async.NewMockReconciler(mockCtrl)
+			firewallMock := mock_async.NewMockReconciler(mockCtrl)
+			routeMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), policyMock.EXPECT(), groupMock.EXPECT(), firewallMock.EXPECT(), routeMock.EXPECT())
+
+			s := &Service{
+				Scope:                         scopeMock,
+				policyReconciler:              policyMock,
+				ruleCollectionGroupReconciler: groupMock,
+				firewallReconciler:            firewallMock,
+				routeReconciler:               routeMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteAzureFirewall(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "no firewall",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(nil, nil, nil)
+			},
+		},
+		{
+			name:          "delete the routes, the firewall and the firewall policy",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(fakePolicySpec, fakeRuleCollectionGroupSpec, fakeFirewallSpec)
+				s.IsVnetManaged().Return(true)
+				s.AzureFirewallRouteSpecs().Return([]azure.ResourceSpecGetter{fakeRouteSpec})
+				routeReconciler.DeleteResource(gomockinternal.AContext(), fakeRouteSpec, ServiceName).Return(nil)
+				firewallReconciler.DeleteResource(gomockinternal.AContext(), fakeFirewallSpec, ServiceName).Return(nil)
+				policyReconciler.DeleteResource(gomockinternal.AContext(), fakePolicySpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "only delete the routes through an existing firewall",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(nil, nil, fakeExistingFirewallSpec)
+				s.IsVnetManaged().Return(true)
+				s.AzureFirewallRouteSpecs().Return([]azure.ResourceSpecGetter{fakeRouteSpec})
+				routeReconciler.DeleteResource(gomockinternal.AContext(), fakeRouteSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to delete the firewall",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_azurefirewalls.MockScopeMockRecorder, policyReconciler, firewallReconciler, routeReconciler *mock_async.MockReconcilerMockRecorder) {
+				s.AzureFirewallSpecs().Return(fakePolicySpec, fakeRuleCollectionGroupSpec, fakeFirewallSpec)
+				s.IsVnetManaged().Return(false)
+				firewallReconciler.DeleteResource(gomockinternal.AContext(), fakeFirewallSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_azurefirewalls.NewMockScope(mockCtrl)
+			policyMock := mock_async.NewMockReconciler(mockCtrl)
+			firewallMock := mock_async.NewMockReconciler(mockCtrl)
+			routeMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), policyMock.EXPECT(), firewallMock.EXPECT(), routeMock.EXPECT())
+
+			s := &Service{
+				Scope:              scopeMock,
+				policyReconciler:   policyMock,
+				firewallReconciler: firewallMock,
+				routeReconciler:    routeMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
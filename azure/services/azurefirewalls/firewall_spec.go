@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// FirewallSpec defines the specification for an Azure Firewall.
+type FirewallSpec struct {
+	Name           string
+	ResourceGroup  string
+	Location       string
+	ClusterName    string
+	SubnetID       string
+	PublicIPID     string
+	PolicyID       string
+	Tier           infrav1.AzureFirewallTier
+	Zones          []string
+	AdditionalTags infrav1.Tags
+	// Existing is true if the firewall is an existing firewall that is not created by CAPZ.
+	Existing bool
+}
+
+// ResourceName returns the name of an Azure Firewall.
+func (s *FirewallSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of an Azure Firewall.
+func (s *FirewallSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for Azure Firewalls.
+func (s *FirewallSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for an Azure Firewall.
+func (s *FirewallSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	tags := converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        to.StringPtr(s.Name),
+		Additional:  s.AdditionalTags,
+	}))
+
+	if existing != nil {
+		existingFirewall, ok := existing.(network.AzureFirewall)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.AzureFirewall", existing)
+		}
+		if s.Existing {
+			// an existing Azure Firewall is not configured by CAPZ
+			return nil, nil
+		}
+		if s.isUpToDate(existingFirewall, tags) {
+			// Azure Firewall is up to date
+			return nil, nil
+		}
+		// keep the tags that were added to the Azure Firewall outside of CAPZ
+		for k, v := range existingFirewall.Tags {
+			if _, ok := tags[k]; !ok {
+				tags[k] = v
+			}
+		}
+	}
+
+	if s.Existing {
+		return nil, errors.Errorf("existing Azure Firewall %s not found in resource group %s", s.Name, s.ResourceGroup)
+	}
+
+	return network.AzureFirewall{
+		Location: to.StringPtr(s.Location),
+		AzureFirewallPropertiesFormat: &network.AzureFirewallPropertiesFormat{
+			Sku: &network.AzureFirewallSku{
+				Name: network.AzureFirewallSkuNameAZFWVNet,
+				Tier: s.skuTier(),
+			},
+			IPConfigurations: &[]network.AzureFirewallIPConfiguration{
+				{
+					Name: to.StringPtr(s.Name + "-ipconfig"),
+					AzureFirewallIPConfigurationPropertiesFormat: &network.AzureFirewallIPConfigurationPropertiesFormat{
+						Subnet:          &network.SubResource{ID: to.StringPtr(s.SubnetID)},
+						PublicIPAddress: &network.SubResource{ID: to.StringPtr(s.PublicIPID)},
+					},
+				},
+			},
+			FirewallPolicy:  &network.SubResource{ID: to.StringPtr(s.PolicyID)},
+			ThreatIntelMode: network.AzureFirewallThreatIntelModeAlert,
+		},
+		Zones: to.StringSlicePtr(s.Zones),
+		Tags:  tags,
+	}, nil
+}
+
+func (s *FirewallSpec) skuTier() network.AzureFirewallSkuTier {
+	if s.Tier == infrav1.AzureFirewallTierPremium {
+		return network.AzureFirewallSkuTierPremium
+	}
+	return network.AzureFirewallSkuTierStandard
+}
+
+// isUpToDate returns true if the tier, firewall policy, public IP and tags managed by CAPZ of an Azure Firewall match the spec.
+func (s *FirewallSpec) isUpToDate(existing network.AzureFirewall, tags map[string]*string) bool {
+	props := existing.AzureFirewallPropertiesFormat
+	if props == nil || props.Sku == nil || props.Sku.Tier != s.skuTier() {
+		return false
+	}
+	if props.FirewallPolicy == nil || !strings.EqualFold(to.String(props.FirewallPolicy.ID), s.PolicyID) {
+		return false
+	}
+	if props.IPConfigurations == nil {
+		return false
+	}
+	publicIPFound := false
+	for _, ipConfig := range *props.IPConfigurations {
+		if ipConfig.AzureFirewallIPConfigurationPropertiesFormat != nil && ipConfig.PublicIPAddress != nil &&
+			strings.EqualFold(to.String(ipConfig.PublicIPAddress.ID), s.PublicIPID) {
+			publicIPFound = true
+			break
+		}
+	}
+	if !publicIPFound {
+		return false
+	}
+	for k, v := range tags {
+		if existingValue, ok := existing.Tags[k]; !ok || to.String(existingValue) != to.String(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// PrivateIP returns the private IP address of an Azure Firewall, or an empty string if it has none yet.
+func PrivateIP(firewall network.AzureFirewall) string {
+	if firewall.AzureFirewallPropertiesFormat == nil || firewall.IPConfigurations == nil {
+		return ""
+	}
+	for _, ipConfig := range *firewall.IPConfigurations {
+		if ipConfig.AzureFirewallIPConfigurationPropertiesFormat != nil && ipConfig.PrivateIPAddress != nil {
+			return *ipConfig.PrivateIPAddress
+		}
+	}
+	return ""
+}
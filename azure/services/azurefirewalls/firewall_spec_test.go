@@ -0,0 +1,276 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var fakeManagedFirewallSpec = FirewallSpec{
+	Name:        "my-cluster-firewall",
+	ClusterName: "my-cluster",
+	PublicIPID:  "my-public-ip-id",
+	PolicyID:    "my-policy-id",
+}
+
+func fakeExistingFirewall(policyID, publicIPID string, tier network.AzureFirewallSkuTier) network.AzureFirewall {
+	return network.AzureFirewall{
+		AzureFirewallPropertiesFormat: &network.AzureFirewallPropertiesFormat{
+			Sku: &network.AzureFirewallSku{
+				Name: network.AzureFirewallSkuNameAZFWVNet,
+				Tier: tier,
+			},
+			IPConfigurations: &[]network.AzureFirewallIPConfiguration{
+				{
+					AzureFirewallIPConfigurationPropertiesFormat: &network.AzureFirewallIPConfigurationPropertiesFormat{
+						PublicIPAddress: &network.SubResource{ID: to.StringPtr(publicIPID)},
+					},
+				},
+			},
+			FirewallPolicy: &network.SubResource{ID: to.StringPtr(policyID)},
+		},
+		Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+			"Name": to.StringPtr("my-cluster-firewall"),
+			"team": to.StringPtr("networking"),
+		},
+	}
+}
+
+func TestFirewallSpec_Parameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          FirewallSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "new Azure Firewall",
+			spec: FirewallSpec{
+				Name:        "my-cluster-firewall",
+				Location:    "westus",
+				ClusterName: "my-cluster",
+				SubnetID:    "my-subnet-id",
+				PublicIPID:  "my-public-ip-id",
+				PolicyID:    "my-policy-id",
+				Tier:        infrav1.AzureFirewallTierPremium,
+				Zones:       []string{"1", "2", "3"},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.AzureFirewall{
+					Location: to.StringPtr("westus"),
+					AzureFirewallPropertiesFormat: &network.AzureFirewallPropertiesFormat{
+						Sku: &network.AzureFirewallSku{
+							Name: network.AzureFirewallSkuNameAZFWVNet,
+							Tier: network.AzureFirewallSkuTierPremium,
+						},
+						IPConfigurations: &[]network.AzureFirewallIPConfiguration{
+							{
+								Name: to.StringPtr("my-cluster-firewall-ipconfig"),
+								AzureFirewallIPConfigurationPropertiesFormat: &network.AzureFirewallIPConfigurationPropertiesFormat{
+									Subnet:          &network.SubResource{ID: to.StringPtr("my-subnet-id")},
+									PublicIPAddress: &network.SubResource{ID: to.StringPtr("my-public-ip-id")},
+								},
+							},
+						},
+						FirewallPolicy:  &network.SubResource{ID: to.StringPtr("my-policy-id")},
+						ThreatIntelMode: network.AzureFirewallThreatIntelModeAlert,
+					},
+					Zones: to.StringSlicePtr([]string{"1", "2", "3"}),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("my-cluster-firewall"),
+					},
+				}))
+			},
+		},
+		{
+			name:     "Azure Firewall is up to date",
+			spec:     fakeManagedFirewallSpec,
+			existing: fakeExistingFirewall("my-policy-id", "my-public-ip-id", network.AzureFirewallSkuTierStandard),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "Azure Firewall IDs differ only in case",
+			spec:     fakeManagedFirewallSpec,
+			existing: fakeExistingFirewall("MY-POLICY-ID", "MY-PUBLIC-IP-ID", network.AzureFirewallSkuTierStandard),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "Azure Firewall uses another firewall policy",
+			spec:     fakeManagedFirewallSpec,
+			existing: fakeExistingFirewall("old-policy-id", "my-public-ip-id", network.AzureFirewallSkuTierStandard),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.AzureFirewall{}))
+				firewall := result.(network.AzureFirewall)
+				g.Expect(to.String(firewall.FirewallPolicy.ID)).To(Equal("my-policy-id"))
+				// tags added outside of CAPZ are kept
+				g.Expect(firewall.Tags).To(HaveKeyWithValue("team", to.StringPtr("networking")))
+			},
+		},
+		{
+			name:     "Azure Firewall uses another public IP",
+			spec:     fakeManagedFirewallSpec,
+			existing: fakeExistingFirewall("my-policy-id", "old-public-ip-id", network.AzureFirewallSkuTierStandard),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.AzureFirewall{}))
+				firewall := result.(network.AzureFirewall)
+				g.Expect(to.String((*firewall.IPConfigurations)[0].PublicIPAddress.ID)).To(Equal("my-public-ip-id"))
+			},
+		},
+		{
+			name:     "Azure Firewall has another tier",
+			spec:     fakeManagedFirewallSpec,
+			existing: fakeExistingFirewall("my-policy-id", "my-public-ip-id", network.AzureFirewallSkuTierPremium),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.AzureFirewall{}))
+				g.Expect(result.(network.AzureFirewall).Sku.Tier).To(Equal(network.AzureFirewallSkuTierStandard))
+			},
+		},
+		{
+			name: "Azure Firewall is missing an additional tag",
+			spec: FirewallSpec{
+				Name:           "my-cluster-firewall",
+				ClusterName:    "my-cluster",
+				PublicIPID:     "my-public-ip-id",
+				PolicyID:       "my-policy-id",
+				AdditionalTags: infrav1.Tags{"env": "prod"},
+			},
+			existing: fakeExistingFirewall("my-policy-id", "my-public-ip-id", network.AzureFirewallSkuTierStandard),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.AzureFirewall{}))
+				g.Expect(result.(network.AzureFirewall).Tags).To(HaveKeyWithValue("env", to.StringPtr("prod")))
+			},
+		},
+		{
+			name:     "existing Azure Firewall is found",
+			spec:     FirewallSpec{Name: "hub-firewall", ResourceGroup: "hub-rg", Existing: true},
+			existing: network.AzureFirewall{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing Azure Firewall is not found",
+			spec:          FirewallSpec{Name: "hub-firewall", ResourceGroup: "hub-rg", Existing: true},
+			expectedError: "existing Azure Firewall hub-firewall not found in resource group hub-rg",
+		},
+		{
+			name:          "existing is not an Azure Firewall",
+			spec:          FirewallSpec{Name: "my-cluster-firewall"},
+			existing:      network.FirewallPolicy{},
+			expectedError: "network.FirewallPolicy is not a network.AzureFirewall",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
+
+func TestRouteSpec_Parameters(t *testing.T) {
+	spec := RouteSpec{
+		Name:              "azure-firewall-egress",
+		RouteTableName:    "my-node-routetable",
+		ResourceGroup:     "my-rg",
+		FirewallPrivateIP: "10.255.255.4",
+	}
+	wantRoute := network.Route{
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    to.StringPtr("0.0.0.0/0"),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: to.StringPtr("10.255.255.4"),
+		},
+	}
+	testcases := []struct {
+		name          string
+		spec          RouteSpec
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "new route",
+			spec:     spec,
+			expected: wantRoute,
+		},
+		{
+			name:     "route is up to date",
+			spec:     spec,
+			existing: wantRoute,
+			expected: nil,
+		},
+		{
+			name: "route to another firewall IP",
+			spec: spec,
+			existing: network.Route{
+				RoutePropertiesFormat: &network.RoutePropertiesFormat{
+					AddressPrefix:    to.StringPtr("0.0.0.0/0"),
+					NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+					NextHopIPAddress: to.StringPtr("10.255.255.5"),
+				},
+			},
+			expected: wantRoute,
+		},
+		{
+			name:          "firewall private IP is unknown",
+			spec:          RouteSpec{Name: "azure-firewall-egress"},
+			expectedError: "the private IP address of the firewall of route azure-firewall-egress is unknown",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expected == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tc.expected))
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// PolicySpec defines the specification for a firewall policy.
+type PolicySpec struct {
+	Name           string
+	ResourceGroup  string
+	Location       string
+	ClusterName    string
+	Tier           infrav1.AzureFirewallTier
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of a firewall policy.
+func (s *PolicySpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of a firewall policy.
+func (s *PolicySpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for firewall policies.
+func (s *PolicySpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for a firewall policy.
+func (s *PolicySpec) Parameters(existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		if _, ok := existing.(network.FirewallPolicy); !ok {
+			return nil, errors.Errorf("%T is not a network.FirewallPolicy", existing)
+		}
+		// firewall policy already exists, its rules are managed in its rule collection group
+		return nil, nil
+	}
+
+	tier := network.FirewallPolicySkuTierStandard
+	if s.Tier == infrav1.AzureFirewallTierPremium {
+		tier = network.FirewallPolicySkuTierPremium
+	}
+
+	return network.FirewallPolicy{
+		Location: to.StringPtr(s.Location),
+		FirewallPolicyPropertiesFormat: &network.FirewallPolicyPropertiesFormat{
+			Sku:             &network.FirewallPolicySku{Tier: tier},
+			ThreatIntelMode: network.AzureFirewallThreatIntelModeAlert,
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        to.StringPtr(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+)
+
+// defaultRoutePrefix is the address prefix of the route sending all the egress traffic of a subnet to the firewall.
+const defaultRoutePrefix = "0.0.0.0/0"
+
+// RouteSpec defines the specification for the route of a subnet route table through an Azure Firewall.
+type RouteSpec struct {
+	Name           string
+	RouteTableName string
+	ResourceGroup  string
+	// FirewallPrivateIP is the next hop of the route. It is set by the service once the firewall is provisioned.
+	FirewallPrivateIP string
+}
+
+// ResourceName returns the name of a route.
+func (s *RouteSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of a route.
+func (s *RouteSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the route table of a route.
+func (s *RouteSpec) OwnerResourceName() string {
+	return s.RouteTableName
+}
+
+// Parameters returns the parameters for a route.
+func (s *RouteSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingRoute, ok := existing.(network.Route)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.Route", existing)
+		}
+		if existingRoute.RoutePropertiesFormat != nil &&
+			to.String(existingRoute.AddressPrefix) == defaultRoutePrefix &&
+			existingRoute.NextHopType == network.RouteNextHopTypeVirtualAppliance &&
+			to.String(existingRoute.NextHopIPAddress) == s.FirewallPrivateIP {
+			// route is up to date
+			return nil, nil
+		}
+	}
+
+	if s.FirewallPrivateIP == "" {
+		return nil, errors.Errorf("the private IP address of the firewall of route %s is unknown", s.Name)
+	}
+
+	return network.Route{
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    to.StringPtr(defaultRoutePrefix),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: to.StringPtr(s.FirewallPrivateIP),
+		},
+	}, nil
+}
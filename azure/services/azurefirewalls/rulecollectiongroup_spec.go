@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ruleCollectionGroupPriority is the priority of the rule collection group of the cluster in the firewall policy.
+	ruleCollectionGroupPriority = 200
+	// applicationRuleCollectionName is the name of the rule collection allowing the HTTP and HTTPS egress traffic.
+	applicationRuleCollectionName = "application-rules"
+	// networkRuleCollectionName is the name of the rule collection allowing the non HTTP egress traffic.
+	networkRuleCollectionName = "network-rules"
+	// bootstrapRuleName is the name of the rule allowing the FQDNs needed to bootstrap Kubernetes nodes.
+	bootstrapRuleName = "kubernetes-bootstrap"
+	// allowedFQDNsRuleName is the name of the rule allowing the additional FQDNs of the firewall policy.
+	allowedFQDNsRuleName = "allowed-fqdns"
+	// ntpRuleName is the name of the rule allowing the nodes to synchronize their clocks.
+	ntpRuleName = "ntp"
+)
+
+// bootstrapFQDNs are the FQDNs nodes reach to install Kubernetes and pull the images of its components.
+var bootstrapFQDNs = []string{
+	"mcr.microsoft.com",
+	"*.data.mcr.microsoft.com",
+	"registry.k8s.io",
+	"*.pkg.dev",
+	"k8s.gcr.io",
+	"storage.googleapis.com",
+	"dl.k8s.io",
+	"packages.microsoft.com",
+	"acs-mirror.azureedge.net",
+	"*.ubuntu.com",
+}
+
+// RuleCollectionGroupSpec defines the specification for the rule collection group allowing the egress traffic of a cluster
+// in a firewall policy.
+type RuleCollectionGroupSpec struct {
+	Name          string
+	PolicyName    string
+	ResourceGroup string
+	// SourceAddresses are the address prefixes the egress traffic is allowed from.
+	SourceAddresses []string
+	// AzureFQDNs are the FQDNs of the Azure Resource Manager and Azure Active Directory endpoints of the cloud of the cluster.
+	AzureFQDNs []string
+	// AllowedFQDNs are the additional FQDNs the egress traffic is allowed to.
+	AllowedFQDNs []string
+}
+
+// ResourceName returns the name of a rule collection group.
+func (s *RuleCollectionGroupSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of a rule collection group.
+func (s *RuleCollectionGroupSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the firewall policy of a rule collection group.
+func (s *RuleCollectionGroupSpec) OwnerResourceName() string {
+	return s.PolicyName
+}
+
+// Parameters returns the parameters for a rule collection group.
+func (s *RuleCollectionGroupSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	group := network.FirewallPolicyRuleCollectionGroup{
+		FirewallPolicyRuleCollectionGroupProperties: &network.FirewallPolicyRuleCollectionGroupProperties{
+			Priority:        to.Int32Ptr(ruleCollectionGroupPriority),
+			RuleCollections: s.ruleCollections(),
+		},
+	}
+
+	if existing != nil {
+		existingGroup, ok := existing.(network.FirewallPolicyRuleCollectionGroup)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.FirewallPolicyRuleCollectionGroup", existing)
+		}
+		if existingGroup.FirewallPolicyRuleCollectionGroupProperties != nil &&
+			reflect.DeepEqual(ruleTargets(existingGroup.RuleCollections), ruleTargets(group.RuleCollections)) {
+			// rule collection group is up to date
+			return nil, nil
+		}
+	}
+
+	return group, nil
+}
+
+// ruleCollections returns the rule collections allowing the egress traffic of the cluster.
+func (s *RuleCollectionGroupSpec) ruleCollections() *[]network.BasicFirewallPolicyRuleCollection {
+	protocols := []network.FirewallPolicyRuleApplicationProtocol{
+		{ProtocolType: network.FirewallPolicyRuleApplicationProtocolTypeHTTPS, Port: to.Int32Ptr(443)},
+		{ProtocolType: network.FirewallPolicyRuleApplicationProtocolTypeHTTP, Port: to.Int32Ptr(80)},
+	}
+	applicationRules := []network.BasicFirewallPolicyRule{
+		network.ApplicationRule{
+			Name:            to.StringPtr(bootstrapRuleName),
+			RuleType:        network.RuleTypeApplicationRule,
+			SourceAddresses: to.StringSlicePtr(s.SourceAddresses),
+			Protocols:       &protocols,
+			TargetFqdns:     to.StringSlicePtr(append(append([]string{}, s.AzureFQDNs...), bootstrapFQDNs...)),
+		},
+	}
+	if len(s.AllowedFQDNs) > 0 {
+		applicationRules = append(applicationRules, network.ApplicationRule{
+			Name:            to.StringPtr(allowedFQDNsRuleName),
+			RuleType:        network.RuleTypeApplicationRule,
+			SourceAddresses: to.StringSlicePtr(s.SourceAddresses),
+			Protocols:       &protocols,
+			TargetFqdns:     to.StringSlicePtr(s.AllowedFQDNs),
+		})
+	}
+
+	return &[]network.BasicFirewallPolicyRuleCollection{
+		network.FirewallPolicyFilterRuleCollection{
+			Name:               to.StringPtr(applicationRuleCollectionName),
+			Priority:           to.Int32Ptr(100),
+			RuleCollectionType: network.RuleCollectionTypeFirewallPolicyFilterRuleCollection,
+			Action:             &network.FirewallPolicyFilterRuleCollectionAction{Type: network.FirewallPolicyFilterRuleCollectionActionTypeAllow},
+			Rules:              &applicationRules,
+		},
+		network.FirewallPolicyFilterRuleCollection{
+			Name:               to.StringPtr(networkRuleCollectionName),
+			Priority:           to.Int32Ptr(200),
+			RuleCollectionType: network.RuleCollectionTypeFirewallPolicyFilterRuleCollection,
+			Action:             &network.FirewallPolicyFilterRuleCollectionAction{Type: network.FirewallPolicyFilterRuleCollectionActionTypeAllow},
+			Rules: &[]network.BasicFirewallPolicyRule{
+				network.Rule{
+					Name:                 to.StringPtr(ntpRuleName),
+					RuleType:             network.RuleTypeNetworkRule,
+					IPProtocols:          &[]network.FirewallPolicyRuleNetworkProtocol{network.FirewallPolicyRuleNetworkProtocolUDP},
+					SourceAddresses:      to.StringSlicePtr(s.SourceAddresses),
+					DestinationAddresses: to.StringSlicePtr([]string{"*"}),
+					DestinationPorts:     to.StringSlicePtr([]string{"123"}),
+				},
+			},
+		},
+	}
+}
+
+// ruleTargets returns the sorted sources and destinations of the rules of rule collections, keyed by collection and rule name.
+// The rules returned by Azure carry fields CAPZ does not set, so only the fields CAPZ manages are compared.
+func ruleTargets(collections *[]network.BasicFirewallPolicyRuleCollection) map[string][]string {
+	targets := make(map[string][]string)
+	if collections == nil {
+		return targets
+	}
+	for _, basicCollection := range *collections {
+		collection, ok := basicCollection.AsFirewallPolicyFilterRuleCollection()
+		if !ok || collection.Rules == nil {
+			continue
+		}
+		for _, basicRule := range *collection.Rules {
+			var key string
+			var values []string
+			if rule, ok := basicRule.AsApplicationRule(); ok {
+				key = to.String(collection.Name) + "/" + to.String(rule.Name)
+				values = append(values, to.StringSlice(rule.SourceAddresses)...)
+				values = append(values, to.StringSlice(rule.TargetFqdns)...)
+			} else if rule, ok := basicRule.AsRule(); ok {
+				key = to.String(collection.Name) + "/" + to.String(rule.Name)
+				values = append(values, to.StringSlice(rule.SourceAddresses)...)
+				values = append(values, to.StringSlice(rule.DestinationAddresses)...)
+				values = append(values, to.StringSlice(rule.DestinationPorts)...)
+			} else {
+				continue
+			}
+			sort.Strings(values)
+			targets[key] = values
+		}
+	}
+	return targets
+}
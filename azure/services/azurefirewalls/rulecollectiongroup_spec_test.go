@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestRuleCollectionGroupSpec_Parameters(t *testing.T) {
+	spec := RuleCollectionGroupSpec{
+		Name:            "kubernetes-egress",
+		PolicyName:      "my-cluster-firewall-policy",
+		ResourceGroup:   "my-rg",
+		SourceAddresses: []string{"10.0.0.0/8"},
+		AzureFQDNs:      []string{"management.azure.com", "login.microsoftonline.com"},
+		AllowedFQDNs:    []string{"example.com"},
+	}
+	upToDate, err := spec.Parameters(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name          string
+		spec          RuleCollectionGroupSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "new rule collection group",
+			spec: spec,
+			expect: func(g *WithT, result interface{}) {
+				group, ok := result.(network.FirewallPolicyRuleCollectionGroup)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(*group.Priority).To(Equal(int32(200)))
+				g.Expect(ruleTargets(group.RuleCollections)).To(Equal(map[string][]string{
+					"application-rules/kubernetes-bootstrap": sortedStrings(append([]string{"10.0.0.0/8", "management.azure.com", "login.microsoftonline.com"}, bootstrapFQDNs...)),
+					"application-rules/allowed-fqdns":        {"10.0.0.0/8", "example.com"},
+					"network-rules/ntp":                      {"*", "10.0.0.0/8", "123"},
+				}))
+			},
+		},
+		{
+			name:     "rule collection group is up to date",
+			spec:     spec,
+			existing: upToDate,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "allowed FQDNs changed",
+			spec: RuleCollectionGroupSpec{
+				Name:            "kubernetes-egress",
+				PolicyName:      "my-cluster-firewall-policy",
+				ResourceGroup:   "my-rg",
+				SourceAddresses: []string{"10.0.0.0/8"},
+				AzureFQDNs:      []string{"management.azure.com", "login.microsoftonline.com"},
+				AllowedFQDNs:    []string{"example.com", "*.example.org"},
+			},
+			existing: upToDate,
+			expect: func(g *WithT, result interface{}) {
+				group, ok := result.(network.FirewallPolicyRuleCollectionGroup)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(ruleTargets(group.RuleCollections)["application-rules/allowed-fqdns"]).To(Equal([]string{"*.example.org", "10.0.0.0/8", "example.com"}))
+			},
+		},
+		{
+			name:          "existing is not a rule collection group",
+			spec:          spec,
+			existing:      network.FirewallPolicy{Name: to.StringPtr("my-cluster-firewall-policy")},
+			expectedError: "network.FirewallPolicy is not a network.FirewallPolicyRuleCollectionGroup",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
+
+func sortedStrings(s []string) []string {
+	sort.Strings(s)
+	return s
+}
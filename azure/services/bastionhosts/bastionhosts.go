@@ -26,7 +26,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "bastionhosts"
+// ServiceName is the name of this service.
+const ServiceName = "bastionhosts"
 
 // BastionScope defines the scope interface for a bastion host service.
 type BastionScope interface {
@@ -52,7 +53,7 @@ func New(scope BastionScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a bastion host.
@@ -65,12 +66,12 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	var resultingErr error
 	if bastionSpec := s.Scope.AzureBastionSpec(); bastionSpec != nil {
-		_, resultingErr = s.CreateOrUpdateResource(ctx, bastionSpec, serviceName)
+		_, resultingErr = s.CreateOrUpdateResource(ctx, bastionSpec, ServiceName)
 	} else {
 		return nil
 	}
 
-	s.Scope.UpdatePutStatus(infrav1.BastionHostReadyCondition, serviceName, resultingErr)
+	s.Scope.UpdatePutStatus(infrav1.BastionHostReadyCondition, ServiceName, resultingErr)
 	return resultingErr
 }
 
@@ -84,12 +85,12 @@ func (s *Service) Delete(ctx context.Context) error {
 
 	var resultingErr error
 	if bastionSpec := s.Scope.AzureBastionSpec(); bastionSpec != nil {
-		resultingErr = s.DeleteResource(ctx, bastionSpec, serviceName)
+		resultingErr = s.DeleteResource(ctx, bastionSpec, ServiceName)
 	} else {
 		return nil
 	}
 
-	s.Scope.UpdateDeleteStatus(infrav1.BastionHostReadyCondition, serviceName, resultingErr)
+	s.Scope.UpdateDeleteStatus(infrav1.BastionHostReadyCondition, ServiceName, resultingErr)
 	return resultingErr
 }
 
@@ -109,6 +109,20 @@ func (mr *MockBastionScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockBastionScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockBastionScope) ApplicationSecurityGroups() v1beta1.ApplicationSecurityGroups {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].(v1beta1.ApplicationSecurityGroups)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockBastionScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockBastionScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockBastionScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -401,6 +415,20 @@ func (mr *MockBastionScopeMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockBastionScope)(nil).NodeSubnets))
 }
 
+// OutboundLBInboundNATPoolNames mocks base method.
+func (m *MockBastionScope) OutboundLBInboundNATPoolNames(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundLBInboundNATPoolNames", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OutboundLBInboundNATPoolNames indicates an expected call of OutboundLBInboundNATPoolNames.
+func (mr *MockBastionScopeMockRecorder) OutboundLBInboundNATPoolNames(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBInboundNATPoolNames", reflect.TypeOf((*MockBastionScope)(nil).OutboundLBInboundNATPoolNames), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockBastionScope) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -35,6 +35,13 @@ type AzureBastionSpec struct {
 	ClusterName   string
 	SubnetID      string
 	PublicIPID    string
+	Sku           infrav1.BastionHostSkuName
+	ScaleUnits    *int32
+
+	EnableTunneling     bool
+	EnableIPConnect     bool
+	EnableShareableLink bool
+	DisableCopyPaste    bool
 }
 
 // AzureBastionSpecInput defines the required inputs to construct an azure bastion spec.
@@ -62,40 +69,126 @@ func (s *AzureBastionSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the bastion host.
 func (s *AzureBastionSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.BastionHost); !ok {
+		existingBastion, ok := existing.(network.BastionHost)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.BastionHost", existing)
 		}
-		// bastion host already exists
-		return nil, nil
+		drift, err := s.Drift(existingBastion)
+		if err != nil {
+			return nil, err
+		}
+		if len(drift) == 0 {
+			// Skip update for the bastion host as its SKU, scale units and features are up to date.
+			return nil, nil
+		}
+		// bastion host already exists, only update the SKU, scale units and features.
+		bastion := existingBastion
+		if bastion.BastionHostPropertiesFormat == nil {
+			bastion.BastionHostPropertiesFormat = &network.BastionHostPropertiesFormat{}
+		} else {
+			properties := *bastion.BastionHostPropertiesFormat
+			bastion.BastionHostPropertiesFormat = &properties
+		}
+		bastion.Sku = &network.Sku{Name: s.skuName()}
+		s.setFeatures(bastion.BastionHostPropertiesFormat)
+		return bastion, nil
 	}
 
 	bastionHostIPConfigName := fmt.Sprintf("%s-%s", s.Name, "bastionIP")
 
+	properties := &network.BastionHostPropertiesFormat{
+		DNSName: to.StringPtr(fmt.Sprintf("%s-bastion", strings.ToLower(s.Name))),
+		IPConfigurations: &[]network.BastionHostIPConfiguration{
+			{
+				Name: to.StringPtr(bastionHostIPConfigName),
+				BastionHostIPConfigurationPropertiesFormat: &network.BastionHostIPConfigurationPropertiesFormat{
+					Subnet: &network.SubResource{
+						ID: &s.SubnetID,
+					},
+					PublicIPAddress: &network.SubResource{
+						ID: &s.PublicIPID,
+					},
+					PrivateIPAllocationMethod: network.IPAllocationMethodDynamic,
+				},
+			},
+		},
+	}
+	s.setFeatures(properties)
+
 	return network.BastionHost{
 		Name:     to.StringPtr(s.Name),
 		Location: to.StringPtr(s.Location),
+		Sku:      &network.Sku{Name: s.skuName()},
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
 			Lifecycle:   infrav1.ResourceLifecycleOwned,
 			Name:        to.StringPtr(s.Name),
 			Role:        to.StringPtr("Bastion"),
 		})),
-		BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
-			DNSName: to.StringPtr(fmt.Sprintf("%s-bastion", strings.ToLower(s.Name))),
-			IPConfigurations: &[]network.BastionHostIPConfiguration{
-				{
-					Name: to.StringPtr(bastionHostIPConfigName),
-					BastionHostIPConfigurationPropertiesFormat: &network.BastionHostIPConfigurationPropertiesFormat{
-						Subnet: &network.SubResource{
-							ID: &s.SubnetID,
-						},
-						PublicIPAddress: &network.SubResource{
-							ID: &s.PublicIPID,
-						},
-						PrivateIPAllocationMethod: network.IPAllocationMethodDynamic,
-					},
-				},
-			},
-		},
+		BastionHostPropertiesFormat: properties,
 	}, nil
 }
+
+// Drift returns the SKU, scale units and features of the spec that differ in the existing bastion host.
+func (s *AzureBastionSpec) Drift(existing interface{}) ([]string, error) {
+	existingBastion, ok := existing.(network.BastionHost)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.BastionHost", existing)
+	}
+
+	// Bastion hosts created before the SKU was configurable have no SKU, which Azure treats as Basic.
+	existingSku := network.BastionHostSkuNameBasic
+	if existingBastion.Sku != nil && existingBastion.Sku.Name != "" {
+		existingSku = existingBastion.Sku.Name
+	}
+	got := existingBastion.BastionHostPropertiesFormat
+	if got == nil {
+		got = &network.BastionHostPropertiesFormat{}
+	}
+
+	var drift []string
+	if !strings.EqualFold(string(existingSku), string(s.skuName())) {
+		drift = append(drift, "sku")
+	}
+	if scaleUnits(got.ScaleUnits) != scaleUnits(s.ScaleUnits) {
+		drift = append(drift, "scaleUnits")
+	}
+	if to.Bool(got.EnableTunneling) != s.EnableTunneling {
+		drift = append(drift, "enableTunneling")
+	}
+	if to.Bool(got.EnableIPConnect) != s.EnableIPConnect {
+		drift = append(drift, "enableIPConnect")
+	}
+	if to.Bool(got.EnableShareableLink) != s.EnableShareableLink {
+		drift = append(drift, "enableShareableLink")
+	}
+	if to.Bool(got.DisableCopyPaste) != s.DisableCopyPaste {
+		drift = append(drift, "disableCopyPaste")
+	}
+	return drift, nil
+}
+
+// skuName returns the SDK SKU name of the bastion host, defaulting to Basic.
+func (s *AzureBastionSpec) skuName() network.BastionHostSkuName {
+	if s.Sku == infrav1.StandardBastionHostSku {
+		return network.BastionHostSkuNameStandard
+	}
+	return network.BastionHostSkuNameBasic
+}
+
+// setFeatures sets the scale units and features of the spec on the bastion host properties.
+func (s *AzureBastionSpec) setFeatures(properties *network.BastionHostPropertiesFormat) {
+	properties.ScaleUnits = to.Int32Ptr(scaleUnits(s.ScaleUnits))
+	properties.EnableTunneling = to.BoolPtr(s.EnableTunneling)
+	properties.EnableIPConnect = to.BoolPtr(s.EnableIPConnect)
+	properties.EnableShareableLink = to.BoolPtr(s.EnableShareableLink)
+	properties.DisableCopyPaste = to.BoolPtr(s.DisableCopyPaste)
+}
+
+// scaleUnits returns the number of scale units, defaulting to the minimum of 2 used by Azure.
+func scaleUnits(units *int32) int32 {
+	if units == nil {
+		return infrav1.DefaultAzureBastionScaleUnits
+	}
+	return *units
+}
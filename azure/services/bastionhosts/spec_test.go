@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastionhosts
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var (
+	existingIPConfigurations = &[]network.BastionHostIPConfiguration{
+		{
+			Name: to.StringPtr("my-bastion-bastionIP"),
+			BastionHostIPConfigurationPropertiesFormat: &network.BastionHostIPConfigurationPropertiesFormat{
+				Subnet:                    &network.SubResource{ID: to.StringPtr("my-subnet-id")},
+				PublicIPAddress:           &network.SubResource{ID: to.StringPtr("my-public-ip-id")},
+				PrivateIPAllocationMethod: network.IPAllocationMethodDynamic,
+			},
+		},
+	}
+	// basicBastion is a bastion host created before the SKU and features were configurable.
+	basicBastion = network.BastionHost{
+		Name:     to.StringPtr("my-bastion"),
+		Location: to.StringPtr("westus"),
+		Etag:     to.StringPtr("fake-etag"),
+		BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+			DNSName:          to.StringPtr("my-bastion-bastion"),
+			IPConfigurations: existingIPConfigurations,
+		},
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *AzureBastionSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "bastion host does not exist",
+			spec: &AzureBastionSpec{
+				Name:            "my-bastion",
+				ResourceGroup:   "my-rg",
+				Location:        "westus",
+				ClusterName:     "my-cluster",
+				SubnetID:        "my-subnet-id",
+				PublicIPID:      "my-public-ip-id",
+				Sku:             infrav1.StandardBastionHostSku,
+				ScaleUnits:      to.Int32Ptr(4),
+				EnableTunneling: true,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.BastionHost{
+					Name:     to.StringPtr("my-bastion"),
+					Location: to.StringPtr("westus"),
+					Sku:      &network.Sku{Name: network.BastionHostSkuNameStandard},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("Bastion"),
+						"Name": to.StringPtr("my-bastion"),
+					},
+					BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+						DNSName:             to.StringPtr("my-bastion-bastion"),
+						IPConfigurations:    existingIPConfigurations,
+						ScaleUnits:          to.Int32Ptr(4),
+						EnableTunneling:     to.BoolPtr(true),
+						EnableIPConnect:     to.BoolPtr(false),
+						EnableShareableLink: to.BoolPtr(false),
+						DisableCopyPaste:    to.BoolPtr(false),
+					},
+				}))
+			},
+		},
+		{
+			name: "existing basic bastion host without SKU is up to date",
+			spec: &AzureBastionSpec{
+				Name: "my-bastion",
+				Sku:  infrav1.BasicBastionHostSku,
+			},
+			existing: basicBastion,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing bastion host is upgraded to the standard SKU",
+			spec: &AzureBastionSpec{
+				Name:            "my-bastion",
+				Location:        "eastus",
+				SubnetID:        "other-subnet-id",
+				Sku:             infrav1.StandardBastionHostSku,
+				ScaleUnits:      to.Int32Ptr(4),
+				EnableTunneling: true,
+			},
+			existing: basicBastion,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.BastionHost{
+					Name:     to.StringPtr("my-bastion"),
+					Location: to.StringPtr("westus"),
+					Etag:     to.StringPtr("fake-etag"),
+					Sku:      &network.Sku{Name: network.BastionHostSkuNameStandard},
+					BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+						DNSName:             to.StringPtr("my-bastion-bastion"),
+						IPConfigurations:    existingIPConfigurations,
+						ScaleUnits:          to.Int32Ptr(4),
+						EnableTunneling:     to.BoolPtr(true),
+						EnableIPConnect:     to.BoolPtr(false),
+						EnableShareableLink: to.BoolPtr(false),
+						DisableCopyPaste:    to.BoolPtr(false),
+					},
+				}))
+				// The existing bastion host must not be modified.
+				g.Expect(basicBastion.Sku).To(BeNil())
+				g.Expect(basicBastion.ScaleUnits).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not a bastion host",
+			spec:          &AzureBastionSpec{Name: "my-bastion"},
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.BastionHost",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestDrift(t *testing.T) {
+	testcases := []struct {
+		name          string
+		existing      interface{}
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "no drift",
+			existing: network.BastionHost{
+				Sku: &network.Sku{Name: network.BastionHostSkuNameStandard},
+				BastionHostPropertiesFormat: &network.BastionHostPropertiesFormat{
+					ScaleUnits:       to.Int32Ptr(4),
+					EnableTunneling:  to.BoolPtr(true),
+					DisableCopyPaste: to.BoolPtr(false),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:     "SKU, scale units and tunneling changed",
+			existing: basicBastion,
+			expected: []string{"sku", "scaleUnits", "enableTunneling"},
+		},
+		{
+			name:          "existing is not a bastion host",
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.BastionHost",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := &AzureBastionSpec{
+				Name:            "my-bastion",
+				Sku:             infrav1.StandardBastionHostSku,
+				ScaleUnits:      to.Int32Ptr(4),
+				EnableTunneling: true,
+			}
+			drift, err := spec.Drift(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drift).To(Equal(tc.expected))
+		})
+	}
+}
@@ -26,8 +26,10 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// ServiceName is the name of this service.
+const ServiceName = "loadbalancers"
+
 const (
-	serviceName = "loadbalancers"
 	tcpProbe    = "TCPProbe"
 	lbRuleHTTPS = "LBRuleHTTPS"
 	outboundNAT = "OutboundNATAllProtocols"
@@ -57,7 +59,7 @@ func New(scope LBScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a load balancer.
@@ -73,19 +75,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of LBSpecs to reconcile each one, independently of the result of the previous one.
+	// We reconcile the LBSpecs concurrently, each one independently of the result of the others.
 	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var result error
-	for _, lbSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, lbSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
+	_, result := async.CreateOrUpdateResources(ctx, s.Reconciler, specs, ServiceName)
 
-	s.Scope.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, result)
+	s.Scope.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, ServiceName, result)
 	return result
 }
 
@@ -102,19 +96,11 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of LBSpecs to delete each one, independently of the result of the previous one.
+	// We delete the LBSpecs concurrently, each one independently of the result of the others.
 	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	var result error
-	for _, lbSpec := range specs {
-		if err := s.DeleteResource(ctx, lbSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
+	result := async.DeleteResources(ctx, s.Reconciler, specs, ServiceName)
 
-	s.Scope.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, result)
+	s.Scope.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, ServiceName, result)
 	return result
 }
 
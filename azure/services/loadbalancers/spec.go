@@ -17,12 +17,16 @@ limitations under the License.
 package loadbalancers
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/pointers"
 )
 
 // LBSpec defines the specification for a Load Balancer.
@@ -43,6 +47,9 @@ type LBSpec struct {
 	APIServerPort        int32
 	IdleTimeoutInMinutes *int32
 	AdditionalTags       map[string]string
+	LoadBalancingRules   infrav1.LoadBalancingRules
+	Probes               infrav1.LoadBalancerProbes
+	InboundNATPools      infrav1.InboundNATPools
 }
 
 // ResourceName returns the name of the load balancer.
@@ -70,6 +77,7 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 		backendAddressPools = make([]network.BackendAddressPool, 0)
 		outboundRules       = make([]network.OutboundRule, 0)
 		probes              = make([]network.Probe, 0)
+		inboundNATPools     = make([]network.InboundNatPool, 0)
 	)
 
 	if existing != nil {
@@ -92,11 +100,24 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 			}
 		}
 
-		loadBalancingRules = *existingLB.LoadBalancingRules
+		// The rules and probes that were declared by the user and have been removed from the spec are deleted.
+		for _, rule := range *existingLB.LoadBalancingRules {
+			if s.isRemovedRule(rule) {
+				update = true
+				continue
+			}
+			loadBalancingRules = append(loadBalancingRules, rule)
+		}
 		for _, rule := range getLoadBalancingRules(*s, wantedFrontendIDs) {
-			if !lbRuleExists(loadBalancingRules, rule) {
+			i := lbRuleIndex(loadBalancingRules, rule)
+			switch {
+			case i < 0:
 				update = true
 				loadBalancingRules = append(loadBalancingRules, rule)
+			case s.isUserRule(to.String(rule.Name)) && lbRuleChanged(loadBalancingRules[i], rule):
+				// Only the rules declared by the user are updated, the API server rule is left as is.
+				update = true
+				loadBalancingRules[i] = rule
 			}
 		}
 
@@ -116,11 +137,37 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 			}
 		}
 
-		probes = *existingLB.Probes
+		for _, probe := range *existingLB.Probes {
+			if s.isRemovedProbe(probe, loadBalancingRules) {
+				update = true
+				continue
+			}
+			probes = append(probes, probe)
+		}
 		for _, probe := range getProbes(*s) {
-			if !probeExists(probes, probe) {
+			i := probeIndex(probes, probe)
+			switch {
+			case i < 0:
 				update = true
 				probes = append(probes, probe)
+			case s.isUserProbe(to.String(probe.Name)) && probeChanged(probes[i], probe):
+				update = true
+				probes[i] = probe
+			}
+		}
+
+		if existingLB.InboundNatPools != nil {
+			inboundNATPools = *existingLB.InboundNatPools
+		}
+		for _, pool := range getInboundNATPools(*s, wantedFrontendIDs) {
+			i := inboundNATPoolIndex(inboundNATPools, pool)
+			switch {
+			case i < 0:
+				update = true
+				inboundNATPools = append(inboundNATPools, pool)
+			case inboundNATPoolChanged(inboundNATPools[i], pool):
+				update = true
+				inboundNATPools[i] = pool
 			}
 		}
 
@@ -134,6 +181,7 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 		backendAddressPools = getBackendAddressPools(*s)
 		outboundRules = getOutboundRules(*s, frontendIDs)
 		probes = getProbes(*s)
+		inboundNATPools = getInboundNATPools(*s, frontendIDs)
 	}
 
 	lb := network.LoadBalancer{
@@ -152,12 +200,140 @@ func (s *LBSpec) Parameters(existing interface{}) (parameters interface{}, err e
 			OutboundRules:            &outboundRules,
 			Probes:                   &probes,
 			LoadBalancingRules:       &loadBalancingRules,
+			InboundNatPools:          &inboundNATPools,
 		},
 	}
 
 	return lb, nil
 }
 
+// Drift returns the fields of the existing load balancer that are missing or differ from the spec.
+// Only the frontend IP configurations, backend pools, rules and probes created from the spec are compared.
+func (s *LBSpec) Drift(existing interface{}) ([]string, error) {
+	existingLB, ok := existing.(network.LoadBalancer)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.LoadBalancer", existing)
+	}
+	props := existingLB.LoadBalancerPropertiesFormat
+	if props == nil {
+		props = &network.LoadBalancerPropertiesFormat{}
+	}
+
+	var drift []string
+	wantedIPs, wantedFrontendIDs := getFrontendIPConfigs(*s)
+	for _, ip := range wantedIPs {
+		if props.FrontendIPConfigurations == nil || !ipExists(*props.FrontendIPConfigurations, ip) {
+			drift = append(drift, fmt.Sprintf("frontendIPConfigurations[%s]", to.String(ip.Name)))
+		}
+	}
+	for _, pool := range getBackendAddressPools(*s) {
+		if props.BackendAddressPools == nil || !poolExists(*props.BackendAddressPools, pool) {
+			drift = append(drift, fmt.Sprintf("backendAddressPools[%s]", to.String(pool.Name)))
+		}
+	}
+	for _, rule := range getOutboundRules(*s, wantedFrontendIDs) {
+		if props.OutboundRules == nil || !outboundRuleExists(*props.OutboundRules, rule) {
+			drift = append(drift, fmt.Sprintf("outboundRules[%s]", to.String(rule.Name)))
+		}
+	}
+
+	for _, want := range getLoadBalancingRules(*s, wantedFrontendIDs) {
+		name := to.String(want.Name)
+		var got *network.LoadBalancingRule
+		if props.LoadBalancingRules != nil {
+			for i := range *props.LoadBalancingRules {
+				if to.String((*props.LoadBalancingRules)[i].Name) == name {
+					got = &(*props.LoadBalancingRules)[i]
+				}
+			}
+		}
+		if got == nil {
+			drift = append(drift, fmt.Sprintf("loadBalancingRules[%s]", name))
+			continue
+		}
+		gotProps := got.LoadBalancingRulePropertiesFormat
+		if gotProps == nil {
+			gotProps = &network.LoadBalancingRulePropertiesFormat{}
+		}
+		if gotProps.Protocol != want.Protocol {
+			drift = append(drift, fmt.Sprintf("loadBalancingRules[%s].protocol", name))
+		}
+		if to.Int32(gotProps.FrontendPort) != to.Int32(want.FrontendPort) {
+			drift = append(drift, fmt.Sprintf("loadBalancingRules[%s].frontendPort", name))
+		}
+		if to.Int32(gotProps.BackendPort) != to.Int32(want.BackendPort) {
+			drift = append(drift, fmt.Sprintf("loadBalancingRules[%s].backendPort", name))
+		}
+		if s.isUserRule(name) {
+			if !strings.EqualFold(subResourceID(gotProps.Probe), subResourceID(want.Probe)) {
+				drift = append(drift, fmt.Sprintf("loadBalancingRules[%s].probe", name))
+			}
+			if pointers.BoolChanged(want.EnableFloatingIP, gotProps.EnableFloatingIP) {
+				drift = append(drift, fmt.Sprintf("loadBalancingRules[%s].enableFloatingIP", name))
+			}
+			if pointers.BoolChanged(want.EnableTCPReset, gotProps.EnableTCPReset) {
+				drift = append(drift, fmt.Sprintf("loadBalancingRules[%s].enableTCPReset", name))
+			}
+		}
+	}
+
+	for _, want := range getProbes(*s) {
+		name := to.String(want.Name)
+		var got *network.Probe
+		if props.Probes != nil {
+			for i := range *props.Probes {
+				if to.String((*props.Probes)[i].Name) == name {
+					got = &(*props.Probes)[i]
+				}
+			}
+		}
+		if got == nil {
+			drift = append(drift, fmt.Sprintf("probes[%s]", name))
+			continue
+		}
+		gotProps := got.ProbePropertiesFormat
+		if gotProps == nil {
+			gotProps = &network.ProbePropertiesFormat{}
+		}
+		if gotProps.Protocol != want.Protocol {
+			drift = append(drift, fmt.Sprintf("probes[%s].protocol", name))
+		}
+		if to.Int32(gotProps.Port) != to.Int32(want.Port) {
+			drift = append(drift, fmt.Sprintf("probes[%s].port", name))
+		}
+		if to.Int32(gotProps.IntervalInSeconds) != to.Int32(want.IntervalInSeconds) {
+			drift = append(drift, fmt.Sprintf("probes[%s].intervalInSeconds", name))
+		}
+		if to.Int32(gotProps.NumberOfProbes) != to.Int32(want.NumberOfProbes) {
+			drift = append(drift, fmt.Sprintf("probes[%s].numberOfProbes", name))
+		}
+		if to.String(gotProps.RequestPath) != to.String(want.RequestPath) {
+			drift = append(drift, fmt.Sprintf("probes[%s].requestPath", name))
+		}
+	}
+
+	for _, want := range getInboundNATPools(*s, wantedFrontendIDs) {
+		name := to.String(want.Name)
+		var got *network.InboundNatPool
+		if props.InboundNatPools != nil {
+			for i := range *props.InboundNatPools {
+				if to.String((*props.InboundNatPools)[i].Name) == name {
+					got = &(*props.InboundNatPools)[i]
+				}
+			}
+		}
+		if got == nil {
+			drift = append(drift, fmt.Sprintf("inboundNatPools[%s]", name))
+			continue
+		}
+		if inboundNATPoolChanged(*got, want) {
+			drift = append(drift, fmt.Sprintf("inboundNatPools[%s]", name))
+		}
+	}
+
+	return drift, nil
+}
+
 func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []network.SubResource) {
 	frontendIPConfigurations := make([]network.FrontendIPConfiguration, 0)
 	frontendIDs := make([]network.SubResource, 0)
@@ -209,6 +385,7 @@ func getOutboundRules(lbSpec LBSpec, frontendIDs []network.SubResource) []networ
 }
 
 func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []network.LoadBalancingRule {
+	rules := make([]network.LoadBalancingRule, 0, len(lbSpec.LoadBalancingRules)+1)
 	if lbSpec.Role == infrav1.APIServerRole {
 		// We disable outbound SNAT explicitly in the HTTPS LB rule and enable TCP and UDP outbound NAT with an outbound rule.
 		// For more information on Standard LB outbound connections see https://docs.microsoft.com/en-us/azure/load-balancer/load-balancer-outbound-connections.
@@ -216,29 +393,61 @@ func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []n
 		if len(frontendIDs) != 0 {
 			frontendIPConfig = frontendIDs[0]
 		}
-		return []network.LoadBalancingRule{
-			{
-				Name: to.StringPtr(lbRuleHTTPS),
-				LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
-					DisableOutboundSnat:     to.BoolPtr(true),
-					Protocol:                network.TransportProtocolTCP,
-					FrontendPort:            to.Int32Ptr(lbSpec.APIServerPort),
-					BackendPort:             to.Int32Ptr(lbSpec.APIServerPort),
-					IdleTimeoutInMinutes:    lbSpec.IdleTimeoutInMinutes,
-					EnableFloatingIP:        to.BoolPtr(false),
-					LoadDistribution:        network.LoadDistributionDefault,
-					FrontendIPConfiguration: &frontendIPConfig,
-					BackendAddressPool: &network.SubResource{
-						ID: to.StringPtr(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
-					},
-					Probe: &network.SubResource{
-						ID: to.StringPtr(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, tcpProbe)),
-					},
+		rules = append(rules, network.LoadBalancingRule{
+			Name: to.StringPtr(lbRuleHTTPS),
+			LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+				DisableOutboundSnat:     to.BoolPtr(true),
+				Protocol:                network.TransportProtocolTCP,
+				FrontendPort:            to.Int32Ptr(lbSpec.APIServerPort),
+				BackendPort:             to.Int32Ptr(lbSpec.APIServerPort),
+				IdleTimeoutInMinutes:    lbSpec.IdleTimeoutInMinutes,
+				EnableFloatingIP:        to.BoolPtr(false),
+				LoadDistribution:        network.LoadDistributionDefault,
+				FrontendIPConfiguration: &frontendIPConfig,
+				BackendAddressPool: &network.SubResource{
+					ID: to.StringPtr(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
+				},
+				Probe: &network.SubResource{
+					ID: to.StringPtr(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, tcpProbe)),
 				},
 			},
+		})
+	}
+
+	for _, rule := range lbSpec.LoadBalancingRules {
+		idleTimeout := rule.IdleTimeoutInMinutes
+		if idleTimeout == nil {
+			idleTimeout = lbSpec.IdleTimeoutInMinutes
+		}
+		frontendIPConfig := lbSpec.frontendIPConfig(rule.FrontendIPName, frontendIDs)
+		properties := &network.LoadBalancingRulePropertiesFormat{
+			Protocol:                converters.LoadBalancerProtocolToSDK(rule.Protocol),
+			FrontendPort:            to.Int32Ptr(rule.FrontendPort),
+			BackendPort:             to.Int32Ptr(rule.BackendPort),
+			IdleTimeoutInMinutes:    idleTimeout,
+			EnableFloatingIP:        rule.EnableFloatingIP,
+			EnableTCPReset:          rule.EnableTCPReset,
+			LoadDistribution:        network.LoadDistributionDefault,
+			FrontendIPConfiguration: &frontendIPConfig,
+			BackendAddressPool: &network.SubResource{
+				ID: to.StringPtr(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
+			},
+		}
+		if lbSpec.Type != infrav1.Internal {
+			// Outbound connections of public load balancers use the outbound rule.
+			properties.DisableOutboundSnat = to.BoolPtr(true)
+		}
+		if rule.ProbeName != "" {
+			properties.Probe = &network.SubResource{
+				ID: to.StringPtr(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, rule.ProbeName)),
+			}
 		}
+		rules = append(rules, network.LoadBalancingRule{
+			Name:                              to.StringPtr(rule.Name),
+			LoadBalancingRulePropertiesFormat: properties,
+		})
 	}
-	return []network.LoadBalancingRule{}
+	return rules
 }
 
 func getBackendAddressPools(lbSpec LBSpec) []network.BackendAddressPool {
@@ -250,31 +459,203 @@ func getBackendAddressPools(lbSpec LBSpec) []network.BackendAddressPool {
 }
 
 func getProbes(lbSpec LBSpec) []network.Probe {
+	probes := make([]network.Probe, 0, len(lbSpec.Probes)+1)
 	if lbSpec.Role == infrav1.APIServerRole {
-		return []network.Probe{
-			{
-				Name: to.StringPtr(tcpProbe),
-				ProbePropertiesFormat: &network.ProbePropertiesFormat{
-					Protocol:          network.ProbeProtocolTCP,
-					Port:              to.Int32Ptr(lbSpec.APIServerPort),
-					IntervalInSeconds: to.Int32Ptr(15),
-					NumberOfProbes:    to.Int32Ptr(4),
-				},
+		probes = append(probes, network.Probe{
+			Name: to.StringPtr(tcpProbe),
+			ProbePropertiesFormat: &network.ProbePropertiesFormat{
+				Protocol:          network.ProbeProtocolTCP,
+				Port:              to.Int32Ptr(lbSpec.APIServerPort),
+				IntervalInSeconds: to.Int32Ptr(15),
+				NumberOfProbes:    to.Int32Ptr(4),
 			},
+		})
+	}
+
+	for _, probe := range lbSpec.Probes {
+		properties := &network.ProbePropertiesFormat{
+			Protocol:          network.ProbeProtocol(probe.Protocol),
+			Port:              to.Int32Ptr(probe.Port),
+			IntervalInSeconds: to.Int32Ptr(15),
+			NumberOfProbes:    to.Int32Ptr(4),
+		}
+		if probe.RequestPath != "" {
+			properties.RequestPath = to.StringPtr(probe.RequestPath)
+		}
+		if probe.IntervalInSeconds != nil {
+			properties.IntervalInSeconds = probe.IntervalInSeconds
+		}
+		if probe.NumberOfProbes != nil {
+			properties.NumberOfProbes = probe.NumberOfProbes
 		}
+		probes = append(probes, network.Probe{
+			Name:                  to.StringPtr(probe.Name),
+			ProbePropertiesFormat: properties,
+		})
 	}
-	return []network.Probe{}
+	return probes
 }
 
-func probeExists(probes []network.Probe, probe network.Probe) bool {
-	for _, p := range probes {
-		if to.String(p.Name) == to.String(probe.Name) {
+func getInboundNATPools(lbSpec LBSpec, frontendIDs []network.SubResource) []network.InboundNatPool {
+	pools := make([]network.InboundNatPool, 0, len(lbSpec.InboundNATPools))
+	for _, pool := range lbSpec.InboundNATPools {
+		idleTimeout := pool.IdleTimeoutInMinutes
+		if idleTimeout == nil {
+			idleTimeout = lbSpec.IdleTimeoutInMinutes
+		}
+		frontendIPConfig := lbSpec.frontendIPConfig(pool.FrontendIPName, frontendIDs)
+		pools = append(pools, network.InboundNatPool{
+			Name: to.StringPtr(pool.Name),
+			InboundNatPoolPropertiesFormat: &network.InboundNatPoolPropertiesFormat{
+				FrontendIPConfiguration: &frontendIPConfig,
+				Protocol:                converters.LoadBalancerProtocolToSDK(pool.Protocol),
+				FrontendPortRangeStart:  to.Int32Ptr(pool.FrontendPortRangeStart),
+				FrontendPortRangeEnd:    to.Int32Ptr(pool.FrontendPortRangeEnd),
+				BackendPort:             to.Int32Ptr(pool.BackendPort),
+				IdleTimeoutInMinutes:    idleTimeout,
+				EnableFloatingIP:        pool.EnableFloatingIP,
+				EnableTCPReset:          pool.EnableTCPReset,
+			},
+		})
+	}
+	return pools
+}
+
+// frontendIPConfig returns the reference to the frontend IP configuration with the given name,
+// or to the first frontend IP configuration of the load balancer if name is empty.
+func (s *LBSpec) frontendIPConfig(name string, frontendIDs []network.SubResource) network.SubResource {
+	if name != "" {
+		return network.SubResource{
+			ID: to.StringPtr(azure.FrontendIPConfigID(s.SubscriptionID, s.ResourceGroup, s.Name, name)),
+		}
+	}
+	if len(frontendIDs) != 0 {
+		return frontendIDs[0]
+	}
+	return network.SubResource{}
+}
+
+// isUserRule returns true if the load balancing rule is declared in the spec of the load balancer.
+func (s *LBSpec) isUserRule(name string) bool {
+	for _, rule := range s.LoadBalancingRules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isUserProbe returns true if the probe is declared in the spec of the load balancer.
+func (s *LBSpec) isUserProbe(name string) bool {
+	for _, probe := range s.Probes {
+		if probe.Name == name {
 			return true
 		}
 	}
 	return false
 }
 
+// isRemovedRule returns true if the existing load balancing rule was declared by the user and is no longer in the spec.
+// The rules that don't use the backend pool of the load balancer, e.g. the ones of the cloud provider, are left as is.
+func (s *LBSpec) isRemovedRule(rule network.LoadBalancingRule) bool {
+	name := to.String(rule.Name)
+	if name == lbRuleHTTPS || s.isUserRule(name) || rule.LoadBalancingRulePropertiesFormat == nil {
+		return false
+	}
+	poolID := azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.Name, s.BackendPoolName)
+	return strings.EqualFold(subResourceID(rule.BackendAddressPool), poolID)
+}
+
+// isRemovedProbe returns true if the existing probe is no longer in the spec and none of the remaining load balancing
+// rules uses it.
+func (s *LBSpec) isRemovedProbe(probe network.Probe, rules []network.LoadBalancingRule) bool {
+	name := to.String(probe.Name)
+	if name == tcpProbe || s.isUserProbe(name) {
+		return false
+	}
+	probeID := azure.ProbeID(s.SubscriptionID, s.ResourceGroup, s.Name, name)
+	for _, rule := range rules {
+		if rule.LoadBalancingRulePropertiesFormat != nil && strings.EqualFold(subResourceID(rule.Probe), probeID) {
+			return false
+		}
+	}
+	return true
+}
+
+// lbRuleChanged returns true if a property of the wanted load balancing rule differs in the existing one.
+func lbRuleChanged(existing, want network.LoadBalancingRule) bool {
+	got := existing.LoadBalancingRulePropertiesFormat
+	if got == nil {
+		return true
+	}
+	wantProps := want.LoadBalancingRulePropertiesFormat
+	return got.Protocol != wantProps.Protocol ||
+		to.Int32(got.FrontendPort) != to.Int32(wantProps.FrontendPort) ||
+		to.Int32(got.BackendPort) != to.Int32(wantProps.BackendPort) ||
+		!strings.EqualFold(subResourceID(got.FrontendIPConfiguration), subResourceID(wantProps.FrontendIPConfiguration)) ||
+		!strings.EqualFold(subResourceID(got.Probe), subResourceID(wantProps.Probe)) ||
+		pointers.Int32Changed(wantProps.IdleTimeoutInMinutes, got.IdleTimeoutInMinutes) ||
+		pointers.BoolChanged(wantProps.EnableFloatingIP, got.EnableFloatingIP) ||
+		pointers.BoolChanged(wantProps.EnableTCPReset, got.EnableTCPReset)
+}
+
+// probeChanged returns true if a property of the wanted probe differs in the existing one.
+func probeChanged(existing, want network.Probe) bool {
+	got := existing.ProbePropertiesFormat
+	if got == nil {
+		return true
+	}
+	wantProps := want.ProbePropertiesFormat
+	return got.Protocol != wantProps.Protocol ||
+		to.Int32(got.Port) != to.Int32(wantProps.Port) ||
+		to.String(got.RequestPath) != to.String(wantProps.RequestPath) ||
+		to.Int32(got.IntervalInSeconds) != to.Int32(wantProps.IntervalInSeconds) ||
+		to.Int32(got.NumberOfProbes) != to.Int32(wantProps.NumberOfProbes)
+}
+
+// inboundNATPoolChanged returns true if a property of the wanted inbound NAT pool differs in the existing one.
+func inboundNATPoolChanged(existing, want network.InboundNatPool) bool {
+	got := existing.InboundNatPoolPropertiesFormat
+	if got == nil {
+		return true
+	}
+	wantProps := want.InboundNatPoolPropertiesFormat
+	return got.Protocol != wantProps.Protocol ||
+		to.Int32(got.FrontendPortRangeStart) != to.Int32(wantProps.FrontendPortRangeStart) ||
+		to.Int32(got.FrontendPortRangeEnd) != to.Int32(wantProps.FrontendPortRangeEnd) ||
+		to.Int32(got.BackendPort) != to.Int32(wantProps.BackendPort) ||
+		!strings.EqualFold(subResourceID(got.FrontendIPConfiguration), subResourceID(wantProps.FrontendIPConfiguration)) ||
+		pointers.Int32Changed(wantProps.IdleTimeoutInMinutes, got.IdleTimeoutInMinutes) ||
+		pointers.BoolChanged(wantProps.EnableFloatingIP, got.EnableFloatingIP) ||
+		pointers.BoolChanged(wantProps.EnableTCPReset, got.EnableTCPReset)
+}
+
+// subResourceID returns the ID of a sub-resource reference, or an empty string if it is nil.
+func subResourceID(ref *network.SubResource) string {
+	if ref == nil {
+		return ""
+	}
+	return to.String(ref.ID)
+}
+
+func probeIndex(probes []network.Probe, probe network.Probe) int {
+	for i, p := range probes {
+		if to.String(p.Name) == to.String(probe.Name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func inboundNATPoolIndex(pools []network.InboundNatPool, pool network.InboundNatPool) int {
+	for i, p := range pools {
+		if to.String(p.Name) == to.String(pool.Name) {
+			return i
+		}
+	}
+	return -1
+}
+
 func outboundRuleExists(rules []network.OutboundRule, rule network.OutboundRule) bool {
 	for _, r := range rules {
 		if to.String(r.Name) == to.String(rule.Name) {
@@ -293,13 +674,13 @@ func poolExists(pools []network.BackendAddressPool, pool network.BackendAddressP
 	return false
 }
 
-func lbRuleExists(rules []network.LoadBalancingRule, rule network.LoadBalancingRule) bool {
-	for _, r := range rules {
+func lbRuleIndex(rules []network.LoadBalancingRule, rule network.LoadBalancingRule) int {
+	for i, r := range rules {
 		if to.String(r.Name) == to.String(rule.Name) {
-			return true
+			return i
 		}
 	}
-	return false
+	return -1
 }
 
 func ipExists(configs []network.FrontendIPConfiguration, config network.FrontendIPConfiguration) bool {
@@ -145,6 +145,98 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "node outbound load balancer exists without user-declared rules, probes and inbound NAT pools",
+			spec:     newNodeOutboundLBSpecWithRules(),
+			existing: newDefaultNodeOutboundLB(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.LoadBalancingRules).To(HaveLen(1))
+				rule := (*lb.LoadBalancingRules)[0]
+				g.Expect(rule.Name).To(Equal(to.StringPtr("ingress-https")))
+				g.Expect(rule.Protocol).To(Equal(network.TransportProtocolTCP))
+				g.Expect(rule.DisableOutboundSnat).To(Equal(to.BoolPtr(true)))
+				g.Expect(rule.EnableTCPReset).To(Equal(to.BoolPtr(true)))
+				g.Expect(rule.IdleTimeoutInMinutes).To(Equal(to.Int32Ptr(30)))
+				g.Expect(rule.Probe.ID).To(Equal(to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/probes/ingress-probe")))
+				g.Expect(*lb.Probes).To(HaveLen(1))
+				probe := (*lb.Probes)[0]
+				g.Expect(probe.Protocol).To(Equal(network.ProbeProtocolHTTPS))
+				g.Expect(probe.RequestPath).To(Equal(to.StringPtr("/healthz")))
+				g.Expect(probe.IntervalInSeconds).To(Equal(to.Int32Ptr(5)))
+				g.Expect(probe.NumberOfProbes).To(Equal(to.Int32Ptr(4)))
+				g.Expect(*lb.InboundNatPools).To(HaveLen(1))
+				pool := (*lb.InboundNatPools)[0]
+				g.Expect(pool.FrontendPortRangeStart).To(Equal(to.Int32Ptr(50000)))
+				g.Expect(pool.FrontendPortRangeEnd).To(Equal(to.Int32Ptr(50099)))
+				g.Expect(pool.FrontendIPConfiguration.ID).To(Equal(to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/frontendIPConfigurations/my-cluster-frontEnd")))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "node outbound load balancer exists with all user-declared rules, probes and inbound NAT pools",
+			spec:     newNodeOutboundLBSpecWithRules(),
+			existing: newNodeOutboundLBWithRules(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "node outbound load balancer exists with modified user-declared rule",
+			spec: newNodeOutboundLBSpecWithRules(),
+			existing: func() network.LoadBalancer {
+				lb := newNodeOutboundLBWithRules()
+				(*lb.LoadBalancingRules)[0].BackendPort = to.Int32Ptr(8443)
+				return lb
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.LoadBalancingRules).To(HaveLen(1))
+				g.Expect((*lb.LoadBalancingRules)[0].BackendPort).To(Equal(to.Int32Ptr(443)))
+			},
+			expectedError: "",
+		},
+		{
+			name: "node outbound load balancer exists with user-declared rule and probe removed from the spec",
+			spec: func() *LBSpec {
+				spec := newNodeOutboundLBSpecWithRules()
+				spec.LoadBalancingRules = nil
+				spec.Probes = nil
+				return spec
+			}(),
+			existing: newNodeOutboundLBWithRules(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.LoadBalancingRules).To(BeEmpty())
+				g.Expect(*lb.Probes).To(BeEmpty())
+				g.Expect(*lb.InboundNatPools).To(HaveLen(1))
+			},
+			expectedError: "",
+		},
+		{
+			name: "node outbound load balancer exists with a rule and a probe of the cloud provider",
+			spec: newNodeOutboundLBSpecWithRules(),
+			existing: func() network.LoadBalancer {
+				lb := newNodeOutboundLBWithRules()
+				*lb.LoadBalancingRules = append(*lb.LoadBalancingRules, network.LoadBalancingRule{
+					Name: to.StringPtr("a1234-TCP-80"),
+					LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+						BackendAddressPool: &network.SubResource{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/backendAddressPools/my-cluster")},
+						Probe:              &network.SubResource{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/probes/a1234-TCP-80")},
+					},
+				})
+				*lb.Probes = append(*lb.Probes, network.Probe{Name: to.StringPtr("a1234-TCP-80")})
+				return lb
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -164,6 +256,143 @@ func TestParameters(t *testing.T) {
 	}
 }
 
+func TestDrift(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *LBSpec
+		existing      interface{}
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "public API load balancer without drift",
+			spec:     &fakePublicAPILBSpec,
+			existing: newSamplePublicAPIServerLB(true, true, true, false, true),
+			expected: nil,
+		},
+		{
+			name:     "node outbound load balancer without drift",
+			spec:     &fakeNodeOutboundLBSpec,
+			existing: newDefaultNodeOutboundLB(),
+			expected: nil,
+		},
+		{
+			name:     "probe modified",
+			spec:     &fakePublicAPILBSpec,
+			existing: newSamplePublicAPIServerLB(false, false, false, true, false),
+			expected: []string{"probes[TCPProbe].numberOfProbes"},
+		},
+		{
+			name: "load balancing rule and probe deleted",
+			spec: &fakePublicAPILBSpec,
+			existing: func() network.LoadBalancer {
+				lb := newSamplePublicAPIServerLB(false, false, false, false, false)
+				lb.LoadBalancingRules = &[]network.LoadBalancingRule{}
+				lb.Probes = nil
+				return lb
+			}(),
+			expected: []string{"loadBalancingRules[LBRuleHTTPS]", "probes[TCPProbe]"},
+		},
+		{
+			name:     "node outbound load balancer with user-declared rules without drift",
+			spec:     newNodeOutboundLBSpecWithRules(),
+			existing: newNodeOutboundLBWithRules(),
+			expected: nil,
+		},
+		{
+			name: "user-declared rule, probe and inbound NAT pool modified",
+			spec: newNodeOutboundLBSpecWithRules(),
+			existing: func() network.LoadBalancer {
+				lb := newNodeOutboundLBWithRules()
+				(*lb.LoadBalancingRules)[0].EnableTCPReset = to.BoolPtr(false)
+				(*lb.Probes)[0].RequestPath = to.StringPtr("/")
+				(*lb.InboundNatPools)[0].FrontendPortRangeEnd = to.Int32Ptr(50010)
+				return lb
+			}(),
+			expected: []string{"loadBalancingRules[ingress-https].enableTCPReset", "probes[ingress-probe].requestPath", "inboundNatPools[ssh]"},
+		},
+		{
+			name: "user-declared inbound NAT pool deleted",
+			spec: newNodeOutboundLBSpecWithRules(),
+			existing: func() network.LoadBalancer {
+				lb := newNodeOutboundLBWithRules()
+				lb.InboundNatPools = nil
+				return lb
+			}(),
+			expected: []string{"inboundNatPools[ssh]"},
+		},
+		{
+			name:          "existing is not a load balancer",
+			spec:          &fakePublicAPILBSpec,
+			existing:      "not an lb",
+			expectedError: "string is not a network.LoadBalancer",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			drift, err := tc.spec.Drift(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drift).To(Equal(tc.expected))
+		})
+	}
+}
+
+// newNodeOutboundLBSpecWithRules returns a node outbound load balancer spec with user-declared
+// load balancing rules, probes and inbound NAT pools.
+func newNodeOutboundLBSpecWithRules() *LBSpec {
+	spec := fakeNodeOutboundLBSpec
+	spec.LoadBalancingRules = infrav1.LoadBalancingRules{
+		{
+			Name:           "ingress-https",
+			Protocol:       infrav1.LoadBalancerProtocolTCP,
+			FrontendPort:   443,
+			BackendPort:    443,
+			ProbeName:      "ingress-probe",
+			EnableTCPReset: to.BoolPtr(true),
+		},
+	}
+	spec.Probes = infrav1.LoadBalancerProbes{
+		{
+			Name:              "ingress-probe",
+			Protocol:          infrav1.ProbeProtocolHTTPS,
+			Port:              443,
+			RequestPath:       "/healthz",
+			IntervalInSeconds: to.Int32Ptr(5),
+		},
+	}
+	spec.InboundNATPools = infrav1.InboundNATPools{
+		{
+			Name:                   "ssh",
+			FrontendPortRangeStart: 50000,
+			FrontendPortRangeEnd:   50099,
+			BackendPort:            22,
+		},
+	}
+	return &spec
+}
+
+// newNodeOutboundLBWithRules returns the node outbound load balancer matching newNodeOutboundLBSpecWithRules.
+func newNodeOutboundLBWithRules() network.LoadBalancer {
+	spec := newNodeOutboundLBSpecWithRules()
+	_, frontendIDs := getFrontendIPConfigs(*spec)
+	lb := newDefaultNodeOutboundLB()
+	rules := getLoadBalancingRules(*spec, frontendIDs)
+	probes := getProbes(*spec)
+	pools := getInboundNATPools(*spec, frontendIDs)
+	lb.LoadBalancingRules = &rules
+	lb.Probes = &probes
+	lb.InboundNatPools = &pools
+	return lb
+}
+
 func newDefaultNodeOutboundLB() network.LoadBalancer {
 	return network.LoadBalancer{
 		Tags: map[string]*string{
@@ -188,6 +417,7 @@ func newDefaultNodeOutboundLB() network.LoadBalancer {
 			},
 			LoadBalancingRules: &[]network.LoadBalancingRule{},
 			Probes:             &[]network.Probe{},
+			InboundNatPools:    &[]network.InboundNatPool{},
 			OutboundRules: &[]network.OutboundRule{
 				{
 					Name: to.StringPtr("OutboundNATAllProtocols"),
@@ -291,6 +521,7 @@ func newSamplePublicAPIServerLB(verifyFrontendIP bool, verifyBackendAddressPools
 					},
 				},
 			},
+			InboundNatPools: &[]network.InboundNatPool{},
 			OutboundRules: &[]network.OutboundRule{
 				{
 					Name: to.StringPtr("OutboundNATAllProtocols"),
@@ -359,7 +590,8 @@ func newDefaultInternalAPIServerLB() network.LoadBalancer {
 					},
 				},
 			},
-			OutboundRules: &[]network.OutboundRule{},
+			InboundNatPools: &[]network.InboundNatPool{},
+			OutboundRules:   &[]network.OutboundRule{},
 			Probes: &[]network.Probe{
 				{
 					Name: to.StringPtr(tcpProbe),
@@ -109,6 +109,20 @@ func (mr *MockNatGatewayScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockNatGatewayScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroups mocks base method.
+func (m *MockNatGatewayScope) ApplicationSecurityGroups() v1beta1.ApplicationSecurityGroups {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroups")
+	ret0, _ := ret[0].(v1beta1.ApplicationSecurityGroups)
+	return ret0
+}
+
+// ApplicationSecurityGroups indicates an expected call of ApplicationSecurityGroups.
+func (mr *MockNatGatewayScopeMockRecorder) ApplicationSecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroups", reflect.TypeOf((*MockNatGatewayScope)(nil).ApplicationSecurityGroups))
+}
+
 // Authorizer mocks base method.
 func (m *MockNatGatewayScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -401,6 +415,20 @@ func (mr *MockNatGatewayScopeMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockNatGatewayScope)(nil).NodeSubnets))
 }
 
+// OutboundLBInboundNATPoolNames mocks base method.
+func (m *MockNatGatewayScope) OutboundLBInboundNATPoolNames(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundLBInboundNATPoolNames", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OutboundLBInboundNATPoolNames indicates an expected call of OutboundLBInboundNATPoolNames.
+func (mr *MockNatGatewayScopeMockRecorder) OutboundLBInboundNATPoolNames(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundLBInboundNATPoolNames", reflect.TypeOf((*MockNatGatewayScope)(nil).OutboundLBInboundNATPoolNames), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockNatGatewayScope) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -28,7 +28,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "natgateways"
+// ServiceName is the name of this service.
+const ServiceName = "natgateways"
 
 // NatGatewayScope defines the scope interface for NAT gateway service.
 type NatGatewayScope interface {
@@ -55,7 +56,7 @@ func New(scope NatGatewayScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a NAT gateway.
@@ -84,13 +85,13 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
 	var resultingErr error
 	for _, natGatewaySpec := range specs {
-		result, err := s.CreateOrUpdateResource(ctx, natGatewaySpec, serviceName)
+		result, err := s.CreateOrUpdateResource(ctx, natGatewaySpec, ServiceName)
 		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
 				resultingErr = err
 			}
 		}
-		if err == nil {
+		if err == nil && result != nil {
 			natGateway, ok := result.(network.NatGateway)
 			if !ok {
 				// Return out of loop since this would be an unexpected fatal error
@@ -103,7 +104,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 	}
 
-	s.Scope.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, serviceName, resultingErr)
+	s.Scope.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, ServiceName, resultingErr)
 	return resultingErr
 }
 
@@ -132,13 +133,13 @@ func (s *Service) Delete(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
 	var resultingErr error
 	for _, natGatewaySpec := range specs {
-		if err := s.DeleteResource(ctx, natGatewaySpec, serviceName); err != nil {
+		if err := s.DeleteResource(ctx, natGatewaySpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
 				resultingErr = err
 			}
 		}
 	}
-	s.Scope.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, serviceName, resultingErr)
+	s.Scope.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, ServiceName, resultingErr)
 	return resultingErr
 }
 
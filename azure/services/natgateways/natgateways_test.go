@@ -95,9 +95,9 @@ func TestReconcileNatGateways(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return(natGateway1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &natGatewaySpec1, ServiceName).Return(natGateway1, nil)
 				s.SetNatGatewayIDInSubnets(natGatewaySpec1.Name, *natGateway1.ID)
-				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -107,8 +107,8 @@ func TestReconcileNatGateways(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return(nil, internalError)
-				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &natGatewaySpec1, ServiceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -118,8 +118,8 @@ func TestReconcileNatGateways(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return("not a nat gateway", nil)
-				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, serviceName, gomockinternal.ErrStrEq("created resource string is not a network.NatGateway"))
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &natGatewaySpec1, ServiceName).Return("not a nat gateway", nil)
+				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, ServiceName, gomockinternal.ErrStrEq("created resource string is not a network.NatGateway"))
 			},
 		},
 	}
@@ -183,8 +183,8 @@ func TestDeleteNatGateway(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.DeleteResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &natGatewaySpec1, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -194,8 +194,8 @@ func TestDeleteNatGateway(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.DeleteResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &natGatewaySpec1, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
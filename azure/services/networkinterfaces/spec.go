@@ -17,6 +17,8 @@ limitations under the License.
 package networkinterfaces
 
 import (
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
@@ -48,6 +50,7 @@ type NICSpec struct {
 	EnableIPForwarding        bool
 	SKU                       *resourceskus.SKU
 	DNSServers                []string
+	ApplicationSecurityGroups []string
 	AdditionalTags            infrav1.Tags
 	ClusterName               string
 }
@@ -70,11 +73,16 @@ func (s *NICSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the network interface.
 func (s *NICSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.Interface); !ok {
+		existingNIC, ok := existing.(network.Interface)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.Interface", existing)
 		}
-		// network interface already exists
-		return nil, nil
+		// The application security groups of the cluster can change after the network interface is created,
+		// its other properties are not updated.
+		if s.hasApplicationSecurityGroups(existingNIC) {
+			return nil, nil
+		}
+		return s.withApplicationSecurityGroups(existingNIC), nil
 	}
 
 	nicConfig := &network.InterfaceIPConfigurationPropertiesFormat{}
@@ -120,6 +128,9 @@ func (s *NICSpec) Parameters(existing interface{}) (parameters interface{}, err
 		}
 	}
 
+	applicationSecurityGroups := s.applicationSecurityGroups()
+	nicConfig.ApplicationSecurityGroups = applicationSecurityGroups
+
 	if s.AcceleratedNetworking == nil {
 		// set accelerated networking to the capability of the VMSize
 		if s.SKU == nil {
@@ -149,6 +160,8 @@ func (s *NICSpec) Parameters(existing interface{}) (parameters interface{}, err
 				PrivateIPAddressVersion: "IPv6",
 				Primary:                 to.BoolPtr(false),
 				Subnet:                  &network.Subnet{ID: subnet.ID},
+				// Azure requires all the IP configurations of a network interface to be in the same application security groups.
+				ApplicationSecurityGroups: applicationSecurityGroups,
 			},
 		}
 
@@ -171,3 +184,71 @@ func (s *NICSpec) Parameters(existing interface{}) (parameters interface{}, err
 		})),
 	}, nil
 }
+
+// applicationSecurityGroups returns the application security groups of the IP configurations of the network interface.
+func (s *NICSpec) applicationSecurityGroups() *[]network.ApplicationSecurityGroup {
+	if len(s.ApplicationSecurityGroups) == 0 {
+		return nil
+	}
+	asgs := make([]network.ApplicationSecurityGroup, 0, len(s.ApplicationSecurityGroups))
+	for _, asgName := range s.ApplicationSecurityGroups {
+		asgs = append(asgs, network.ApplicationSecurityGroup{
+			ID: to.StringPtr(azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ResourceGroup, asgName)),
+		})
+	}
+	return &asgs
+}
+
+// hasApplicationSecurityGroups returns true if all the IP configurations of the existing network interface are members
+// of exactly the application security groups of the spec. A spec without application security groups leaves the
+// membership of the existing network interface untouched.
+func (s *NICSpec) hasApplicationSecurityGroups(existing network.Interface) bool {
+	if len(s.ApplicationSecurityGroups) == 0 || existing.InterfacePropertiesFormat == nil || existing.IPConfigurations == nil {
+		return true
+	}
+
+	wanted := make(map[string]bool, len(s.ApplicationSecurityGroups))
+	for _, asg := range *s.applicationSecurityGroups() {
+		wanted[strings.ToLower(to.String(asg.ID))] = true
+	}
+	for _, ipConfig := range *existing.IPConfigurations {
+		if ipConfig.InterfaceIPConfigurationPropertiesFormat == nil || ipConfig.ApplicationSecurityGroups == nil {
+			return false
+		}
+		existingASGs := make(map[string]bool, len(*ipConfig.ApplicationSecurityGroups))
+		for _, asg := range *ipConfig.ApplicationSecurityGroups {
+			existingASGs[strings.ToLower(to.String(asg.ID))] = true
+		}
+		if len(existingASGs) != len(wanted) {
+			return false
+		}
+		for id := range wanted {
+			if !existingASGs[id] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// withApplicationSecurityGroups returns a copy of the existing network interface whose IP configurations are members of
+// the application security groups of the spec. Azure only supports updating the tags of a network interface with a PATCH,
+// so the whole existing network interface is sent back with a PUT to keep its other properties.
+func (s *NICSpec) withApplicationSecurityGroups(existing network.Interface) network.Interface {
+	ipConfigurations := make([]network.InterfaceIPConfiguration, len(*existing.IPConfigurations))
+	for i, ipConfig := range *existing.IPConfigurations {
+		ipConfigurations[i] = ipConfig
+		properties := network.InterfaceIPConfigurationPropertiesFormat{}
+		if ipConfig.InterfaceIPConfigurationPropertiesFormat != nil {
+			properties = *ipConfig.InterfaceIPConfigurationPropertiesFormat
+		}
+		// Azure requires all the IP configurations of a network interface to be in the same application security groups.
+		properties.ApplicationSecurityGroups = s.applicationSecurityGroups()
+		ipConfigurations[i].InterfaceIPConfigurationPropertiesFormat = &properties
+	}
+
+	properties := *existing.InterfacePropertiesFormat
+	properties.IPConfigurations = &ipConfigurations
+	existing.InterfacePropertiesFormat = &properties
+	return existing
+}
@@ -179,6 +179,21 @@ var (
 		DNSServers:                fakeCustomDNSServers,
 		ClusterName:               "my-cluster",
 	}
+
+	fakeApplicationSecurityGroupsNICSpec = NICSpec{
+		Name:                      "my-net-interface",
+		ResourceGroup:             "my-rg",
+		Location:                  "fake-location",
+		SubscriptionID:            "123",
+		MachineName:               "azure-test1",
+		SubnetName:                "my-subnet",
+		VNetName:                  "my-vnet",
+		IPv6Enabled:               true,
+		VNetResourceGroup:         "my-rg",
+		AcceleratedNetworking:     to.BoolPtr(false),
+		ApplicationSecurityGroups: []string{"control-plane-asg", "all-machines-asg"},
+		ClusterName:               "my-cluster",
+	}
 )
 
 func TestParameters(t *testing.T) {
@@ -432,6 +447,95 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for network interface with application security groups",
+			spec:     &fakeApplicationSecurityGroupsNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				asgs := &[]network.ApplicationSecurityGroup{
+					{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/control-plane-asg")},
+					{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/all-machines-asg")},
+				}
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				g.Expect(result.(network.Interface)).To(Equal(network.Interface{
+					Tags: map[string]*string{
+						"Name": to.StringPtr("my-net-interface"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
+					Location: to.StringPtr("fake-location"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableAcceleratedNetworking: to.BoolPtr(false),
+						EnableIPForwarding:          to.BoolPtr(false),
+						DNSSettings:                 &network.InterfaceDNSSettings{},
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{
+								Name: to.StringPtr("pipConfig"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									Subnet:                          &network.Subnet{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									PrivateIPAllocationMethod:       network.IPAllocationMethodDynamic,
+									LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{},
+									ApplicationSecurityGroups:       asgs,
+								},
+							},
+							{
+								Name: to.StringPtr("ipConfigv6"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									Subnet:                    &network.Subnet{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									Primary:                   to.BoolPtr(false),
+									PrivateIPAddressVersion:   "IPv6",
+									ApplicationSecurityGroups: asgs,
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing network interface is already in the application security groups",
+			spec:     &fakeApplicationSecurityGroupsNICSpec,
+			existing: existingNIC("control-plane-asg", "all-machines-asg"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing network interface without application security groups in the spec is not updated",
+			spec:     &fakeStaticPrivateIPNICSpec,
+			existing: existingNIC("control-plane-asg"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing network interface is added to the new application security groups of the spec",
+			spec:     &fakeApplicationSecurityGroupsNICSpec,
+			existing: existingNIC("control-plane-asg"),
+			expect: func(g *WithT, result interface{}) {
+				asgs := &[]network.ApplicationSecurityGroup{
+					{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/control-plane-asg")},
+					{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/all-machines-asg")},
+				}
+				expected := existingNIC("control-plane-asg")
+				for i := range *expected.IPConfigurations {
+					(*expected.IPConfigurations)[i].ApplicationSecurityGroups = asgs
+				}
+				g.Expect(result).To(Equal(expected))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing is not a network interface",
+			spec:     &fakeApplicationSecurityGroupsNICSpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not a network.Interface",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -450,3 +554,43 @@ func TestParameters(t *testing.T) {
 		})
 	}
 }
+
+// existingNIC returns a network interface as returned by Azure, with two IP configurations in the given application security groups.
+func existingNIC(asgNames ...string) network.Interface {
+	var asgs []network.ApplicationSecurityGroup
+	for _, name := range asgNames {
+		asgs = append(asgs, network.ApplicationSecurityGroup{
+			ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/" + name),
+		})
+	}
+	return network.Interface{
+		ID:       to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkInterfaces/my-net-interface"),
+		Name:     to.StringPtr("my-net-interface"),
+		Location: to.StringPtr("fake-location"),
+		Tags: map[string]*string{
+			"Name": to.StringPtr("my-net-interface"),
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+		},
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			EnableAcceleratedNetworking: to.BoolPtr(false),
+			IPConfigurations: &[]network.InterfaceIPConfiguration{
+				{
+					Name: to.StringPtr("pipConfig"),
+					InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+						PrivateIPAddress:          to.StringPtr("10.0.0.4"),
+						Subnet:                    &network.Subnet{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+						ApplicationSecurityGroups: &asgs,
+					},
+				},
+				{
+					Name: to.StringPtr("ipConfigv6"),
+					InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+						PrivateIPAddressVersion:   "IPv6",
+						Subnet:                    &network.Subnet{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+						ApplicationSecurityGroups: &asgs,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementgroups
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client wraps go-sdk.
+type Client interface {
+	ListCapacityReservations(ctx context.Context, capacityReservationGroupID string) ([]compute.CapacityReservation, error)
+	GetDedicatedHostGroup(ctx context.Context, dedicatedHostGroupID string) (compute.DedicatedHostGroup, error)
+	GetProximityPlacementGroup(ctx context.Context, proximityPlacementGroupID string) (compute.ProximityPlacementGroup, error)
+	GetVirtualMachine(ctx context.Context, vmID string) (compute.VirtualMachine, error)
+	GetVirtualMachineScaleSet(ctx context.Context, vmssID string) (compute.VirtualMachineScaleSet, error)
+}
+
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	capacityReservations     compute.CapacityReservationsClient
+	dedicatedHostGroups      compute.DedicatedHostGroupsClient
+	proximityPlacementGroups compute.ProximityPlacementGroupsClient
+	virtualMachines          compute.VirtualMachinesClient
+	virtualMachineScaleSets  compute.VirtualMachineScaleSetsClient
+}
+
+var _ Client = &AzureClient{}
+
+// NewClient creates a new placement groups client from auth info.
+func NewClient(auth azure.Authorizer) *AzureClient {
+	return &AzureClient{
+		capacityReservations:     newCapacityReservationsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		dedicatedHostGroups:      newDedicatedHostGroupsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		proximityPlacementGroups: newProximityPlacementGroupsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		virtualMachines:          newVirtualMachinesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		virtualMachineScaleSets:  newVirtualMachineScaleSetsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newCapacityReservationsClient creates a new capacity reservations client from subscription ID, base URI, and authorizer.
+func newCapacityReservationsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.CapacityReservationsClient {
+	capacityReservationsClient := compute.NewCapacityReservationsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&capacityReservationsClient.Client, authorizer)
+	return capacityReservationsClient
+}
+
+// newDedicatedHostGroupsClient creates a new dedicated host groups client from subscription ID, base URI, and authorizer.
+func newDedicatedHostGroupsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.DedicatedHostGroupsClient {
+	dedicatedHostGroupsClient := compute.NewDedicatedHostGroupsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&dedicatedHostGroupsClient.Client, authorizer)
+	return dedicatedHostGroupsClient
+}
+
+// newProximityPlacementGroupsClient creates a new proximity placement groups client from subscription ID, base URI, and authorizer.
+func newProximityPlacementGroupsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.ProximityPlacementGroupsClient {
+	proximityPlacementGroupsClient := compute.NewProximityPlacementGroupsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&proximityPlacementGroupsClient.Client, authorizer)
+	return proximityPlacementGroupsClient
+}
+
+// newVirtualMachinesClient creates a new virtual machines client from subscription ID, base URI, and authorizer.
+func newVirtualMachinesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachinesClient {
+	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&virtualMachinesClient.Client, authorizer)
+	return virtualMachinesClient
+}
+
+// newVirtualMachineScaleSetsClient creates a new virtual machine scale sets client from subscription ID, base URI, and authorizer.
+func newVirtualMachineScaleSetsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachineScaleSetsClient {
+	virtualMachineScaleSetsClient := compute.NewVirtualMachineScaleSetsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&virtualMachineScaleSetsClient.Client, authorizer)
+	return virtualMachineScaleSetsClient
+}
+
+// ListCapacityReservations returns the capacity reservations of a capacity reservation group, given its resource ID.
+// The capacity reservation group may be in a different subscription than the cluster.
+func (ac *AzureClient) ListCapacityReservations(ctx context.Context, capacityReservationGroupID string) ([]compute.CapacityReservation, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "placementgroups.AzureClient.ListCapacityReservations")
+	defer done()
+
+	parsed, err := azureautorest.ParseResourceID(capacityReservationGroupID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse capacity reservation group ID %s", capacityReservationGroupID)
+	}
+
+	client := ac.capacityReservations
+	client.SubscriptionID = parsed.SubscriptionID
+	iter, err := client.ListByCapacityReservationGroupComplete(ctx, parsed.ResourceGroup, parsed.ResourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var reservations []compute.CapacityReservation
+	for iter.NotDone() {
+		reservations = append(reservations, iter.Value())
+		if err := iter.NextWithContext(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not iterate capacity reservations")
+		}
+	}
+
+	return reservations, nil
+}
+
+// GetDedicatedHostGroup returns a dedicated host group, given its resource ID.
+// The dedicated host group may be in a different subscription than the cluster.
+func (ac *AzureClient) GetDedicatedHostGroup(ctx context.Context, dedicatedHostGroupID string) (compute.DedicatedHostGroup, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "placementgroups.AzureClient.GetDedicatedHostGroup")
+	defer done()
+
+	parsed, err := azureautorest.ParseResourceID(dedicatedHostGroupID)
+	if err != nil {
+		return compute.DedicatedHostGroup{}, errors.Wrapf(err, "failed to parse dedicated host group ID %s", dedicatedHostGroupID)
+	}
+
+	client := ac.dedicatedHostGroups
+	client.SubscriptionID = parsed.SubscriptionID
+	return client.Get(ctx, parsed.ResourceGroup, parsed.ResourceName, "")
+}
+
+// GetProximityPlacementGroup returns a proximity placement group, given its resource ID.
+// The proximity placement group may be in a different subscription than the cluster.
+func (ac *AzureClient) GetProximityPlacementGroup(ctx context.Context, proximityPlacementGroupID string) (compute.ProximityPlacementGroup, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "placementgroups.AzureClient.GetProximityPlacementGroup")
+	defer done()
+
+	parsed, err := azureautorest.ParseResourceID(proximityPlacementGroupID)
+	if err != nil {
+		return compute.ProximityPlacementGroup{}, errors.Wrapf(err, "failed to parse proximity placement group ID %s", proximityPlacementGroupID)
+	}
+
+	client := ac.proximityPlacementGroups
+	client.SubscriptionID = parsed.SubscriptionID
+	return client.Get(ctx, parsed.ResourceGroup, parsed.ResourceName, "")
+}
+
+// GetVirtualMachine returns a virtual machine of a proximity placement group, given its resource ID.
+func (ac *AzureClient) GetVirtualMachine(ctx context.Context, vmID string) (compute.VirtualMachine, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "placementgroups.AzureClient.GetVirtualMachine")
+	defer done()
+
+	parsed, err := azureautorest.ParseResourceID(vmID)
+	if err != nil {
+		return compute.VirtualMachine{}, errors.Wrapf(err, "failed to parse virtual machine ID %s", vmID)
+	}
+
+	client := ac.virtualMachines
+	client.SubscriptionID = parsed.SubscriptionID
+	return client.Get(ctx, parsed.ResourceGroup, parsed.ResourceName, "")
+}
+
+// GetVirtualMachineScaleSet returns a virtual machine scale set of a proximity placement group, given its resource ID.
+func (ac *AzureClient) GetVirtualMachineScaleSet(ctx context.Context, vmssID string) (compute.VirtualMachineScaleSet, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "placementgroups.AzureClient.GetVirtualMachineScaleSet")
+	defer done()
+
+	parsed, err := azureautorest.ParseResourceID(vmssID)
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, errors.Wrapf(err, "failed to parse virtual machine scale set ID %s", vmssID)
+	}
+
+	client := ac.virtualMachineScaleSets
+	client.SubscriptionID = parsed.SubscriptionID
+	return client.Get(ctx, parsed.ResourceGroup, parsed.ResourceName, "")
+}
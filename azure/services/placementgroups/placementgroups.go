@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementgroups
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Placement describes the capacity reservation group, dedicated host group and proximity placement group a virtual machine
+// or a scale set is placed in.
+type Placement struct {
+	CapacityReservationGroupID string
+	DedicatedHostGroupID       string
+	ProximityPlacementGroupID  string
+	SKU                        resourceskus.SKU
+	// Zones are the availability zones of the virtual machine or the scale set. It is empty for regional deployments.
+	Zones []string
+}
+
+// Validate checks that the capacity reservation group, the dedicated host group and the proximity placement group of a
+// placement can host a virtual machine of the placement's SKU in its zones. A mismatch is returned as a terminal error, since retrying cannot fix it.
+func Validate(ctx context.Context, client Client, placement Placement) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "placementgroups.Validate")
+	defer done()
+
+	if placement.CapacityReservationGroupID != "" {
+		if err := validateCapacityReservationGroup(ctx, client, placement); err != nil {
+			return err
+		}
+	}
+
+	if placement.DedicatedHostGroupID != "" {
+		if err := validateDedicatedHostGroup(ctx, client, placement); err != nil {
+			return err
+		}
+	}
+
+	if placement.ProximityPlacementGroupID != "" {
+		if err := validateProximityPlacementGroup(ctx, client, placement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateCapacityReservationGroup checks that the VM size supports capacity reservations and that the capacity
+// reservation group reserves capacity for it in every zone of the placement.
+func validateCapacityReservationGroup(ctx context.Context, client Client, placement Placement) error {
+	size := to.String(placement.SKU.Name)
+	if !placement.SKU.HasCapability(resourceskus.CapacityReservationSupported) {
+		return azure.WithTerminalError(errors.Errorf("vm size %s does not support capacity reservations", size))
+	}
+
+	reservations, err := client.ListCapacityReservations(ctx, placement.CapacityReservationGroupID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list capacity reservations of capacity reservation group %s", placement.CapacityReservationGroupID)
+	}
+
+	if len(placement.Zones) == 0 {
+		if !hasCapacityReservation(reservations, size, "") {
+			return azure.WithTerminalError(errors.Errorf("capacity reservation group %s has no regional capacity reservation for vm size %s", placement.CapacityReservationGroupID, size))
+		}
+		return nil
+	}
+
+	for _, zone := range placement.Zones {
+		if !hasCapacityReservation(reservations, size, zone) {
+			return azure.WithTerminalError(errors.Errorf("capacity reservation group %s has no capacity reservation for vm size %s in zone %s", placement.CapacityReservationGroupID, size, zone))
+		}
+	}
+
+	return nil
+}
+
+// hasCapacityReservation returns true if one of the capacity reservations reserves the VM size in the zone.
+// An empty zone matches regional capacity reservations only.
+func hasCapacityReservation(reservations []compute.CapacityReservation, size, zone string) bool {
+	for _, reservation := range reservations {
+		if reservation.Sku == nil || !strings.EqualFold(to.String(reservation.Sku.Name), size) {
+			continue
+		}
+		zones := to.StringSlice(reservation.Zones)
+		if zone == "" && len(zones) == 0 {
+			return true
+		}
+		if zone != "" && contains(zones, zone) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDedicatedHostGroup checks that the dedicated host group supports automatic placement and spans the zones
+// of the placement.
+func validateDedicatedHostGroup(ctx context.Context, client Client, placement Placement) error {
+	hostGroup, err := client.GetDedicatedHostGroup(ctx, placement.DedicatedHostGroupID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get dedicated host group %s", placement.DedicatedHostGroupID)
+	}
+
+	if hostGroup.DedicatedHostGroupProperties == nil || hostGroup.SupportAutomaticPlacement == nil || !*hostGroup.SupportAutomaticPlacement {
+		return azure.WithTerminalError(errors.Errorf("dedicated host group %s does not support automatic placement", placement.DedicatedHostGroupID))
+	}
+
+	hostGroupZones := to.StringSlice(hostGroup.Zones)
+
+	if len(hostGroupZones) == 0 {
+		if len(placement.Zones) > 0 {
+			return azure.WithTerminalError(errors.Errorf("dedicated host group %s is regional and cannot host zonal virtual machines", placement.DedicatedHostGroupID))
+		}
+		return nil
+	}
+
+	if len(placement.Zones) == 0 {
+		return azure.WithTerminalError(errors.Errorf("dedicated host group %s is zonal and cannot host regional virtual machines", placement.DedicatedHostGroupID))
+	}
+
+	for _, zone := range placement.Zones {
+		if !contains(hostGroupZones, zone) {
+			return azure.WithTerminalError(errors.Errorf("dedicated host group %s is not available in zone %s", placement.DedicatedHostGroupID, zone))
+		}
+	}
+
+	return nil
+}
+
+// validateProximityPlacementGroup checks that the zones of the placement match the zone the proximity placement group
+// is pinned to. A proximity placement group has no zone of its own: it is pinned to the datacenter of the first resource
+// placed in it, so its zone is the zone of its zonal virtual machines and scale sets.
+func validateProximityPlacementGroup(ctx context.Context, client Client, placement Placement) error {
+	if len(placement.Zones) == 0 {
+		return nil
+	}
+	if len(placement.Zones) > 1 {
+		return azure.WithTerminalError(errors.Errorf("proximity placement group %s cannot host virtual machines spread across zones %v", placement.ProximityPlacementGroupID, placement.Zones))
+	}
+
+	ppg, err := client.GetProximityPlacementGroup(ctx, placement.ProximityPlacementGroupID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get proximity placement group %s", placement.ProximityPlacementGroupID)
+	}
+
+	memberID, zones, err := proximityPlacementGroupZones(ctx, client, ppg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the zones of proximity placement group %s", placement.ProximityPlacementGroupID)
+	}
+	if len(zones) > 0 && !contains(zones, placement.Zones[0]) {
+		return azure.WithTerminalError(errors.Errorf("proximity placement group %s is pinned to zones %v by %s and cannot host virtual machines in zone %s", placement.ProximityPlacementGroupID, zones, memberID, placement.Zones[0]))
+	}
+
+	return nil
+}
+
+// proximityPlacementGroupZones returns the zones of the first zonal virtual machine or scale set of a proximity placement
+// group, along with its ID. It returns no zones if the proximity placement group is empty or only has regional members.
+func proximityPlacementGroupZones(ctx context.Context, client Client, ppg compute.ProximityPlacementGroup) (string, []string, error) {
+	if ppg.ProximityPlacementGroupProperties == nil {
+		return "", nil, nil
+	}
+
+	if ppg.VirtualMachines != nil {
+		for _, member := range *ppg.VirtualMachines {
+			vm, err := client.GetVirtualMachine(ctx, to.String(member.ID))
+			if err != nil {
+				return "", nil, err
+			}
+			if zones := to.StringSlice(vm.Zones); len(zones) > 0 {
+				return to.String(member.ID), zones, nil
+			}
+		}
+	}
+
+	if ppg.VirtualMachineScaleSets != nil {
+		for _, member := range *ppg.VirtualMachineScaleSets {
+			vmss, err := client.GetVirtualMachineScaleSet(ctx, to.String(member.ID))
+			if err != nil {
+				return "", nil, err
+			}
+			if zones := to.StringSlice(vmss.Zones); len(zones) > 0 {
+				return to.String(member.ID), zones, nil
+			}
+		}
+	}
+
+	return "", nil, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
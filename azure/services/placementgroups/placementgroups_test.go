@@ -0,0 +1,278 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placementgroups
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/placementgroups/mock_placementgroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const (
+	fakeCapacityReservationGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg"
+	fakeDedicatedHostGroupID       = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+	fakeProximityPlacementGroupID  = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg"
+	fakeVMID                       = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"
+	fakeVMSSID                     = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/my-vmss"
+)
+
+var (
+	fakeSKU = resourceskus.SKU{
+		Name: to.StringPtr("Standard_D2s_v3"),
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  to.StringPtr(resourceskus.CapacityReservationSupported),
+				Value: to.StringPtr(string(resourceskus.CapabilitySupported)),
+			},
+		},
+	}
+	fakeSKUWithoutCapacityReservation = resourceskus.SKU{
+		Name: to.StringPtr("Standard_D2s_v3"),
+	}
+	fakeCapacityReservations = []compute.CapacityReservation{
+		{Sku: &compute.Sku{Name: to.StringPtr("Standard_D4s_v3")}, Zones: &[]string{"1"}},
+		{Sku: &compute.Sku{Name: to.StringPtr("standard_d2s_v3")}, Zones: &[]string{"1"}},
+		{Sku: &compute.Sku{Name: to.StringPtr("Standard_D2s_v3")}, Zones: &[]string{"2"}},
+	}
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func fakeDedicatedHostGroup(supportAutomaticPlacement bool, zones ...string) compute.DedicatedHostGroup {
+	hostGroup := compute.DedicatedHostGroup{
+		DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+			SupportAutomaticPlacement: to.BoolPtr(supportAutomaticPlacement),
+		},
+	}
+	if len(zones) > 0 {
+		hostGroup.Zones = &zones
+	}
+	return hostGroup
+}
+
+func fakeProximityPlacementGroup(vmIDs, vmssIDs []string) compute.ProximityPlacementGroup {
+	vms := make([]compute.SubResourceWithColocationStatus, len(vmIDs))
+	for i := range vmIDs {
+		vms[i] = compute.SubResourceWithColocationStatus{ID: to.StringPtr(vmIDs[i])}
+	}
+	vmsses := make([]compute.SubResourceWithColocationStatus, len(vmssIDs))
+	for i := range vmssIDs {
+		vmsses[i] = compute.SubResourceWithColocationStatus{ID: to.StringPtr(vmssIDs[i])}
+	}
+	return compute.ProximityPlacementGroup{
+		ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{
+			VirtualMachines:         &vms,
+			VirtualMachineScaleSets: &vmsses,
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testcases := []struct {
+		name          string
+		placement     Placement
+		expectedError string
+		terminal      bool
+		expect        func(m *mock_placementgroups.MockClientMockRecorder)
+	}{
+		{
+			name:      "noop without capacity reservation group, dedicated host group and proximity placement group",
+			placement: Placement{SKU: fakeSKU, Zones: []string{"1"}},
+			expect:    func(m *mock_placementgroups.MockClientMockRecorder) {},
+		},
+		{
+			name:      "capacity reservation group with a reservation for the size in every zone",
+			placement: Placement{CapacityReservationGroupID: fakeCapacityReservationGroupID, SKU: fakeSKU, Zones: []string{"1", "2"}},
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.ListCapacityReservations(gomockinternal.AContext(), fakeCapacityReservationGroupID).Return(fakeCapacityReservations, nil)
+			},
+		},
+		{
+			name:          "capacity reservation group without a reservation for the size in a zone",
+			placement:     Placement{CapacityReservationGroupID: fakeCapacityReservationGroupID, SKU: fakeSKU, Zones: []string{"1", "3"}},
+			expectedError: "capacity reservation group " + fakeCapacityReservationGroupID + " has no capacity reservation for vm size Standard_D2s_v3 in zone 3",
+			terminal:      true,
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.ListCapacityReservations(gomockinternal.AContext(), fakeCapacityReservationGroupID).Return(fakeCapacityReservations, nil)
+			},
+		},
+		{
+			name:          "zonal capacity reservations do not match a regional vm",
+			placement:     Placement{CapacityReservationGroupID: fakeCapacityReservationGroupID, SKU: fakeSKU},
+			expectedError: "capacity reservation group " + fakeCapacityReservationGroupID + " has no regional capacity reservation for vm size Standard_D2s_v3",
+			terminal:      true,
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.ListCapacityReservations(gomockinternal.AContext(), fakeCapacityReservationGroupID).Return(fakeCapacityReservations, nil)
+			},
+		},
+		{
+			name:      "regional capacity reservation matches a regional vm",
+			placement: Placement{CapacityReservationGroupID: fakeCapacityReservationGroupID, SKU: fakeSKU},
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.ListCapacityReservations(gomockinternal.AContext(), fakeCapacityReservationGroupID).Return([]compute.CapacityReservation{
+					{Sku: &compute.Sku{Name: to.StringPtr("Standard_D2s_v3")}},
+				}, nil)
+			},
+		},
+		{
+			name:          "vm size does not support capacity reservations",
+			placement:     Placement{CapacityReservationGroupID: fakeCapacityReservationGroupID, SKU: fakeSKUWithoutCapacityReservation, Zones: []string{"1"}},
+			expectedError: "vm size Standard_D2s_v3 does not support capacity reservations",
+			terminal:      true,
+			expect:        func(m *mock_placementgroups.MockClientMockRecorder) {},
+		},
+		{
+			name:          "error listing capacity reservations",
+			placement:     Placement{CapacityReservationGroupID: fakeCapacityReservationGroupID, SKU: fakeSKU, Zones: []string{"1"}},
+			expectedError: "failed to list capacity reservations of capacity reservation group " + fakeCapacityReservationGroupID + ": #: Internal Server Error: StatusCode=500",
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.ListCapacityReservations(gomockinternal.AContext(), fakeCapacityReservationGroupID).Return(nil, internalError)
+			},
+		},
+		{
+			name:      "zonal dedicated host group spanning the zones",
+			placement: Placement{DedicatedHostGroupID: fakeDedicatedHostGroupID, SKU: fakeSKU, Zones: []string{"1"}},
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetDedicatedHostGroup(gomockinternal.AContext(), fakeDedicatedHostGroupID).Return(fakeDedicatedHostGroup(true, "1"), nil)
+			},
+		},
+		{
+			name:          "zonal dedicated host group in another zone",
+			placement:     Placement{DedicatedHostGroupID: fakeDedicatedHostGroupID, SKU: fakeSKU, Zones: []string{"2"}},
+			expectedError: "dedicated host group " + fakeDedicatedHostGroupID + " is not available in zone 2",
+			terminal:      true,
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetDedicatedHostGroup(gomockinternal.AContext(), fakeDedicatedHostGroupID).Return(fakeDedicatedHostGroup(true, "1"), nil)
+			},
+		},
+		{
+			name:          "zonal dedicated host group with a regional vm",
+			placement:     Placement{DedicatedHostGroupID: fakeDedicatedHostGroupID, SKU: fakeSKU},
+			expectedError: "dedicated host group " + fakeDedicatedHostGroupID + " is zonal and cannot host regional virtual machines",
+			terminal:      true,
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetDedicatedHostGroup(gomockinternal.AContext(), fakeDedicatedHostGroupID).Return(fakeDedicatedHostGroup(true, "1"), nil)
+			},
+		},
+		{
+			name:          "regional dedicated host group with a zonal vm",
+			placement:     Placement{DedicatedHostGroupID: fakeDedicatedHostGroupID, SKU: fakeSKU, Zones: []string{"1"}},
+			expectedError: "dedicated host group " + fakeDedicatedHostGroupID + " is regional and cannot host zonal virtual machines",
+			terminal:      true,
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetDedicatedHostGroup(gomockinternal.AContext(), fakeDedicatedHostGroupID).Return(fakeDedicatedHostGroup(true), nil)
+			},
+		},
+		{
+			name:          "dedicated host group without automatic placement",
+			placement:     Placement{DedicatedHostGroupID: fakeDedicatedHostGroupID, SKU: fakeSKU},
+			expectedError: "dedicated host group " + fakeDedicatedHostGroupID + " does not support automatic placement",
+			terminal:      true,
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetDedicatedHostGroup(gomockinternal.AContext(), fakeDedicatedHostGroupID).Return(fakeDedicatedHostGroup(false), nil)
+			},
+		},
+		{
+			name:          "error getting dedicated host group",
+			placement:     Placement{DedicatedHostGroupID: fakeDedicatedHostGroupID, SKU: fakeSKU},
+			expectedError: "failed to get dedicated host group " + fakeDedicatedHostGroupID + ": #: Internal Server Error: StatusCode=500",
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetDedicatedHostGroup(gomockinternal.AContext(), fakeDedicatedHostGroupID).Return(compute.DedicatedHostGroup{}, internalError)
+			},
+		},
+		{
+			name:      "empty proximity placement group",
+			placement: Placement{ProximityPlacementGroupID: fakeProximityPlacementGroupID, SKU: fakeSKU, Zones: []string{"1"}},
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetProximityPlacementGroup(gomockinternal.AContext(), fakeProximityPlacementGroupID).Return(compute.ProximityPlacementGroup{}, nil)
+			},
+		},
+		{
+			name:      "proximity placement group pinned to the zone of the vm",
+			placement: Placement{ProximityPlacementGroupID: fakeProximityPlacementGroupID, SKU: fakeSKU, Zones: []string{"1"}},
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetProximityPlacementGroup(gomockinternal.AContext(), fakeProximityPlacementGroupID).Return(fakeProximityPlacementGroup([]string{fakeVMID}, nil), nil)
+				m.GetVirtualMachine(gomockinternal.AContext(), fakeVMID).Return(compute.VirtualMachine{Zones: &[]string{"1"}}, nil)
+			},
+		},
+		{
+			name:          "proximity placement group pinned to another zone by a scale set",
+			placement:     Placement{ProximityPlacementGroupID: fakeProximityPlacementGroupID, SKU: fakeSKU, Zones: []string{"1"}},
+			expectedError: "proximity placement group " + fakeProximityPlacementGroupID + " is pinned to zones [2] by " + fakeVMSSID + " and cannot host virtual machines in zone 1",
+			terminal:      true,
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetProximityPlacementGroup(gomockinternal.AContext(), fakeProximityPlacementGroupID).Return(fakeProximityPlacementGroup([]string{fakeVMID}, []string{fakeVMSSID}), nil)
+				m.GetVirtualMachine(gomockinternal.AContext(), fakeVMID).Return(compute.VirtualMachine{}, nil)
+				m.GetVirtualMachineScaleSet(gomockinternal.AContext(), fakeVMSSID).Return(compute.VirtualMachineScaleSet{Zones: &[]string{"2"}}, nil)
+			},
+		},
+		{
+			name:          "proximity placement group with vms spread across zones",
+			placement:     Placement{ProximityPlacementGroupID: fakeProximityPlacementGroupID, SKU: fakeSKU, Zones: []string{"1", "2"}},
+			expectedError: "proximity placement group " + fakeProximityPlacementGroupID + " cannot host virtual machines spread across zones [1 2]",
+			terminal:      true,
+			expect:        func(m *mock_placementgroups.MockClientMockRecorder) {},
+		},
+		{
+			name:      "proximity placement group with a regional vm",
+			placement: Placement{ProximityPlacementGroupID: fakeProximityPlacementGroupID, SKU: fakeSKU},
+			expect:    func(m *mock_placementgroups.MockClientMockRecorder) {},
+		},
+		{
+			name:          "error getting a vm of the proximity placement group",
+			placement:     Placement{ProximityPlacementGroupID: fakeProximityPlacementGroupID, SKU: fakeSKU, Zones: []string{"1"}},
+			expectedError: "failed to get the zones of proximity placement group " + fakeProximityPlacementGroupID + ": #: Internal Server Error: StatusCode=500",
+			expect: func(m *mock_placementgroups.MockClientMockRecorder) {
+				m.GetProximityPlacementGroup(gomockinternal.AContext(), fakeProximityPlacementGroupID).Return(fakeProximityPlacementGroup([]string{fakeVMID}, nil), nil)
+				m.GetVirtualMachine(gomockinternal.AContext(), fakeVMID).Return(compute.VirtualMachine{}, internalError)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clientMock := mock_placementgroups.NewMockClient(mockCtrl)
+
+			tc.expect(clientMock.EXPECT())
+
+			err := Validate(context.TODO(), clientMock, tc.placement)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+				var reconcileErr azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileErr) && reconcileErr.IsTerminal()).To(Equal(tc.terminal))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -27,14 +27,15 @@ import (
 
 // LinkSpec defines the specification for a virtual network link in a private DNS zone.
 type LinkSpec struct {
-	Name              string
-	ZoneName          string
-	SubscriptionID    string
-	VNetResourceGroup string
-	VNetName          string
-	ResourceGroup     string
-	ClusterName       string
-	AdditionalTags    infrav1.Tags
+	Name                string
+	ZoneName            string
+	SubscriptionID      string
+	VNetResourceGroup   string
+	VNetName            string
+	ResourceGroup       string
+	ClusterName         string
+	RegistrationEnabled bool
+	AdditionalTags      infrav1.Tags
 }
 
 // ResourceName returns the name of the virtual network link.
@@ -67,7 +68,7 @@ func (s LinkSpec) Parameters(existing interface{}) (params interface{}, err erro
 			VirtualNetwork: &privatedns.SubResource{
 				ID: to.StringPtr(azure.VNetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName)),
 			},
-			RegistrationEnabled: to.BoolPtr(false),
+			RegistrationEnabled: to.BoolPtr(s.RegistrationEnabled),
 		},
 		Location: to.StringPtr(azure.Global),
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
@@ -263,6 +263,20 @@ func (mr *MockScopeMockRecorder) PrivateDNSSpec() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateDNSSpec", reflect.TypeOf((*MockScope)(nil).PrivateDNSSpec))
 }
 
+// PrivateDNSZoneAuthorizer mocks base method.
+func (m *MockScope) PrivateDNSZoneAuthorizer(subscriptionID string) azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivateDNSZoneAuthorizer", subscriptionID)
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// PrivateDNSZoneAuthorizer indicates an expected call of PrivateDNSZoneAuthorizer.
+func (mr *MockScopeMockRecorder) PrivateDNSZoneAuthorizer(subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateDNSZoneAuthorizer", reflect.TypeOf((*MockScope)(nil).PrivateDNSZoneAuthorizer), subscriptionID)
+}
+
 // ResourceGroup mocks base method.
 func (m *MockScope) ResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -19,6 +19,7 @@ package privatedns
 import (
 	"context"
 
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -29,7 +30,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "privatedns"
+// ServiceName is the name of this service.
+const ServiceName = "privatedns"
 
 // Scope defines the scope interface for a private dns service.
 type Scope interface {
@@ -37,6 +39,7 @@ type Scope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
 	PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linksSpec, recordsSpec []azure.ResourceSpecGetter)
+	PrivateDNSZoneAuthorizer(subscriptionID string) azure.Authorizer
 }
 
 // Service provides operations on Azure resources.
@@ -46,6 +49,12 @@ type Service struct {
 	zoneReconciler     async.Reconciler
 	vnetLinkReconciler async.Reconciler
 	recordReconciler   async.Reconciler
+	// recordGetter gets the record sets of an existing private DNS zone to check that they are owned before deleting them.
+	recordGetter async.Getter
+	// zoneSubscriptionID is the subscription of an existing private DNS zone that is not in the subscription of the cluster.
+	zoneSubscriptionID string
+	// newZoneReconcilers creates the reconcilers of the links and records of a private DNS zone managed with the given authorizer.
+	newZoneReconcilers func(auth azure.Authorizer) (vnetLinkReconciler, recordReconciler async.Reconciler, recordGetter async.Getter, tagsGetter async.TagsGetter)
 }
 
 // New creates a new private dns service.
@@ -60,12 +69,18 @@ func New(scope Scope) *Service {
 		zoneReconciler:     async.New(scope, zoneClient, zoneClient),
 		vnetLinkReconciler: async.New(scope, vnetLinkClient, vnetLinkClient),
 		recordReconciler:   async.New(scope, recordSetsClient, recordSetsClient),
+		recordGetter:       recordSetsClient,
+		newZoneReconcilers: func(auth azure.Authorizer) (async.Reconciler, async.Reconciler, async.Getter, async.TagsGetter) {
+			zoneVnetLinkClient := newVirtualNetworkLinksClient(auth)
+			zoneRecordSetsClient := newRecordSetsClient(auth)
+			return async.New(scope, zoneVnetLinkClient, zoneVnetLinkClient), async.New(scope, zoneRecordSetsClient, zoneRecordSetsClient), zoneRecordSetsClient, tags.NewClient(auth)
+		},
 	}
 }
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile creates or updates the private zone, links it to the vnet, and creates DNS records.
@@ -80,10 +95,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	if zoneSpec == nil {
 		return nil
 	}
+	s.useZoneSubscription(zoneSpec)
 
 	managed, err := s.reconcileZone(ctx, zoneSpec)
 	if managed {
-		s.Scope.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
@@ -91,14 +107,14 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	managed, err = s.reconcileLinks(ctx, links)
 	if managed {
-		s.Scope.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
 	}
 
 	err = s.reconcileRecords(ctx, records)
-	s.Scope.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
+	s.Scope.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
 	return err
 }
 
@@ -110,14 +126,24 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
 	defer cancel()
 
-	zoneSpec, links, _ := s.Scope.PrivateDNSSpec()
+	zoneSpec, links, records := s.Scope.PrivateDNSSpec()
 	if zoneSpec == nil {
 		return nil
 	}
+	s.useZoneSubscription(zoneSpec)
+
+	// The records of an existing zone are not deleted with the zone, so the ones owned by the cluster have to be deleted one by one.
+	if isExistingZone(zoneSpec) {
+		err := s.deleteRecords(ctx, records)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
+		if err != nil {
+			return err
+		}
+	}
 
 	managed, err := s.deleteLinks(ctx, links)
 	if managed {
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
@@ -125,17 +151,57 @@ func (s *Service) Delete(ctx context.Context) error {
 
 	managed, err = s.deleteZone(ctx, zoneSpec)
 	if managed {
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, err)
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
 	}
 
 	return err
 }
 
+// useZoneSubscription makes the service manage the links and records of a private DNS zone that is not in the subscription
+// of the cluster with clients of the subscription of the zone.
+func (s *Service) useZoneSubscription(zoneSpec azure.ResourceSpecGetter) {
+	spec, ok := zoneSpec.(ZoneSpec)
+	if !ok || spec.SubscriptionID == "" || spec.SubscriptionID == s.Scope.SubscriptionID() || spec.SubscriptionID == s.zoneSubscriptionID {
+		return
+	}
+	s.vnetLinkReconciler, s.recordReconciler, s.recordGetter, s.TagsGetter = s.newZoneReconcilers(s.Scope.PrivateDNSZoneAuthorizer(spec.SubscriptionID))
+	s.zoneSubscriptionID = spec.SubscriptionID
+}
+
+// subscriptionID returns the subscription of the private DNS zone.
+func (s *Service) subscriptionID() string {
+	if s.zoneSubscriptionID != "" {
+		return s.zoneSubscriptionID
+	}
+	return s.Scope.SubscriptionID()
+}
+
+// isExistingZone returns true if the private DNS zone is an existing zone that is not created by CAPZ.
+func isExistingZone(zoneSpec azure.ResourceSpecGetter) bool {
+	spec, ok := zoneSpec.(ZoneSpec)
+	return ok && spec.Existing
+}
+
+// isRecordSetManaged returns true if the record set has metadata with the cluster name as owner,
+// meaning that the record set lifecycle is managed.
+func (s *Service) isRecordSetManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
+	existing, err := s.recordGetter.Get(ctx, spec)
+	if err != nil {
+		return false, err
+	}
+
+	set, ok := existing.(privatedns.RecordSet)
+	if !ok {
+		return false, errors.Errorf("%T is not a privatedns.RecordSet", existing)
+	}
+	return isRecordSetOwned(set, s.Scope.ClusterName()), nil
+}
+
 // isVnetLinkManaged returns true if the vnet link has an owned tag with the cluster name as value,
 // meaning that the vnet link lifecycle is managed.
 func (s *Service) isVnetLinkManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
-	scope := azure.VirtualNetworkLinkID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	scope := azure.VirtualNetworkLinkID(s.subscriptionID(), spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
 	result, err := s.TagsGetter.GetAtScope(ctx, scope)
 	if err != nil {
 		return false, err
@@ -151,12 +217,15 @@ func (s *Service) isVnetLinkManaged(ctx context.Context, spec azure.ResourceSpec
 }
 
 // IsManaged returns true if the private DNS has an owned tag with the cluster name as value,
-// meaning that the DNS lifecycle is managed.
+// meaning that the DNS lifecycle is managed. An existing private DNS zone is never managed.
 func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	zoneSpec, _, _ := s.Scope.PrivateDNSSpec()
 	if zoneSpec == nil {
 		return false, errors.Errorf("no private dns zone spec available")
 	}
+	if isExistingZone(zoneSpec) {
+		return false, nil
+	}
 
 	scope := azure.PrivateDNSZoneID(s.Scope.SubscriptionID(), zoneSpec.ResourceGroupName(), zoneSpec.ResourceName())
 	result, err := s.TagsGetter.GetAtScope(ctx, scope)
@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -28,6 +29,7 @@ import (
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns/mock_privatedns"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -74,8 +76,48 @@ var (
 		AdditionalTags:    nil,
 	}
 
+	fakeExistingZone = ZoneSpec{
+		Name:           zoneName,
+		ResourceGroup:  "my-dns-rg",
+		SubscriptionID: "456",
+		Existing:       true,
+		ClusterName:    clusterName,
+	}
+
+	fakeExistingZoneLink = LinkSpec{
+		Name:              linkName1,
+		ZoneName:          zoneName,
+		SubscriptionID:    subscriptionID,
+		VNetResourceGroup: vnetResourceGroup,
+		VNetName:          vnetName,
+		ResourceGroup:     "my-dns-rg",
+		ClusterName:       clusterName,
+	}
+
+	fakeExistingZoneRecord = RecordSpec{
+		Record:        infrav1.PrivateDNSRecord{Name: "my-host", Type: infrav1.PrivateDNSRecordTypeA, IPs: []string{"10.0.0.8"}},
+		ZoneName:      zoneName,
+		ResourceGroup: "my-dns-rg",
+		ClusterName:   clusterName,
+		Existing:      true,
+	}
+
+	fakeExistingZoneRecord2 = RecordSpec{
+		Record:        infrav1.PrivateDNSRecord{Name: "my-other-host", Type: infrav1.PrivateDNSRecordTypeA, IPs: []string{"10.0.0.9"}},
+		ZoneName:      zoneName,
+		ResourceGroup: "my-dns-rg",
+		ClusterName:   clusterName,
+		Existing:      true,
+	}
+
+	ownedRecordSet = privatedns.RecordSet{
+		RecordSetProperties: &privatedns.RecordSetProperties{
+			Metadata: map[string]*string{recordOwnerMetadataKey: to.StringPtr(clusterName)},
+		},
+	}
+
 	fakeRecord1 = RecordSpec{
-		Record:        infrav1.AddressRecord{Hostname: "my-host", IP: "10.0.0.8"},
+		Record:        infrav1.PrivateDNSRecord{Name: "my-host", Type: infrav1.PrivateDNSRecordTypeA, IPs: []string{"10.0.0.8"}},
 		ZoneName:      zoneName,
 		ResourceGroup: resourceGroup,
 	}
@@ -132,13 +174,13 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -150,8 +192,8 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, notDoneError)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -163,8 +205,8 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -182,11 +224,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -204,11 +246,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, errFake)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, errFake)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -226,11 +268,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -248,11 +290,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, notDoneError)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, notDoneError)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -271,10 +313,10 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, nil)
 				s.ClusterName().Return(clusterName)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -292,12 +334,27 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "existing zone in another subscription is linked but not updated",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeExistingZoneLink}, []azure.ResourceSpecGetter{fakeExistingZoneRecord})
+				s.SubscriptionID().Return("123")
+				s.PrivateDNSZoneAuthorizer("456").Return(nil)
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("456", "my-dns-rg", zoneName, linkName1)).Return(resources.TagsResource{}, notFoundError)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeExistingZoneLink, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeExistingZoneRecord, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -315,13 +372,13 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(resources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
 			},
 		},
 	}
@@ -348,6 +405,9 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				vnetLinkReconciler: vnetLinkReconcilerMock,
 				recordReconciler:   recordReconcilerMock,
 				TagsGetter:         tagsGetterMock,
+				newZoneReconcilers: func(azure.Authorizer) (async.Reconciler, async.Reconciler, async.Getter, async.TagsGetter) {
+					return vnetLinkReconcilerMock, recordReconcilerMock, nil, tagsGetterMock
+				},
 			}
 
 			err := s.Reconcile(context.TODO())
@@ -365,47 +425,90 @@ func TestDeletePrivateDNS(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_privatedns.MockScopeMockRecorder, linkReconciler, zoneReconciler *mock_async.MockReconcilerMockRecorder, tagsGetter *mock_async.MockTagsGetterMockRecorder)
+		expect        func(s *mock_privatedns.MockScopeMockRecorder, linkReconciler, zoneReconciler, recordReconciler *mock_async.MockReconcilerMockRecorder,
+			tagsGetter *mock_async.MockTagsGetterMockRecorder, recordGetter *mock_async.MockGetterMockRecorder)
 	}{
 		{
 			name:          "no private dns",
 			expectedError: "",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(nil, nil, nil)
 			},
 		},
 		{
 			name:          "dns and links deletion succeeds",
 			expectedError: "",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "records and links of an existing zone are deleted but not the zone",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeExistingZoneLink}, []azure.ResourceSpecGetter{fakeExistingZoneRecord})
+				s.SubscriptionID().Return("123")
+				s.PrivateDNSZoneAuthorizer("456").Return(nil)
+
+				rg.Get(gomockinternal.AContext(), fakeExistingZoneRecord).Return(ownedRecordSet, nil)
+				s.ClusterName().Return(clusterName)
+				rr.DeleteResource(gomockinternal.AContext(), fakeExistingZoneRecord, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("456", "my-dns-rg", zoneName, linkName1)).Return(managedTags, nil)
+				s.ClusterName().Return(clusterName)
+				lr.DeleteResource(gomockinternal.AContext(), fakeExistingZoneLink, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "records of an existing zone that are not owned by the cluster or already deleted are not deleted",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeExistingZoneLink}, []azure.ResourceSpecGetter{fakeExistingZoneRecord, fakeExistingZoneRecord2})
+				s.SubscriptionID().Return("123")
+				s.PrivateDNSZoneAuthorizer("456").Return(nil)
+
+				rg.Get(gomockinternal.AContext(), fakeExistingZoneRecord).Return(privatedns.RecordSet{
+					RecordSetProperties: &privatedns.RecordSetProperties{
+						Metadata: map[string]*string{recordOwnerMetadataKey: to.StringPtr("other-cluster")},
+					},
+				}, nil)
+				s.ClusterName().Return(clusterName)
+				rg.Get(gomockinternal.AContext(), fakeExistingZoneRecord2).Return(privatedns.RecordSet{}, notFoundError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("456", "my-dns-rg", zoneName, linkName1)).Return(managedTags, nil)
+				s.ClusterName().Return(clusterName)
+				lr.DeleteResource(gomockinternal.AContext(), fakeExistingZoneLink, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
 			},
 		},
 		{
 			name:          "skips if zone and links are unmanaged",
 			expectedError: "",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
@@ -424,7 +527,7 @@ func TestDeletePrivateDNS(t *testing.T) {
 		{
 			name:          "skips if unmanaged, but deletes the next resource if it is managed",
 			expectedError: "",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
@@ -434,114 +537,114 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
 			name:          "link1 is deleted, link2 is long running. It returns not done error",
 			expectedError: "operation type resourceType on Azure resource my-rg/resourceName is not done",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1})
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(notDoneError)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(notDoneError)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, notDoneError)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
 			name:          "link1 deletion fails and link2 is long running, returns the more pressing error",
 			expectedError: "this is an error",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1})
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(errFake)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(errFake)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
 			name:          "links are deleted, zone is long running",
 			expectedError: "operation type resourceType on Azure resource my-rg/resourceName is not done",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(notDoneError)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(notDoneError)
 
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, notDoneError)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
 			name:          "links are deleted, zone deletion fails with error",
 			expectedError: "this is an error",
-			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, lr, zr, rr *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder, rg *mock_async.MockGetterMockRecorder) {
 				s.PrivateDNSSpec().Return(fakeZone, []azure.ResourceSpecGetter{fakeLink1, fakeLink2}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(errFake)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(errFake)
 
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, errFake)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
 			},
 		},
 	}
@@ -557,15 +660,22 @@ func TestDeletePrivateDNS(t *testing.T) {
 			scopeMock := mock_privatedns.NewMockScope(mockCtrl)
 			vnetLinkReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
 			zoneReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			recordReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
 			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			recordGetterMock := mock_async.NewMockGetter(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), zoneReconcilerMock.EXPECT(), tagsGetterMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), zoneReconcilerMock.EXPECT(), recordReconcilerMock.EXPECT(), tagsGetterMock.EXPECT(), recordGetterMock.EXPECT())
 
 			s := &Service{
 				Scope:              scopeMock,
 				zoneReconciler:     zoneReconcilerMock,
 				vnetLinkReconciler: vnetLinkReconcilerMock,
+				recordReconciler:   recordReconcilerMock,
+				recordGetter:       recordGetterMock,
 				TagsGetter:         tagsGetterMock,
+				newZoneReconcilers: func(azure.Authorizer) (async.Reconciler, async.Reconciler, async.Getter, async.TagsGetter) {
+					return vnetLinkReconcilerMock, recordReconcilerMock, recordGetterMock, tagsGetterMock
+				},
 			}
 
 			err := s.Delete(context.TODO())
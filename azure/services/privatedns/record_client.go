@@ -51,19 +51,12 @@ func (arc *azureRecordsClient) CreateOrUpdateAsync(ctx context.Context, spec azu
 		return nil, nil, errors.Errorf("%T is not a privatedns.RecordSet", parameters)
 	}
 
-	// Determine record type.
-	var (
-		recordType privatedns.RecordType
-		aRecords   = set.RecordSetProperties.ARecords
-		aaaRecords = set.RecordSetProperties.AaaaRecords
-	)
-	if aRecords != nil && len(*aRecords) > 0 && (*aRecords)[0].Ipv4Address != nil {
-		recordType = privatedns.A
-	} else if aaaRecords != nil && len(*aaaRecords) > 0 && (*aaaRecords)[0].Ipv6Address != nil {
-		recordType = privatedns.AAAA
+	recordSpec, ok := spec.(RecordSpec)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a RecordSpec", spec)
 	}
 
-	recordSet, err := arc.recordsets.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordType, spec.ResourceName(), set, "", "")
+	recordSet, err := arc.recordsets.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordSpec.RecordType(), spec.ResourceName(), set, "", "")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -71,14 +64,36 @@ func (arc *azureRecordsClient) CreateOrUpdateAsync(ctx context.Context, spec azu
 	return recordSet, nil, err
 }
 
-// Get gets the specified record set. Noop for records.
+// Get gets the specified record set.
 func (arc *azureRecordsClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
-	return nil, nil
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.azureRecordsClient.Get")
+	defer done()
+
+	recordSpec, ok := spec.(RecordSpec)
+	if !ok {
+		return nil, errors.Errorf("%T is not a RecordSpec", spec)
+	}
+
+	set, err := arc.recordsets.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordSpec.RecordType(), spec.ResourceName())
+	if err != nil {
+		return privatedns.RecordSet{}, err
+	}
+	return set, nil
 }
 
-// DeleteAsync deletes a record asynchronously. Noop for records.
+// DeleteAsync deletes a record asynchronously.
+// Deleting a record set is not a long running operation, so we don't ever return a future.
 func (arc *azureRecordsClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
-	return nil, nil
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.azureRecordsClient.DeleteAsync")
+	defer done()
+
+	recordSpec, ok := spec.(RecordSpec)
+	if !ok {
+		return nil, errors.Errorf("%T is not a RecordSpec", spec)
+	}
+
+	_, err = arc.recordsets.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), recordSpec.RecordType(), spec.ResourceName(), "")
+	return nil, err
 }
 
 // IsDone returns true if the long-running operation has completed. Noop for records.
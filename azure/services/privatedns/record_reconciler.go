@@ -19,6 +19,7 @@ package privatedns
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -33,7 +34,44 @@ func (s *Service) reconcileRecords(ctx context.Context, records []azure.Resource
 	// If multiple errors occur, we return the most pressing one.
 	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	for _, recordSpec := range records {
-		if _, err := s.recordReconciler.CreateOrUpdateResource(ctx, recordSpec, serviceName); err != nil {
+		if _, err := s.recordReconciler.CreateOrUpdateResource(ctx, recordSpec, ServiceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || resErr == nil {
+				resErr = err
+			}
+		}
+	}
+
+	return resErr
+}
+
+func (s *Service) deleteRecords(ctx context.Context, records []azure.ResourceSpecGetter) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.deleteRecords")
+	defer done()
+
+	var resErr error
+
+	// We go through the list of records to delete each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	for _, recordSpec := range records {
+		// The record sets of an existing zone can be shared with other clusters, so only the ones owned by this cluster are deleted.
+		isRecordSetManaged, err := s.isRecordSetManaged(ctx, recordSpec)
+		if err != nil {
+			if azure.ResourceNotFound(err) {
+				// already deleted or doesn't exist.
+				continue
+			}
+			return errors.Wrapf(err, "could not get record set %s of private dns zone %s in resource group %s",
+				recordSpec.ResourceName(), recordSpec.OwnerResourceName(), recordSpec.ResourceGroupName())
+		}
+
+		if !isRecordSetManaged {
+			log.V(2).Info("Skipping record set deletion for unmanaged record set", "record set",
+				recordSpec.ResourceName(), "private dns zone", recordSpec.OwnerResourceName())
+			continue
+		}
+
+		if err := s.recordReconciler.DeleteResource(ctx, recordSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || resErr == nil {
 				resErr = err
 			}
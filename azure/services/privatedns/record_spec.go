@@ -21,19 +21,30 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
-	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
+// defaultRecordTTL is the time to live, in seconds, of the record sets that do not specify one.
+const defaultRecordTTL = 300
+
+// recordOwnerMetadataKey is the metadata key of a record set whose value is the name of the cluster that owns it.
+// Metadata keys of record sets can only contain alphanumeric and underscore characters.
+const recordOwnerMetadataKey = "sigs_k8s_io_cluster_api_provider_azure_cluster"
+
 // RecordSpec defines the specification for a record set.
 type RecordSpec struct {
-	Record        infrav1.AddressRecord
+	Record        infrav1.PrivateDNSRecord
+	TTL           int64
 	ZoneName      string
 	ResourceGroup string
+	ClusterName   string
+	// Existing is true when the record set is in an existing private DNS zone that can be shared with other clusters,
+	// in which case only the record sets owned by the cluster are updated or deleted.
+	Existing bool
 }
 
 // ResourceName returns the name of a record set.
 func (s RecordSpec) ResourceName() string {
-	return s.Record.Hostname
+	return s.Record.Name
 }
 
 // OwnerResourceName returns the zone name of a record set.
@@ -46,31 +57,78 @@ func (s RecordSpec) ResourceGroupName() string {
 	return s.ResourceGroup
 }
 
+// RecordType returns the type of a record set.
+func (s RecordSpec) RecordType() privatedns.RecordType {
+	return privatedns.RecordType(s.Record.Type)
+}
+
 // Parameters returns the parameters for a record set.
 func (s RecordSpec) Parameters(existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(privatedns.RecordSet); !ok {
+		existingSet, ok := existing.(privatedns.RecordSet)
+		if !ok {
 			return nil, errors.Errorf("%T is not a privatedns.RecordSet", existing)
 		}
+		if s.Existing && !isRecordSetOwned(existingSet, s.ClusterName) {
+			return nil, errors.Errorf("record set %s of private DNS zone %s is not owned by cluster %s", s.Record.Name, s.ZoneName, s.ClusterName)
+		}
+	}
+
+	ttl := s.TTL
+	if s.Record.TTL != nil {
+		ttl = *s.Record.TTL
+	}
+	if ttl == 0 {
+		ttl = defaultRecordTTL
 	}
 	set := privatedns.RecordSet{
 		RecordSetProperties: &privatedns.RecordSetProperties{
-			TTL: to.Int64Ptr(300),
+			TTL: to.Int64Ptr(ttl),
+			Metadata: map[string]*string{
+				recordOwnerMetadataKey: to.StringPtr(s.ClusterName),
+			},
 		},
 	}
-	recordType := converters.GetRecordType(s.Record.IP)
-	switch recordType {
+	switch s.RecordType() {
 	case privatedns.A:
-		set.RecordSetProperties.ARecords = &[]privatedns.ARecord{{
-			Ipv4Address: &s.Record.IP,
-		}}
+		aRecords := make([]privatedns.ARecord, len(s.Record.IPs))
+		for i := range s.Record.IPs {
+			aRecords[i] = privatedns.ARecord{Ipv4Address: to.StringPtr(s.Record.IPs[i])}
+		}
+		set.RecordSetProperties.ARecords = &aRecords
 	case privatedns.AAAA:
-		set.RecordSetProperties.AaaaRecords = &[]privatedns.AaaaRecord{{
-			Ipv6Address: &s.Record.IP,
-		}}
+		aaaaRecords := make([]privatedns.AaaaRecord, len(s.Record.IPs))
+		for i := range s.Record.IPs {
+			aaaaRecords[i] = privatedns.AaaaRecord{Ipv6Address: to.StringPtr(s.Record.IPs[i])}
+		}
+		set.RecordSetProperties.AaaaRecords = &aaaaRecords
+	case privatedns.CNAME:
+		set.RecordSetProperties.CnameRecord = &privatedns.CnameRecord{
+			Cname: to.StringPtr(s.Record.CNAME),
+		}
+	case privatedns.SRV:
+		srvRecords := make([]privatedns.SrvRecord, len(s.Record.SRVRecords))
+		for i, srv := range s.Record.SRVRecords {
+			srvRecords[i] = privatedns.SrvRecord{
+				Priority: to.Int32Ptr(srv.Priority),
+				Weight:   to.Int32Ptr(srv.Weight),
+				Port:     to.Int32Ptr(srv.Port),
+				Target:   to.StringPtr(srv.Target),
+			}
+		}
+		set.RecordSetProperties.SrvRecords = &srvRecords
 	default:
-		return nil, errors.Errorf("unknown record type %s", recordType)
+		return nil, errors.Errorf("unknown record type %s", s.Record.Type)
 	}
 
 	return set, nil
 }
+
+// isRecordSetOwned returns true if the metadata of the record set marks it as owned by the given cluster.
+func isRecordSetOwned(set privatedns.RecordSet, clusterName string) bool {
+	if set.RecordSetProperties == nil || set.RecordSetProperties.Metadata == nil {
+		return false
+	}
+	owner, ok := set.RecordSetProperties.Metadata[recordOwnerMetadataKey]
+	return ok && owner != nil && *owner == clusterName
+}
@@ -27,15 +27,21 @@ import (
 
 var (
 	recordSpec = RecordSpec{
-		Record:        infrav1.AddressRecord{Hostname: "privatednsHostname", IP: "10.0.0.8"},
+		Record:        infrav1.PrivateDNSRecord{Name: "privatednsHostname", Type: infrav1.PrivateDNSRecordTypeA, IPs: []string{"10.0.0.8"}},
 		ZoneName:      "my-zone",
 		ResourceGroup: "my-rg",
+		ClusterName:   "my-cluster",
 	}
 
 	recordSpecIpv6 = RecordSpec{
-		Record:        infrav1.AddressRecord{Hostname: "privatednsHostname", IP: "2603:1030:805:2::b"},
+		Record:        infrav1.PrivateDNSRecord{Name: "privatednsHostname", Type: infrav1.PrivateDNSRecordTypeAAAA, IPs: []string{"2603:1030:805:2::b"}},
 		ZoneName:      "my-zone",
 		ResourceGroup: "my-rg",
+		ClusterName:   "my-cluster",
+	}
+
+	ownerMetadata = map[string]*string{
+		"sigs_k8s_io_cluster_api_provider_azure_cluster": to.StringPtr("my-cluster"),
 	}
 )
 
@@ -54,6 +60,11 @@ func TestRecordSpec_OwnerResourceName(t *testing.T) {
 	g.Expect(recordSpec.OwnerResourceName()).Should(Equal("my-zone"))
 }
 
+func TestRecordSpec_RecordType(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(recordSpec.RecordType()).Should(Equal(privatedns.A))
+}
+
 func TestRecordSpec_Parameters(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -69,7 +80,8 @@ func TestRecordSpec_Parameters(t *testing.T) {
 			expect: func(g *WithT, result interface{}) {
 				g.Expect(result).To(Equal(privatedns.RecordSet{
 					RecordSetProperties: &privatedns.RecordSetProperties{
-						TTL: to.Int64Ptr(300),
+						Metadata: ownerMetadata,
+						TTL:      to.Int64Ptr(300),
 						ARecords: &[]privatedns.ARecord{
 							{
 								Ipv4Address: to.StringPtr("10.0.0.8"),
@@ -86,7 +98,8 @@ func TestRecordSpec_Parameters(t *testing.T) {
 			expect: func(g *WithT, result interface{}) {
 				g.Expect(result).To(Equal(privatedns.RecordSet{
 					RecordSetProperties: &privatedns.RecordSetProperties{
-						TTL: to.Int64Ptr(300),
+						Metadata: ownerMetadata,
+						TTL:      to.Int64Ptr(300),
 						AaaaRecords: &[]privatedns.AaaaRecord{
 							{
 								Ipv6Address: to.StringPtr("2603:1030:805:2::b"),
@@ -96,6 +109,146 @@ func TestRecordSpec_Parameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name:          "new private dns CNAME record with zone TTL",
+			expectedError: "",
+			spec: RecordSpec{
+				Record:        infrav1.PrivateDNSRecord{Name: "api", Type: infrav1.PrivateDNSRecordTypeCNAME, CNAME: "apiserver.my-zone"},
+				TTL:           60,
+				ZoneName:      "my-zone",
+				ResourceGroup: "my-rg",
+				ClusterName:   "my-cluster",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(privatedns.RecordSet{
+					RecordSetProperties: &privatedns.RecordSetProperties{
+						Metadata:    ownerMetadata,
+						TTL:         to.Int64Ptr(60),
+						CnameRecord: &privatedns.CnameRecord{Cname: to.StringPtr("apiserver.my-zone")},
+					},
+				}))
+			},
+		},
+		{
+			name:          "new private dns SRV record with record TTL",
+			expectedError: "",
+			spec: RecordSpec{
+				Record: infrav1.PrivateDNSRecord{
+					Name: "_etcd-server-ssl._tcp",
+					Type: infrav1.PrivateDNSRecordTypeSRV,
+					TTL:  to.Int64Ptr(30),
+					SRVRecords: []infrav1.SRVRecord{
+						{Priority: 0, Weight: 10, Port: 2380, Target: "etcd-0.my-zone"},
+						{Priority: 0, Weight: 10, Port: 2380, Target: "etcd-1.my-zone"},
+					},
+				},
+				TTL:           60,
+				ZoneName:      "my-zone",
+				ResourceGroup: "my-rg",
+				ClusterName:   "my-cluster",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(privatedns.RecordSet{
+					RecordSetProperties: &privatedns.RecordSetProperties{
+						Metadata: ownerMetadata,
+						TTL:      to.Int64Ptr(30),
+						SrvRecords: &[]privatedns.SrvRecord{
+							{Priority: to.Int32Ptr(0), Weight: to.Int32Ptr(10), Port: to.Int32Ptr(2380), Target: to.StringPtr("etcd-0.my-zone")},
+							{Priority: to.Int32Ptr(0), Weight: to.Int32Ptr(10), Port: to.Int32Ptr(2380), Target: to.StringPtr("etcd-1.my-zone")},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name:          "existing private dns record owned by the cluster in an existing zone",
+			expectedError: "",
+			spec: RecordSpec{
+				Record:        infrav1.PrivateDNSRecord{Name: "privatednsHostname", Type: infrav1.PrivateDNSRecordTypeA, IPs: []string{"10.0.0.9"}},
+				ZoneName:      "my-zone",
+				ResourceGroup: "my-rg",
+				ClusterName:   "my-cluster",
+				Existing:      true,
+			},
+			existing: privatedns.RecordSet{
+				RecordSetProperties: &privatedns.RecordSetProperties{
+					Metadata: ownerMetadata,
+					TTL:      to.Int64Ptr(300),
+					ARecords: &[]privatedns.ARecord{{Ipv4Address: to.StringPtr("10.0.0.8")}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(privatedns.RecordSet{
+					RecordSetProperties: &privatedns.RecordSetProperties{
+						Metadata: ownerMetadata,
+						TTL:      to.Int64Ptr(300),
+						ARecords: &[]privatedns.ARecord{{Ipv4Address: to.StringPtr("10.0.0.9")}},
+					},
+				}))
+			},
+		},
+		{
+			name:          "existing private dns record owned by another cluster in an existing zone",
+			expectedError: "record set privatednsHostname of private DNS zone my-zone is not owned by cluster my-cluster",
+			spec: RecordSpec{
+				Record:        infrav1.PrivateDNSRecord{Name: "privatednsHostname", Type: infrav1.PrivateDNSRecordTypeA, IPs: []string{"10.0.0.9"}},
+				ZoneName:      "my-zone",
+				ResourceGroup: "my-rg",
+				ClusterName:   "my-cluster",
+				Existing:      true,
+			},
+			existing: privatedns.RecordSet{
+				RecordSetProperties: &privatedns.RecordSetProperties{
+					Metadata: map[string]*string{"sigs_k8s_io_cluster_api_provider_azure_cluster": to.StringPtr("other-cluster")},
+					TTL:      to.Int64Ptr(300),
+				},
+			},
+		},
+		{
+			name:          "existing private dns record without owner in an existing zone",
+			expectedError: "record set privatednsHostname of private DNS zone my-zone is not owned by cluster my-cluster",
+			spec: RecordSpec{
+				Record:        infrav1.PrivateDNSRecord{Name: "privatednsHostname", Type: infrav1.PrivateDNSRecordTypeA, IPs: []string{"10.0.0.9"}},
+				ZoneName:      "my-zone",
+				ResourceGroup: "my-rg",
+				ClusterName:   "my-cluster",
+				Existing:      true,
+			},
+			existing: privatedns.RecordSet{
+				RecordSetProperties: &privatedns.RecordSetProperties{
+					TTL: to.Int64Ptr(300),
+				},
+			},
+		},
+		{
+			name:          "existing private dns record without owner in a zone created for the cluster",
+			expectedError: "",
+			spec:          recordSpec,
+			existing: privatedns.RecordSet{
+				RecordSetProperties: &privatedns.RecordSetProperties{
+					TTL: to.Int64Ptr(300),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(privatedns.RecordSet{
+					RecordSetProperties: &privatedns.RecordSetProperties{
+						Metadata: ownerMetadata,
+						TTL:      to.Int64Ptr(300),
+						ARecords: &[]privatedns.ARecord{{Ipv4Address: to.StringPtr("10.0.0.8")}},
+					},
+				}))
+			},
+		},
+		{
+			name:          "unknown record type",
+			expectedError: "unknown record type MX",
+			spec: RecordSpec{
+				Record:        infrav1.PrivateDNSRecord{Name: "mail", Type: "MX"},
+				ZoneName:      "my-zone",
+				ResourceGroup: "my-rg",
+				ClusterName:   "my-cluster",
+			},
+		},
 	}
 
 	for _, tc := range testcases {
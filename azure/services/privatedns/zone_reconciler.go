@@ -29,6 +29,11 @@ func (s *Service) reconcileZone(ctx context.Context, zoneSpec azure.ResourceSpec
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.reconcileZone")
 	defer done()
 
+	if isExistingZone(zoneSpec) {
+		log.V(1).Info("Skipping reconciliation of existing private DNS zone", "private DNS", zoneSpec.ResourceName())
+		return false, nil
+	}
+
 	managed, err = s.IsManaged(ctx)
 	if err != nil {
 		if azure.ResourceNotFound(err) {
@@ -46,7 +51,7 @@ func (s *Service) reconcileZone(ctx context.Context, zoneSpec azure.ResourceSpec
 		return managed, nil
 	}
 
-	_, err = s.zoneReconciler.CreateOrUpdateResource(ctx, zoneSpec, serviceName)
+	_, err = s.zoneReconciler.CreateOrUpdateResource(ctx, zoneSpec, ServiceName)
 	return managed, err
 }
 
@@ -54,12 +59,17 @@ func (s *Service) deleteZone(ctx context.Context, zoneSpec azure.ResourceSpecGet
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.deleteZone")
 	defer done()
 
+	if isExistingZone(zoneSpec) {
+		log.V(1).Info("Skipping deletion of existing private DNS zone", "private DNS", zoneSpec.ResourceName())
+		return false, nil
+	}
+
 	// Skip deleting the private DNS zone when it's not managed by capz.
 	isManaged, err := s.IsManaged(ctx)
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			// already deleted or doesn't exist, cleanup status and return.
-			s.Scope.DeleteLongRunningOperationState(zoneSpec.ResourceName(), serviceName, infrav1.DeleteFuture)
+			s.Scope.DeleteLongRunningOperationState(zoneSpec.ResourceName(), ServiceName, infrav1.DeleteFuture)
 			return managed, nil
 		}
 		return managed, errors.Wrapf(err, "could not get private DNS zone state of %s in resource group %s", zoneSpec.ResourceName(), zoneSpec.ResourceGroupName())
@@ -74,6 +84,6 @@ func (s *Service) deleteZone(ctx context.Context, zoneSpec azure.ResourceSpecGet
 	managed = true
 
 	// Delete the private DNS zone, which also deletes all records
-	err = s.zoneReconciler.DeleteResource(ctx, zoneSpec, serviceName)
+	err = s.zoneReconciler.DeleteResource(ctx, zoneSpec, ServiceName)
 	return managed, err
 }
@@ -29,6 +29,10 @@ import (
 type ZoneSpec struct {
 	Name           string
 	ResourceGroup  string
+	SubscriptionID string
+	// Existing is true for an existing zone, which CAPZ links the virtual networks to and creates records in,
+	// but never creates, updates nor deletes.
+	Existing       bool
 	ClusterName    string
 	AdditionalTags infrav1.Tags
 }
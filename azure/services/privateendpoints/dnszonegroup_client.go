@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// dnsZoneGroupClient wraps go-sdk for private DNS zone groups.
+type dnsZoneGroupClient interface {
+	List(context.Context, string, string) (result []network.PrivateDNSZoneGroup, err error)
+}
+
+// azurePrivateDNSZoneGroupsClient contains the Azure go-sdk Client for private DNS zone groups.
+type azurePrivateDNSZoneGroupsClient struct {
+	dnszonegroups network.PrivateDNSZoneGroupsClient
+}
+
+var _ dnsZoneGroupClient = (*azurePrivateDNSZoneGroupsClient)(nil)
+
+// newPrivateDNSZoneGroupsClient creates a new private DNS zone group client.
+func newPrivateDNSZoneGroupsClient(auth azure.Authorizer) *azurePrivateDNSZoneGroupsClient {
+	groupsClient := network.NewPrivateDNSZoneGroupsClientWithBaseURI(auth.BaseURI(), auth.SubscriptionID())
+	azure.SetAutoRestClientDefaults(&groupsClient.Client, auth.Authorizer())
+	return &azurePrivateDNSZoneGroupsClient{
+		dnszonegroups: groupsClient,
+	}
+}
+
+// List returns all private DNS zone groups of a private endpoint.
+func (ac *azurePrivateDNSZoneGroupsClient) List(ctx context.Context, resourceGroupName, privateEndpointName string) (result []network.PrivateDNSZoneGroup, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.azurePrivateDNSZoneGroupsClient.List")
+	defer done()
+
+	iter, err := ac.dnszonegroups.ListComplete(ctx, privateEndpointName, resourceGroupName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list private DNS zone groups for private endpoint %s", privateEndpointName)
+	}
+
+	var groups []network.PrivateDNSZoneGroup
+	for iter.NotDone() {
+		groups = append(groups, iter.Value())
+		if err := iter.NextWithContext(ctx); err != nil {
+			return groups, errors.Wrap(err, "could not iterate private DNS zone groups")
+		}
+	}
+
+	return groups, nil
+}
+
+// CreateOrUpdateAsync creates or updates a private DNS zone group asynchronously.
+// It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *azurePrivateDNSZoneGroupsClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.azurePrivateDNSZoneGroupsClient.CreateOrUpdateAsync")
+	defer done()
+
+	group, ok := parameters.(network.PrivateDNSZoneGroup)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a network.PrivateDNSZoneGroup", parameters)
+	}
+
+	createFuture, err := ac.dnszonegroups.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), group)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = createFuture.WaitForCompletionRef(ctx, ac.dnszonegroups.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, &createFuture, err
+	}
+	result, err = createFuture.Result(ac.dnszonegroups)
+	// if the operation completed, return a nil future
+	return result, nil, err
+}
+
+// Get gets the specified private DNS zone group.
+func (ac *azurePrivateDNSZoneGroupsClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.azurePrivateDNSZoneGroupsClient.Get")
+	defer done()
+	group, err := ac.dnszonegroups.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	if err != nil {
+		return network.PrivateDNSZoneGroup{}, err
+	}
+	return group, nil
+}
+
+// DeleteAsync deletes a private DNS zone group asynchronously. DeleteAsync sends a DELETE
+// request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *azurePrivateDNSZoneGroupsClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.azurePrivateDNSZoneGroupsClient.DeleteAsync")
+	defer done()
+
+	deleteFuture, err := ac.dnszonegroups.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = deleteFuture.WaitForCompletionRef(ctx, ac.dnszonegroups.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return &deleteFuture, err
+	}
+	_, err = deleteFuture.Result(ac.dnszonegroups)
+	// if the operation completed, return a nil future.
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+func (ac *azurePrivateDNSZoneGroupsClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.azurePrivateDNSZoneGroupsClient.IsDone")
+	defer done()
+
+	return future.DoneWithContext(ctx, ac.dnszonegroups)
+}
+
+// Result fetches the result of a long-running operation future.
+func (ac *azurePrivateDNSZoneGroupsClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.azurePrivateDNSZoneGroupsClient.Result")
+	defer done()
+
+	if future == nil {
+		return nil, errors.Errorf("cannot get result from nil future")
+	}
+
+	switch futureType {
+	case infrav1.PutFuture:
+		// Marshal and Unmarshal the future to put it into the correct future type so we can access the Result function.
+		// Unfortunately the FutureAPI can't be casted directly to PrivateDNSZoneGroupsCreateOrUpdateFuture because it is a azureautorest.Future, which doesn't implement the Result function. See PR #1686 for discussion on alternatives.
+		// It was converted back to a generic azureautorest.Future from the CAPZ infrav1.Future type stored in Status: https://github.com/kubernetes-sigs/cluster-api-provider-azure/blob/main/azure/converters/futures.go#L49.
+		var createFuture *network.PrivateDNSZoneGroupsCreateOrUpdateFuture
+		jsonData, err := future.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal future")
+		}
+		if err := json.Unmarshal(jsonData, &createFuture); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal future data")
+		}
+		return createFuture.Result(ac.dnszonegroups)
+
+	case infrav1.DeleteFuture:
+		// Delete does not return a result private DNS zone group.
+		return nil, nil
+
+	default:
+		return nil, errors.Errorf("unknown future type %q", futureType)
+	}
+}
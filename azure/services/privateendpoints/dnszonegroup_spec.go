@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+)
+
+// PrivateDNSZoneGroupSpec defines the specification for the private DNS zone group of a private endpoint.
+type PrivateDNSZoneGroupSpec struct {
+	Name                string
+	PrivateEndpointName string
+	ResourceGroup       string
+	PrivateDNSZoneIDs   []string
+}
+
+// ResourceName returns the name of the private DNS zone group.
+func (s *PrivateDNSZoneGroupSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PrivateDNSZoneGroupSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the private endpoint of the private DNS zone group.
+func (s *PrivateDNSZoneGroupSpec) OwnerResourceName() string {
+	return s.PrivateEndpointName
+}
+
+// Parameters returns the parameters for the private DNS zone group.
+func (s *PrivateDNSZoneGroupSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
+	if existing != nil {
+		existingGroup, ok := existing.(network.PrivateDNSZoneGroup)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.PrivateDNSZoneGroup", existing)
+		}
+		if s.hasZones(existingGroup) {
+			// Skip update for the private DNS zone group as its zones are up to date.
+			return nil, nil
+		}
+	}
+
+	configs := make([]network.PrivateDNSZoneConfig, 0, len(s.PrivateDNSZoneIDs))
+	for _, zoneID := range s.PrivateDNSZoneIDs {
+		configs = append(configs, network.PrivateDNSZoneConfig{
+			Name: to.StringPtr(zoneConfigName(zoneID)),
+			PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+				PrivateDNSZoneID: to.StringPtr(zoneID),
+			},
+		})
+	}
+
+	return network.PrivateDNSZoneGroup{
+		Name: to.StringPtr(s.Name),
+		PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+			PrivateDNSZoneConfigs: &configs,
+		},
+	}, nil
+}
+
+// hasZones returns true if the existing private DNS zone group contains exactly the private DNS zones of the spec.
+func (s *PrivateDNSZoneGroupSpec) hasZones(existing network.PrivateDNSZoneGroup) bool {
+	existingZoneIDs := make(map[string]bool)
+	if existing.PrivateDNSZoneGroupPropertiesFormat != nil && existing.PrivateDNSZoneConfigs != nil {
+		for _, config := range *existing.PrivateDNSZoneConfigs {
+			if config.PrivateDNSZonePropertiesFormat != nil {
+				existingZoneIDs[strings.ToLower(to.String(config.PrivateDNSZoneID))] = true
+			}
+		}
+	}
+
+	if len(existingZoneIDs) != len(s.PrivateDNSZoneIDs) {
+		return false
+	}
+	for _, zoneID := range s.PrivateDNSZoneIDs {
+		if !existingZoneIDs[strings.ToLower(zoneID)] {
+			return false
+		}
+	}
+	return true
+}
+
+// zoneConfigName returns the name of the configuration of a private DNS zone in a private DNS zone group,
+// derived from the zone name the same way as the Azure portal, e.g. "privatelink-blob-core-windows-net".
+func zoneConfigName(zoneID string) string {
+	zoneName := zoneID[strings.LastIndex(zoneID, "/")+1:]
+	return strings.ReplaceAll(zoneName, ".", "-")
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestPrivateDNSZoneGroupParameters(t *testing.T) {
+	blobZoneConfigs := &[]network.PrivateDNSZoneConfig{
+		{
+			Name: to.StringPtr("privatelink-blob-core-windows-net"),
+			PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+				PrivateDNSZoneID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"),
+			},
+		},
+	}
+	testcases := []struct {
+		name          string
+		spec          *PrivateDNSZoneGroupSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "private DNS zone group does not exist",
+			spec:     &fakePrivateDNSZoneGroupSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PrivateDNSZoneGroup{
+					Name: to.StringPtr("default"),
+					PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+						PrivateDNSZoneConfigs: blobZoneConfigs,
+					},
+				}))
+			},
+		},
+		{
+			name: "private DNS zone group already has the private DNS zones",
+			spec: &fakePrivateDNSZoneGroupSpec,
+			existing: network.PrivateDNSZoneGroup{
+				Name: to.StringPtr("default"),
+				PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+					PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+						{
+							Name: to.StringPtr("blob"),
+							PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+								PrivateDNSZoneID: to.StringPtr("/subscriptions/123/resourcegroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "private DNS zone group has different private DNS zones",
+			spec: &fakePrivateDNSZoneGroupSpec,
+			existing: network.PrivateDNSZoneGroup{
+				Name: to.StringPtr("default"),
+				PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+					PrivateDNSZoneConfigs: &[]network.PrivateDNSZoneConfig{
+						{
+							Name: to.StringPtr("privatelink-file-core-windows-net"),
+							PrivateDNSZonePropertiesFormat: &network.PrivateDNSZonePropertiesFormat{
+								PrivateDNSZoneID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.file.core.windows.net"),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PrivateDNSZoneGroup{
+					Name: to.StringPtr("default"),
+					PrivateDNSZoneGroupPropertiesFormat: &network.PrivateDNSZoneGroupPropertiesFormat{
+						PrivateDNSZoneConfigs: blobZoneConfigs,
+					},
+				}))
+			},
+		},
+		{
+			name:          "existing is not a private DNS zone group",
+			spec:          &fakePrivateDNSZoneGroupSpec,
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.PrivateDNSZoneGroup",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "privateendpoints"
+
+// PrivateEndpointScope defines the scope interface for a private endpoint service.
+type PrivateEndpointScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	azure.ClusterDescriber
+	PrivateEndpointSpecs() []azure.ResourceSpecGetter
+	PrivateDNSZoneGroupSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PrivateEndpointScope
+	async.Reconciler
+	async.TagsGetter
+	client                 client
+	dnsZoneGroupClient     dnsZoneGroupClient
+	dnsZoneGroupReconciler async.Reconciler
+}
+
+// New creates a new service.
+func New(scope PrivateEndpointScope) *Service {
+	client := newClient(scope)
+	dnsZoneGroupClient := newPrivateDNSZoneGroupsClient(scope)
+	tagsClient := tags.NewClient(scope)
+	return &Service{
+		Scope:                  scope,
+		TagsGetter:             tagsClient,
+		Reconciler:             async.New(scope, client, client),
+		client:                 client,
+		dnsZoneGroupClient:     dnsZoneGroupClient,
+		dnsZoneGroupReconciler: async.New(scope, dnsZoneGroupClient, dnsZoneGroupClient),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the private endpoints and their private DNS zone groups, and deletes
+// the private endpoints and private DNS zone groups managed by CAPZ that were removed from the spec.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PrivateEndpointSpecs()
+	groupSpecs := s.Scope.PrivateDNSZoneGroupSpecs()
+
+	var result error
+	if len(specs) > 0 {
+		// We reconcile the private endpoints concurrently, each one independently of the result of the others.
+		// If multiple errors occur, we return the most pressing one.
+		_, result = async.CreateOrUpdateResources(ctx, s.Reconciler, specs, ServiceName)
+
+		// The private DNS zone groups are child resources of the private endpoints, which must exist first.
+		if result == nil && len(groupSpecs) > 0 {
+			_, result = async.CreateOrUpdateResources(ctx, s.dnsZoneGroupReconciler, groupSpecs, ServiceName)
+		}
+	}
+
+	// The private endpoints and private DNS zone groups removed from the spec are only deleted once the others are up to date.
+	removed := false
+	if result == nil {
+		removed, result = s.deleteRemoved(ctx, specs, groupSpecs)
+	}
+
+	if len(specs) == 0 && !removed && result == nil {
+		return nil
+	}
+	s.Scope.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, result)
+	return result
+}
+
+// deleteRemoved deletes the private endpoints owned by the cluster that are no longer in the spec, and the private DNS
+// zone groups that are no longer in the spec from the private endpoints owned by the cluster. Private endpoints that
+// are not owned by the cluster are never modified. It returns true if any resource had to be deleted.
+func (s *Service) deleteRemoved(ctx context.Context, specs, groupSpecs []azure.ResourceSpecGetter) (bool, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.deleteRemoved")
+	defer done()
+
+	existingEndpoints, err := s.client.List(ctx, s.Scope.ResourceGroup())
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list private endpoints")
+	}
+
+	wantedEndpoints := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		wantedEndpoints[strings.ToLower(spec.ResourceName())] = true
+	}
+	wantedGroups := make(map[string]bool, len(groupSpecs))
+	for _, spec := range groupSpecs {
+		wantedGroups[zoneGroupKey(spec.OwnerResourceName(), spec.ResourceName())] = true
+	}
+
+	var removedEndpoints, removedGroups []azure.ResourceSpecGetter
+	for _, existing := range existingEndpoints {
+		name := to.String(existing.Name)
+		if !converters.MapToTags(existing.Tags).HasOwned(s.Scope.ClusterName()) {
+			continue
+		}
+		if !wantedEndpoints[strings.ToLower(name)] {
+			// Deleting a private endpoint also deletes its private DNS zone groups.
+			removedEndpoints = append(removedEndpoints, &PrivateEndpointSpec{
+				Name:          name,
+				ResourceGroup: s.Scope.ResourceGroup(),
+				ClusterName:   s.Scope.ClusterName(),
+			})
+			continue
+		}
+
+		existingGroups, err := s.dnsZoneGroupClient.List(ctx, s.Scope.ResourceGroup(), name)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to list private DNS zone groups of private endpoint %s", name)
+		}
+		for _, group := range existingGroups {
+			if !wantedGroups[zoneGroupKey(name, to.String(group.Name))] {
+				removedGroups = append(removedGroups, &PrivateDNSZoneGroupSpec{
+					Name:                to.String(group.Name),
+					PrivateEndpointName: name,
+					ResourceGroup:       s.Scope.ResourceGroup(),
+				})
+			}
+		}
+	}
+
+	if len(removedEndpoints) == 0 && len(removedGroups) == 0 {
+		return false, nil
+	}
+
+	log.V(2).Info("deleting private endpoints and private DNS zone groups removed from the spec", "private endpoints", len(removedEndpoints), "private DNS zone groups", len(removedGroups))
+	var errs []error
+	if len(removedGroups) > 0 {
+		errs = append(errs, async.DeleteResources(ctx, s.dnsZoneGroupReconciler, removedGroups, ServiceName))
+	}
+	if len(removedEndpoints) > 0 {
+		errs = append(errs, async.DeleteResources(ctx, s.Reconciler, removedEndpoints, ServiceName))
+	}
+	return true, async.MostPressingError(errs)
+}
+
+// zoneGroupKey returns a key identifying a private DNS zone group of a private endpoint.
+func zoneGroupKey(privateEndpointName, groupName string) string {
+	return strings.ToLower(privateEndpointName + "/" + groupName)
+}
+
+// Delete deletes the private endpoints managed by CAPZ, along with their private DNS zone groups.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PrivateEndpointSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var managedSpecs []azure.ResourceSpecGetter
+	for _, privateEndpointSpec := range specs {
+		managed, err := s.isPrivateEndpointManaged(ctx, privateEndpointSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrap(err, "could not get private endpoint management state")
+		}
+
+		if !managed {
+			log.V(2).Info("Skipping private endpoint deletion for unmanaged private endpoint", "private endpoint", privateEndpointSpec.ResourceName())
+			continue
+		}
+		managedSpecs = append(managedSpecs, privateEndpointSpec)
+	}
+
+	if len(managedSpecs) == 0 {
+		return nil
+	}
+
+	// We delete the managed private endpoints concurrently, each one independently of the result of the others.
+	// Deleting a private endpoint also deletes its private DNS zone groups.
+	// If multiple errors occur, we return the most pressing one.
+	log.V(2).Info("deleting private endpoints", "count", len(managedSpecs))
+	result := async.DeleteResources(ctx, s.Reconciler, managedSpecs, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, result)
+
+	return result
+}
+
+// isPrivateEndpointManaged returns true if the private endpoint has an owned tag with the cluster name as value,
+// meaning that the private endpoint's lifecycle is managed.
+func (s *Service) isPrivateEndpointManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
+	scope := azure.PrivateEndpointID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName())
+	result, err := s.TagsGetter.GetAtScope(ctx, scope)
+	if err != nil {
+		return false, err
+	}
+
+	tagsMap := make(map[string]*string)
+	if result.Properties != nil && result.Properties.Tags != nil {
+		tagsMap = result.Properties.Tags
+	}
+
+	tags := converters.MapToTags(tagsMap)
+	return tags.HasOwned(s.Scope.ClusterName()), nil
+}
+
+// IsManaged returns always returns true as private endpoints are managed on a one-by-one basis.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
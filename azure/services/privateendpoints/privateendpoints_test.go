@@ -0,0 +1,325 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/scheme"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints/mock_privateendpoints"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+}
+
+var (
+	fakePrivateEndpointSpec1 = PrivateEndpointSpec{
+		Name:                 "my-storage-pe",
+		ResourceGroup:        "my-rg",
+		Location:             "westus",
+		ClusterName:          "my-cluster",
+		SubnetID:             "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/node-subnet",
+		PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+		GroupIDs:             []string{"blob"},
+	}
+	fakePrivateEndpointSpec2 = PrivateEndpointSpec{
+		Name:                 "my-vault-pe",
+		ResourceGroup:        "my-rg",
+		Location:             "westus",
+		ClusterName:          "my-cluster",
+		SubnetID:             "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/node-subnet",
+		PrivateLinkServiceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.KeyVault/vaults/myvault",
+		GroupIDs:             []string{"vault"},
+		ManualApproval:       true,
+	}
+	fakePrivateDNSZoneGroupSpec = PrivateDNSZoneGroupSpec{
+		Name:                "default",
+		PrivateEndpointName: "my-storage-pe",
+		ResourceGroup:       "my-rg",
+		PrivateDNSZoneIDs:   []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"},
+	}
+
+	managedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+			},
+		},
+	}
+
+	unmanagedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"foo": to.StringPtr("bar"),
+			},
+		},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+)
+
+func TestReconcilePrivateEndpoints(t *testing.T) {
+	ownedEndpoint := func(name string) network.PrivateEndpoint {
+		return network.PrivateEndpoint{
+			Name: to.StringPtr(name),
+			Tags: map[string]*string{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned")},
+		}
+	}
+	removedEndpointSpec := &PrivateEndpointSpec{Name: "my-old-pe", ResourceGroup: "my-rg", ClusterName: "my-cluster"}
+	removedGroupSpec := &PrivateDNSZoneGroupSpec{Name: "old", PrivateEndpointName: "my-storage-pe", ResourceGroup: "my-rg"}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, c *mock_privateendpoints.MockclientMockRecorder, gc *mock_privateendpoints.MockdnsZoneGroupClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no private endpoints",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, c *mock_privateendpoints.MockclientMockRecorder, gc *mock_privateendpoints.MockdnsZoneGroupClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{})
+				s.PrivateDNSZoneGroupSpecs().Return([]azure.ResourceSpecGetter{})
+				c.List(gomockinternal.AContext(), "my-rg").Return([]network.PrivateEndpoint{{Name: to.StringPtr("unmanaged-pe")}}, nil)
+			},
+		},
+		{
+			name:          "successfully create private endpoints and private DNS zone groups",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, c *mock_privateendpoints.MockclientMockRecorder, gc *mock_privateendpoints.MockdnsZoneGroupClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointSpec1, &fakePrivateEndpointSpec2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointSpec1, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointSpec2, ServiceName).Return(nil, nil)
+				s.PrivateDNSZoneGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateDNSZoneGroupSpec})
+				z.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateDNSZoneGroupSpec, ServiceName).Return(nil, nil)
+				c.List(gomockinternal.AContext(), "my-rg").Return([]network.PrivateEndpoint{ownedEndpoint("my-storage-pe"), ownedEndpoint("my-vault-pe")}, nil)
+				gc.List(gomockinternal.AContext(), "my-rg", "my-storage-pe").Return([]network.PrivateDNSZoneGroup{{Name: to.StringPtr("default")}}, nil)
+				gc.List(gomockinternal.AContext(), "my-rg", "my-vault-pe").Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "delete the owned private endpoints and the private DNS zone groups removed from the spec",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, c *mock_privateendpoints.MockclientMockRecorder, gc *mock_privateendpoints.MockdnsZoneGroupClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointSpec1, ServiceName).Return(nil, nil)
+				s.PrivateDNSZoneGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateDNSZoneGroupSpec})
+				z.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateDNSZoneGroupSpec, ServiceName).Return(nil, nil)
+				c.List(gomockinternal.AContext(), "my-rg").Return([]network.PrivateEndpoint{ownedEndpoint("my-storage-pe"), ownedEndpoint("my-old-pe"), {Name: to.StringPtr("unmanaged-pe")}}, nil)
+				gc.List(gomockinternal.AContext(), "my-rg", "my-storage-pe").Return([]network.PrivateDNSZoneGroup{{Name: to.StringPtr("default")}, {Name: to.StringPtr("old")}}, nil)
+				z.DeleteResource(gomockinternal.AContext(), removedGroupSpec, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), removedEndpointSpec, ServiceName).Return(nil)
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "delete the owned private endpoints when all private endpoints are removed from the spec",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, c *mock_privateendpoints.MockclientMockRecorder, gc *mock_privateendpoints.MockdnsZoneGroupClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{})
+				s.PrivateDNSZoneGroupSpecs().Return([]azure.ResourceSpecGetter{})
+				c.List(gomockinternal.AContext(), "my-rg").Return([]network.PrivateEndpoint{ownedEndpoint("my-old-pe")}, nil)
+				r.DeleteResource(gomockinternal.AContext(), removedEndpointSpec, ServiceName).Return(internalError)
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+			},
+		},
+		{
+			name:          "fail to list the private endpoints",
+			expectedError: "failed to list private endpoints: " + internalError.Error(),
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, c *mock_privateendpoints.MockclientMockRecorder, gc *mock_privateendpoints.MockdnsZoneGroupClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointSpec1, ServiceName).Return(nil, nil)
+				s.PrivateDNSZoneGroupSpecs().Return([]azure.ResourceSpecGetter{})
+				c.List(gomockinternal.AContext(), "my-rg").Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, gomockinternal.ErrStrEq("failed to list private endpoints: "+internalError.Error()))
+			},
+		},
+		{
+			name:          "private DNS zone groups are not created when a private endpoint fails to be created",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, c *mock_privateendpoints.MockclientMockRecorder, gc *mock_privateendpoints.MockdnsZoneGroupClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointSpec1, &fakePrivateEndpointSpec2})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointSpec1, ServiceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointSpec2, ServiceName).Return(nil, nil)
+				s.PrivateDNSZoneGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateDNSZoneGroupSpec})
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+			},
+		},
+		{
+			name:          "fail to create a private DNS zone group",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, c *mock_privateendpoints.MockclientMockRecorder, gc *mock_privateendpoints.MockdnsZoneGroupClientMockRecorder, r *mock_async.MockReconcilerMockRecorder, z *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpointSpec1, ServiceName).Return(nil, nil)
+				s.PrivateDNSZoneGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateDNSZoneGroupSpec})
+				z.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateDNSZoneGroupSpec, ServiceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_privateendpoints.NewMockPrivateEndpointScope(mockCtrl)
+			clientMock := mock_privateendpoints.NewMockclient(mockCtrl)
+			dnsZoneGroupClientMock := mock_privateendpoints.NewMockdnsZoneGroupClient(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			dnsZoneGroupReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			scopeMock.EXPECT().ResourceGroup().Return("my-rg").AnyTimes()
+			scopeMock.EXPECT().ClusterName().Return("my-cluster").AnyTimes()
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), dnsZoneGroupClientMock.EXPECT(), reconcilerMock.EXPECT(), dnsZoneGroupReconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:                  scopeMock,
+				Reconciler:             reconcilerMock,
+				client:                 clientMock,
+				dnsZoneGroupClient:     dnsZoneGroupClientMock,
+				dnsZoneGroupReconciler: dnsZoneGroupReconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePrivateEndpoints(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no private endpoints",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "successfully delete managed private endpoints and ignore unmanaged private endpoints",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointSpec1, &fakePrivateEndpointSpec2})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PrivateEndpointID("123", fakePrivateEndpointSpec1.ResourceGroupName(), fakePrivateEndpointSpec1.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpointSpec1, ServiceName).Return(nil)
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PrivateEndpointID("123", fakePrivateEndpointSpec2.ResourceGroupName(), fakePrivateEndpointSpec2.ResourceName())).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				s.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "noop if the private endpoints do not exist",
+			expectedError: "",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointSpec1})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PrivateEndpointID("123", fakePrivateEndpointSpec1.ResourceGroupName(), fakePrivateEndpointSpec1.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+			},
+		},
+		{
+			name:          "fail to get the management state of a private endpoint",
+			expectedError: "could not get private endpoint management state: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointSpec1})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PrivateEndpointID("123", fakePrivateEndpointSpec1.ResourceGroupName(), fakePrivateEndpointSpec1.ResourceName())).Return(resources.TagsResource{}, internalError)
+			},
+		},
+		{
+			name:          "fail to delete a managed private endpoint",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpointSpec1})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PrivateEndpointID("123", fakePrivateEndpointSpec1.ResourceGroupName(), fakePrivateEndpointSpec1.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpointSpec1, ServiceName).Return(internalError)
+
+				s.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_privateendpoints.NewMockPrivateEndpointScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				TagsGetter: tagsGetterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
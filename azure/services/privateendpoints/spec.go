@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// PrivateEndpointSpec defines the specification for a private endpoint.
+type PrivateEndpointSpec struct {
+	Name                       string
+	ResourceGroup              string
+	Location                   string
+	ClusterName                string
+	SubnetID                   string
+	PrivateLinkServiceID       string
+	GroupIDs                   []string
+	ManualApproval             bool
+	RequestMessage             string
+	CustomNetworkInterfaceName string
+	AdditionalTags             infrav1.Tags
+}
+
+// ResourceName returns the name of the private endpoint.
+func (s *PrivateEndpointSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PrivateEndpointSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for private endpoints.
+func (s *PrivateEndpointSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the private endpoint.
+func (s *PrivateEndpointSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        to.StringPtr(s.Name),
+		Additional:  s.AdditionalTags,
+	})
+
+	if existing != nil {
+		existingEndpoint, ok := existing.(network.PrivateEndpoint)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.PrivateEndpoint", existing)
+		}
+		if !converters.MapToTags(existingEndpoint.Tags).HasOwned(s.ClusterName) {
+			// Skip update for a private endpoint that was not created by CAPZ.
+			return nil, nil
+		}
+		if s.isUpToDate(existingEndpoint, tags) {
+			// Skip update for the private endpoint as its connection and tags are up to date.
+			return nil, nil
+		}
+		// The subnet and the custom network interface name of a private endpoint are immutable, the update only
+		// changes the private link service connection and the tags. Tags added outside of CAPZ are kept.
+		existingTags := converters.MapToTags(existingEndpoint.Tags)
+		existingTags.Merge(tags)
+		tags = existingTags
+	}
+
+	connection := network.PrivateLinkServiceConnection{
+		Name: to.StringPtr(s.Name),
+		PrivateLinkServiceConnectionProperties: &network.PrivateLinkServiceConnectionProperties{
+			PrivateLinkServiceID: to.StringPtr(s.PrivateLinkServiceID),
+		},
+	}
+	if len(s.GroupIDs) > 0 {
+		groupIDs := s.GroupIDs
+		connection.GroupIds = &groupIDs
+	}
+	if s.RequestMessage != "" {
+		connection.RequestMessage = to.StringPtr(s.RequestMessage)
+	}
+
+	properties := &network.PrivateEndpointProperties{
+		Subnet: &network.Subnet{
+			ID: to.StringPtr(s.SubnetID),
+		},
+	}
+	// Connections that require an approval from the owner of the target resource are declared separately.
+	if s.ManualApproval {
+		properties.ManualPrivateLinkServiceConnections = &[]network.PrivateLinkServiceConnection{connection}
+	} else {
+		properties.PrivateLinkServiceConnections = &[]network.PrivateLinkServiceConnection{connection}
+	}
+	if s.CustomNetworkInterfaceName != "" {
+		properties.CustomNetworkInterfaceName = to.StringPtr(s.CustomNetworkInterfaceName)
+	}
+
+	return network.PrivateEndpoint{
+		Name:                      to.StringPtr(s.Name),
+		Location:                  to.StringPtr(s.Location),
+		PrivateEndpointProperties: properties,
+		Tags:                      converters.TagsToMap(tags),
+	}, nil
+}
+
+// isUpToDate returns true if the existing private endpoint has the private link service connection and the tags of the spec.
+func (s *PrivateEndpointSpec) isUpToDate(existing network.PrivateEndpoint, tags infrav1.Tags) bool {
+	existingTags := converters.MapToTags(existing.Tags)
+	for key, value := range tags {
+		if existingValue, ok := existingTags[key]; !ok || existingValue != value {
+			return false
+		}
+	}
+
+	if existing.PrivateEndpointProperties == nil {
+		return false
+	}
+	connections, otherConnections := existing.PrivateLinkServiceConnections, existing.ManualPrivateLinkServiceConnections
+	if s.ManualApproval {
+		connections, otherConnections = otherConnections, connections
+	}
+	if otherConnections != nil && len(*otherConnections) > 0 {
+		// The connection switched between automatic and manual approval.
+		return false
+	}
+	if connections == nil || len(*connections) != 1 {
+		return false
+	}
+
+	connection := (*connections)[0]
+	if connection.PrivateLinkServiceConnectionProperties == nil {
+		return false
+	}
+	if !strings.EqualFold(to.String(connection.PrivateLinkServiceID), s.PrivateLinkServiceID) {
+		return false
+	}
+	if to.String(connection.RequestMessage) != s.RequestMessage {
+		return false
+	}
+
+	var existingGroupIDs []string
+	if connection.GroupIds != nil {
+		existingGroupIDs = *connection.GroupIds
+	}
+	if len(existingGroupIDs) != len(s.GroupIDs) {
+		return false
+	}
+	for i := range s.GroupIDs {
+		if !strings.EqualFold(existingGroupIDs[i], s.GroupIDs[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privateendpoints
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *PrivateEndpointSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "private endpoint does not exist",
+			spec:     &fakePrivateEndpointSpec1,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PrivateEndpoint{
+					Name:     to.StringPtr("my-storage-pe"),
+					Location: to.StringPtr("westus"),
+					PrivateEndpointProperties: &network.PrivateEndpointProperties{
+						Subnet: &network.Subnet{
+							ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/node-subnet"),
+						},
+						PrivateLinkServiceConnections: &[]network.PrivateLinkServiceConnection{
+							{
+								Name: to.StringPtr("my-storage-pe"),
+								PrivateLinkServiceConnectionProperties: &network.PrivateLinkServiceConnectionProperties{
+									PrivateLinkServiceID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage"),
+									GroupIds:             &[]string{"blob"},
+								},
+							},
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("my-storage-pe"),
+					},
+				}))
+			},
+		},
+		{
+			name: "private endpoint with manual approval and custom network interface name does not exist",
+			spec: &PrivateEndpointSpec{
+				Name:                       "my-vault-pe",
+				ResourceGroup:              "my-rg",
+				Location:                   "westus",
+				ClusterName:                "my-cluster",
+				SubnetID:                   "my-subnet-id",
+				PrivateLinkServiceID:       "my-vault-id",
+				GroupIDs:                   []string{"vault"},
+				ManualApproval:             true,
+				RequestMessage:             "please approve",
+				CustomNetworkInterfaceName: "my-vault-pe-nic",
+				AdditionalTags:             map[string]string{"foo": "bar"},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PrivateEndpoint{
+					Name:     to.StringPtr("my-vault-pe"),
+					Location: to.StringPtr("westus"),
+					PrivateEndpointProperties: &network.PrivateEndpointProperties{
+						Subnet: &network.Subnet{
+							ID: to.StringPtr("my-subnet-id"),
+						},
+						ManualPrivateLinkServiceConnections: &[]network.PrivateLinkServiceConnection{
+							{
+								Name: to.StringPtr("my-vault-pe"),
+								PrivateLinkServiceConnectionProperties: &network.PrivateLinkServiceConnectionProperties{
+									PrivateLinkServiceID: to.StringPtr("my-vault-id"),
+									GroupIds:             &[]string{"vault"},
+									RequestMessage:       to.StringPtr("please approve"),
+								},
+							},
+						},
+						CustomNetworkInterfaceName: to.StringPtr("my-vault-pe-nic"),
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("my-vault-pe"),
+						"foo":  to.StringPtr("bar"),
+					},
+				}))
+			},
+		},
+		{
+			name:     "private endpoint is up to date",
+			spec:     &fakePrivateEndpointSpec1,
+			existing: existingStoragePrivateEndpoint(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "private endpoint with a changed private link service is updated and keeps the tags added outside of CAPZ",
+			spec: &fakePrivateEndpointSpec1,
+			existing: func() network.PrivateEndpoint {
+				existing := existingStoragePrivateEndpoint()
+				(*existing.PrivateLinkServiceConnections)[0].PrivateLinkServiceID = to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/oldstorage")
+				existing.Tags["foo"] = to.StringPtr("bar")
+				return existing
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.PrivateEndpoint{}))
+				endpoint := result.(network.PrivateEndpoint)
+				g.Expect(*endpoint.PrivateLinkServiceConnections).To(HaveLen(1))
+				g.Expect((*endpoint.PrivateLinkServiceConnections)[0].PrivateLinkServiceID).To(Equal(to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage")))
+				g.Expect(endpoint.Tags).To(HaveKeyWithValue("foo", to.StringPtr("bar")))
+				g.Expect(endpoint.Tags).To(HaveKeyWithValue("sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster", to.StringPtr("owned")))
+			},
+		},
+		{
+			name: "private endpoint with changed group IDs is updated",
+			spec: &fakePrivateEndpointSpec1,
+			existing: func() network.PrivateEndpoint {
+				existing := existingStoragePrivateEndpoint()
+				(*existing.PrivateLinkServiceConnections)[0].GroupIds = &[]string{"file"}
+				return existing
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.PrivateEndpoint{}))
+				endpoint := result.(network.PrivateEndpoint)
+				g.Expect((*endpoint.PrivateLinkServiceConnections)[0].GroupIds).To(Equal(&[]string{"blob"}))
+			},
+		},
+		{
+			name: "private endpoint switched to manual approval is updated",
+			spec: func() *PrivateEndpointSpec {
+				spec := fakePrivateEndpointSpec1
+				spec.ManualApproval = true
+				return &spec
+			}(),
+			existing: existingStoragePrivateEndpoint(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.PrivateEndpoint{}))
+				endpoint := result.(network.PrivateEndpoint)
+				g.Expect(endpoint.PrivateLinkServiceConnections).To(BeNil())
+				g.Expect(*endpoint.ManualPrivateLinkServiceConnections).To(HaveLen(1))
+			},
+		},
+		{
+			name: "private endpoint not owned by the cluster is not updated",
+			spec: &fakePrivateEndpointSpec1,
+			existing: func() network.PrivateEndpoint {
+				existing := existingStoragePrivateEndpoint()
+				existing.Tags = map[string]*string{"foo": to.StringPtr("bar")}
+				(*existing.PrivateLinkServiceConnections)[0].GroupIds = &[]string{"file"}
+				return existing
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not a private endpoint",
+			spec:          &fakePrivateEndpointSpec1,
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.PrivateEndpoint",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+// existingStoragePrivateEndpoint returns the private endpoint of fakePrivateEndpointSpec1 as returned by Azure.
+func existingStoragePrivateEndpoint() network.PrivateEndpoint {
+	return network.PrivateEndpoint{
+		Name:     to.StringPtr("my-storage-pe"),
+		Location: to.StringPtr("westus"),
+		PrivateEndpointProperties: &network.PrivateEndpointProperties{
+			Subnet: &network.Subnet{
+				ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/node-subnet"),
+			},
+			PrivateLinkServiceConnections: &[]network.PrivateLinkServiceConnection{
+				{
+					Name: to.StringPtr("my-storage-pe"),
+					PrivateLinkServiceConnectionProperties: &network.PrivateLinkServiceConnectionProperties{
+						PrivateLinkServiceID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage"),
+						GroupIds:             &[]string{"blob"},
+					},
+				},
+			},
+			ManualPrivateLinkServiceConnections: &[]network.PrivateLinkServiceConnection{},
+		},
+		Tags: map[string]*string{
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+			"Name": to.StringPtr("my-storage-pe"),
+		},
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "publicipprefixes"
+
+// PublicIPPrefixScope defines the scope interface for a public IP prefix service.
+type PublicIPPrefixScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	azure.ClusterDescriber
+	PublicIPPrefixSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PublicIPPrefixScope
+	async.Reconciler
+	async.TagsGetter
+}
+
+// New creates a new service.
+func New(scope PublicIPPrefixScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		TagsGetter: tags.NewClient(scope),
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile idempotently creates or updates the public IP prefixes.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PublicIPPrefixSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// We reconcile the public IP prefixes concurrently, each one independently of the result of the others.
+	// If multiple errors occur, we return the most pressing one.
+	_, result := async.CreateOrUpdateResources(ctx, s.Reconciler, specs, ServiceName)
+
+	s.Scope.UpdatePutStatus(infrav1.PublicIPPrefixesReadyCondition, ServiceName, result)
+	return result
+}
+
+// Delete deletes the public IP prefixes managed by CAPZ.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PublicIPPrefixSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var managedSpecs []azure.ResourceSpecGetter
+	for _, prefixSpec := range specs {
+		managed, err := s.isPublicIPPrefixManaged(ctx, prefixSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrap(err, "could not get public IP prefix management state")
+		}
+
+		if !managed {
+			log.V(2).Info("Skipping public IP prefix deletion for unmanaged public IP prefix", "public IP prefix", prefixSpec.ResourceName())
+			continue
+		}
+		managedSpecs = append(managedSpecs, prefixSpec)
+	}
+
+	if len(managedSpecs) == 0 {
+		return nil
+	}
+
+	// We delete the managed public IP prefixes concurrently, each one independently of the result of the others.
+	// If multiple errors occur, we return the most pressing one.
+	log.V(2).Info("deleting public IP prefixes", "count", len(managedSpecs))
+	result := async.DeleteResources(ctx, s.Reconciler, managedSpecs, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.PublicIPPrefixesReadyCondition, ServiceName, result)
+
+	return result
+}
+
+// isPublicIPPrefixManaged returns true if the public IP prefix has an owned tag with the cluster name as value,
+// meaning that the public IP prefix's lifecycle is managed.
+func (s *Service) isPublicIPPrefixManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
+	scope := azure.PublicIPPrefixID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName())
+	result, err := s.TagsGetter.GetAtScope(ctx, scope)
+	if err != nil {
+		return false, err
+	}
+
+	tagsMap := make(map[string]*string)
+	if result.Properties != nil && result.Properties.Tags != nil {
+		tagsMap = result.Properties.Tags
+	}
+
+	tags := converters.MapToTags(tagsMap)
+	return tags.HasOwned(s.Scope.ClusterName()), nil
+}
+
+// IsManaged returns always returns true as public IP prefixes are managed on a one-by-one basis.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
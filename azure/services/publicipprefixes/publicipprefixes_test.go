@@ -0,0 +1,238 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/scheme"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes/mock_publicipprefixes"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+}
+
+var (
+	fakeOutboundPrefixSpec = PublicIPPrefixSpec{
+		Name:          "my-cluster-outbound-prefix",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+	fakeNodePrefixSpec = PublicIPPrefixSpec{
+		Name:          "my-cluster-node-prefix",
+		ResourceGroup: "my-rg",
+		Location:      "westus",
+		ClusterName:   "my-cluster",
+	}
+
+	managedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+			},
+		},
+	}
+
+	unmanagedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"foo": to.StringPtr("bar"),
+			},
+		},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+)
+
+func TestReconcilePublicIPPrefixes(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "successfully create public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakeOutboundPrefixSpec, &fakeNodePrefixSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeOutboundPrefixSpec, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodePrefixSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PublicIPPrefixesReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "fail to create a public IP prefix",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakeOutboundPrefixSpec, &fakeNodePrefixSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeOutboundPrefixSpec, ServiceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodePrefixSpec, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PublicIPPrefixesReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_publicipprefixes.NewMockPublicIPPrefixScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePublicIPPrefixes(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "successfully delete managed public IP prefixes and ignore unmanaged public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakeOutboundPrefixSpec, &fakeNodePrefixSpec})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakeOutboundPrefixSpec.ResourceGroupName(), fakeOutboundPrefixSpec.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeOutboundPrefixSpec, ServiceName).Return(nil)
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakeNodePrefixSpec.ResourceGroupName(), fakeNodePrefixSpec.ResourceName())).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				s.UpdateDeleteStatus(infrav1.PublicIPPrefixesReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "noop if the public IP prefixes do not exist",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakeOutboundPrefixSpec})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakeOutboundPrefixSpec.ResourceGroupName(), fakeOutboundPrefixSpec.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+			},
+		},
+		{
+			name:          "fail to get the management state of a public IP prefix",
+			expectedError: "could not get public IP prefix management state: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakeOutboundPrefixSpec})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakeOutboundPrefixSpec.ResourceGroupName(), fakeOutboundPrefixSpec.ResourceName())).Return(resources.TagsResource{}, internalError)
+			},
+		},
+		{
+			name:          "fail to delete a managed public IP prefix",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakeOutboundPrefixSpec})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakeOutboundPrefixSpec.ResourceGroupName(), fakeOutboundPrefixSpec.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+				r.DeleteResource(gomockinternal.AContext(), &fakeOutboundPrefixSpec, ServiceName).Return(internalError)
+
+				s.UpdateDeleteStatus(infrav1.PublicIPPrefixesReadyCondition, ServiceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_publicipprefixes.NewMockPublicIPPrefixScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				TagsGetter: tagsGetterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// PublicIPPrefixSpec defines the specification for a public IP prefix.
+type PublicIPPrefixSpec struct {
+	Name           string
+	ResourceGroup  string
+	Location       string
+	ClusterName    string
+	PrefixLength   int32
+	FailureDomains []string
+	Zones          []string
+	Tier           infrav1.PublicIPTier
+	IsIPv6         bool
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the public IP prefix.
+func (s *PublicIPPrefixSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PublicIPPrefixSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for public IP prefixes.
+func (s *PublicIPPrefixSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the public IP prefix.
+func (s *PublicIPPrefixSpec) Parameters(existing interface{}) (parameters interface{}, err error) {
+	if existing != nil {
+		if _, ok := existing.(network.PublicIPPrefix); !ok {
+			return nil, errors.Errorf("%T is not a network.PublicIPPrefix", existing)
+		}
+		// public IP prefix already exists
+		return nil, nil
+	}
+
+	addressVersion := network.IPVersionIPv4
+	if s.IsIPv6 {
+		addressVersion = network.IPVersionIPv6
+	}
+
+	var tier network.PublicIPPrefixSkuTier
+	zones := s.FailureDomains
+	if len(s.Zones) > 0 {
+		zones = s.Zones
+	}
+	if s.Tier == infrav1.PublicIPTierGlobal {
+		// global public IP prefixes are not zonal
+		tier = network.PublicIPPrefixSkuTierGlobal
+		zones = nil
+	}
+
+	return network.PublicIPPrefix{
+		Name:     to.StringPtr(s.Name),
+		Location: to.StringPtr(s.Location),
+		Sku:      &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard, Tier: tier},
+		PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+			PrefixLength:           to.Int32Ptr(s.PrefixLength),
+			PublicIPAddressVersion: addressVersion,
+		},
+		Zones: to.StringSlicePtr(zones),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        to.StringPtr(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *PublicIPPrefixSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "public IP prefix does not exist",
+			spec: &PublicIPPrefixSpec{
+				Name:           "my-cluster-outbound-prefix",
+				ResourceGroup:  "my-rg",
+				Location:       "westus",
+				ClusterName:    "my-cluster",
+				PrefixLength:   30,
+				FailureDomains: []string{"1", "2", "3"},
+				AdditionalTags: map[string]string{"foo": "bar"},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PublicIPPrefix{
+					Name:     to.StringPtr("my-cluster-outbound-prefix"),
+					Location: to.StringPtr("westus"),
+					Sku:      &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard},
+					PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+						PrefixLength:           to.Int32Ptr(30),
+						PublicIPAddressVersion: network.IPVersionIPv4,
+					},
+					Zones: to.StringSlicePtr([]string{"1", "2", "3"}),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("my-cluster-outbound-prefix"),
+						"foo":  to.StringPtr("bar"),
+					},
+				}))
+			},
+		},
+		{
+			name: "global IPv6 public IP prefix does not exist",
+			spec: &PublicIPPrefixSpec{
+				Name:           "my-cluster-outbound-prefix",
+				ResourceGroup:  "my-rg",
+				Location:       "westus",
+				ClusterName:    "my-cluster",
+				PrefixLength:   127,
+				FailureDomains: []string{"1", "2", "3"},
+				Tier:           infrav1.PublicIPTierGlobal,
+				IsIPv6:         true,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PublicIPPrefix{
+					Name:     to.StringPtr("my-cluster-outbound-prefix"),
+					Location: to.StringPtr("westus"),
+					Sku:      &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard, Tier: network.PublicIPPrefixSkuTierGlobal},
+					PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+						PrefixLength:           to.Int32Ptr(127),
+						PublicIPAddressVersion: network.IPVersionIPv6,
+					},
+					Zones: to.StringSlicePtr(nil),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("my-cluster-outbound-prefix"),
+					},
+				}))
+			},
+		},
+		{
+			name: "zonal public IP prefix does not exist",
+			spec: &PublicIPPrefixSpec{
+				Name:           "my-cluster-outbound-prefix",
+				ResourceGroup:  "my-rg",
+				Location:       "westus",
+				ClusterName:    "my-cluster",
+				PrefixLength:   31,
+				FailureDomains: []string{"1", "2", "3"},
+				Zones:          []string{"1"},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.PublicIPPrefix{
+					Name:     to.StringPtr("my-cluster-outbound-prefix"),
+					Location: to.StringPtr("westus"),
+					Sku:      &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard},
+					PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+						PrefixLength:           to.Int32Ptr(31),
+						PublicIPAddressVersion: network.IPVersionIPv4,
+					},
+					Zones: to.StringSlicePtr([]string{"1"}),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("my-cluster-outbound-prefix"),
+					},
+				}))
+			},
+		},
+		{
+			name:     "public IP prefix already exists",
+			spec:     &fakeOutboundPrefixSpec,
+			existing: network.PublicIPPrefix{Name: to.StringPtr("my-cluster-outbound-prefix")},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:          "existing is not a public IP prefix",
+			spec:          &fakeOutboundPrefixSpec,
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.PublicIPPrefix",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -28,7 +28,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "publicips"
+// ServiceName is the name of this service.
+const ServiceName = "publicips"
 
 // PublicIPScope defines the scope interface for a public IP service.
 type PublicIPScope interface {
@@ -60,7 +61,7 @@ func New(scope PublicIPScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a public IP.
@@ -73,19 +74,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of PublicIPSpecs to reconcile each one, independently of the result of the previous one.
+	// We reconcile the PublicIPSpecs concurrently, each one independently of the result of the others.
 	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var result error
-	for _, publicIPSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, publicIPSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
+	_, result := async.CreateOrUpdateResources(ctx, s.Reconciler, specs, ServiceName)
 
-	s.Scope.UpdatePutStatus(infrav1.PublicIPsReadyCondition, serviceName, result)
+	s.Scope.UpdatePutStatus(infrav1.PublicIPsReadyCondition, ServiceName, result)
 	return result
 }
 
@@ -99,12 +92,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	hasManagedPublicIPs := false
-
-	// We go through the list of VnetPeeringSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	var result error
+	var managedSpecs []azure.ResourceSpecGetter
 	for _, publicIPSpec := range specs {
 		managed, err := s.isIPManaged(ctx, publicIPSpec)
 		if err != nil && !azure.ResourceNotFound(err) {
@@ -115,22 +103,19 @@ func (s *Service) Delete(ctx context.Context) error {
 			log.V(2).Info("Skipping IP deletion for unmanaged public IP", "public ip", publicIPSpec.ResourceName())
 			continue
 		}
-
-		log.V(2).Info("deleting public IP", "public ip", publicIPSpec.ResourceName())
-		hasManagedPublicIPs = true
-		if err := s.DeleteResource(ctx, publicIPSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-
-		log.V(2).Info("deleted public IP", "public ip", publicIPSpec.ResourceName())
+		managedSpecs = append(managedSpecs, publicIPSpec)
 	}
 
-	if hasManagedPublicIPs {
-		s.Scope.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, serviceName, result)
+	if len(managedSpecs) == 0 {
+		return nil
 	}
 
+	// We delete the managed public IPs concurrently, each one independently of the result of the others.
+	// If multiple errors occur, we return the most pressing one.
+	log.V(2).Info("deleting public IPs", "count", len(managedSpecs))
+	result := async.DeleteResources(ctx, s.Reconciler, managedSpecs, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, ServiceName, result)
+
 	return result
 }
 
@@ -133,11 +133,11 @@ func TestReconcilePublicIP(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.PublicIPSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPSpec1, &fakePublicIPSpec2, &fakePublicIPSpec3, &fakePublicIPSpecIpv6})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec1, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec3, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec1, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec3, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -145,11 +145,11 @@ func TestReconcilePublicIP(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_publicips.MockPublicIPScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.PublicIPSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPSpec1, &fakePublicIPSpec2, &fakePublicIPSpec3, &fakePublicIPSpecIpv6})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec1, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec3, serviceName).Return(nil, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec1, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpec3, ServiceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
@@ -208,12 +208,12 @@ func TestDeletePublicIP(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPID("123", fakePublicIPSpec1.ResourceGroupName(), fakePublicIPSpec1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec1, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPID("123", fakePublicIPSpec2.ResourceGroupName(), fakePublicIPSpec2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec2, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPID("123", fakePublicIPSpec3.ResourceGroupName(), fakePublicIPSpec3.ResourceName())).Return(unmanagedTags, nil)
@@ -222,9 +222,9 @@ func TestDeletePublicIP(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPID("123", fakePublicIPSpecIpv6.ResourceGroupName(), fakePublicIPSpecIpv6.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, ServiceName).Return(nil)
 
-				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, serviceName, nil)
+				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -259,24 +259,24 @@ func TestDeletePublicIP(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPID("123", fakePublicIPSpec1.ResourceGroupName(), fakePublicIPSpec1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec1, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPID("123", fakePublicIPSpec2.ResourceGroupName(), fakePublicIPSpec2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec2, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPID("123", fakePublicIPSpec3.ResourceGroupName(), fakePublicIPSpec3.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec3, serviceName).Return(internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpec3, ServiceName).Return(internalError)
 
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPID("123", fakePublicIPSpecIpv6.ResourceGroupName(), fakePublicIPSpecIpv6.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return("my-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakePublicIPSpecIpv6, ServiceName).Return(nil)
 
-				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, serviceName, internalError)
+				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
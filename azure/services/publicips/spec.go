@@ -28,15 +28,18 @@ import (
 
 // PublicIPSpec defines the specification for a Public IP.
 type PublicIPSpec struct {
-	Name           string
-	ResourceGroup  string
-	ClusterName    string
-	DNSName        string
-	IsIPv6         bool
-	Location       string
-	FailureDomains []string
-	AdditionalTags infrav1.Tags
-	IPTags         []infrav1.IPTag
+	Name             string
+	ResourceGroup    string
+	ClusterName      string
+	DNSName          string
+	IsIPv6           bool
+	Location         string
+	FailureDomains   []string
+	Zones            []string
+	Tier             infrav1.PublicIPTier
+	PublicIPPrefixID string
+	AdditionalTags   infrav1.Tags
+	IPTags           []infrav1.IPTag
 }
 
 // ResourceName returns the name of the public IP.
@@ -78,6 +81,23 @@ func (s *PublicIPSpec) Parameters(existing interface{}) (params interface{}, err
 		}
 	}
 
+	// the public IP is allocated from the public IP prefix if one is specified
+	var publicIPPrefix *network.SubResource
+	if s.PublicIPPrefixID != "" {
+		publicIPPrefix = &network.SubResource{ID: to.StringPtr(s.PublicIPPrefixID)}
+	}
+
+	var tier network.PublicIPAddressSkuTier
+	zones := s.FailureDomains
+	if len(s.Zones) > 0 {
+		zones = s.Zones
+	}
+	if s.Tier == infrav1.PublicIPTierGlobal {
+		// global public IPs are not zonal
+		tier = network.PublicIPAddressSkuTierGlobal
+		zones = nil
+	}
+
 	return network.PublicIPAddress{
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
@@ -85,7 +105,7 @@ func (s *PublicIPSpec) Parameters(existing interface{}) (params interface{}, err
 			Name:        to.StringPtr(s.Name),
 			Additional:  s.AdditionalTags,
 		})),
-		Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard},
+		Sku:      &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard, Tier: tier},
 		Name:     to.StringPtr(s.Name),
 		Location: to.StringPtr(s.Location),
 		PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
@@ -93,7 +113,8 @@ func (s *PublicIPSpec) Parameters(existing interface{}) (params interface{}, err
 			PublicIPAllocationMethod: network.IPAllocationMethodStatic,
 			DNSSettings:              dnsSettings,
 			IPTags:                   converters.IPTagsToSDK(s.IPTags),
+			PublicIPPrefix:           publicIPPrefix,
 		},
-		Zones: to.StringSlicePtr(s.FailureDomains),
+		Zones: to.StringSlicePtr(zones),
 	}, nil
 }
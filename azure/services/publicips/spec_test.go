@@ -142,6 +142,45 @@ func TestParameters(t *testing.T) {
 			expected:      fakePublicIPIpv6,
 			expectedError: "",
 		},
+		{
+			name:     "public ip address allocated from a public ip prefix in explicit zones",
+			existing: nil,
+			spec: func() PublicIPSpec {
+				spec := fakePublicIPSpecWithoutDNS
+				spec.Zones = []string{"1"}
+				spec.PublicIPPrefixID = "/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Network/publicIPPrefixes/shared-prefix"
+				return spec
+			}(),
+			expected: func() network.PublicIPAddress {
+				publicIP := fakePublicIPWithoutDNS
+				publicIP.PublicIPAddressPropertiesFormat = &network.PublicIPAddressPropertiesFormat{
+					PublicIPAddressVersion:   network.IPVersionIPv4,
+					PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+					PublicIPPrefix: &network.SubResource{
+						ID: to.StringPtr("/subscriptions/123/resourceGroups/shared-rg/providers/Microsoft.Network/publicIPPrefixes/shared-prefix"),
+					},
+				}
+				publicIP.Zones = to.StringSlicePtr([]string{"1"})
+				return publicIP
+			}(),
+			expectedError: "",
+		},
+		{
+			name:     "global public ip address is not zonal",
+			existing: nil,
+			spec: func() PublicIPSpec {
+				spec := fakePublicIPSpecWithoutDNS
+				spec.Tier = infrav1.PublicIPTierGlobal
+				return spec
+			}(),
+			expected: func() network.PublicIPAddress {
+				publicIP := fakePublicIPWithoutDNS
+				publicIP.Sku = &network.PublicIPAddressSku{Name: network.PublicIPAddressSkuNameStandard, Tier: network.PublicIPAddressSkuTierGlobal}
+				publicIP.Zones = to.StringSlicePtr(nil)
+				return publicIP
+			}(),
+			expectedError: "",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -31,20 +31,34 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// Cache loads resource SKUs at the beginning of reconcile to expose
-// features available on compute resources. It exposes convenience
-// functionality for trawling Azure SKU capabilities. It may be adapted
-// to periodically refresh data in the background.
+// maxDataAge is the age after which cached sku information is considered stale
+// and Get or Map will attempt to refresh it inline. Under normal operation the
+// Refresher keeps data much fresher than this.
+const maxDataAge = 24 * time.Hour
+
+// Cache loads resource SKUs on first use to expose features available on
+// compute resources. It exposes convenience functionality for trawling Azure
+// SKU capabilities. Caches returned by GetCache are shared across reconciles
+// and are kept up to date in the background by a Refresher.
 type Cache struct {
 	client Client
 
 	// location is the Azure location for which this cache stores sku info.
-	// we do lookup once per reconcile for the given cluster/location.
 	location string
 
-	// data is the cached sku information from Azure.
-	// synchronization required if data is cached across reconcile calls, (i.e., refreshed in background as Runnable via mgr.Add(...))
+	// refreshMu serializes calls to the Azure API so that concurrent
+	// reconciles of a cold cache result in a single List call.
+	refreshMu sync.Mutex
+
+	// mu guards data and lastRefresh.
+	mu sync.RWMutex
+
+	// data is the cached sku information from Azure. It is only ever
+	// replaced as a whole, never modified in place.
 	data []compute.ResourceSku
+
+	// lastRefresh is the time data was last successfully loaded from Azure.
+	lastRefresh time.Time
 }
 
 // Cacher describes the ability to get and to add items to cache.
@@ -60,6 +74,11 @@ var (
 	_           Client = &AzureClient{}
 	doOnce      sync.Once
 	clientCache Cacher
+	cacheErr    error
+
+	// knownKeys tracks the keys of every cache handed out by GetCache so the
+	// Refresher can find them without touching their expiration.
+	knownKeys sync.Map
 )
 
 // newCache instantiates a cache and initializes its contents.
@@ -72,26 +91,35 @@ func newCache(auth azure.Authorizer, location string) *Cache {
 
 // GetCache either creates a new SKUs cache or returns an existing one based on the location + Authorizer HashKey().
 func GetCache(auth azure.Authorizer, location string) (*Cache, error) {
-	var err error
-	doOnce.Do(func() {
-		clientCache, err = ttllru.New(128, 24*time.Hour)
-	})
-
+	cacher, err := sharedCache()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed creating LRU cache for resourceSKUs cache")
+		return nil, err
 	}
 
 	key := location + "_" + auth.HashKey()
-	c, ok := clientCache.Get(key)
+	c, ok := cacher.Get(key)
 	if ok {
 		return c.(*Cache), nil
 	}
 
 	c = newCache(auth, location)
-	_ = clientCache.Add(key, c)
+	_ = cacher.Add(key, c)
+	knownKeys.Store(key, struct{}{})
 	return c.(*Cache), nil
 }
 
+// sharedCache returns the process wide cache of resource SKU caches, creating it on first use.
+func sharedCache() (Cacher, error) {
+	doOnce.Do(func() {
+		clientCache, cacheErr = ttllru.New(128, 24*time.Hour)
+	})
+
+	if cacheErr != nil {
+		return nil, errors.Wrap(cacheErr, "failed creating LRU cache for resourceSKUs cache")
+	}
+	return clientCache, nil
+}
+
 // NewStaticCache initializes a cache with data and no ability to refresh. Used for testing.
 func NewStaticCache(data []compute.ResourceSku, location string) *Cache {
 	return &Cache{
@@ -104,33 +132,87 @@ func (c *Cache) refresh(ctx context.Context, location string) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.refresh")
 	defer done()
 
+	requested := time.Now()
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// Another caller may have refreshed the data while we waited for the lock.
+	if c.lastRefreshed().After(requested) {
+		return nil
+	}
+
 	data, err := c.client.List(ctx, fmt.Sprintf("location eq '%s'", location))
 	if err != nil {
+		refreshFailures.WithLabelValues(location).Inc()
 		return errors.Wrap(err, "failed to refresh resource sku cache")
 	}
 
+	c.mu.Lock()
 	c.data = data
+	c.lastRefresh = time.Now()
+	c.mu.Unlock()
+
+	lastRefreshTimestamp.WithLabelValues(location).SetToCurrentTime()
 
 	return nil
 }
 
+// lastRefreshed returns the time data was last loaded from Azure.
+func (c *Cache) lastRefreshed() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastRefresh
+}
+
+// snapshot returns the cached sku information, loading it from Azure if the
+// cache is empty or stale. If a refresh of stale data fails, the stale data is
+// returned instead of an error so callers do not depend on a live List call.
+func (c *Cache) snapshot(ctx context.Context) ([]compute.ResourceSku, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.snapshot")
+	defer done()
+
+	c.mu.RLock()
+	data, lastRefresh := c.data, c.lastRefresh
+	c.mu.RUnlock()
+
+	if data == nil {
+		if err := c.refresh(ctx, c.location); err != nil {
+			return nil, err
+		}
+	} else if !lastRefresh.IsZero() && time.Since(lastRefresh) > maxDataAge {
+		if err := c.refresh(ctx, c.location); err != nil {
+			log.V(2).Info("failed to refresh stale resource sku cache, using stale data", "location", c.location, "lastRefresh", lastRefresh, "error", err.Error())
+			staleReads.WithLabelValues(c.location).Inc()
+			return data, nil
+		}
+	} else {
+		return data, nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.data, nil
+}
+
 // Get returns a resource SKU with the provided name and category. It
-// returns an error if we could not find a match. We should consider
-// enhancing this function to handle restrictions (e.g. SKU not
-// supported in region), which is why it returns an error and not a
-// boolean.
+// returns a terminal error if we could not find a match, or if the SKU
+// is restricted in the cache's location for the subscription (see
+// RestrictionError). Zone restrictions are not checked here; use
+// SKU.CheckRestrictions with the zones of interest.
 func (c *Cache) Get(ctx context.Context, name string, kind ResourceType) (SKU, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.Get")
 	defer done()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return SKU{}, err
-		}
+	data, err := c.snapshot(ctx)
+	if err != nil {
+		return SKU{}, err
 	}
 
-	for _, sku := range c.data {
+	for _, sku := range data {
 		if sku.Name != nil && *sku.Name == name {
+			if err := SKU(sku).CheckRestrictions(c.location); err != nil {
+				return SKU{}, azure.WithTerminalError(err)
+			}
 			return SKU(sku), nil
 		}
 	}
@@ -142,14 +224,13 @@ func (c *Cache) Map(ctx context.Context, mapFn func(sku SKU)) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.Map")
 	defer done()
 
-	if c.data == nil {
-		if err := c.refresh(ctx, c.location); err != nil {
-			return err
-		}
+	data, err := c.snapshot(ctx)
+	if err != nil {
+		return err
 	}
 
-	for i := range c.data {
-		val := SKU(c.data[i])
+	for i := range data {
+		val := SKU(data[i])
 		mapFn(val)
 	}
 
@@ -222,11 +303,13 @@ func (c *Cache) GetZones(ctx context.Context, location string) ([]string, error)
 }
 
 // GetZonesWithVMSize returns available zones for a virtual machine size in the given location.
+// It returns a terminal RestrictionError if the size cannot be deployed in the location at all.
 func (c *Cache) GetZonesWithVMSize(ctx context.Context, size, location string) ([]string, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.GetZonesWithVMSize")
 	defer done()
 
 	var allZones = make(map[string]bool)
+	var restrictionErr error
 	mapFn := func(sku SKU) {
 		if sku.Name != nil && strings.EqualFold(*sku.Name, size) && sku.ResourceType != nil && strings.EqualFold(*sku.ResourceType, string(VirtualMachines)) {
 			// find matching location
@@ -246,6 +329,12 @@ func (c *Cache) GetZonesWithVMSize(ctx context.Context, size, location string) (
 					for _, restriction := range *sku.Restrictions {
 						// Can't deploy anything in this subscription in this location. Bail out.
 						if restriction.Type == compute.ResourceSkuRestrictionsTypeLocation {
+							restrictionErr = RestrictionError{
+								Name:       size,
+								Location:   location,
+								Type:       compute.ResourceSkuRestrictionsTypeLocation,
+								ReasonCode: restriction.ReasonCode,
+							}
 							availableZones = nil
 							break
 						}
@@ -272,6 +361,10 @@ func (c *Cache) GetZonesWithVMSize(ctx context.Context, size, location string) (
 		return nil, err
 	}
 
+	if restrictionErr != nil {
+		return nil, azure.WithTerminalError(restrictionErr)
+	}
+
 	var zones = make([]string, 0, len(allZones))
 	for zone := range allZones {
 		zones = append(zones, zone)
@@ -282,3 +375,30 @@ func (c *Cache) GetZonesWithVMSize(ctx context.Context, size, location string) (
 
 	return zones, nil
 }
+
+// GetVMSizesInFamily returns the virtual machine sizes of the given SKU family.
+// A virtual machine can be resized in place between the sizes of a family.
+func (c *Cache) GetVMSizesInFamily(ctx context.Context, family string) ([]string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.GetVMSizesInFamily")
+	defer done()
+
+	var sizes []string
+	mapFn := func(sku SKU) {
+		if sku.Name == nil || sku.Family == nil || !strings.EqualFold(*sku.Family, family) {
+			return
+		}
+		if sku.ResourceType == nil || !strings.EqualFold(*sku.ResourceType, string(VirtualMachines)) {
+			return
+		}
+		sizes = append(sizes, *sku.Name)
+	}
+
+	if err := c.Map(ctx, mapFn); err != nil {
+		return nil, err
+	}
+
+	// lexical sort for testing
+	sort.Strings(sizes)
+
+	return sizes, nil
+}
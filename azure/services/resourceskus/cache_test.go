@@ -248,8 +248,9 @@ func TestCacheGetZones(t *testing.T) {
 
 func TestCacheGetZonesWithVMSize(t *testing.T) {
 	cases := map[string]struct {
-		have []compute.ResourceSku
-		want []string
+		have    []compute.ResourceSku
+		want    []string
+		wantErr bool
 	}{
 		"should find 1 result": {
 			have: []compute.ResourceSku{
@@ -345,7 +346,8 @@ func TestCacheGetZonesWithVMSize(t *testing.T) {
 					},
 				},
 			},
-			want: nil,
+			want:    nil,
+			wantErr: true,
 		},
 		"should not find due to zone restriction": {
 			have: []compute.ResourceSku{
@@ -385,7 +387,11 @@ func TestCacheGetZonesWithVMSize(t *testing.T) {
 			}
 
 			zones, err := cache.GetZonesWithVMSize(context.Background(), "foo", "baz")
-			if err != nil {
+			if tc.wantErr {
+				if !IsLocationRestricted(err) {
+					t.Fatalf("expected a location restriction error, got %v", err)
+				}
+			} else if err != nil {
 				t.Error(err)
 			}
 			if diff := cmp.Diff(zones, tc.want, []cmp.Option{cmpopts.EquateEmpty()}...); diff != "" {
@@ -394,3 +400,60 @@ func TestCacheGetZonesWithVMSize(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheGetVMSizesInFamily(t *testing.T) {
+	cases := map[string]struct {
+		have []compute.ResourceSku
+		want []string
+	}{
+		"should find the sizes of the family": {
+			have: []compute.ResourceSku{
+				{
+					Name:         to.StringPtr("Standard_D4s_v3"),
+					Family:       to.StringPtr("standardDSv3Family"),
+					ResourceType: to.StringPtr(string(VirtualMachines)),
+				},
+				{
+					Name:         to.StringPtr("Standard_D2s_v3"),
+					Family:       to.StringPtr("standardDSv3Family"),
+					ResourceType: to.StringPtr(string(VirtualMachines)),
+				},
+				{
+					Name:         to.StringPtr("Standard_E2s_v3"),
+					Family:       to.StringPtr("standardESv3Family"),
+					ResourceType: to.StringPtr(string(VirtualMachines)),
+				},
+			},
+			want: []string{"Standard_D2s_v3", "Standard_D4s_v3"},
+		},
+		"should not find disks of the family": {
+			have: []compute.ResourceSku{
+				{
+					Name:         to.StringPtr("Premium_LRS"),
+					Family:       to.StringPtr("standardDSv3Family"),
+					ResourceType: to.StringPtr(string(Disks)),
+				},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := &Cache{
+				data: tc.have,
+			}
+
+			sizes, err := cache.GetVMSizesInFamily(context.Background(), "standardDSv3Family")
+			if err != nil {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(sizes, tc.want, []cmp.Option{cmpopts.EquateEmpty()}...); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
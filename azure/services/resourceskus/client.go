@@ -29,11 +29,13 @@ import (
 // Client wraps go-sdk.
 type Client interface {
 	List(context.Context, string) ([]compute.ResourceSku, error)
+	ListUsages(context.Context, string) ([]compute.Usage, error)
 }
 
 // AzureClient contains the Azure go-sdk Client.
 type AzureClient struct {
-	skus compute.ResourceSkusClient
+	skus   compute.ResourceSkusClient
+	usages compute.UsageClient
 }
 
 var _ Client = &AzureClient{}
@@ -41,7 +43,8 @@ var _ Client = &AzureClient{}
 // NewClient creates a new Resource SKUs client from subscription ID.
 func NewClient(auth azure.Authorizer) *AzureClient {
 	return &AzureClient{
-		skus: newResourceSkusClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		skus:   newResourceSkusClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		usages: newUsageClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
 	}
 }
 
@@ -52,6 +55,13 @@ func newResourceSkusClient(subscriptionID string, baseURI string, authorizer aut
 	return c
 }
 
+// newUsageClient creates a new compute usage client from subscription ID.
+func newUsageClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.UsageClient {
+	c := compute.NewUsageClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
 // List returns all Resource SKUs available to the subscription.
 func (ac *AzureClient) List(ctx context.Context, filter string) ([]compute.ResourceSku, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.AzureClient.List")
@@ -72,3 +82,24 @@ func (ac *AzureClient) List(ctx context.Context, filter string) ([]compute.Resou
 
 	return skus, nil
 }
+
+// ListUsages returns the current compute resource usage and limits for the subscription in a location.
+func (ac *AzureClient) ListUsages(ctx context.Context, location string) ([]compute.Usage, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.AzureClient.ListUsages")
+	defer done()
+
+	iter, err := ac.usages.ListComplete(ctx, location)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list compute usages")
+	}
+
+	var usages []compute.Usage
+	for iter.NotDone() {
+		usages = append(usages, iter.Value())
+		if err := iter.NextWithContext(ctx); err != nil {
+			return usages, errors.Wrap(err, "could not iterate compute usages")
+		}
+	}
+
+	return usages, nil
+}
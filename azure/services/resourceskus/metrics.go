@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	lastRefreshTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capz_resourceskus_cache_last_refresh_timestamp_seconds",
+			Help: "Unix time of the last successful refresh of the resource SKU cache for a location.",
+		},
+		[]string{"location"},
+	)
+
+	refreshFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_resourceskus_cache_refresh_failures_total",
+			Help: "Total number of failed attempts to refresh the resource SKU cache for a location.",
+		},
+		[]string{"location"},
+	)
+
+	staleReads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_resourceskus_cache_stale_reads_total",
+			Help: "Total number of reads served from stale resource SKU data because a refresh failed.",
+		},
+		[]string{"location"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(lastRefreshTimestamp, refreshFailures, staleReads)
+}
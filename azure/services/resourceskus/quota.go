@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const (
+	// RegionalVCPUQuota is the name of the usage tracking all regular vCPUs in a location.
+	RegionalVCPUQuota = "cores"
+	// SpotVCPUQuota is the name of the usage tracking Spot (low priority) vCPUs in a location.
+	SpotVCPUQuota = "lowPriorityCores"
+
+	// quotaExceededRequeue is the time after which an exceeded quota is checked again. Quota is often only exhausted
+	// for a short time, e.g. during a rollout while the old VMs are deleted.
+	quotaExceededRequeue = 5 * time.Minute
+)
+
+// QuotaError is returned when deploying a resource SKU would exceed a compute quota of the subscription.
+type QuotaError struct {
+	// Quota is the name of the exceeded quota, e.g. "cores" or "standardDSv3Family".
+	Quota string
+	// Location is the location of the quota.
+	Location string
+	// Requested is the number of vCPUs which would be consumed.
+	Requested int64
+	// Available is the number of vCPUs left before reaching the limit.
+	Available int64
+	// Limit is the quota limit.
+	Limit int64
+}
+
+// Error returns the error string.
+func (e QuotaError) Error() string {
+	return fmt.Sprintf("insufficient %s vCPU quota in location %s: %d vCPUs requested but only %d of %d are available, request a quota increase or choose a different VM size", e.Quota, e.Location, e.Requested, e.Available, e.Limit)
+}
+
+// IsQuotaExceeded returns true if the error is a QuotaError.
+func IsQuotaExceeded(err error) bool {
+	return errors.As(err, &QuotaError{})
+}
+
+// CheckQuota verifies that count more instances of the virtual machine SKU fit within the
+// remaining vCPU quota of the subscription in the cache's location. Regular VMs are checked
+// against both the regional and the VM family quota, Spot VMs against the Spot quota. It returns
+// a transient QuotaError if a quota would be exceeded.
+func (c *Cache) CheckQuota(ctx context.Context, sku SKU, count int64, spot bool) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "resourceskus.Cache.CheckQuota")
+	defer done()
+
+	// Static caches have no client to query usages with.
+	if count <= 0 || c.client == nil {
+		return nil
+	}
+
+	vCPUStr, ok := sku.GetCapability(VCPUs)
+	if !ok {
+		return nil
+	}
+	vCPUs, err := strconv.ParseInt(vCPUStr, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse string '%s' as int64", vCPUStr)
+	}
+	requested := vCPUs * count
+
+	quotas := []string{RegionalVCPUQuota, to.String(sku.Family)}
+	if spot {
+		quotas = []string{SpotVCPUQuota}
+	}
+
+	usages, err := c.client.ListUsages(ctx, c.location)
+	if err != nil {
+		return errors.Wrap(err, "failed to get compute usages")
+	}
+
+	for _, quota := range quotas {
+		if quota == "" {
+			continue
+		}
+		for _, usage := range usages {
+			if usage.Name == nil || !strings.EqualFold(to.String(usage.Name.Value), quota) || usage.Limit == nil || usage.CurrentValue == nil {
+				continue
+			}
+			available := *usage.Limit - int64(*usage.CurrentValue)
+			if requested > available {
+				return azure.WithTransientError(QuotaError{
+					Quota:     quota,
+					Location:  c.location,
+					Requested: requested,
+					Available: available,
+					Limit:     *usage.Limit,
+				}, quotaExceededRequeue)
+			}
+			break
+		}
+	}
+
+	return nil
+}
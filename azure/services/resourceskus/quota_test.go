@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus/mock_resourceskus"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+func usage(name string, current int32, limit int64) compute.Usage {
+	return compute.Usage{
+		Name:         &compute.UsageName{Value: to.StringPtr(name)},
+		CurrentValue: to.Int32Ptr(current),
+		Limit:        to.Int64Ptr(limit),
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	sku := SKU{
+		Name:   to.StringPtr("Standard_D4s_v3"),
+		Family: to.StringPtr("standardDSv3Family"),
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{Name: to.StringPtr(VCPUs), Value: to.StringPtr("4")},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		count         int64
+		spot          bool
+		expect        func(m *mock_resourceskus.MockClientMockRecorder)
+		expectedQuota string
+		expectedErr   string
+	}{
+		{
+			name:  "enough quota",
+			count: 2,
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.ListUsages(gomockinternal.AContext(), "westus").Return([]compute.Usage{
+					usage("cores", 10, 100),
+					usage("standardDSv3Family", 0, 8),
+				}, nil)
+			},
+		},
+		{
+			name:  "family quota exceeded",
+			count: 3,
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.ListUsages(gomockinternal.AContext(), "westus").Return([]compute.Usage{
+					usage("cores", 10, 100),
+					usage("standardDSv3Family", 0, 8),
+				}, nil)
+			},
+			expectedQuota: "standardDSv3Family",
+		},
+		{
+			name:  "regional quota exceeded",
+			count: 1,
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.ListUsages(gomockinternal.AContext(), "westus").Return([]compute.Usage{
+					usage("cores", 98, 100),
+					usage("standardDSv3Family", 0, 8),
+				}, nil)
+			},
+			expectedQuota: "cores",
+		},
+		{
+			name:  "spot VMs only check the spot quota",
+			count: 1,
+			spot:  true,
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.ListUsages(gomockinternal.AContext(), "westus").Return([]compute.Usage{
+					usage("cores", 100, 100),
+					usage("lowPriorityCores", 2, 4),
+				}, nil)
+			},
+			expectedQuota: "lowPriorityCores",
+		},
+		{
+			name:   "nothing to add",
+			count:  0,
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {},
+		},
+		{
+			name:  "usages cannot be listed",
+			count: 1,
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.ListUsages(gomockinternal.AContext(), "westus").Return(nil, errors.New("boom"))
+			},
+			expectedErr: "failed to get compute usages: boom",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clientMock := mock_resourceskus.NewMockClient(mockCtrl)
+			tc.expect(clientMock.EXPECT())
+
+			c := &Cache{client: clientMock, location: "westus"}
+			err := c.CheckQuota(context.TODO(), sku, tc.count, tc.spot)
+			switch {
+			case tc.expectedQuota != "":
+				var quotaErr QuotaError
+				g.Expect(errors.As(err, &quotaErr)).To(BeTrue())
+				g.Expect(quotaErr.Quota).To(Equal(tc.expectedQuota))
+				var reconcileErr azure.ReconcileError
+				g.Expect(errors.As(err, &reconcileErr)).To(BeTrue())
+				g.Expect(reconcileErr.IsTransient()).To(BeTrue())
+			case tc.expectedErr != "":
+				g.Expect(err).To(MatchError(tc.expectedErr))
+				g.Expect(IsQuotaExceeded(err)).To(BeFalse())
+			default:
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
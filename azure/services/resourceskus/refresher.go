@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// DefaultRefreshInterval is the default interval at which the Refresher reloads cached resource SKUs.
+const DefaultRefreshInterval = 1 * time.Hour
+
+// Refresher is a manager.Runnable which periodically reloads every resource SKU
+// cache handed out by GetCache, so reconcilers rarely need to call Azure to
+// validate a SKU. Caches which have not been used within the TTL of the shared
+// cache are evicted and no longer refreshed.
+type Refresher struct {
+	interval time.Duration
+	cacher   func() (Cacher, error)
+}
+
+var _ manager.Runnable = &Refresher{}
+var _ manager.LeaderElectionRunnable = &Refresher{}
+
+// NewRefresher returns a Refresher which reloads caches every interval.
+func NewRefresher(interval time.Duration) *Refresher {
+	return &Refresher{
+		interval: interval,
+		cacher:   sharedCache,
+	}
+}
+
+// Start refreshes caches every interval until the context is cancelled.
+// A non-positive interval disables background refreshes.
+func (r *Refresher) Start(ctx context.Context) error {
+	if r.interval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.refreshAll(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns false so that webhooks served by every replica
+// also benefit from warm caches.
+func (r *Refresher) NeedLeaderElection() bool {
+	return false
+}
+
+// refreshAll reloads every live cache. Failures are logged and counted, and the
+// affected cache keeps serving its previous data.
+func (r *Refresher) refreshAll(ctx context.Context) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "resourceskus.Refresher.refreshAll")
+	defer done()
+
+	cacher, err := r.cacher()
+	if err != nil {
+		log.Error(err, "failed to get resource sku caches")
+		return
+	}
+
+	knownKeys.Range(func(key, _ interface{}) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		c, ok := peek(cacher, key)
+		if !ok {
+			knownKeys.Delete(key)
+			return true
+		}
+
+		if err := c.refresh(ctx, c.location); err != nil {
+			log.Error(err, "failed to refresh resource sku cache in the background", "location", c.location)
+		}
+		return true
+	})
+}
+
+// peek looks up a cache without extending its time to live when the underlying
+// cacher supports it.
+func peek(cacher Cacher, key interface{}) (*Cache, bool) {
+	var (
+		val interface{}
+		ok  bool
+	)
+	if p, isPeeker := cacher.(interface {
+		Peek(key interface{}) (interface{}, time.Time, bool)
+	}); isPeeker {
+		val, _, ok = p.Peek(key)
+	} else {
+		val, ok = cacher.Get(key)
+	}
+	if !ok {
+		return nil, false
+	}
+
+	c, ok := val.(*Cache)
+	return c, ok
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus/mock_resourceskus"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+)
+
+func TestCacheGetStaleData(t *testing.T) {
+	stale := []compute.ResourceSku{{Name: to.StringPtr("old")}}
+	fresh := []compute.ResourceSku{{Name: to.StringPtr("new")}}
+
+	testcases := []struct {
+		name         string
+		data         []compute.ResourceSku
+		lastRefresh  time.Time
+		expect       func(m *mock_resourceskus.MockClientMockRecorder)
+		expectedName string
+		expectedErr  string
+	}{
+		{
+			name: "cold cache loads data",
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.List(gomockinternal.AContext(), "location eq 'test'").Return(fresh, nil)
+			},
+			expectedName: "new",
+		},
+		{
+			name: "cold cache returns list error",
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.List(gomockinternal.AContext(), "location eq 'test'").Return(nil, errors.New("throttled"))
+			},
+			expectedErr: "failed to refresh resource sku cache: throttled",
+		},
+		{
+			name:         "fresh data is served without calling Azure",
+			data:         stale,
+			lastRefresh:  time.Now(),
+			expect:       func(m *mock_resourceskus.MockClientMockRecorder) {},
+			expectedName: "old",
+		},
+		{
+			name:        "stale data is refreshed",
+			data:        stale,
+			lastRefresh: time.Now().Add(-2 * maxDataAge),
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.List(gomockinternal.AContext(), "location eq 'test'").Return(fresh, nil)
+			},
+			expectedName: "new",
+		},
+		{
+			name:        "stale data is served when refresh fails",
+			data:        stale,
+			lastRefresh: time.Now().Add(-2 * maxDataAge),
+			expect: func(m *mock_resourceskus.MockClientMockRecorder) {
+				m.List(gomockinternal.AContext(), "location eq 'test'").Return(nil, errors.New("throttled"))
+			},
+			expectedName: "old",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clientMock := mock_resourceskus.NewMockClient(mockCtrl)
+			tc.expect(clientMock.EXPECT())
+
+			c := &Cache{
+				client:      clientMock,
+				location:    "test",
+				data:        tc.data,
+				lastRefresh: tc.lastRefresh,
+			}
+
+			sku, err := c.Get(context.TODO(), tc.expectedName, VirtualMachines)
+			if tc.expectedErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(Equal(tc.expectedErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(*sku.Name).To(Equal(tc.expectedName))
+		})
+	}
+}
+
+func TestRefresherRefreshAll(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	cacher, err := ttllru.New(10, time.Hour)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	liveClient := mock_resourceskus.NewMockClient(mockCtrl)
+	liveClient.EXPECT().List(gomockinternal.AContext(), "location eq 'live'").Return([]compute.ResourceSku{{Name: to.StringPtr("new")}}, nil)
+	live := &Cache{client: liveClient, location: "live", data: []compute.ResourceSku{{Name: to.StringPtr("old")}}}
+	cacher.Add("live_key", live)
+	knownKeys.Store("live_key", struct{}{})
+
+	failingClient := mock_resourceskus.NewMockClient(mockCtrl)
+	failingClient.EXPECT().List(gomockinternal.AContext(), "location eq 'failing'").Return(nil, errors.New("throttled"))
+	failing := &Cache{client: failingClient, location: "failing", data: []compute.ResourceSku{{Name: to.StringPtr("old")}}}
+	cacher.Add("failing_key", failing)
+	knownKeys.Store("failing_key", struct{}{})
+
+	// evicted caches are forgotten and never refreshed.
+	knownKeys.Store("evicted_key", struct{}{})
+
+	r := &Refresher{
+		interval: time.Hour,
+		cacher:   func() (Cacher, error) { return cacher, nil },
+	}
+	r.refreshAll(context.TODO())
+
+	g.Expect(live.data).To(HaveLen(1))
+	g.Expect(*live.data[0].Name).To(Equal("new"))
+	g.Expect(live.lastRefreshed().IsZero()).To(BeFalse())
+	g.Expect(failing.data).To(HaveLen(1))
+	g.Expect(*failing.data[0].Name).To(Equal("old"))
+	_, ok := knownKeys.Load("evicted_key")
+	g.Expect(ok).To(BeFalse())
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+)
+
+// RestrictionError is returned when a resource SKU cannot be deployed to a location or zone
+// because of a restriction on the subscription.
+type RestrictionError struct {
+	// Name is the name of the restricted SKU.
+	Name string
+	// Location is the location in which the SKU is restricted.
+	Location string
+	// Zones are the restricted zones. It is empty for location restrictions.
+	Zones []string
+	// Type is either compute.ResourceSkuRestrictionsTypeLocation or compute.ResourceSkuRestrictionsTypeZone.
+	Type compute.ResourceSkuRestrictionsType
+	// ReasonCode is the reason reported by Azure, e.g. NotAvailableForSubscription.
+	ReasonCode compute.ResourceSkuRestrictionsReasonCode
+}
+
+// Error returns the error string.
+func (e RestrictionError) Error() string {
+	reason := ""
+	if e.ReasonCode != "" {
+		reason = fmt.Sprintf(" (%s)", e.ReasonCode)
+	}
+	if e.Type == compute.ResourceSkuRestrictionsTypeZone {
+		return fmt.Sprintf("resource sku %s is not available in zone(s) %s of location %s for this subscription%s", e.Name, strings.Join(e.Zones, ", "), e.Location, reason)
+	}
+	return fmt.Sprintf("resource sku %s is not available in location %s for this subscription%s", e.Name, e.Location, reason)
+}
+
+// IsLocationRestricted returns true if the error is a RestrictionError for a whole location.
+func IsLocationRestricted(err error) bool {
+	var restrictionErr RestrictionError
+	return errors.As(err, &restrictionErr) && restrictionErr.Type == compute.ResourceSkuRestrictionsTypeLocation
+}
+
+// IsZoneRestricted returns true if the error is a RestrictionError for one or more zones.
+func IsZoneRestricted(err error) bool {
+	var restrictionErr RestrictionError
+	return errors.As(err, &restrictionErr) && restrictionErr.Type == compute.ResourceSkuRestrictionsTypeZone
+}
+
+// CheckRestrictions returns a RestrictionError if the SKU cannot be deployed to the location,
+// or to any of the given zones within that location.
+func (s SKU) CheckRestrictions(location string, zones ...string) error {
+	if s.Restrictions == nil {
+		return nil
+	}
+
+	var restrictedZones []string
+	var zoneReason compute.ResourceSkuRestrictionsReasonCode
+	for _, restriction := range *s.Restrictions {
+		switch restriction.Type {
+		case compute.ResourceSkuRestrictionsTypeLocation:
+			if restrictionAppliesToLocation(restriction, location) {
+				return RestrictionError{
+					Name:       to.String(s.Name),
+					Location:   location,
+					Type:       compute.ResourceSkuRestrictionsTypeLocation,
+					ReasonCode: restriction.ReasonCode,
+				}
+			}
+		case compute.ResourceSkuRestrictionsTypeZone:
+			if restriction.RestrictionInfo == nil || restriction.RestrictionInfo.Zones == nil || !restrictionAppliesToLocation(restriction, location) {
+				continue
+			}
+			for _, zone := range zones {
+				for _, restrictedZone := range *restriction.RestrictionInfo.Zones {
+					if zone == restrictedZone {
+						restrictedZones = append(restrictedZones, zone)
+						zoneReason = restriction.ReasonCode
+					}
+				}
+			}
+		}
+	}
+
+	if len(restrictedZones) > 0 {
+		return RestrictionError{
+			Name:       to.String(s.Name),
+			Location:   location,
+			Zones:      restrictedZones,
+			Type:       compute.ResourceSkuRestrictionsTypeZone,
+			ReasonCode: zoneReason,
+		}
+	}
+
+	return nil
+}
+
+// restrictionAppliesToLocation returns true if the restriction lists the location, or lists no location at all.
+func restrictionAppliesToLocation(restriction compute.ResourceSkuRestrictions, location string) bool {
+	var locations []string
+	if restriction.RestrictionInfo != nil && restriction.RestrictionInfo.Locations != nil {
+		locations = *restriction.RestrictionInfo.Locations
+	} else if restriction.Values != nil && restriction.Type == compute.ResourceSkuRestrictionsTypeLocation {
+		locations = *restriction.Values
+	}
+
+	if len(locations) == 0 {
+		return true
+	}
+	for _, l := range locations {
+		if strings.EqualFold(l, location) {
+			return true
+		}
+	}
+	return false
+}
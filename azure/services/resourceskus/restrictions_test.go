@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestCheckRestrictions(t *testing.T) {
+	testcases := []struct {
+		name            string
+		restrictions    *[]compute.ResourceSkuRestrictions
+		zones           []string
+		locationErr     bool
+		zoneErr         bool
+		expectedMessage string
+	}{
+		{
+			name: "no restrictions",
+		},
+		{
+			name: "location restricted for subscription",
+			restrictions: &[]compute.ResourceSkuRestrictions{
+				{
+					Type:       compute.ResourceSkuRestrictionsTypeLocation,
+					Values:     &[]string{"westus"},
+					ReasonCode: compute.ResourceSkuRestrictionsReasonCodeNotAvailableForSubscription,
+				},
+			},
+			locationErr:     true,
+			expectedMessage: "resource sku Standard_D2s_v3 is not available in location westus for this subscription (NotAvailableForSubscription)",
+		},
+		{
+			name: "location restriction for another location is ignored",
+			restrictions: &[]compute.ResourceSkuRestrictions{
+				{
+					Type:   compute.ResourceSkuRestrictionsTypeLocation,
+					Values: &[]string{"eastus"},
+					RestrictionInfo: &compute.ResourceSkuRestrictionInfo{
+						Locations: &[]string{"eastus"},
+					},
+				},
+			},
+		},
+		{
+			name: "requested zone restricted",
+			restrictions: &[]compute.ResourceSkuRestrictions{
+				{
+					Type: compute.ResourceSkuRestrictionsTypeZone,
+					RestrictionInfo: &compute.ResourceSkuRestrictionInfo{
+						Locations: &[]string{"westus"},
+						Zones:     &[]string{"2", "3"},
+					},
+					ReasonCode: compute.ResourceSkuRestrictionsReasonCodeNotAvailableForSubscription,
+				},
+			},
+			zones:           []string{"1", "3"},
+			zoneErr:         true,
+			expectedMessage: "resource sku Standard_D2s_v3 is not available in zone(s) 3 of location westus for this subscription (NotAvailableForSubscription)",
+		},
+		{
+			name: "other zone restricted",
+			restrictions: &[]compute.ResourceSkuRestrictions{
+				{
+					Type: compute.ResourceSkuRestrictionsTypeZone,
+					RestrictionInfo: &compute.ResourceSkuRestrictionInfo{
+						Locations: &[]string{"westus"},
+						Zones:     &[]string{"2"},
+					},
+				},
+			},
+			zones: []string{"1"},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			sku := SKU{
+				Name:         to.StringPtr("Standard_D2s_v3"),
+				Restrictions: tc.restrictions,
+			}
+			err := sku.CheckRestrictions("westus", tc.zones...)
+			g.Expect(IsLocationRestricted(err)).To(Equal(tc.locationErr))
+			g.Expect(IsZoneRestricted(err)).To(Equal(tc.zoneErr))
+			if tc.expectedMessage != "" {
+				g.Expect(err).To(MatchError(tc.expectedMessage))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestCacheGetRestricted(t *testing.T) {
+	g := NewWithT(t)
+	cache := NewStaticCache([]compute.ResourceSku{
+		{
+			Name: to.StringPtr("Standard_D2s_v3"),
+			Restrictions: &[]compute.ResourceSkuRestrictions{
+				{
+					Type:   compute.ResourceSkuRestrictionsTypeLocation,
+					Values: &[]string{"westus"},
+				},
+			},
+		},
+	}, "westus")
+
+	_, err := cache.Get(context.Background(), "Standard_D2s_v3", VirtualMachines)
+	g.Expect(IsLocationRestricted(err)).To(BeTrue())
+}
@@ -68,6 +68,16 @@ const (
 	MaximumPlatformFaultDomainCount = "MaximumPlatformFaultDomainCount"
 	// UltraSSDAvailable identifies the capability for the support of UltraSSD data disks.
 	UltraSSDAvailable = "UltraSSDAvailable"
+	// CapacityReservationSupported identifies the capability for the support of capacity reservations.
+	CapacityReservationSupported = "CapacityReservationSupported"
+	// TrustedLaunchDisabled identifies the capability for the lack of support of trusted launch.
+	TrustedLaunchDisabled = "TrustedLaunchDisabled"
+	// ConfidentialComputingType identifies the confidential computing technology of the VM size, e.g. "SNP".
+	ConfidentialComputingType = "ConfidentialComputingType"
+	// HyperVGenerations identifies the Hyper-V generations supported by the VM size, e.g. "V1,V2".
+	HyperVGenerations = "HyperVGenerations"
+	// HyperVGenerationV2 is the Hyper-V generation required by trusted launch and confidential VMs.
+	HyperVGenerationV2 = "V2"
 )
 
 // HasCapability return true for a capability which can be either
@@ -165,3 +175,28 @@ func (s SKU) HasLocationCapability(capabilityName, location, zone string) bool {
 	}
 	return false
 }
+
+// HasHyperVGeneration returns true if the SKU supports the given Hyper-V generation, e.g. "V2".
+func (s SKU) HasHyperVGeneration(generation string) bool {
+	generations, ok := s.GetCapability(HyperVGenerations)
+	if !ok {
+		return false
+	}
+	for _, g := range strings.Split(generations, ",") {
+		if strings.EqualFold(strings.TrimSpace(g), generation) {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsTrustedLaunch returns true if VMs of the SKU can be created with trusted launch.
+func (s SKU) SupportsTrustedLaunch() bool {
+	return !s.HasCapability(TrustedLaunchDisabled) && s.HasHyperVGeneration(HyperVGenerationV2)
+}
+
+// SupportsConfidentialVM returns true if VMs of the SKU can be created as confidential VMs.
+func (s SKU) SupportsConfidentialVM() bool {
+	computingType, ok := s.GetCapability(ConfidentialComputingType)
+	return ok && computingType != "" && s.HasHyperVGeneration(HyperVGenerationV2)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestSKUSecurityTypes(t *testing.T) {
+	testcases := []struct {
+		name                   string
+		capabilities           map[string]string
+		expectedGen2           bool
+		expectedTrustedLaunch  bool
+		expectedConfidentialVM bool
+	}{
+		{
+			name: "no capabilities",
+		},
+		{
+			name:         "generation 1 only",
+			capabilities: map[string]string{HyperVGenerations: "V1"},
+		},
+		{
+			name:                  "generation 2 supports trusted launch",
+			capabilities:          map[string]string{HyperVGenerations: "V1,V2"},
+			expectedGen2:          true,
+			expectedTrustedLaunch: true,
+		},
+		{
+			name: "trusted launch disabled",
+			capabilities: map[string]string{
+				HyperVGenerations:     "V1,V2",
+				TrustedLaunchDisabled: string(CapabilitySupported),
+			},
+			expectedGen2: true,
+		},
+		{
+			name: "confidential computing",
+			capabilities: map[string]string{
+				HyperVGenerations:         "V2",
+				ConfidentialComputingType: "SNP",
+			},
+			expectedGen2:           true,
+			expectedTrustedLaunch:  true,
+			expectedConfidentialVM: true,
+		},
+		{
+			name: "confidential computing requires generation 2",
+			capabilities: map[string]string{
+				HyperVGenerations:         "V1",
+				ConfidentialComputingType: "SNP",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			capabilities := []compute.ResourceSkuCapabilities{}
+			for name, value := range tc.capabilities {
+				capabilities = append(capabilities, compute.ResourceSkuCapabilities{
+					Name:  to.StringPtr(name),
+					Value: to.StringPtr(value),
+				})
+			}
+			sku := SKU{
+				Name:         to.StringPtr("Standard_D2s_v3"),
+				Capabilities: &capabilities,
+			}
+			g.Expect(sku.HasHyperVGeneration(HyperVGenerationV2)).To(Equal(tc.expectedGen2))
+			g.Expect(sku.SupportsTrustedLaunch()).To(Equal(tc.expectedTrustedLaunch))
+			g.Expect(sku.SupportsConfidentialVM()).To(Equal(tc.expectedConfidentialVM))
+		})
+	}
+}
@@ -68,8 +68,22 @@ func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 		return nil, nil, errors.Errorf("%T is not a network.RouteTable", parameters)
 	}
 
-	createFuture, err := ac.routetables.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), rt)
+	var etag string
+	if rt.Etag != nil {
+		etag = *rt.Etag
+	}
+	req, err := ac.routetables.CreateOrUpdatePreparer(ctx, spec.ResourceGroupName(), spec.ResourceName(), rt)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.RouteTablesClient", "CreateOrUpdate", nil, "Failure preparing request")
+		return nil, nil, err
+	}
+	if etag != "" {
+		req.Header.Add("If-Match", etag)
+	}
+
+	createFuture, err := ac.routetables.CreateOrUpdateSender(req)
 	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.RouteTablesClient", "CreateOrUpdate", createFuture.Response(), "Failure sending request")
 		return nil, nil, err
 	}
 
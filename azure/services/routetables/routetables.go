@@ -27,7 +27,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "routetables"
+// ServiceName is the name of this service.
+const ServiceName = "routetables"
 
 // RouteTableScope defines the scope interface for route table service.
 type RouteTableScope interface {
@@ -54,7 +55,7 @@ func New(scope RouteTableScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a set of route tables.
@@ -79,18 +80,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of route tables to reconcile each one, independently of the result of the previous one.
+	// We reconcile the route tables concurrently, each one independently of the result of the others.
 	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	for _, rtSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, rtSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || resErr == nil {
-				resErr = err
-			}
-		}
-	}
+	_, resErr = async.CreateOrUpdateResources(ctx, s.Reconciler, specs, ServiceName)
 
-	s.Scope.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, resErr)
+	s.Scope.UpdatePutStatus(infrav1.RouteTablesReadyCondition, ServiceName, resErr)
 	return resErr
 }
 
@@ -116,18 +110,10 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of RouteTableSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one
-	// order of precedence is: error deleting -> deleting in progress -> deleted (no error)
-	var result error
-	for _, rtSpec := range specs {
-		if err := s.DeleteResource(ctx, rtSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-	s.Scope.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, result)
+	// We delete the route tables concurrently, each one independently of the result of the others.
+	// If multiple errors occur, we return the most pressing one.
+	result := async.DeleteResources(ctx, s.Reconciler, specs, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, ServiceName, result)
 	return result
 }
 
@@ -71,9 +71,9 @@ func TestReconcileRouteTables(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -82,9 +82,9 @@ func TestReconcileRouteTables(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -93,9 +93,9 @@ func TestReconcileRouteTables(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -156,9 +156,9 @@ func TestDeleteRouteTable(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -167,9 +167,9 @@ func TestDeleteRouteTable(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -178,9 +178,9 @@ func TestDeleteRouteTable(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT, ServiceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
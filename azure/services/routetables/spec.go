@@ -17,6 +17,9 @@ limitations under the License.
 package routetables
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
@@ -30,6 +33,7 @@ type RouteTableSpec struct {
 	ResourceGroup  string
 	Location       string
 	ClusterName    string
+	Routes         infrav1.Routes
 	AdditionalTags infrav1.Tags
 }
 
@@ -49,23 +53,155 @@ func (s *RouteTableSpec) OwnerResourceName() string {
 }
 
 // Parameters returns the parameters for the route table.
+// Routes of an existing route table that were not created from the spec, such as the ones added by the Azure cloud provider
+// or the Azure Firewall egress route, are preserved. Routes created from the spec are tagged on the route table, so that they
+// are removed once they are removed from the spec.
 func (s *RouteTableSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	routes := make([]network.Route, 0, len(s.Routes))
+	var etag *string
+
 	if existing != nil {
-		if _, ok := existing.(network.RouteTable); !ok {
+		existingRT, ok := existing.(network.RouteTable)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.RouteTable", existing)
 		}
 		// route table already exists
-		// currently don't support specifying your own routes via spec.
-		return nil, nil
+		// We append the existing route table etag to the header to ensure we only apply the updates if the route table has not been modified.
+		etag = existingRT.Etag
+		var existingRoutes []network.Route
+		if existingRT.RouteTablePropertiesFormat != nil && existingRT.Routes != nil {
+			existingRoutes = *existingRT.Routes
+		}
+		update := false
+		for _, existingRoute := range existingRoutes {
+			name := to.String(existingRoute.Name)
+			if _, found := s.findRoute(name); found {
+				continue
+			}
+			if _, managed := existingRT.Tags[routeTagKey(name)]; managed {
+				// The route was created from the spec and has been removed from it since.
+				update = true
+				continue
+			}
+			routes = append(routes, existingRoute)
+		}
+		for _, route := range s.Routes {
+			want := converters.RouteToSDK(route)
+			got, found := findRoute(existingRoutes, route.Name)
+			if !found || len(routeDrift(got, want)) > 0 {
+				update = true
+			}
+			if _, managed := existingRT.Tags[routeTagKey(route.Name)]; !managed {
+				// Route tables created before routes were tagged get the tags of their routes.
+				update = true
+			}
+			routes = append(routes, want)
+		}
+		if !update {
+			// Skip update for the route table as the routes of the spec are up to date.
+			return nil, nil
+		}
+	} else {
+		// new route table
+		for _, route := range s.Routes {
+			routes = append(routes, converters.RouteToSDK(route))
+		}
 	}
+
 	return network.RouteTable{
-		Location:                   to.StringPtr(s.Location),
-		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{},
-		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.ClusterName,
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        to.StringPtr(s.Name),
-			Additional:  s.AdditionalTags,
-		})),
+		Location: to.StringPtr(s.Location),
+		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+			Routes: &routes,
+		},
+		Etag: etag,
+		Tags: s.tags(),
 	}, nil
 }
+
+// tags returns the tags of the route table, including a tag for each route of the spec.
+func (s *RouteTableSpec) tags() map[string]*string {
+	tags := converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        to.StringPtr(s.Name),
+		Additional:  s.AdditionalTags,
+	}))
+	for _, route := range s.Routes {
+		tags[routeTagKey(route.Name)] = to.StringPtr(string(infrav1.ResourceLifecycleOwned))
+	}
+	return tags
+}
+
+// routeTagKey returns the key of the tag marking a route of the route table as created from the spec.
+// Route names are case-insensitive, so the key uses the lowercase name.
+func routeTagKey(name string) string {
+	return fmt.Sprintf("%sroute_%s", infrav1.NameAzureProviderPrefix, strings.ToLower(name))
+}
+
+// Drift returns the fields of the routes in the spec that are missing or differ in the existing route table.
+// Routes that are not part of the spec, such as the ones added by the Azure cloud provider, are ignored.
+func (s *RouteTableSpec) Drift(existing interface{}) ([]string, error) {
+	existingRT, ok := existing.(network.RouteTable)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.RouteTable", existing)
+	}
+
+	var existingRoutes []network.Route
+	if existingRT.RouteTablePropertiesFormat != nil && existingRT.Routes != nil {
+		existingRoutes = *existingRT.Routes
+	}
+
+	var drift []string
+	for _, route := range s.Routes {
+		got, found := findRoute(existingRoutes, route.Name)
+		if !found {
+			drift = append(drift, fmt.Sprintf("routes[%s]", route.Name))
+			continue
+		}
+		for _, field := range routeDrift(got, converters.RouteToSDK(route)) {
+			drift = append(drift, fmt.Sprintf("routes[%s].%s", route.Name, field))
+		}
+	}
+	return drift, nil
+}
+
+// findRoute returns the route of the spec with the given name.
+func (s *RouteTableSpec) findRoute(name string) (infrav1.Route, bool) {
+	for _, route := range s.Routes {
+		if strings.EqualFold(route.Name, name) {
+			return route, true
+		}
+	}
+	return infrav1.Route{}, false
+}
+
+// findRoute returns the route with the given name.
+func findRoute(routes []network.Route, name string) (network.Route, bool) {
+	for _, route := range routes {
+		if strings.EqualFold(to.String(route.Name), name) {
+			return route, true
+		}
+	}
+	return network.Route{}, false
+}
+
+// routeDrift returns the names of the properties of existing that differ from want.
+func routeDrift(existing, want network.Route) []string {
+	got := existing.RoutePropertiesFormat
+	if got == nil {
+		got = &network.RoutePropertiesFormat{}
+	}
+	expected := want.RoutePropertiesFormat
+
+	var fields []string
+	if !strings.EqualFold(to.String(got.AddressPrefix), to.String(expected.AddressPrefix)) {
+		fields = append(fields, "addressPrefix")
+	}
+	if !strings.EqualFold(string(got.NextHopType), string(expected.NextHopType)) {
+		fields = append(fields, "nextHopType")
+	}
+	if to.String(got.NextHopIPAddress) != to.String(expected.NextHopIPAddress) {
+		fields = append(fields, "nextHopIPAddress")
+	}
+	return fields
+}
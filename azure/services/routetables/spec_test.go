@@ -0,0 +1,360 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routetables
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+var (
+	defaultRoute = infrav1.Route{
+		Name:             "default",
+		AddressPrefix:    "0.0.0.0/0",
+		NextHopType:      infrav1.RouteNextHopTypeVirtualAppliance,
+		NextHopIPAddress: "10.100.0.4",
+	}
+	onPremRoute = infrav1.Route{
+		Name:          "on-prem",
+		AddressPrefix: "192.168.0.0/16",
+		NextHopType:   infrav1.RouteNextHopTypeVirtualNetworkGateway,
+	}
+	// podRoute is a route added by the Azure cloud provider, which is not part of the spec.
+	podRoute = network.Route{
+		Name: to.StringPtr("node-0"),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    to.StringPtr("10.244.0.0/24"),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: to.StringPtr("10.1.0.4"),
+		},
+	}
+	// firewallRoute is the route sending the egress traffic to the Azure Firewall, which is not part of the spec.
+	firewallRoute = network.Route{
+		Name: to.StringPtr("azure-firewall-egress"),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    to.StringPtr("0.0.0.0/0"),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: to.StringPtr("10.0.3.4"),
+		},
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *RouteTableSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "route table already exists with all routes present",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				Location:      "test-location",
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+				Routes:        infrav1.Routes{defaultRoute, onPremRoute},
+			},
+			existing: network.RouteTable{
+				Name: to.StringPtr("test-rt"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{
+						converters.RouteToSDK(defaultRoute),
+						podRoute,
+						converters.RouteToSDK(onPremRoute),
+					},
+				},
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_route_default": to.StringPtr("owned"),
+					"sigs.k8s.io_cluster-api-provider-azure_route_on-prem": to.StringPtr("owned"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "route table already exists with a route removed from the spec",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				Location:      "test-location",
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+				Routes:        infrav1.Routes{defaultRoute},
+			},
+			existing: network.RouteTable{
+				Name: to.StringPtr("test-rt"),
+				Etag: to.StringPtr("fake-etag"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{
+						converters.RouteToSDK(defaultRoute),
+						podRoute,
+						converters.RouteToSDK(onPremRoute),
+						firewallRoute,
+					},
+				},
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_route_default": to.StringPtr("owned"),
+					"sigs.k8s.io_cluster-api-provider-azure_route_on-prem": to.StringPtr("owned"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.RouteTable{
+					Location: to.StringPtr("test-location"),
+					Etag:     to.StringPtr("fake-etag"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						Routes: &[]network.Route{
+							podRoute,
+							firewallRoute,
+							converters.RouteToSDK(defaultRoute),
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("test-rt"),
+						"sigs.k8s.io_cluster-api-provider-azure_route_default": to.StringPtr("owned"),
+					},
+				}))
+			},
+		},
+		{
+			name: "route table created before routes were tagged gets the tags of its routes",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				Location:      "test-location",
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+				Routes:        infrav1.Routes{defaultRoute},
+			},
+			existing: network.RouteTable{
+				Name: to.StringPtr("test-rt"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{
+						podRoute,
+						converters.RouteToSDK(defaultRoute),
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.RouteTable{
+					Location: to.StringPtr("test-location"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						Routes: &[]network.Route{
+							podRoute,
+							converters.RouteToSDK(defaultRoute),
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("test-rt"),
+						"sigs.k8s.io_cluster-api-provider-azure_route_default": to.StringPtr("owned"),
+					},
+				}))
+			},
+		},
+		{
+			name: "route table already exists without routes in the spec",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				Location:      "test-location",
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+			},
+			existing: network.RouteTable{
+				Name: to.StringPtr("test-rt"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{podRoute},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "route table already exists with a missing and a modified route",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				Location:      "test-location",
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+				Routes:        infrav1.Routes{defaultRoute, onPremRoute},
+			},
+			existing: network.RouteTable{
+				Name:     to.StringPtr("test-rt"),
+				Location: to.StringPtr("test-location"),
+				Etag:     to.StringPtr("fake-etag"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{
+						podRoute,
+						{
+							Name: to.StringPtr("default"),
+							RoutePropertiesFormat: &network.RoutePropertiesFormat{
+								AddressPrefix: to.StringPtr("0.0.0.0/0"),
+								NextHopType:   network.RouteNextHopTypeInternet,
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				g.Expect(result).To(Equal(network.RouteTable{
+					Location: to.StringPtr("test-location"),
+					Etag:     to.StringPtr("fake-etag"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						Routes: &[]network.Route{
+							podRoute,
+							converters.RouteToSDK(defaultRoute),
+							converters.RouteToSDK(onPremRoute),
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("test-rt"),
+						"sigs.k8s.io_cluster-api-provider-azure_route_default": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_route_on-prem": to.StringPtr("owned"),
+					},
+				}))
+			},
+		},
+		{
+			name: "route table does not exist",
+			spec: &RouteTableSpec{
+				Name:          "test-rt",
+				Location:      "test-location",
+				ResourceGroup: "test-group",
+				ClusterName:   "my-cluster",
+				Routes:        infrav1.Routes{defaultRoute},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				g.Expect(result).To(Equal(network.RouteTable{
+					Location: to.StringPtr("test-location"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+						Routes: &[]network.Route{
+							converters.RouteToSDK(defaultRoute),
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"Name": to.StringPtr("test-rt"),
+						"sigs.k8s.io_cluster-api-provider-azure_route_default": to.StringPtr("owned"),
+					},
+				}))
+			},
+		},
+		{
+			name:          "existing is not a route table",
+			spec:          &RouteTableSpec{Name: "test-rt"},
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.RouteTable",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestDrift(t *testing.T) {
+	modifiedDefaultRoute := converters.RouteToSDK(defaultRoute)
+	modifiedDefaultRoute.NextHopType = network.RouteNextHopTypeInternet
+	modifiedDefaultRoute.NextHopIPAddress = nil
+
+	testcases := []struct {
+		name          string
+		existing      interface{}
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "no drift, extra routes are ignored",
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{
+						converters.RouteToSDK(defaultRoute),
+						converters.RouteToSDK(onPremRoute),
+						podRoute,
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "route modified and route deleted",
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+					Routes: &[]network.Route{
+						modifiedDefaultRoute,
+					},
+				},
+			},
+			expected: []string{
+				"routes[default].nextHopType",
+				"routes[default].nextHopIPAddress",
+				"routes[on-prem]",
+			},
+		},
+		{
+			name:          "existing is not a route table",
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.RouteTable",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := &RouteTableSpec{
+				Name:   "test-rt",
+				Routes: infrav1.Routes{defaultRoute, onPremRoute},
+			}
+			drift, err := spec.Drift(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drift).To(Equal(tc.expected))
+		})
+	}
+}
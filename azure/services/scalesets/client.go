@@ -31,6 +31,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -41,7 +42,6 @@ type Client interface {
 	ListInstances(context.Context, string, string) ([]compute.VirtualMachineScaleSetVM, error)
 	Get(context.Context, string, string) (compute.VirtualMachineScaleSet, error)
 	CreateOrUpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSet) (*infrav1.Future, error)
-	UpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSetUpdate) (*infrav1.Future, error)
 	GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachineScaleSet, error)
 	UpdateInstances(context.Context, string, string, []string) error
 	DeleteAsync(context.Context, string, string) (*infrav1.Future, error)
@@ -67,9 +67,15 @@ type (
 	deleteResultAdapter struct {
 		compute.VirtualMachineScaleSetsDeleteFuture
 	}
+
+	// updateClient updates existing scale sets with PATCH requests tracked by the async service.
+	updateClient struct {
+		scalesets compute.VirtualMachineScaleSetsClient
+	}
 )
 
 var _ Client = &AzureClient{}
+var _ async.Updater = &updateClient{}
 
 // NewClient creates a new VMSS client from subscription ID.
 func NewClient(auth azure.Authorizer) *AzureClient {
@@ -79,6 +85,13 @@ func NewClient(auth azure.Authorizer) *AzureClient {
 	}
 }
 
+// newUpdateClient creates a new client to update scale sets from subscription ID.
+func newUpdateClient(auth azure.Authorizer) *updateClient {
+	return &updateClient{
+		scalesets: newVirtualMachineScaleSetsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
 // newVirtualMachineScaleSetVMsClient creates a new vmss VM client from subscription ID.
 func newVirtualMachineScaleSetVMsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachineScaleSetVMsClient {
 	c := compute.NewVirtualMachineScaleSetVMsClientWithBaseURI(baseURI, subscriptionID)
@@ -171,39 +184,6 @@ func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, resourceGroupNam
 	return nil, err
 }
 
-// UpdateAsync update a VM scale set without waiting for the result of the operation. UpdateAsync sends a PATCH
-// request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
-// progress of the operation.
-//
-// Parameters:
-//
-//	resourceGroupName - the name of the resource group.
-//	vmssName - the name of the VM scale set to create or update. parameters - the scale set object.
-func (ac *AzureClient) UpdateAsync(ctx context.Context, resourceGroupName, vmssName string, parameters compute.VirtualMachineScaleSetUpdate) (*infrav1.Future, error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.UpdateAsync")
-	defer done()
-
-	future, err := ac.scalesets.Update(ctx, resourceGroupName, vmssName, parameters)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed updating vmss named %q", vmssName)
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
-	defer cancel()
-
-	err = future.WaitForCompletionRef(ctx, ac.scalesets.Client)
-	if err != nil {
-		// if an error occurs, return the future.
-		// this means the long-running operation didn't finish in the specified timeout.
-		return converters.SDKToFuture(&future, infrav1.PatchFuture, serviceName, vmssName, resourceGroupName)
-	}
-	// todo: this returns the result VMSS, we should use it
-	_, err = future.Result(ac.scalesets)
-
-	// if the operation completed, return a nil future.
-	return nil, err
-}
-
 // GetResultIfDone fetches the result of a long-running operation future if it is done.
 func (ac *AzureClient) GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachineScaleSet, error) {
 	var genericFuture genericScaleSetFuture
@@ -213,16 +193,6 @@ func (ac *AzureClient) GetResultIfDone(ctx context.Context, future *infrav1.Futu
 	}
 
 	switch future.Type {
-	case infrav1.PatchFuture:
-		var future compute.VirtualMachineScaleSetsUpdateFuture
-		if err := json.Unmarshal(futureData, &future); err != nil {
-			return compute.VirtualMachineScaleSet{}, errors.Wrap(err, "failed to unmarshal future data")
-		}
-
-		genericFuture = &genericScaleSetFutureImpl{
-			FutureAPI: &future,
-			result:    future.Result,
-		}
 	case infrav1.PutFuture:
 		var future compute.VirtualMachineScaleSetsCreateOrUpdateFuture
 		if err := json.Unmarshal(futureData, &future); err != nil {
@@ -326,3 +296,75 @@ func (da *deleteResultAdapter) Result(client compute.VirtualMachineScaleSetsClie
 func (g *genericScaleSetFutureImpl) Result(client compute.VirtualMachineScaleSetsClient) (compute.VirtualMachineScaleSet, error) {
 	return g.result(client)
 }
+
+// Get gets the scale set to update.
+func (uc *updateClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.updateClient.Get")
+	defer done()
+
+	return uc.scalesets.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
+}
+
+// UpdateAsync updates a scale set asynchronously. UpdateAsync sends a PATCH request to Azure and if accepted without error,
+// the func will return a Future which can be used to track the ongoing progress of the operation.
+func (uc *updateClient) UpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.updateClient.UpdateAsync")
+	defer done()
+
+	update, ok := parameters.(compute.VirtualMachineScaleSetUpdate)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSetUpdate", parameters)
+	}
+
+	updateFuture, err := uc.scalesets.Update(ctx, spec.ResourceGroupName(), spec.ResourceName(), update)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = updateFuture.WaitForCompletionRef(ctx, uc.scalesets.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, &updateFuture, err
+	}
+	result, err = updateFuture.Result(uc.scalesets)
+	// if the operation completed, return a nil future
+	return result, nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+func (uc *updateClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.updateClient.IsDone")
+	defer done()
+
+	return future.DoneWithContext(ctx, uc.scalesets)
+}
+
+// Result fetches the result of a long-running PATCH operation future.
+func (uc *updateClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "scalesets.updateClient.Result")
+	defer done()
+
+	if future == nil {
+		return nil, errors.Errorf("cannot get result from nil future")
+	}
+	if futureType != infrav1.PatchFuture {
+		return nil, errors.Errorf("unknown future type %q", futureType)
+	}
+
+	// Marshal and Unmarshal the future to put it into the correct future type so we can access the Result function.
+	// The FutureAPI is a generic azureautorest.Future converted back from the CAPZ infrav1.Future type stored in Status,
+	// which doesn't implement the Result function.
+	var updateFuture *compute.VirtualMachineScaleSetsUpdateFuture
+	jsonData, err := future.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal future")
+	}
+	if err := json.Unmarshal(jsonData, &updateFuture); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal future data")
+	}
+	return updateFuture.Result(uc.scalesets)
+}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -28,7 +27,10 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/placementgroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/generators"
 	"sigs.k8s.io/cluster-api-provider-azure/util/slice"
@@ -57,7 +59,10 @@ type (
 	Service struct {
 		Scope ScaleSetScope
 		Client
+		async.Reconciler
 		resourceSKUCache *resourceskus.Cache
+		placementGetter  placementgroups.Client
+		imagesGetter     virtualmachineimages.Client
 	}
 )
 
@@ -65,8 +70,11 @@ type (
 func New(scope ScaleSetScope, skuCache *resourceskus.Cache) *Service {
 	return &Service{
 		Client:           NewClient(scope),
+		Reconciler:       async.NewWithUpdater(scope, nil, newUpdateClient(scope), nil),
 		Scope:            scope,
 		resourceSKUCache: skuCache,
+		placementGetter:  placementgroups.NewClient(scope),
+		imagesGetter:     virtualmachineimages.NewClient(scope),
 	}
 }
 
@@ -89,12 +97,9 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 
 	scaleSetSpec := s.Scope.ScaleSetSpec()
 
-	// check if there is an ongoing long running operation
+	// check if there is an ongoing long running create operation, updates are tracked by the async service
 	var fetchedVMSS *azure.VMSS
 	future := s.Scope.GetLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PutFuture)
-	if future == nil {
-		future = s.Scope.GetLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PatchFuture)
-	}
 
 	defer func() {
 		// save the updated state of the VMSS for the MachinePoolScope to use for updating K8s state
@@ -136,9 +141,13 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 		// HTTP(200)
 		// VMSS already exists and may have changes; update it with a PATCH
 		// we do this to avoid overwriting fields in networkProfile modified by cloud-provider
-		future, err = s.patchVMSSIfNeeded(ctx, fetchedVMSS)
+		updated, err := s.patchVMSSIfNeeded(ctx, fetchedVMSS)
 		if err != nil {
-			return errors.Wrap(err, "failed to start updating VMSS")
+			return errors.Wrap(err, "failed to update VMSS")
+		}
+		if updated {
+			// refresh the state of the VMSS in the deferred update
+			fetchedVMSS = nil
 		}
 	}
 
@@ -147,13 +156,12 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 	if future != nil {
 		fetchedVMSS, err = s.getVirtualMachineScaleSetIfDone(ctx, future)
 		if err != nil {
-			return errors.Wrapf(err, "failed to get VMSS %s after create or update", scaleSetSpec.Name)
+			return errors.Wrapf(err, "failed to get VMSS %s after create", scaleSetSpec.Name)
 		}
 	}
 
 	// If we get to here, we have completed any long running VMSS operations (creates / updates)
 	s.Scope.DeleteLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PutFuture)
-	s.Scope.DeleteLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PatchFuture)
 
 	// This also means that the VMSS extensions were successfully installed
 	// Note: we want to handle UpdatePutStatus when VMSSExtensions have an error when scalesets become an async service
@@ -239,6 +247,18 @@ func (s *Service) createVMSS(ctx context.Context) (*infrav1.Future, error) {
 		return nil, errors.Wrap(err, "failed building VMSS from spec")
 	}
 
+	if err := s.validatePlacement(ctx, spec); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateImageSecurityType(ctx, spec); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkQuota(ctx, spec, spec.Capacity); err != nil {
+		return nil, err
+	}
+
 	future, err := s.Client.CreateOrUpdateAsync(ctx, s.Scope.ResourceGroup(), spec.Name, vmss)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot create VMSS")
@@ -249,54 +269,53 @@ func (s *Service) createVMSS(ctx context.Context) (*infrav1.Future, error) {
 	return future, err
 }
 
-func (s *Service) patchVMSSIfNeeded(ctx context.Context, infraVMSS *azure.VMSS) (*infrav1.Future, error) {
+// patchVMSSIfNeeded updates the existing VMSS with a PATCH request if its model changes or its replica count increases.
+// It returns true if the VMSS was updated.
+func (s *Service) patchVMSSIfNeeded(ctx context.Context, infraVMSS *azure.VMSS) (bool, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.patchVMSSIfNeeded")
 	defer done()
 
 	spec := s.Scope.ScaleSetSpec()
-
-	vmss, err := s.buildVMSSFromSpec(ctx, spec)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to generate scale set update parameters for %s", spec.Name)
+	patchSpec := &VMSSPatchSpec{
+		Name:          spec.Name,
+		ResourceGroup: s.Scope.ResourceGroup(),
 	}
 
-	patch, err := getVMSSUpdateFromVMSS(vmss)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to generate vmss patch for %s", spec.Name)
-	}
+	// An ongoing update is completed by the async service, there is no need to build the update parameters.
+	if s.Scope.GetLongRunningOperationState(spec.Name, serviceName, infrav1.PatchFuture) == nil {
+		vmss, err := s.buildVMSSFromSpec(ctx, spec)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to generate scale set update parameters for %s", spec.Name)
+		}
 
-	maxSurge, err := s.Scope.MaxSurge()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to calculate maxSurge")
-	}
+		maxSurge, err := s.Scope.MaxSurge()
+		if err != nil {
+			return false, errors.Wrap(err, "failed to calculate maxSurge")
+		}
 
-	hasModelChanges := hasModelModifyingDifferences(infraVMSS, vmss)
-	if maxSurge > 0 && (hasModelChanges || !infraVMSS.HasEnoughLatestModelOrNotMixedModel()) {
-		// surge capacity with the intention of lowering during instance reconciliation
-		surge := spec.Capacity + int64(maxSurge)
-		log.V(4).Info("surging...", "surge", surge)
-		patch.Sku.Capacity = to.Int64Ptr(surge)
-	}
+		patchSpec.VMSS = vmss
+		patchSpec.Capacity = spec.Capacity
+		patchSpec.MaxSurge = maxSurge
+		patchSpec.Instances = infraVMSS.Instances
+
+		capacity, hasModelChanges := patchSpec.desiredCapacity(infraVMSS)
+		if capacity <= infraVMSS.Capacity && !hasModelChanges {
+			log.V(4).Info("nothing to update on vmss", "scale set", spec.Name, "newReplicas", capacity, "oldReplicas", infraVMSS.Capacity, "hasChanges", hasModelChanges)
+			return false, nil
+		}
 
-	// If there are no model changes and no increase in the replica count, do not update the VMSS.
-	// Decreases in replica count is handled by deleting AzureMachinePoolMachine instances in the MachinePoolScope
-	if *patch.Sku.Capacity <= infraVMSS.Capacity && !hasModelChanges {
-		log.V(4).Info("nothing to update on vmss", "scale set", spec.Name, "newReplicas", *patch.Sku.Capacity, "oldReplicas", infraVMSS.Capacity, "hasChanges", hasModelChanges)
-		return nil, nil
+		if err := s.checkQuota(ctx, spec, capacity-infraVMSS.Capacity); err != nil {
+			return false, err
+		}
 	}
 
-	log.V(4).Info("patching vmss", "scale set", spec.Name, "patch", patch)
-	future, err := s.UpdateAsync(ctx, s.Scope.ResourceGroup(), spec.Name, patch)
+	result, err := s.UpdateResource(ctx, patchSpec, serviceName)
 	if err != nil {
-		if azure.ResourceConflict(err) {
-			return nil, azure.WithTransientError(err, 30*time.Second)
-		}
-		return nil, errors.Wrap(err, "failed updating VMSS")
+		return false, err
 	}
 
-	s.Scope.SetLongRunningOperationState(future)
-	log.V(2).Info("successfully started to update vmss", "scale set", spec.Name)
-	return future, err
+	log.V(2).Info("successfully updated vmss", "scale set", spec.Name)
+	return result != nil, nil
 }
 
 func hasModelModifyingDifferences(infraVMSS *azure.VMSS, vmss compute.VirtualMachineScaleSet) bool {
@@ -340,8 +359,8 @@ func (s *Service) validateSpec(ctx context.Context) error {
 		return azure.WithTerminalError(fmt.Errorf("vm size %s does not support ephemeral os. select a different vm size or disable ephemeral os", spec.Size))
 	}
 
-	if spec.SecurityProfile != nil && !sku.HasCapability(resourceskus.EncryptionAtHost) {
-		return azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", spec.Size))
+	if err := validateSecurityProfile(spec, sku); err != nil {
+		return err
 	}
 
 	// Fetch location and zone to check for their support of ultra disks.
@@ -372,6 +391,11 @@ func (s *Service) validateSpec(ctx context.Context) error {
 		}
 	}
 
+	// Checking if the VM type is restricted in any of the selected availability zones
+	if err := sku.CheckRestrictions(s.Scope.Location(), spec.FailureDomains...); err != nil {
+		return azure.WithTerminalError(err)
+	}
+
 	// Checking if selected availability zones are available selected VM type in location
 	azsInLocation, err := s.resourceSKUCache.GetZonesWithVMSize(ctx, spec.Size, s.Scope.Location())
 	if err != nil {
@@ -387,6 +411,66 @@ func (s *Service) validateSpec(ctx context.Context) error {
 	return nil
 }
 
+// validatePlacement checks that the capacity reservation group, the dedicated host group and the proximity placement group
+// of the scale set can host its instances in its failure domains.
+func (s *Service) validatePlacement(ctx context.Context, spec azure.ScaleSetSpec) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.validatePlacement")
+	defer done()
+
+	if spec.CapacityReservationGroupID == "" && spec.DedicatedHostGroupID == "" && spec.ProximityPlacementGroupID == "" {
+		return nil
+	}
+
+	sku, err := s.resourceSKUCache.Get(ctx, spec.Size, resourceskus.VirtualMachines)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get SKU %s in compute api", spec.Size)
+	}
+
+	return placementgroups.Validate(ctx, s.placementGetter, placementgroups.Placement{
+		CapacityReservationGroupID: spec.CapacityReservationGroupID,
+		DedicatedHostGroupID:       spec.DedicatedHostGroupID,
+		ProximityPlacementGroupID:  spec.ProximityPlacementGroupID,
+		SKU:                        sku,
+		Zones:                      spec.FailureDomains,
+	})
+}
+
+// validateImageSecurityType checks that the image of the scale set supports its security type.
+func (s *Service) validateImageSecurityType(ctx context.Context, spec azure.ScaleSetSpec) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.validateImageSecurityType")
+	defer done()
+
+	if spec.SecurityProfile == nil {
+		return nil
+	}
+
+	image, err := s.Scope.GetVMImage(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get VM image")
+	}
+
+	return virtualmachineimages.ValidateSecurityType(ctx, s.imagesGetter, s.Scope.Location(), image, spec.SecurityProfile.SecurityType)
+}
+
+// checkQuota verifies there is enough vCPU quota left to add count instances to the scale set.
+func (s *Service) checkQuota(ctx context.Context, spec azure.ScaleSetSpec, count int64) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.checkQuota")
+	defer done()
+
+	sku, err := s.resourceSKUCache.Get(ctx, spec.Size, resourceskus.VirtualMachines)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get SKU %s in compute api", spec.Size)
+	}
+
+	err = s.resourceSKUCache.CheckQuota(ctx, sku, count, spec.SpotVMOptions != nil)
+	if err != nil && !resourceskus.IsQuotaExceeded(err) {
+		// Quota checks are best effort, let Azure reject the request if we cannot get the current usage.
+		log.V(2).Info("unable to check compute quota, continuing", "error", err.Error())
+		return nil
+	}
+	return err
+}
+
 func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSetSpec) (compute.VirtualMachineScaleSet, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.buildVMSSFromSpec")
 	defer done()
@@ -422,8 +506,9 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 		return compute.VirtualMachineScaleSet{}, errors.Wrapf(err, "failed to get Spot VM options")
 	}
 
-	// Get the node outbound LB backend pool ID
+	// Get the node outbound LB backend pool ID and inbound NAT pool IDs
 	var backendAddressPools []compute.SubResource
+	var inboundNATPools *[]compute.SubResource
 	if vmssSpec.PublicLBName != "" {
 		if vmssSpec.PublicLBAddressPoolName != "" {
 			backendAddressPools = append(backendAddressPools,
@@ -431,6 +516,15 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 					ID: to.StringPtr(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), vmssSpec.PublicLBName, vmssSpec.PublicLBAddressPoolName)),
 				})
 		}
+		if len(vmssSpec.PublicLBInboundNATPoolNames) > 0 {
+			pools := make([]compute.SubResource, 0, len(vmssSpec.PublicLBInboundNATPoolNames))
+			for _, name := range vmssSpec.PublicLBInboundNATPoolNames {
+				pools = append(pools, compute.SubResource{
+					ID: to.StringPtr(azure.InboundNATPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), vmssSpec.PublicLBName, name)),
+				})
+			}
+			inboundNATPools = &pools
+		}
 	}
 
 	osProfile, err := s.generateOSProfile(ctx, vmssSpec)
@@ -448,20 +542,19 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 		Zones: to.StringSlicePtr(vmssSpec.FailureDomains),
 		Plan:  s.generateImagePlan(ctx),
 		VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
-			SinglePlacementGroup: to.BoolPtr(false),
+			SinglePlacementGroup:    to.BoolPtr(false),
+			HostGroup:               subResource(vmssSpec.DedicatedHostGroupID),
+			ProximityPlacementGroup: subResource(vmssSpec.ProximityPlacementGroupID),
 			UpgradePolicy: &compute.UpgradePolicy{
 				Mode: compute.UpgradeModeManual,
 			},
 			Overprovision: to.BoolPtr(false),
 			VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
-				OsProfile:       osProfile,
-				StorageProfile:  storageProfile,
-				SecurityProfile: securityProfile,
-				DiagnosticsProfile: &compute.DiagnosticsProfile{
-					BootDiagnostics: &compute.BootDiagnostics{
-						Enabled: to.BoolPtr(true),
-					},
-				},
+				OsProfile:           osProfile,
+				StorageProfile:      storageProfile,
+				SecurityProfile:     securityProfile,
+				DiagnosticsProfile:  converters.GetDiagnosticsProfile(vmssSpec.Diagnostics),
+				CapacityReservation: getCapacityReservation(vmssSpec),
 				NetworkProfile: &compute.VirtualMachineScaleSetNetworkProfile{
 					NetworkInterfaceConfigurations: &[]compute.VirtualMachineScaleSetNetworkConfiguration{
 						{
@@ -479,6 +572,7 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 											Primary:                         to.BoolPtr(true),
 											PrivateIPAddressVersion:         compute.IPVersionIPv4,
 											LoadBalancerBackendAddressPools: &backendAddressPools,
+											LoadBalancerInboundNatPools:     inboundNATPools,
 										},
 									},
 								},
@@ -638,6 +732,7 @@ func (s *Service) generateStorageProfile(ctx context.Context, vmssSpec azure.Sca
 		if vmssSpec.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
 			storageProfile.OsDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(vmssSpec.OSDisk.ManagedDisk.DiskEncryptionSet.ID)}
 		}
+		storageProfile.OsDisk.ManagedDisk.SecurityProfile = converters.DiskSecurityProfileToSDK(vmssSpec.OSDisk.ManagedDisk.SecurityProfile)
 	}
 
 	if vmssSpec.OSDisk.CachingType != "" {
@@ -777,18 +872,53 @@ func getVMSSUpdateFromVMSS(vmss compute.VirtualMachineScaleSet) (compute.Virtual
 	return update, nil
 }
 
+func getCapacityReservation(vmssSpec azure.ScaleSetSpec) *compute.CapacityReservationProfile {
+	if vmssSpec.CapacityReservationGroupID == "" {
+		return nil
+	}
+	return &compute.CapacityReservationProfile{
+		CapacityReservationGroup: subResource(vmssSpec.CapacityReservationGroupID),
+	}
+}
+
+// subResource returns a reference to the resource with the given ID, or nil if the ID is empty.
+func subResource(id string) *compute.SubResource {
+	if id == "" {
+		return nil
+	}
+	return &compute.SubResource{ID: to.StringPtr(id)}
+}
+
 func getSecurityProfile(vmssSpec azure.ScaleSetSpec, sku resourceskus.SKU) (*compute.SecurityProfile, error) {
+	if err := validateSecurityProfile(vmssSpec, sku); err != nil {
+		return nil, err
+	}
+
+	return converters.SecurityProfileToSDK(vmssSpec.SecurityProfile), nil
+}
+
+// validateSecurityProfile checks that the VM size of the scale set supports the features of its security profile.
+func validateSecurityProfile(vmssSpec azure.ScaleSetSpec, sku resourceskus.SKU) error {
 	if vmssSpec.SecurityProfile == nil {
-		return nil, nil
+		return nil
 	}
 
-	if !sku.HasCapability(resourceskus.EncryptionAtHost) {
-		return nil, azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", vmssSpec.Size))
+	if to.Bool(vmssSpec.SecurityProfile.EncryptionAtHost) && !sku.HasCapability(resourceskus.EncryptionAtHost) {
+		return azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", vmssSpec.Size))
 	}
 
-	return &compute.SecurityProfile{
-		EncryptionAtHost: to.BoolPtr(*vmssSpec.SecurityProfile.EncryptionAtHost),
-	}, nil
+	switch vmssSpec.SecurityProfile.SecurityType {
+	case infrav1.SecurityTypesTrustedLaunch:
+		if !sku.SupportsTrustedLaunch() {
+			return azure.WithTerminalError(errors.Errorf("trusted launch is not supported for VM type %s", vmssSpec.Size))
+		}
+	case infrav1.SecurityTypesConfidentialVM:
+		if !sku.SupportsConfidentialVM() {
+			return azure.WithTerminalError(errors.Errorf("confidential VMs are not supported for VM type %s", vmssSpec.Size))
+		}
+	}
+
+	return nil
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO scale set.
@@ -20,6 +20,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
@@ -31,8 +32,11 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/placementgroups/mock_placementgroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets/mock_scalesets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -186,13 +190,13 @@ func TestReconcileVMSS(t *testing.T) {
 
 	testcases := []struct {
 		name          string
-		expect        func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder)
+		expect        func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder)
 		expectedError string
 	}{
 		{
 			name:          "should start creating a vmss",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				defaultSpec := newDefaultVMSSSpec()
 				defaultSpec.DataDisks = append(defaultSpec.DataDisks, infrav1.DataDisk{
 					NameSuffix: "my_disk_with_ultra_disks",
@@ -214,7 +218,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "should finish creating a vmss when long running operation is done",
 			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				defaultSpec := newDefaultVMSSSpec()
 				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
 				createdVMSS := newDefaultVMSS("VM_SIZE")
@@ -222,14 +226,14 @@ func TestReconcileVMSS(t *testing.T) {
 
 				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, createdVMSS, instances)
 				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PutFuture)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PatchFuture)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 			},
 		},
 		{
 			name:          "Windows VMSS should not get patched",
 			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				defaultSpec := newWindowsVMSSSpec()
 				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
 				createdVMSS := newDefaultWindowsVMSS()
@@ -237,14 +241,14 @@ func TestReconcileVMSS(t *testing.T) {
 
 				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, createdVMSS, instances)
 				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PutFuture)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PatchFuture)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 			},
 		},
 		{
 			name:          "should start creating vmss with defaulted accelerated networking when size allows",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.Size = "VM_SIZE_AN"
 				s.ScaleSetSpec().Return(spec).AnyTimes()
@@ -258,10 +262,37 @@ func TestReconcileVMSS(t *testing.T) {
 				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_AN"), putFuture)
 			},
 		},
+		{
+			name:          "should start creating vmss referencing the inbound NAT pools of the node outbound load balancer",
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.PublicLBInboundNATPoolNames = []string{"ssh"}
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+				(*(*netConfigs)[0].IPConfigurations)[0].LoadBalancerInboundNatPools = &[]compute.SubResource{
+					{ID: to.StringPtr("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/capz-lb/inboundNatPools/ssh")},
+				}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+			},
+		},
 		{
 			name:          "should start creating a vmss with spot vm",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
 				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
@@ -284,8 +315,8 @@ func TestReconcileVMSS(t *testing.T) {
 		},
 		{
 			name:          "should start creating a vmss with spot vm and ephemeral disk",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.Size = vmSizeEPH
 				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
@@ -306,8 +337,8 @@ func TestReconcileVMSS(t *testing.T) {
 		},
 		{
 			name:          "should start creating a vmss with spot vm and a defined delete evictionPolicy",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.Size = vmSizeEPH
 				deletePolicy := infrav1.SpotEvictionPolicyDelete
@@ -324,8 +355,8 @@ func TestReconcileVMSS(t *testing.T) {
 		},
 		{
 			name:          "should start creating a vmss with spot vm and a maximum price",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				maxPrice := resource.MustParse("0.001")
 				spec.SpotVMOptions = &infrav1.SpotVMOptions{
@@ -354,8 +385,8 @@ func TestReconcileVMSS(t *testing.T) {
 		},
 		{
 			name:          "should start creating a vmss with encryption",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.OSDisk.ManagedDisk.DiskEncryptionSet = &infrav1.DiskEncryptionSetParameters{
 					ID: "my-diskencryptionset-id",
@@ -386,8 +417,8 @@ func TestReconcileVMSS(t *testing.T) {
 		},
 		{
 			name:          "can start creating a vmss with user assigned identity",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
 					NameSuffix: "my_disk_with_ultra_disks",
@@ -420,8 +451,8 @@ func TestReconcileVMSS(t *testing.T) {
 		},
 		{
 			name:          "should start creating a vmss with encryption at host enabled",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.Size = "VM_SIZE_EAH"
 				spec.SecurityProfile = &infrav1.SecurityProfile{EncryptionAtHost: to.BoolPtr(true)}
@@ -440,7 +471,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "creating a vmss with encryption at host enabled for unsupported VM type fails",
 			expectedError: "reconcile error that cannot be recovered occurred: encryption at host is not supported for VM type VM_SIZE. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:            defaultVMSSName,
 					Size:            "VM_SIZE",
@@ -452,8 +483,8 @@ func TestReconcileVMSS(t *testing.T) {
 		},
 		{
 			name:          "should start creating a vmss with ephemeral osdisk",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				defaultSpec := newDefaultVMSSSpec()
 				defaultSpec.Size = "VM_SIZE_EPH"
 				defaultSpec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
@@ -476,8 +507,8 @@ func TestReconcileVMSS(t *testing.T) {
 		},
 		{
 			name:          "should start updating when scale set already exists and not currently in a long running operation",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PATCH on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expectedError: "failed to update VMSS: operation type PATCH on Azure resource my-rg/my-vmss is not done. Object will be requeued after 15s",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.Capacity = 2
 				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
@@ -494,31 +525,49 @@ func TestReconcileVMSS(t *testing.T) {
 				existingVMSS := newDefaultExistingVMSS("VM_SIZE")
 				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
 				existingVMSS.Sku.Capacity = to.Int64Ptr(2)
-				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
 				instances := newDefaultInstances()
 				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
 				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
 
-				clone := newDefaultExistingVMSS("VM_SIZE")
-				clone.Sku.Capacity = to.Int64Ptr(3)
-				clone.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				r.UpdateResource(gomockinternal.AContext(), gomock.AssignableToTypeOf(&VMSSPatchSpec{}), serviceName).
+					DoAndReturn(func(_ context.Context, spec azure.ResourceSpecGetter, _ string) (interface{}, error) {
+						patchSpec := spec.(*VMSSPatchSpec)
+						g.Expect(patchSpec.Name).To(Equal(defaultVMSSName))
+						g.Expect(patchSpec.ResourceGroup).To(Equal(defaultResourceGroup))
+						g.Expect(patchSpec.Capacity).To(Equal(int64(2)))
+						g.Expect(patchSpec.MaxSurge).To(Equal(1))
+						g.Expect(*patchSpec.VMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version).To(Equal("2.0"))
+						return nil, azure.WithTransientError(azure.NewOperationNotDoneError(patchFuture), 15*time.Second)
+					})
+			},
+		},
+		{
+			name:          "should finish updating when the update is in progress",
+			expectedError: "",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.SetProviderID(azure.ProviderIDPrefix + "subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(patchFuture)
+				s.SetVMSSState(gomock.Any())
 
-				patchVMSS, err := getVMSSUpdateFromVMSS(clone)
-				g.Expect(err).NotTo(HaveOccurred())
-				patchVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
-				patchVMSS.VirtualMachineProfile.NetworkProfile = nil
-				m.UpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(patchVMSS)).
-					Return(patchFuture, nil)
-				s.SetLongRunningOperationState(patchFuture)
-				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(patchFuture))
-				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(clone, nil)
-				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+				existingVMSS := newDefaultExistingVMSS("VM_SIZE")
+				instances := newDefaultInstances()
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil).Times(2)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil).Times(2)
+				r.UpdateResource(gomockinternal.AContext(), &VMSSPatchSpec{Name: defaultVMSSName, ResourceGroup: defaultResourceGroup}, serviceName).
+					Return(existingVMSS, nil)
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
 			},
 		},
 		{
 			name:          "less than 2 vCPUs",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size should be bigger or equal to at least 2 vCPUs. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_1_CPU",
@@ -530,7 +579,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "Memory is less than 2Gi",
 			expectedError: "reconcile error that cannot be recovered occurred: vm memory should be bigger or equal to at least 2Gi. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_1_MEM",
@@ -542,7 +591,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "failed to get SKU",
 			expectedError: "failed to get SKU INVALID_VM_SIZE in compute api: reconcile error that cannot be recovered occurred: resource sku with name 'INVALID_VM_SIZE' and category 'virtualMachines' not found in location 'test-location'. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "INVALID_VM_SIZE",
@@ -554,7 +603,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fails with internal error",
 			expectedError: "failed to start creating VMSS: cannot create VMSS: #: Internal error: StatusCode=500",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				s.ScaleSetSpec().Return(spec).AnyTimes()
 				setupDefaultVMSSStartCreatingExpectations(s, m)
@@ -567,7 +616,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fail to create a vm with ultra disk implicitly enabled by data disk, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_USSD",
@@ -587,7 +636,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fail to create a vm with ultra disk explicitly enabled via additional capabilities, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_USSD",
@@ -603,7 +652,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fail to create a vm with ultra disk explicitly enabled via additional capabilities, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_USSD",
@@ -636,11 +685,14 @@ func TestReconcileVMSS(t *testing.T) {
 			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
 			clientMock := mock_scalesets.NewMockClient(mockCtrl)
 
-			tc.expect(g, scopeMock.EXPECT(), clientMock.EXPECT())
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(g, scopeMock.EXPECT(), clientMock.EXPECT(), reconcilerMock.EXPECT())
 
 			s := &Service{
 				Scope:            scopeMock,
 				Client:           clientMock,
+				Reconciler:       reconcilerMock,
 				resourceSKUCache: resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
 			}
 
@@ -655,6 +707,236 @@ func TestReconcileVMSS(t *testing.T) {
 	}
 }
 
+func TestReconcileVMSSPlacement(t *testing.T) {
+	const hostGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+	const proximityPlacementGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg"
+	const vmID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"
+
+	putFuture := &infrav1.Future{
+		Type:          infrav1.PutFuture,
+		ResourceGroup: defaultResourceGroup,
+		Name:          defaultVMSSName,
+	}
+
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, p *mock_placementgroups.MockClientMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "should start creating a vmss on a dedicated host group in a proximity placement group",
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, p *mock_placementgroups.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.DedicatedHostGroupID = hostGroupID
+				spec.ProximityPlacementGroupID = proximityPlacementGroupID
+				spec.FailureDomains = []string{"1"}
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				p.GetDedicatedHostGroup(gomockinternal.AContext(), hostGroupID).Return(compute.DedicatedHostGroup{
+					Zones: &[]string{"1", "2", "3"},
+					DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+						SupportAutomaticPlacement: to.BoolPtr(true),
+					},
+				}, nil)
+				p.GetProximityPlacementGroup(gomockinternal.AContext(), proximityPlacementGroupID).Return(compute.ProximityPlacementGroup{
+					ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{
+						VirtualMachines: &[]compute.SubResourceWithColocationStatus{{ID: to.StringPtr(vmID)}},
+					},
+				}, nil)
+				p.GetVirtualMachine(gomockinternal.AContext(), vmID).Return(compute.VirtualMachine{Zones: &[]string{"1"}}, nil)
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.Zones = &[]string{"1"}
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.HostGroup = &compute.SubResource{ID: to.StringPtr(hostGroupID)}
+				vmss.ProximityPlacementGroup = &compute.SubResource{ID: to.StringPtr(proximityPlacementGroupID)}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+			},
+		},
+		{
+			name:          "creating a vmss fails when the dedicated host group is not available in its zones",
+			expectedError: "failed to start creating VMSS: reconcile error that cannot be recovered occurred: dedicated host group " + hostGroupID + " is not available in zone 3. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, p *mock_placementgroups.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.DedicatedHostGroupID = hostGroupID
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				p.GetDedicatedHostGroup(gomockinternal.AContext(), hostGroupID).Return(compute.DedicatedHostGroup{
+					Zones: &[]string{"1"},
+					DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+						SupportAutomaticPlacement: to.BoolPtr(true),
+					},
+				}, nil)
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
+			},
+		},
+		{
+			name:          "creating a vmss fails when the proximity placement group is pinned to another zone",
+			expectedError: "failed to start creating VMSS: reconcile error that cannot be recovered occurred: proximity placement group " + proximityPlacementGroupID + " is pinned to zones [2] by " + vmID + " and cannot host virtual machines in zone 1. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, p *mock_placementgroups.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.ProximityPlacementGroupID = proximityPlacementGroupID
+				spec.FailureDomains = []string{"1"}
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				p.GetProximityPlacementGroup(gomockinternal.AContext(), proximityPlacementGroupID).Return(compute.ProximityPlacementGroup{
+					ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{
+						VirtualMachines: &[]compute.SubResourceWithColocationStatus{{ID: to.StringPtr(vmID)}},
+					},
+				}, nil)
+				p.GetVirtualMachine(gomockinternal.AContext(), vmID).Return(compute.VirtualMachine{Zones: &[]string{"2"}}, nil)
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
+			clientMock := mock_scalesets.NewMockClient(mockCtrl)
+			placementMock := mock_placementgroups.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), placementMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				Client:           clientMock,
+				resourceSKUCache: resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
+				placementGetter:  placementMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError), err.Error())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestReconcileVMSSSecurityProfile(t *testing.T) {
+	putFuture := &infrav1.Future{
+		Type:          infrav1.PutFuture,
+		ResourceGroup: defaultResourceGroup,
+		Name:          defaultVMSSName,
+	}
+
+	newTrustedLaunchVMSSSpec := func(size string) azure.ScaleSetSpec {
+		spec := newDefaultVMSSSpec()
+		spec.Size = size
+		spec.SecurityProfile = &infrav1.SecurityProfile{
+			SecurityType: infrav1.SecurityTypesTrustedLaunch,
+			UefiSettings: &infrav1.UefiSettings{
+				SecureBootEnabled: to.BoolPtr(true),
+				VTpmEnabled:       to.BoolPtr(true),
+			},
+		}
+		return spec
+	}
+
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, i *mock_virtualmachineimages.MockClientMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "should start creating a trusted launch vmss",
+			expectedError: "failed to get VMSS my-vmss after create: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, i *mock_virtualmachineimages.MockClientMockRecorder) {
+				spec := newTrustedLaunchVMSSSpec("VM_SIZE_TL")
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				i.Get(gomockinternal.AContext(), "test-location", "fake-publisher", "my-offer", "sku-id", "1.0").Return(compute.VirtualMachineImage{
+					VirtualMachineImageProperties: &compute.VirtualMachineImageProperties{HyperVGeneration: compute.HyperVGenerationTypesV2},
+				}, nil)
+				vmss := newDefaultVMSS("VM_SIZE_TL")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile = &compute.SecurityProfile{
+					SecurityType: compute.SecurityTypesTrustedLaunch,
+					UefiSettings: &compute.UefiSettings{
+						SecureBootEnabled: to.BoolPtr(true),
+						VTpmEnabled:       to.BoolPtr(true),
+					},
+				}
+				vmss.Sku.Name = to.StringPtr(spec.Size)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_TL"), putFuture)
+			},
+		},
+		{
+			name:          "creating a trusted launch vmss fails when the image does not support it",
+			expectedError: "failed to start creating VMSS: reconcile error that cannot be recovered occurred: the VM image does not support security type TrustedLaunch: it has Hyper-V generation \"V1\" and security type feature \"\". Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, i *mock_virtualmachineimages.MockClientMockRecorder) {
+				s.ScaleSetSpec().Return(newTrustedLaunchVMSSSpec("VM_SIZE_TL")).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				i.Get(gomockinternal.AContext(), "test-location", "fake-publisher", "my-offer", "sku-id", "1.0").Return(compute.VirtualMachineImage{
+					VirtualMachineImageProperties: &compute.VirtualMachineImageProperties{HyperVGeneration: compute.HyperVGenerationTypesV1},
+				}, nil)
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
+			},
+		},
+		{
+			name:          "creating a trusted launch vmss for unsupported VM type fails",
+			expectedError: "reconcile error that cannot be recovered occurred: trusted launch is not supported for VM type VM_SIZE. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, i *mock_virtualmachineimages.MockClientMockRecorder) {
+				s.ScaleSetSpec().Return(newTrustedLaunchVMSSSpec("VM_SIZE")).AnyTimes()
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
+			clientMock := mock_scalesets.NewMockClient(mockCtrl)
+			imagesMock := mock_virtualmachineimages.NewMockClient(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), imagesMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				Client:           clientMock,
+				resourceSKUCache: resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
+				imagesGetter:     imagesMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError), err.Error())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDeleteVMSS(t *testing.T) {
 	const (
 		resourceGroup = "my-rg"
@@ -924,6 +1206,34 @@ func getFakeSkus() []compute.ResourceSku {
 				},
 			},
 		},
+		{
+			Name:         to.StringPtr("VM_SIZE_TL"),
+			ResourceType: to.StringPtr(string(resourceskus.VirtualMachines)),
+			Kind:         to.StringPtr(string(resourceskus.VirtualMachines)),
+			Locations: &[]string{
+				"test-location",
+			},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: to.StringPtr("test-location"),
+					Zones:    &[]string{"1", "3"},
+				},
+			},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{
+					Name:  to.StringPtr(resourceskus.VCPUs),
+					Value: to.StringPtr("4"),
+				},
+				{
+					Name:  to.StringPtr(resourceskus.MemoryGB),
+					Value: to.StringPtr("8"),
+				},
+				{
+					Name:  to.StringPtr(resourceskus.HyperVGenerations),
+					Value: to.StringPtr("V1,V2"),
+				},
+			},
+		},
 		{
 			Name:         to.StringPtr("VM_SIZE_USSD"),
 			ResourceType: to.StringPtr(string(resourceskus.VirtualMachines)),
@@ -1323,7 +1633,6 @@ func setupDefaultVMSSInProgressOperationDoneExpectations(s *mock_scalesets.MockS
 func setupDefaultVMSSStartCreatingExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
 	setupDefaultVMSSExpectations(s)
 	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
 	m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
 		Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
 }
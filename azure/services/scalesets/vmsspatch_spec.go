@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalesets
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// VMSSPatchSpec defines the specification for a PATCH update of an existing VMScaleSet.
+type VMSSPatchSpec struct {
+	Name          string
+	ResourceGroup string
+	// VMSS is the desired scale set, built from the ScaleSetSpec.
+	VMSS compute.VirtualMachineScaleSet
+	// Capacity is the desired number of instances of the scale set.
+	Capacity int64
+	// MaxSurge is the number of instances that can be added to the scale set to roll out a new model.
+	MaxSurge int
+	// Instances are the instances of the existing scale set, used to tell whether its latest model is rolled out.
+	Instances []azure.VMSSVM
+}
+
+// ResourceName returns the name of the VMSS.
+func (s *VMSSPatchSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *VMSSPatchSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for VMSS.
+func (s *VMSSPatchSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns nil since VMSSPatchSpec only updates existing scale sets, which are created by the scalesets service.
+func (s *VMSSPatchSpec) Parameters(existing interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+// PatchParameters returns the parameters to update the existing VMSS, or nil if the model of the scale set does not
+// change and its replica count does not increase. Decreases in replica count are handled by deleting
+// AzureMachinePoolMachine instances in the MachinePoolScope.
+// The network profile is left out of the update, so that it does not overwrite the changes made by the cloud provider.
+func (s *VMSSPatchSpec) PatchParameters(existing interface{}) (interface{}, error) {
+	existingVMSS, ok := existing.(compute.VirtualMachineScaleSet)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSet", existing)
+	}
+	infraVMSS := converters.SDKToVMSS(existingVMSS, nil)
+	infraVMSS.Instances = s.Instances
+
+	capacity, hasModelChanges := s.desiredCapacity(infraVMSS)
+	if capacity <= infraVMSS.Capacity && !hasModelChanges {
+		return nil, nil
+	}
+
+	patch, err := getVMSSUpdateFromVMSS(s.VMSS)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate vmss patch for %s", s.Name)
+	}
+	patch.Sku.Capacity = to.Int64Ptr(capacity)
+	return patch, nil
+}
+
+// desiredCapacity returns the capacity of the scale set after the update, surged to roll out a new model if needed,
+// and whether the model of the existing scale set differs from the desired one.
+func (s *VMSSPatchSpec) desiredCapacity(existing *azure.VMSS) (capacity int64, hasModelChanges bool) {
+	hasModelChanges = hasModelModifyingDifferences(existing, s.VMSS)
+	capacity = s.Capacity
+	if s.MaxSurge > 0 && (hasModelChanges || !existing.HasEnoughLatestModelOrNotMixedModel()) {
+		// surge capacity with the intention of lowering during instance reconciliation
+		capacity += int64(s.MaxSurge)
+	}
+	return capacity, hasModelChanges
+}
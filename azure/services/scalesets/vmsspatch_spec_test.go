@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalesets
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestVMSSPatchSpecPatchParameters(t *testing.T) {
+	existingVMSS := newDefaultExistingVMSS("VM_SIZE")
+	existingVMSS.Sku.Capacity = to.Int64Ptr(2)
+
+	updatedImageVMSS := newDefaultExistingVMSS("VM_SIZE")
+	updatedImageVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+
+	testcases := []struct {
+		name          string
+		spec          *VMSSPatchSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "scale set without model changes and with the same capacity is not updated",
+			spec: &VMSSPatchSpec{
+				Name:          defaultVMSSName,
+				ResourceGroup: defaultResourceGroup,
+				VMSS:          newDefaultExistingVMSS("VM_SIZE"),
+				Capacity:      2,
+				MaxSurge:      1,
+			},
+			existing: existingVMSS,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "scale set without model changes and with a lower capacity is not updated",
+			spec: &VMSSPatchSpec{
+				Name:          defaultVMSSName,
+				ResourceGroup: defaultResourceGroup,
+				VMSS:          newDefaultExistingVMSS("VM_SIZE"),
+				Capacity:      1,
+				MaxSurge:      1,
+			},
+			existing: existingVMSS,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "scale set with a higher capacity is scaled out",
+			spec: &VMSSPatchSpec{
+				Name:          defaultVMSSName,
+				ResourceGroup: defaultResourceGroup,
+				VMSS:          newDefaultExistingVMSS("VM_SIZE"),
+				Capacity:      3,
+				MaxSurge:      1,
+			},
+			existing: existingVMSS,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachineScaleSetUpdate{}))
+				patch := result.(compute.VirtualMachineScaleSetUpdate)
+				g.Expect(patch.Sku.Capacity).To(Equal(to.Int64Ptr(3)))
+				g.Expect(patch.VirtualMachineProfile.NetworkProfile).To(BeNil())
+			},
+		},
+		{
+			name: "scale set with model changes is surged",
+			spec: &VMSSPatchSpec{
+				Name:          defaultVMSSName,
+				ResourceGroup: defaultResourceGroup,
+				VMSS:          updatedImageVMSS,
+				Capacity:      2,
+				MaxSurge:      1,
+			},
+			existing: existingVMSS,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachineScaleSetUpdate{}))
+				patch := result.(compute.VirtualMachineScaleSetUpdate)
+				g.Expect(patch.Sku.Capacity).To(Equal(to.Int64Ptr(3)))
+				g.Expect(patch.VirtualMachineProfile.StorageProfile.ImageReference.Version).To(Equal(to.StringPtr("2.0")))
+			},
+		},
+		{
+			name: "scale set with model changes is not surged without max surge",
+			spec: &VMSSPatchSpec{
+				Name:          defaultVMSSName,
+				ResourceGroup: defaultResourceGroup,
+				VMSS:          updatedImageVMSS,
+				Capacity:      2,
+				MaxSurge:      0,
+			},
+			existing: existingVMSS,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachineScaleSetUpdate{}))
+				patch := result.(compute.VirtualMachineScaleSetUpdate)
+				g.Expect(patch.Sku.Capacity).To(Equal(to.Int64Ptr(2)))
+			},
+		},
+		{
+			name: "existing is not a scale set",
+			spec: &VMSSPatchSpec{
+				Name:          defaultVMSSName,
+				ResourceGroup: defaultResourceGroup,
+			},
+			existing:      "foo",
+			expectedError: "string is not a compute.VirtualMachineScaleSet",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.PatchParameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
@@ -27,7 +27,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "securitygroups"
+// ServiceName is the name of this service.
+const ServiceName = "securitygroups"
 
 // NSGScope defines the scope interface for a security groups service.
 type NSGScope interface {
@@ -54,7 +55,7 @@ func New(scope NSGScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a set of network security groups.
@@ -78,20 +79,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	var resErr error
-
-	// We go through the list of security groups to reconcile each one, independently of the result of the previous one.
+	// We reconcile the security groups concurrently, each one independently of the result of the others.
 	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	for _, nsgSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, nsgSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || resErr == nil {
-				resErr = err
-			}
-		}
-	}
+	_, resErr := async.CreateOrUpdateResources(ctx, s.Reconciler, specs, ServiceName)
 
-	s.Scope.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, resErr)
+	s.Scope.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, resErr)
 	return resErr
 }
 
@@ -116,20 +108,11 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	var result error
-
-	// We go through the list of security groups to delete each one, independently of the result of the previous one.
+	// We delete the security groups concurrently, each one independently of the result of the others.
 	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	for _, nsgSpec := range specs {
-		if err := s.DeleteResource(ctx, nsgSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
+	result := async.DeleteResources(ctx, s.Reconciler, specs, ServiceName)
 
-	s.Scope.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, result)
+	s.Scope.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, result)
 	return result
 }
 
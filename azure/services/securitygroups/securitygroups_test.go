@@ -86,9 +86,9 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -97,9 +97,9 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -108,9 +108,9 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil, errFake)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -119,8 +119,8 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, notDoneError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -172,9 +172,9 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -183,9 +183,9 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -194,9 +194,9 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG, &fakeNSG2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(errFake)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG2, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -205,8 +205,8 @@ func TestDeleteSecurityGroups(t *testing.T) {
 			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&fakeNSG})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, notDoneError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeNSG, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -17,6 +17,8 @@ limitations under the License.
 package securitygroups
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
@@ -32,6 +34,7 @@ type NSGSpec struct {
 	SecurityRules  infrav1.SecurityRules
 	Location       string
 	ClusterName    string
+	SubscriptionID string
 	ResourceGroup  string
 	AdditionalTags infrav1.Tags
 }
@@ -68,7 +71,7 @@ func (s *NSGSpec) Parameters(existing interface{}) (interface{}, error) {
 		update := false
 		securityRules = *existingNSG.SecurityRules
 		for _, rule := range s.SecurityRules {
-			sdkRule := converters.SecurityRuleToSDK(rule)
+			sdkRule := converters.SecurityRuleToSDK(rule, s.SubscriptionID, s.ResourceGroup)
 			if !ruleExists(securityRules, sdkRule) {
 				update = true
 				securityRules = append(securityRules, sdkRule)
@@ -81,7 +84,7 @@ func (s *NSGSpec) Parameters(existing interface{}) (interface{}, error) {
 	} else {
 		// new security group
 		for _, rule := range s.SecurityRules {
-			securityRules = append(securityRules, converters.SecurityRuleToSDK(rule))
+			securityRules = append(securityRules, converters.SecurityRuleToSDK(rule, s.SubscriptionID, s.ResourceGroup))
 		}
 	}
 
@@ -100,6 +103,136 @@ func (s *NSGSpec) Parameters(existing interface{}) (interface{}, error) {
 	}, nil
 }
 
+// Drift returns the fields of the security rules in the spec that are missing or differ in the existing security group.
+// Rules that are not part of the spec, such as the ones added by the Azure cloud provider, are ignored.
+func (s *NSGSpec) Drift(existing interface{}) ([]string, error) {
+	existingNSG, ok := existing.(network.SecurityGroup)
+	if !ok {
+		return nil, errors.Errorf("%T is not a network.SecurityGroup", existing)
+	}
+
+	var existingRules []network.SecurityRule
+	if existingNSG.SecurityGroupPropertiesFormat != nil && existingNSG.SecurityRules != nil {
+		existingRules = *existingNSG.SecurityRules
+	}
+
+	var drift []string
+	for _, rule := range s.SecurityRules {
+		want := converters.SecurityRuleToSDK(rule, s.SubscriptionID, s.ResourceGroup)
+		got, found := findRule(existingRules, rule.Name)
+		if !found {
+			drift = append(drift, fmt.Sprintf("securityRules[%s]", rule.Name))
+			continue
+		}
+		for _, field := range ruleDrift(got, want) {
+			drift = append(drift, fmt.Sprintf("securityRules[%s].%s", rule.Name, field))
+		}
+	}
+	return drift, nil
+}
+
+// findRule returns the rule with the given name.
+func findRule(rules []network.SecurityRule, name string) (network.SecurityRule, bool) {
+	for _, rule := range rules {
+		if strings.EqualFold(to.String(rule.Name), name) {
+			return rule, true
+		}
+	}
+	return network.SecurityRule{}, false
+}
+
+// ruleDrift returns the names of the properties of existing that differ from want.
+func ruleDrift(existing, want network.SecurityRule) []string {
+	got := existing.SecurityRulePropertiesFormat
+	if got == nil {
+		got = &network.SecurityRulePropertiesFormat{}
+	}
+	expected := want.SecurityRulePropertiesFormat
+
+	var fields []string
+	if !strings.EqualFold(string(got.Protocol), string(expected.Protocol)) {
+		fields = append(fields, "protocol")
+	}
+	if !strings.EqualFold(string(got.Access), string(expected.Access)) {
+		fields = append(fields, "access")
+	}
+	if !strings.EqualFold(string(got.Direction), string(expected.Direction)) {
+		fields = append(fields, "direction")
+	}
+	if to.Int32(got.Priority) != to.Int32(expected.Priority) {
+		fields = append(fields, "priority")
+	}
+	if !strings.EqualFold(to.String(got.SourceAddressPrefix), to.String(expected.SourceAddressPrefix)) {
+		fields = append(fields, "sourceAddressPrefix")
+	}
+	if !strings.EqualFold(to.String(got.SourcePortRange), to.String(expected.SourcePortRange)) {
+		fields = append(fields, "sourcePortRange")
+	}
+	if !strings.EqualFold(to.String(got.DestinationAddressPrefix), to.String(expected.DestinationAddressPrefix)) {
+		fields = append(fields, "destinationAddressPrefix")
+	}
+	if !strings.EqualFold(to.String(got.DestinationPortRange), to.String(expected.DestinationPortRange)) {
+		fields = append(fields, "destinationPortRange")
+	}
+	if !equalFoldSets(stringSlice(got.SourceAddressPrefixes), stringSlice(expected.SourceAddressPrefixes)) {
+		fields = append(fields, "sourceAddressPrefixes")
+	}
+	if !equalFoldSets(stringSlice(got.DestinationAddressPrefixes), stringSlice(expected.DestinationAddressPrefixes)) {
+		fields = append(fields, "destinationAddressPrefixes")
+	}
+	if !equalFoldSets(applicationSecurityGroupIDs(got.SourceApplicationSecurityGroups), applicationSecurityGroupIDs(expected.SourceApplicationSecurityGroups)) {
+		fields = append(fields, "sourceApplicationSecurityGroups")
+	}
+	if !equalFoldSets(applicationSecurityGroupIDs(got.DestinationApplicationSecurityGroups), applicationSecurityGroupIDs(expected.DestinationApplicationSecurityGroups)) {
+		fields = append(fields, "destinationApplicationSecurityGroups")
+	}
+	return fields
+}
+
+// stringSlice returns the strings of an optional SDK list.
+func stringSlice(s *[]string) []string {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// applicationSecurityGroupIDs returns the IDs of the referenced application security groups.
+func applicationSecurityGroupIDs(asgs *[]network.ApplicationSecurityGroup) []string {
+	if asgs == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(*asgs))
+	for _, asg := range *asgs {
+		ids = append(ids, to.String(asg.ID))
+	}
+	return ids
+}
+
+// equalFoldSets returns true if a and b contain the same strings, ignoring order and case.
+func equalFoldSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = sortedLower(a), sortedLower(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedLower returns a sorted, lower-cased copy of s.
+func sortedLower(s []string) []string {
+	lower := make([]string, len(s))
+	for i, item := range s {
+		lower[i] = strings.ToLower(item)
+	}
+	sort.Strings(lower)
+	return lower
+}
+
 // TODO: review this logic and make sure it is what we want. It seems incorrect to skip rules that don't have a certain protocol, etc.
 func ruleExists(rules []network.SecurityRule, rule network.SecurityRule) bool {
 	for _, existingRule := range rules {
@@ -60,6 +60,18 @@ var (
 		Destination:      to.StringPtr("*"),
 		DestinationPorts: to.StringPtr("80"),
 	}
+	etcdRule = infrav1.SecurityRule{
+		Name:                                 "deny_etcd",
+		Description:                          "Deny etcd from nodes",
+		Priority:                             300,
+		Protocol:                             infrav1.SecurityGroupProtocolTCP,
+		Direction:                            infrav1.SecurityRuleDirectionInbound,
+		Action:                               infrav1.SecurityRuleAccessDeny,
+		Sources:                              []string{"10.1.0.0/16", "10.2.0.0/16"},
+		SourcePorts:                          to.StringPtr("*"),
+		DestinationApplicationSecurityGroups: []string{"control-plane-asg"},
+		DestinationPorts:                     to.StringPtr("2379-2380"),
+	}
 )
 
 func TestParameters(t *testing.T) {
@@ -79,15 +91,16 @@ func TestParameters(t *testing.T) {
 					sshRule,
 					otherRule,
 				},
-				ResourceGroup: "test-group",
-				ClusterName:   "my-cluster",
+				SubscriptionID: "123",
+				ResourceGroup:  "test-group",
+				ClusterName:    "my-cluster",
 			},
 			existing: network.SecurityGroup{
 				Name: to.StringPtr("test-nsg"),
 				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
 					SecurityRules: &[]network.SecurityRule{
-						converters.SecurityRuleToSDK(sshRule),
-						converters.SecurityRuleToSDK(otherRule),
+						converters.SecurityRuleToSDK(sshRule, "123", "test-group"),
+						converters.SecurityRuleToSDK(otherRule, "123", "test-group"),
 					},
 				},
 			},
@@ -104,8 +117,9 @@ func TestParameters(t *testing.T) {
 					sshRule,
 					otherRule,
 				},
-				ResourceGroup: "test-group",
-				ClusterName:   "my-cluster",
+				SubscriptionID: "123",
+				ResourceGroup:  "test-group",
+				ClusterName:    "my-cluster",
 			},
 			existing: network.SecurityGroup{
 				Name:     to.StringPtr("test-nsg"),
@@ -113,8 +127,8 @@ func TestParameters(t *testing.T) {
 				Etag:     to.StringPtr("fake-etag"),
 				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
 					SecurityRules: &[]network.SecurityRule{
-						converters.SecurityRuleToSDK(sshRule),
-						converters.SecurityRuleToSDK(customRule),
+						converters.SecurityRuleToSDK(sshRule, "123", "test-group"),
+						converters.SecurityRuleToSDK(customRule, "123", "test-group"),
 					},
 				},
 			},
@@ -125,9 +139,9 @@ func TestParameters(t *testing.T) {
 					Etag:     to.StringPtr("fake-etag"),
 					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
 						SecurityRules: &[]network.SecurityRule{
-							converters.SecurityRuleToSDK(sshRule),
-							converters.SecurityRuleToSDK(customRule),
-							converters.SecurityRuleToSDK(otherRule),
+							converters.SecurityRuleToSDK(sshRule, "123", "test-group"),
+							converters.SecurityRuleToSDK(customRule, "123", "test-group"),
+							converters.SecurityRuleToSDK(otherRule, "123", "test-group"),
 						},
 					},
 					Tags: map[string]*string{
@@ -146,8 +160,9 @@ func TestParameters(t *testing.T) {
 					sshRule,
 					otherRule,
 				},
-				ResourceGroup: "test-group",
-				ClusterName:   "my-cluster",
+				SubscriptionID: "123",
+				ResourceGroup:  "test-group",
+				ClusterName:    "my-cluster",
 			},
 			existing: nil,
 			expect: func(g *WithT, result interface{}) {
@@ -155,8 +170,8 @@ func TestParameters(t *testing.T) {
 				g.Expect(result).To(Equal(network.SecurityGroup{
 					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
 						SecurityRules: &[]network.SecurityRule{
-							converters.SecurityRuleToSDK(sshRule),
-							converters.SecurityRuleToSDK(otherRule),
+							converters.SecurityRuleToSDK(sshRule, "123", "test-group"),
+							converters.SecurityRuleToSDK(otherRule, "123", "test-group"),
 						},
 					},
 					Location: to.StringPtr("test-location"),
@@ -167,6 +182,39 @@ func TestParameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "NSG does not exist, with a deny rule referencing application security groups",
+			spec: &NSGSpec{
+				Name:           "test-nsg",
+				Location:       "test-location",
+				SecurityRules:  infrav1.SecurityRules{etcdRule},
+				SubscriptionID: "123",
+				ResourceGroup:  "test-group",
+				ClusterName:    "my-cluster",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.SecurityGroup{}))
+				g.Expect(*result.(network.SecurityGroup).SecurityRules).To(Equal([]network.SecurityRule{
+					{
+						Name: to.StringPtr("deny_etcd"),
+						SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+							Description:           to.StringPtr("Deny etcd from nodes"),
+							Protocol:              network.SecurityRuleProtocolTCP,
+							SourcePortRange:       to.StringPtr("*"),
+							DestinationPortRange:  to.StringPtr("2379-2380"),
+							SourceAddressPrefixes: &[]string{"10.1.0.0/16", "10.2.0.0/16"},
+							DestinationApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{
+								{ID: to.StringPtr("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/control-plane-asg")},
+							},
+							Access:    network.SecurityRuleAccessDeny,
+							Priority:  to.Int32Ptr(300),
+							Direction: network.SecurityRuleDirectionInbound,
+						},
+					},
+				}))
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -223,3 +271,125 @@ func TestRuleExists(t *testing.T) {
 		})
 	}
 }
+
+func TestDrift(t *testing.T) {
+	modifiedSSHRule := converters.SecurityRuleToSDK(sshRule, "123", "test-group")
+	modifiedSSHRule.SourceAddressPrefix = to.StringPtr("10.0.0.0/8")
+	modifiedSSHRule.Priority = to.Int32Ptr(100)
+	modifiedEtcdRule := converters.SecurityRuleToSDK(etcdRule, "123", "test-group")
+	modifiedEtcdRule.Access = network.SecurityRuleAccessAllow
+	modifiedEtcdRule.SourceAddressPrefixes = &[]string{"10.1.0.0/16"}
+	modifiedEtcdRule.DestinationApplicationSecurityGroups = &[]network.ApplicationSecurityGroup{
+		{ID: to.StringPtr("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/node-asg")},
+	}
+	reorderedEtcdRule := converters.SecurityRuleToSDK(etcdRule, "123", "test-group")
+	reorderedEtcdRule.SourceAddressPrefixes = &[]string{"10.2.0.0/16", "10.1.0.0/16"}
+	reorderedEtcdRule.DestinationApplicationSecurityGroups = &[]network.ApplicationSecurityGroup{
+		{ID: to.StringPtr("/subscriptions/123/resourceGroups/TEST-GROUP/providers/Microsoft.Network/applicationSecurityGroups/control-plane-asg")},
+	}
+	duplicatedPrefixEtcdRule := converters.SecurityRuleToSDK(etcdRule, "123", "test-group")
+	duplicatedPrefixEtcdRule.SourceAddressPrefixes = &[]string{"10.1.0.0/16", "10.1.0.0/16"}
+	extendedEtcdRule := converters.SecurityRuleToSDK(etcdRule, "123", "test-group")
+	extendedEtcdRule.DestinationAddressPrefixes = &[]string{"10.0.0.0/8"}
+	extendedEtcdRule.SourceApplicationSecurityGroups = &[]network.ApplicationSecurityGroup{
+		{ID: to.StringPtr("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/node-asg")},
+	}
+	securityGroupWithEtcdRule := func(etcd network.SecurityRule) network.SecurityGroup {
+		return network.SecurityGroup{
+			SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+				SecurityRules: &[]network.SecurityRule{
+					converters.SecurityRuleToSDK(sshRule, "123", "test-group"),
+					converters.SecurityRuleToSDK(otherRule, "123", "test-group"),
+					etcd,
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name          string
+		existing      interface{}
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "no drift, extra rules are ignored",
+			existing: network.SecurityGroup{
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						converters.SecurityRuleToSDK(sshRule, "123", "test-group"),
+						converters.SecurityRuleToSDK(otherRule, "123", "test-group"),
+						converters.SecurityRuleToSDK(customRule, "123", "test-group"),
+						converters.SecurityRuleToSDK(etcdRule, "123", "test-group"),
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "rule modified and rule deleted",
+			existing: network.SecurityGroup{
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]network.SecurityRule{
+						modifiedSSHRule,
+						modifiedEtcdRule,
+					},
+				},
+			},
+			expected: []string{
+				"securityRules[allow_ssh].priority",
+				"securityRules[allow_ssh].sourceAddressPrefix",
+				"securityRules[other_rule]",
+				"securityRules[deny_etcd].access",
+				"securityRules[deny_etcd].sourceAddressPrefixes",
+				"securityRules[deny_etcd].destinationApplicationSecurityGroups",
+			},
+		},
+		{
+			name:     "no drift, address prefixes and application security groups are compared regardless of order and case",
+			existing: securityGroupWithEtcdRule(reorderedEtcdRule),
+			expected: nil,
+		},
+		{
+			name:     "duplicated address prefix",
+			existing: securityGroupWithEtcdRule(duplicatedPrefixEtcdRule),
+			expected: []string{
+				"securityRules[deny_etcd].sourceAddressPrefixes",
+			},
+		},
+		{
+			name:     "destination address prefixes and source application security groups added",
+			existing: securityGroupWithEtcdRule(extendedEtcdRule),
+			expected: []string{
+				"securityRules[deny_etcd].destinationAddressPrefixes",
+				"securityRules[deny_etcd].sourceApplicationSecurityGroups",
+			},
+		},
+		{
+			name:          "existing is not a security group",
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.SecurityGroup",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := &NSGSpec{
+				Name:           "test-nsg",
+				SecurityRules:  infrav1.SecurityRules{sshRule, otherRule, etcdRule},
+				SubscriptionID: "123",
+				ResourceGroup:  "test-group",
+			}
+			drift, err := spec.Drift(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drift).To(Equal(tc.expected))
+		})
+	}
+}
@@ -210,12 +210,12 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(fakeSubnet1, nil)
 				s.UpdateSubnetID(fakeSubnetSpec1.Name, to.String(fakeSubnet1.ID))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{to.String(fakeSubnet1.AddressPrefix)})
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -224,16 +224,16 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(fakeSubnet1, nil)
 				s.UpdateSubnetID(fakeSubnetSpec1.Name, to.String(fakeSubnet1.ID))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{to.String(fakeSubnet1.AddressPrefix)})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(fakeSubnet2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, ServiceName).Return(fakeSubnet2, nil)
 				s.UpdateSubnetID(fakeSubnetSpec2.Name, to.String(fakeSubnet2.ID))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{to.String(fakeSubnet2.AddressPrefix)})
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -242,7 +242,7 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpecNotManaged})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpecNotManaged, serviceName).Return(fakeSubnetNotManaged, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpecNotManaged, ServiceName).Return(fakeSubnetNotManaged, nil)
 				s.UpdateSubnetID(fakeSubnetSpecNotManaged.Name, to.String(fakeSubnetNotManaged.ID))
 				s.UpdateSubnetCIDRs(fakeSubnetSpecNotManaged.Name, []string{to.String(fakeSubnetNotManaged.AddressPrefix)})
 
@@ -255,12 +255,12 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeIpv6SubnetSpec})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, serviceName).Return(fakeIpv6Subnet, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, ServiceName).Return(fakeIpv6Subnet, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpec.Name, to.String(fakeIpv6Subnet.ID))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpec.Name, to.StringSlice(fakeIpv6Subnet.AddressPrefixes))
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -269,16 +269,16 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeIpv6SubnetSpec, &fakeIpv6SubnetSpecCP})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, serviceName).Return(fakeIpv6Subnet, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, ServiceName).Return(fakeIpv6Subnet, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpec.Name, to.String(fakeIpv6Subnet.ID))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpec.Name, to.StringSlice(fakeIpv6Subnet.AddressPrefixes))
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpecCP, serviceName).Return(fakeIpv6SubnetCP, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpecCP, ServiceName).Return(fakeIpv6SubnetCP, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpecCP.Name, to.String(fakeIpv6SubnetCP.ID))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpecCP.Name, to.StringSlice(fakeIpv6SubnetCP.AddressPrefixes))
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -286,10 +286,10 @@ func TestReconcileSubnets(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(nil, internalError)
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -297,7 +297,7 @@ func TestReconcileSubnets(t *testing.T) {
 			expectedError: notASubnetErr.Error(),
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(notASubnet, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(notASubnet, nil)
 			},
 		},
 		{
@@ -305,14 +305,14 @@ func TestReconcileSubnets(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(nil, internalError)
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(fakeSubnet2, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, ServiceName).Return(fakeSubnet2, nil)
 				s.UpdateSubnetID(fakeSubnetSpec2.Name, to.String(fakeSubnet2.ID))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{to.String(fakeSubnet2.AddressPrefix)})
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
+				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
@@ -366,9 +366,9 @@ func TestDeleteSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -377,9 +377,9 @@ func TestDeleteSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeCtrlPlaneSubnetSpec})
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeCtrlPlaneSubnetSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeCtrlPlaneSubnetSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -395,8 +395,8 @@ func TestDeleteSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, ServiceName, internalError)
 			},
 		},
 	}
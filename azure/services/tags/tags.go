@@ -18,6 +18,7 @@ package tags
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -27,7 +28,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "tags"
+// ServiceName is the name of this service.
+const ServiceName = "tags"
 
 // TagScope defines the scope interface for a tags service.
 type TagScope interface {
@@ -54,7 +56,7 @@ func New(scope TagScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile ensures tags are correct.
@@ -62,9 +64,15 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "tags.Service.Reconcile")
 	defer done()
 
+	planner, ok := s.Scope.(azure.OperationPlanner)
+	dryRun := ok && planner.DryRun()
+
 	for _, tagsSpec := range s.Scope.TagsSpecs() {
 		existingTags, err := s.client.GetAtScope(ctx, tagsSpec.Scope)
-		if err != nil {
+		if dryRun && azure.ResourceNotFound(err) {
+			// The resource is only planned to be created, and will be created with its tags.
+			continue
+		} else if err != nil {
 			return errors.Wrap(err, "failed to get existing tags")
 		}
 		tags := make(map[string]*string)
@@ -82,6 +90,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			return err
 		}
 		changed, createdOrUpdated, deleted, newAnnotation := tagsChanged(lastAppliedTags, tagsSpec.Tags, tags)
+		if changed && dryRun {
+			planner.PlanOperation(azure.PlannedPatch, ServiceName, "", strings.TrimPrefix(tagsSpec.Scope, "/"))
+			continue
+		}
 		if changed {
 			log.V(2).Info("Updating tags")
 			if len(createdOrUpdated) > 0 {
@@ -18,21 +18,27 @@ package virtualmachineimages
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// Client is an interface for listing VM images.
+// Client is an interface for listing and getting VM images.
 type Client interface {
 	List(ctx context.Context, location, publisher, offer, sku string) (compute.ListVirtualMachineImageResource, error)
+	Get(ctx context.Context, location, publisher, offer, sku, version string) (compute.VirtualMachineImage, error)
+	GetGalleryImage(ctx context.Context, subscriptionID, resourceGroup, gallery, name string) (compute.GalleryImage, error)
 }
 
 // AzureClient contains the Azure go-sdk Client.
 type AzureClient struct {
-	images compute.VirtualMachineImagesClient
+	images        compute.VirtualMachineImagesClient
+	galleryImages compute.GalleryImagesClient
 }
 
 var _ Client = (*AzureClient)(nil)
@@ -40,7 +46,8 @@ var _ Client = (*AzureClient)(nil)
 // NewClient creates a new VM images client from auth info.
 func NewClient(auth azure.Authorizer) *AzureClient {
 	return &AzureClient{
-		images: newVirtualMachineImagesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		images:        newVirtualMachineImagesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		galleryImages: newGalleryImagesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
 	}
 }
 
@@ -51,6 +58,13 @@ func newVirtualMachineImagesClient(subscriptionID, baseURI string, authorizer au
 	return c
 }
 
+// newGalleryImagesClient creates a new gallery images client from subscription ID, base URI and authorizer.
+func newGalleryImagesClient(subscriptionID, baseURI string, authorizer autorest.Authorizer) compute.GalleryImagesClient {
+	c := compute.NewGalleryImagesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
 // List returns a VM image list resource.
 func (ac *AzureClient) List(ctx context.Context, location, publisher, offer, sku string) (compute.ListVirtualMachineImageResource, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.AzureClient.List")
@@ -61,3 +75,34 @@ func (ac *AzureClient) List(ctx context.Context, location, publisher, offer, sku
 	var top *int32
 	return ac.images.List(ctx, location, publisher, offer, sku, expand, top, orderby)
 }
+
+// Get returns a VM image from the Azure Marketplace. The "latest" version is resolved to the most recent version of the image.
+func (ac *AzureClient) Get(ctx context.Context, location, publisher, offer, sku, version string) (compute.VirtualMachineImage, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.AzureClient.Get")
+	defer done()
+
+	if strings.EqualFold(version, azure.LatestVersion) {
+		expand, orderby := "", "name desc"
+		top := to.Int32Ptr(1)
+		images, err := ac.images.List(ctx, location, publisher, offer, sku, expand, top, orderby)
+		if err != nil {
+			return compute.VirtualMachineImage{}, err
+		}
+		if images.Value == nil || len(*images.Value) == 0 || (*images.Value)[0].Name == nil {
+			return compute.VirtualMachineImage{}, errors.Errorf("no VM image found for publisher %s, offer %s and SKU %s in location %s", publisher, offer, sku, location)
+		}
+		version = *(*images.Value)[0].Name
+	}
+
+	return ac.images.Get(ctx, location, publisher, offer, sku, version)
+}
+
+// GetGalleryImage returns the definition of an image in an Azure Compute Gallery, which may be in a different subscription than the cluster.
+func (ac *AzureClient) GetGalleryImage(ctx context.Context, subscriptionID, resourceGroup, gallery, name string) (compute.GalleryImage, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.AzureClient.GetGalleryImage")
+	defer done()
+
+	client := ac.galleryImages
+	client.SubscriptionID = subscriptionID
+	return client.Get(ctx, resourceGroup, gallery, name)
+}
@@ -43,8 +43,9 @@ func New(auth azure.Authorizer) *Service {
 	}
 }
 
-// GetDefaultUbuntuImage returns the default image spec for Ubuntu.
-func (s *Service) GetDefaultUbuntuImage(ctx context.Context, location, k8sVersion string) (*infrav1.Image, error) {
+// GetDefaultUbuntuImage returns the default image spec for Ubuntu. It returns a generation 2 image if gen2 is true,
+// e.g. for a VM with a security type.
+func (s *Service) GetDefaultUbuntuImage(ctx context.Context, location, k8sVersion string, gen2 bool) (*infrav1.Image, error) {
 	v, err := semver.ParseTolerant(k8sVersion)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to parse Kubernetes version \"%s\"", k8sVersion)
@@ -53,7 +54,7 @@ func (s *Service) GetDefaultUbuntuImage(ctx context.Context, location, k8sVersio
 	osVersion := getUbuntuOSVersion(v.Major, v.Minor, v.Patch)
 	publisher, offer := azure.DefaultImagePublisherID, azure.DefaultImageOfferID
 	skuID, version, err := s.getSKUAndVersion(
-		ctx, location, publisher, offer, k8sVersion, fmt.Sprintf("ubuntu-%s", osVersion))
+		ctx, location, publisher, offer, k8sVersion, fmt.Sprintf("ubuntu-%s", osVersion), gen2)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get default image")
 	}
@@ -72,8 +73,9 @@ func (s *Service) GetDefaultUbuntuImage(ctx context.Context, location, k8sVersio
 	return defaultImage, nil
 }
 
-// GetDefaultWindowsImage returns the default image spec for Windows.
-func (s *Service) GetDefaultWindowsImage(ctx context.Context, location, k8sVersion, runtime, osAndVersion string) (*infrav1.Image, error) {
+// GetDefaultWindowsImage returns the default image spec for Windows. It returns a generation 2 image if gen2 is true,
+// e.g. for a VM with a security type.
+func (s *Service) GetDefaultWindowsImage(ctx context.Context, location, k8sVersion, runtime, osAndVersion string, gen2 bool) (*infrav1.Image, error) {
 	v122 := semver.MustParse("1.22.0")
 	v, err := semver.ParseTolerant(k8sVersion)
 	if err != nil {
@@ -96,7 +98,7 @@ func (s *Service) GetDefaultWindowsImage(ctx context.Context, location, k8sVersi
 
 	publisher, offer := azure.DefaultImagePublisherID, azure.DefaultWindowsImageOfferID
 	skuID, version, err := s.getSKUAndVersion(
-		ctx, location, publisher, offer, k8sVersion, osAndVersion)
+		ctx, location, publisher, offer, k8sVersion, osAndVersion, gen2)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get default image")
 	}
@@ -117,24 +119,30 @@ func (s *Service) GetDefaultWindowsImage(ctx context.Context, location, k8sVersi
 
 // getSKUAndVersion gets the SKU ID and version of the image to use for the provided version of Kubernetes.
 // note: osAndVersion is expected to be in the format of {os}-{version} (ex: ubuntu-2004 or windows-2022)
-func (s *Service) getSKUAndVersion(ctx context.Context, location, publisher, offer, k8sVersion, osAndVersion string) (skuID string, imageVersion string, err error) {
+func (s *Service) getSKUAndVersion(ctx context.Context, location, publisher, offer, k8sVersion, osAndVersion string, gen2 bool) (skuID string, imageVersion string, err error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.Service.getSKUAndVersion")
 	defer done()
 
-	log.V(4).Info("Getting VM image SKU and version", "location", location, "publisher", publisher, "offer", offer, "k8sVersion", k8sVersion, "osAndVersion", osAndVersion)
+	log.V(4).Info("Getting VM image SKU and version", "location", location, "publisher", publisher, "offer", offer, "k8sVersion", k8sVersion, "osAndVersion", osAndVersion, "gen2", gen2)
 
 	v, err := semver.ParseTolerant(k8sVersion)
 	if err != nil {
 		return "", "", errors.Wrapf(err, "unable to parse Kubernetes version \"%s\" in spec, expected valid SemVer string", k8sVersion)
 	}
 
-	// Old SKUs before 1.21.12, 1.22.9, or 1.23.6 are named like "k8s-1dot21dot2-ubuntu-2004".
+	// Old SKUs before 1.21.12, 1.22.9, or 1.23.6 are named like "k8s-1dot21dot2-ubuntu-2004" and are generation 1 images only.
 	if k8sVersionInSKUName(v.Major, v.Minor, v.Patch) {
+		if gen2 {
+			return "", "", errors.Errorf("no generation 2 default image is available for Kubernetes version \"%s\", an image must be specified", k8sVersion)
+		}
 		return fmt.Sprintf("k8s-%ddot%ddot%d-%s", v.Major, v.Minor, v.Patch, osAndVersion), azure.LatestVersion, nil
 	}
 
-	// New SKUs don't contain the Kubernetes version and are named like "ubuntu-2004-gen1".
+	// New SKUs don't contain the Kubernetes version and are named like "ubuntu-2004-gen1" or "ubuntu-2004-gen2".
 	sku := fmt.Sprintf("%s-gen1", osAndVersion)
+	if gen2 {
+		sku = fmt.Sprintf("%s-gen2", osAndVersion)
+	}
 
 	imageCache, err := GetCache(s.Authorizer)
 	imageCache.client = s.Client
@@ -166,7 +166,7 @@ func TestGetDefaultUbuntuImage(t *testing.T) {
 					List(gomock.Any(), location, azure.DefaultImagePublisherID, azure.DefaultImageOfferID, gomock.Any()).
 					Return(test.versions, nil)
 			}
-			image, err := svc.GetDefaultUbuntuImage(context.TODO(), location, test.k8sVersion)
+			image, err := svc.GetDefaultUbuntuImage(context.TODO(), location, test.k8sVersion, false)
 
 			g := NewWithT(t)
 			g.Expect(err).NotTo(HaveOccurred())
@@ -260,7 +260,7 @@ func TestGetDefaultWindowsImage(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			g := NewWithT(t)
-			image, err := svc.GetDefaultWindowsImage(context.TODO(), "", test.k8sVersion, test.runtime, test.osVersion)
+			image, err := svc.GetDefaultWindowsImage(context.TODO(), "", test.k8sVersion, test.runtime, test.osVersion, false)
 			if test.expectedErr != "" {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err.Error()).To(Equal(test.expectedErr))
@@ -276,6 +276,7 @@ func TestGetDefaultImageSKUID(t *testing.T) {
 	var tests = []struct {
 		k8sVersion      string
 		osAndVersion    string
+		gen2            bool
 		expectedSKU     string
 		expectedVersion string
 		expectedError   bool
@@ -436,6 +437,25 @@ func TestGetDefaultImageSKUID(t *testing.T) {
 				},
 			},
 		},
+		{
+			k8sVersion:      "v1.24.0",
+			expectedSKU:     "ubuntu-2004-gen2",
+			expectedVersion: "124.0.20220512",
+			expectedError:   false,
+			osAndVersion:    "ubuntu-2004",
+			gen2:            true,
+			versions: compute.ListVirtualMachineImageResource{
+				Value: &[]compute.VirtualMachineImageResource{
+					{Name: to.StringPtr("124.0.20220512")},
+				},
+			},
+		},
+		{
+			k8sVersion:    "v1.21.2",
+			expectedError: true,
+			osAndVersion:  "ubuntu-2004",
+			gen2:          true,
+		},
 		{
 			k8sVersion:    "v1.24.1",
 			expectedError: true,
@@ -474,7 +494,7 @@ func TestGetDefaultImageSKUID(t *testing.T) {
 					Return(test.versions, nil)
 			}
 			id, version, err := svc.getSKUAndVersion(context.TODO(), location, azure.DefaultImagePublisherID,
-				offer, test.k8sVersion, test.osAndVersion)
+				offer, test.k8sVersion, test.osAndVersion, test.gen2)
 
 			g := NewWithT(t)
 			if test.expectedError {
@@ -51,6 +51,36 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// Get mocks base method.
+func (m *MockClient) Get(ctx context.Context, location, publisher, offer, sku, version string) (compute.VirtualMachineImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, location, publisher, offer, sku, version)
+	ret0, _ := ret[0].(compute.VirtualMachineImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockClientMockRecorder) Get(ctx, location, publisher, offer, sku, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockClient)(nil).Get), ctx, location, publisher, offer, sku, version)
+}
+
+// GetGalleryImage mocks base method.
+func (m *MockClient) GetGalleryImage(ctx context.Context, subscriptionID, resourceGroup, gallery, name string) (compute.GalleryImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGalleryImage", ctx, subscriptionID, resourceGroup, gallery, name)
+	ret0, _ := ret[0].(compute.GalleryImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGalleryImage indicates an expected call of GetGalleryImage.
+func (mr *MockClientMockRecorder) GetGalleryImage(ctx, subscriptionID, resourceGroup, gallery, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGalleryImage", reflect.TypeOf((*MockClient)(nil).GetGalleryImage), ctx, subscriptionID, resourceGroup, gallery, name)
+}
+
 // List mocks base method.
 func (m *MockClient) List(ctx context.Context, location, publisher, offer, sku string) (compute.ListVirtualMachineImageResource, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualmachineimages
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// securityTypeFeature is the name of the image feature declaring the security types supported by an image.
+const securityTypeFeature = "SecurityType"
+
+// SecurityFeatures describes the security features of a VM image.
+type SecurityFeatures struct {
+	// HyperVGeneration is the Hyper-V generation of the image, e.g. "V2".
+	HyperVGeneration string
+	// SecurityType is the value of the SecurityType feature of the image, e.g. "TrustedLaunchSupported".
+	// It is empty if the image does not declare it.
+	SecurityType string
+}
+
+// SupportsSecurityType returns whether a VM with the given security type can be created from the image.
+// Trusted launch and confidential VMs require a generation 2 image, and confidential VMs additionally
+// require an image that declares its support for them.
+func (f SecurityFeatures) SupportsSecurityType(securityType infrav1.SecurityTypes) bool {
+	if !strings.EqualFold(f.HyperVGeneration, string(compute.HyperVGenerationV2)) {
+		return false
+	}
+
+	declared := strings.ToLower(f.SecurityType)
+	switch securityType {
+	case infrav1.SecurityTypesTrustedLaunch:
+		return declared == "" || strings.Contains(declared, "trustedlaunch")
+	case infrav1.SecurityTypesConfidentialVM:
+		return strings.Contains(declared, "confidentialvm")
+	default:
+		return true
+	}
+}
+
+// GetSecurityFeatures returns the security features of an Azure Marketplace or Azure Compute Gallery image.
+// It returns nil for images referenced by ID and for gallery images without a subscription and resource group,
+// whose definitions cannot be read.
+func GetSecurityFeatures(ctx context.Context, client Client, location string, image *infrav1.Image) (*SecurityFeatures, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachineimages.GetSecurityFeatures")
+	defer done()
+
+	switch {
+	case image == nil:
+		return nil, nil
+	case image.Marketplace != nil:
+		marketplace := image.Marketplace
+		vmImage, err := client.Get(ctx, location, marketplace.Publisher, marketplace.Offer, marketplace.SKU, marketplace.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get VM image %s/%s/%s/%s", marketplace.Publisher, marketplace.Offer, marketplace.SKU, marketplace.Version)
+		}
+		if vmImage.VirtualMachineImageProperties == nil {
+			return &SecurityFeatures{}, nil
+		}
+		features := &SecurityFeatures{HyperVGeneration: string(vmImage.HyperVGeneration)}
+		if vmImage.Features != nil {
+			for _, feature := range *vmImage.Features {
+				if strings.EqualFold(to.String(feature.Name), securityTypeFeature) {
+					features.SecurityType = to.String(feature.Value)
+				}
+			}
+		}
+		return features, nil
+	case image.SharedGallery != nil:
+		gallery := image.SharedGallery
+		return getGalleryImageSecurityFeatures(ctx, client, gallery.SubscriptionID, gallery.ResourceGroup, gallery.Gallery, gallery.Name)
+	case image.ComputeGallery != nil && image.ComputeGallery.SubscriptionID != nil && image.ComputeGallery.ResourceGroup != nil:
+		gallery := image.ComputeGallery
+		return getGalleryImageSecurityFeatures(ctx, client, *gallery.SubscriptionID, *gallery.ResourceGroup, gallery.Gallery, gallery.Name)
+	default:
+		return nil, nil
+	}
+}
+
+// getGalleryImageSecurityFeatures returns the security features of the definition of an Azure Compute Gallery image.
+func getGalleryImageSecurityFeatures(ctx context.Context, client Client, subscriptionID, resourceGroup, gallery, name string) (*SecurityFeatures, error) {
+	galleryImage, err := client.GetGalleryImage(ctx, subscriptionID, resourceGroup, gallery, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get image %s in gallery %s", name, gallery)
+	}
+	if galleryImage.GalleryImageProperties == nil {
+		return &SecurityFeatures{}, nil
+	}
+
+	features := &SecurityFeatures{HyperVGeneration: string(galleryImage.HyperVGeneration)}
+	if galleryImage.Features != nil {
+		for _, feature := range *galleryImage.Features {
+			if strings.EqualFold(to.String(feature.Name), securityTypeFeature) {
+				features.SecurityType = to.String(feature.Value)
+			}
+		}
+	}
+	return features, nil
+}
+
+// ValidateSecurityType returns a terminal error if a VM with the given security type cannot be created from the image.
+func ValidateSecurityType(ctx context.Context, client Client, location string, image *infrav1.Image, securityType infrav1.SecurityTypes) error {
+	if securityType == "" {
+		return nil
+	}
+
+	features, err := GetSecurityFeatures(ctx, client, location, image)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the security features of the VM image")
+	}
+	if features == nil {
+		// The image definition cannot be read, let Azure reject the VM if the image does not support the security type.
+		return nil
+	}
+
+	if !features.SupportsSecurityType(securityType) {
+		return azure.WithTerminalError(errors.Errorf("the VM image does not support security type %s: it has Hyper-V generation %q and security type feature %q",
+			securityType, features.HyperVGeneration, features.SecurityType))
+	}
+	return nil
+}
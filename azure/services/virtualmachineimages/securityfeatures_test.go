@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualmachineimages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
+)
+
+func TestSupportsSecurityType(t *testing.T) {
+	tests := []struct {
+		name         string
+		features     SecurityFeatures
+		securityType infrav1.SecurityTypes
+		want         bool
+	}{
+		{
+			name:         "generation 1 image does not support trusted launch",
+			features:     SecurityFeatures{HyperVGeneration: "V1"},
+			securityType: infrav1.SecurityTypesTrustedLaunch,
+			want:         false,
+		},
+		{
+			name:         "generation 2 image supports trusted launch",
+			features:     SecurityFeatures{HyperVGeneration: "V2"},
+			securityType: infrav1.SecurityTypesTrustedLaunch,
+			want:         true,
+		},
+		{
+			name:         "generation 2 image declaring trusted launch supports it",
+			features:     SecurityFeatures{HyperVGeneration: "V2", SecurityType: "TrustedLaunchSupported"},
+			securityType: infrav1.SecurityTypesTrustedLaunch,
+			want:         true,
+		},
+		{
+			name:         "confidential vm image does not support trusted launch",
+			features:     SecurityFeatures{HyperVGeneration: "V2", SecurityType: "ConfidentialVMSupported"},
+			securityType: infrav1.SecurityTypesTrustedLaunch,
+			want:         false,
+		},
+		{
+			name:         "generation 2 image without declaration does not support confidential vms",
+			features:     SecurityFeatures{HyperVGeneration: "V2"},
+			securityType: infrav1.SecurityTypesConfidentialVM,
+			want:         false,
+		},
+		{
+			name:         "image declaring both security types supports confidential vms",
+			features:     SecurityFeatures{HyperVGeneration: "V2", SecurityType: "TrustedLaunchAndConfidentialVMSupported"},
+			securityType: infrav1.SecurityTypesConfidentialVM,
+			want:         true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.features.SupportsSecurityType(tt.securityType)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidateSecurityType(t *testing.T) {
+	marketplaceImage := &infrav1.Image{
+		Marketplace: &infrav1.AzureMarketplaceImage{
+			ImagePlan: infrav1.ImagePlan{
+				Publisher: "fake-publisher",
+				Offer:     "fake-offer",
+				SKU:       "fake-sku",
+			},
+			Version: "latest",
+		},
+	}
+	galleryImage := &infrav1.Image{
+		SharedGallery: &infrav1.AzureSharedGalleryImage{
+			SubscriptionID: "fake-subscription",
+			ResourceGroup:  "fake-rg",
+			Gallery:        "fake-gallery",
+			Name:           "fake-image",
+			Version:        "1.0.0",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		image         *infrav1.Image
+		securityType  infrav1.SecurityTypes
+		expect        func(m *mock_virtualmachineimages.MockClientMockRecorder)
+		expectedError string
+	}{
+		{
+			name:         "no security type",
+			image:        marketplaceImage,
+			securityType: "",
+			expect:       func(m *mock_virtualmachineimages.MockClientMockRecorder) {},
+		},
+		{
+			name:         "image referenced by id is not checked",
+			image:        &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+			securityType: infrav1.SecurityTypesTrustedLaunch,
+			expect:       func(m *mock_virtualmachineimages.MockClientMockRecorder) {},
+		},
+		{
+			name:         "marketplace image supports trusted launch",
+			image:        marketplaceImage,
+			securityType: infrav1.SecurityTypesTrustedLaunch,
+			expect: func(m *mock_virtualmachineimages.MockClientMockRecorder) {
+				m.Get(gomock.Any(), "test-location", "fake-publisher", "fake-offer", "fake-sku", "latest").Return(compute.VirtualMachineImage{
+					VirtualMachineImageProperties: &compute.VirtualMachineImageProperties{HyperVGeneration: compute.HyperVGenerationTypesV2},
+				}, nil)
+			},
+		},
+		{
+			name:         "marketplace image does not support trusted launch",
+			image:        marketplaceImage,
+			securityType: infrav1.SecurityTypesTrustedLaunch,
+			expect: func(m *mock_virtualmachineimages.MockClientMockRecorder) {
+				m.Get(gomock.Any(), "test-location", "fake-publisher", "fake-offer", "fake-sku", "latest").Return(compute.VirtualMachineImage{
+					VirtualMachineImageProperties: &compute.VirtualMachineImageProperties{HyperVGeneration: compute.HyperVGenerationTypesV1},
+				}, nil)
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: the VM image does not support security type TrustedLaunch: it has Hyper-V generation \"V1\" and security type feature \"\". Object will not be requeued",
+		},
+		{
+			name:         "gallery image supports confidential vms",
+			image:        galleryImage,
+			securityType: infrav1.SecurityTypesConfidentialVM,
+			expect: func(m *mock_virtualmachineimages.MockClientMockRecorder) {
+				m.GetGalleryImage(gomock.Any(), "fake-subscription", "fake-rg", "fake-gallery", "fake-image").Return(compute.GalleryImage{
+					GalleryImageProperties: &compute.GalleryImageProperties{
+						HyperVGeneration: compute.HyperVGenerationV2,
+						Features: &[]compute.GalleryImageFeature{
+							{Name: to.StringPtr("SecurityType"), Value: to.StringPtr("ConfidentialVMSupported")},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:         "gallery image cannot be read",
+			image:        galleryImage,
+			securityType: infrav1.SecurityTypesConfidentialVM,
+			expect: func(m *mock_virtualmachineimages.MockClientMockRecorder) {
+				m.GetGalleryImage(gomock.Any(), "fake-subscription", "fake-rg", "fake-gallery", "fake-image").Return(compute.GalleryImage{}, errors.New("not found"))
+			},
+			expectedError: "failed to get the security features of the VM image: failed to get image fake-image in gallery fake-gallery: not found",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			clientMock := mock_virtualmachineimages.NewMockClient(mockCtrl)
+			tt.expect(clientMock.EXPECT())
+
+			err := ValidateSecurityType(context.TODO(), clientMock, "test-location", tt.image, tt.securityType)
+			if tt.expectedError != "" {
+				g.Expect(err).To(MatchError(tt.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
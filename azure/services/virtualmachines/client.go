@@ -19,6 +19,10 @@ package virtualmachines
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
@@ -31,6 +35,18 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+const (
+	// serialConsoleLogSASExpirationMinutes is the lifetime of the SAS URI used to download the serial console log of a VM.
+	serialConsoleLogSASExpirationMinutes = 5
+	// serialConsoleLogMaxBytes is the size of the end of the serial console log of a VM that is downloaded.
+	serialConsoleLogMaxBytes = 64 * 1024
+	// serialConsoleLogTimeout bounds the time spent retrieving the serial console log of a VM.
+	serialConsoleLogTimeout = 30 * time.Second
+)
+
+// serialConsoleLogHTTPClient downloads the serial console logs from the storage accounts of the boot diagnostics of VMs.
+var serialConsoleLogHTTPClient = &http.Client{Timeout: serialConsoleLogTimeout}
+
 // AzureClient contains the Azure go-sdk Client.
 type AzureClient struct {
 	virtualmachines compute.VirtualMachinesClient
@@ -88,6 +104,37 @@ func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.Resou
 	return result, nil, err
 }
 
+// UpdateAsync updates a virtual machine asynchronously.
+// It sends a PATCH request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *AzureClient) UpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Update")
+	defer done()
+
+	vm, ok := parameters.(compute.VirtualMachineUpdate)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a compute.VirtualMachineUpdate", parameters)
+	}
+
+	updateFuture, err := ac.virtualmachines.Update(ctx, spec.ResourceGroupName(), spec.ResourceName(), vm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = updateFuture.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, &updateFuture, err
+	}
+	result, err = updateFuture.Result(ac.virtualmachines)
+	// if the operation completed, return a nil future
+	return result, nil, err
+}
+
 // DeleteAsync deletes a virtual machine asynchronously. DeleteAsync sends a DELETE
 // request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
@@ -169,3 +216,67 @@ func (ac *AzureClient) Result(ctx context.Context, future azureautorest.FutureAP
 		return nil, errors.Errorf("unknown future type %q", futureType)
 	}
 }
+
+// GetSerialConsoleLog downloads the end of the serial console log of a virtual machine from its boot diagnostics.
+// At most the last serialConsoleLogMaxBytes bytes of the log are returned.
+func (ac *AzureClient) GetSerialConsoleLog(ctx context.Context, resourceGroupName, vmName string) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.GetSerialConsoleLog")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, serialConsoleLogTimeout)
+	defer cancel()
+
+	data, err := ac.virtualmachines.RetrieveBootDiagnosticsData(ctx, resourceGroupName, vmName, to.Int32Ptr(serialConsoleLogSASExpirationMinutes))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve boot diagnostics data")
+	}
+	blobURI := to.String(data.SerialConsoleLogBlobURI)
+	if blobURI == "" {
+		return "", errors.Errorf("boot diagnostics of virtual machine %s have no serial console log", vmName)
+	}
+
+	size, err := serialConsoleLogSize(ctx, blobURI)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, blobURI, http.NoBody)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create serial console log request")
+	}
+	if size > serialConsoleLogMaxBytes {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", size-serialConsoleLogMaxBytes))
+	}
+	resp, err := serialConsoleLogHTTPClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to download serial console log")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return "", errors.Errorf("failed to download serial console log: %s", resp.Status)
+	}
+	log, err := io.ReadAll(io.LimitReader(resp.Body, serialConsoleLogMaxBytes))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read serial console log")
+	}
+	return string(log), nil
+}
+
+// serialConsoleLogSize returns the size in bytes of the serial console log blob of a virtual machine.
+func serialConsoleLogSize(ctx context.Context, blobURI string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, blobURI, http.NoBody)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create serial console log request")
+	}
+	resp, err := serialConsoleLogHTTPClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get serial console log properties")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("failed to get serial console log properties: %s", resp.Status)
+	}
+	return resp.ContentLength, nil
+}
@@ -19,6 +19,8 @@ package virtualmachines
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest/to"
@@ -32,28 +34,33 @@ import (
 
 // VMSpec defines the specification for a Virtual Machine.
 type VMSpec struct {
-	Name                   string
-	ResourceGroup          string
-	Location               string
-	ClusterName            string
-	Role                   string
-	NICIDs                 []string
-	SSHKeyData             string
-	Size                   string
-	AvailabilitySetID      string
-	Zone                   string
-	Identity               infrav1.VMIdentity
-	OSDisk                 infrav1.OSDisk
-	DataDisks              []infrav1.DataDisk
-	UserAssignedIdentities []infrav1.UserAssignedIdentity
-	SpotVMOptions          *infrav1.SpotVMOptions
-	SecurityProfile        *infrav1.SecurityProfile
-	AdditionalTags         infrav1.Tags
-	AdditionalCapabilities *infrav1.AdditionalCapabilities
-	SKU                    resourceskus.SKU
-	Image                  *infrav1.Image
-	BootstrapData          string
-	ProviderID             string
+	Name                       string
+	ResourceGroup              string
+	Location                   string
+	ClusterName                string
+	Role                       string
+	NICIDs                     []string
+	SSHKeyData                 string
+	Size                       string
+	AvailabilitySetID          string
+	Zone                       string
+	CapacityReservationGroupID string
+	DedicatedHostGroupID       string
+	ProximityPlacementGroupID  string
+	Identity                   infrav1.VMIdentity
+	OSDisk                     infrav1.OSDisk
+	DataDisks                  []infrav1.DataDisk
+	UserAssignedIdentities     []infrav1.UserAssignedIdentity
+	SpotVMOptions              *infrav1.SpotVMOptions
+	SecurityProfile            *infrav1.SecurityProfile
+	AdditionalTags             infrav1.Tags
+	AdditionalCapabilities     *infrav1.AdditionalCapabilities
+	Diagnostics                *infrav1.Diagnostics
+	SKU                        resourceskus.SKU
+	VMSizesInFamily            []string
+	Image                      *infrav1.Image
+	BootstrapData              string
+	ProviderID                 string
 }
 
 // ResourceName returns the name of the virtual machine.
@@ -114,16 +121,13 @@ func (s *VMSpec) Parameters(existing interface{}) (params interface{}, err error
 	return compute.VirtualMachine{
 		Plan:     converters.ImageToPlan(s.Image),
 		Location: to.StringPtr(s.Location),
-		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.ClusterName,
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        to.StringPtr(s.Name),
-			Role:        to.StringPtr(s.Role),
-			Additional:  s.AdditionalTags,
-		})),
+		Tags:     s.tags(),
 		VirtualMachineProperties: &compute.VirtualMachineProperties{
-			AdditionalCapabilities: s.generateAdditionalCapabilities(),
-			AvailabilitySet:        s.getAvailabilitySet(),
+			AdditionalCapabilities:  s.generateAdditionalCapabilities(),
+			AvailabilitySet:         s.getAvailabilitySet(),
+			CapacityReservation:     s.getCapacityReservation(),
+			HostGroup:               subResource(s.DedicatedHostGroupID),
+			ProximityPlacementGroup: subResource(s.ProximityPlacementGroupID),
 			HardwareProfile: &compute.HardwareProfile{
 				VMSize: compute.VirtualMachineSizeTypes(s.Size),
 			},
@@ -133,20 +137,219 @@ func (s *VMSpec) Parameters(existing interface{}) (params interface{}, err error
 			NetworkProfile: &compute.NetworkProfile{
 				NetworkInterfaces: s.generateNICRefs(),
 			},
-			Priority:       priority,
-			EvictionPolicy: evictionPolicy,
-			BillingProfile: billingProfile,
-			DiagnosticsProfile: &compute.DiagnosticsProfile{
-				BootDiagnostics: &compute.BootDiagnostics{
-					Enabled: to.BoolPtr(true),
-				},
-			},
+			Priority:           priority,
+			EvictionPolicy:     evictionPolicy,
+			BillingProfile:     billingProfile,
+			DiagnosticsProfile: s.generateDiagnosticsProfile(),
 		},
 		Identity: identity,
 		Zones:    s.getZones(),
 	}, nil
 }
 
+// PatchParameters returns the parameters to update the in-place mutable properties of an existing virtual machine:
+// its size within the SKU family, its data disks additions and resizes, its user-assigned identities, the max price
+// of a Spot VM and its boot diagnostics. It returns nil if none of them changed.
+func (s *VMSpec) PatchParameters(existing interface{}) (params interface{}, err error) {
+	existingVM, ok := existing.(compute.VirtualMachine)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.VirtualMachine", existing)
+	}
+	properties := existingVM.VirtualMachineProperties
+	if properties == nil {
+		properties = &compute.VirtualMachineProperties{}
+	}
+
+	hardwareProfile, err := s.hardwareProfileUpdate(properties.HardwareProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	dataDisks, err := s.dataDisksUpdate(properties.StorageProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.identityUpdate(existingVM.Identity)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate VM identity")
+	}
+
+	diagnosticsProfile := s.diagnosticsProfileUpdate(properties.DiagnosticsProfile)
+
+	if hardwareProfile == nil && dataDisks == nil && identity == nil && diagnosticsProfile == nil {
+		// VM is up to date, nothing to update.
+		return nil, nil
+	}
+
+	update := compute.VirtualMachineUpdate{
+		Identity: identity,
+	}
+	if hardwareProfile != nil || dataDisks != nil || diagnosticsProfile != nil {
+		update.VirtualMachineProperties = &compute.VirtualMachineProperties{
+			HardwareProfile:    hardwareProfile,
+			DiagnosticsProfile: diagnosticsProfile,
+		}
+		if dataDisks != nil {
+			update.StorageProfile = &compute.StorageProfile{DataDisks: dataDisks}
+		}
+	}
+	return update, nil
+}
+
+// hardwareProfileUpdate returns the hardware profile to resize the VM, or nil if the VM already has the size of the spec.
+// The VM can only be resized in place to a size of the same SKU family.
+func (s *VMSpec) hardwareProfileUpdate(existing *compute.HardwareProfile) (*compute.HardwareProfile, error) {
+	if existing == nil || existing.VMSize == "" || strings.EqualFold(string(existing.VMSize), s.Size) {
+		return nil, nil
+	}
+
+	for _, size := range s.VMSizesInFamily {
+		if strings.EqualFold(size, string(existing.VMSize)) {
+			return &compute.HardwareProfile{
+				VMSize: compute.VirtualMachineSizeTypes(s.Size),
+			}, nil
+		}
+	}
+	return nil, errors.Errorf("vm size %s cannot be changed in place to %s, which is not in the same family %s", existing.VMSize, s.Size, to.String(s.SKU.Family))
+}
+
+// dataDisksUpdate returns the data disks of the VM with the new data disks of the spec added and the data disks whose size
+// grew in the spec resized, or nil if the data disks are up to date. Data disks are never removed or shrunk.
+func (s *VMSpec) dataDisksUpdate(existing *compute.StorageProfile) (*[]compute.DataDisk, error) {
+	var dataDisks []compute.DataDisk
+	if existing != nil && existing.DataDisks != nil {
+		dataDisks = append(dataDisks, *existing.DataDisks...)
+	}
+
+	updated := false
+	for _, disk := range s.DataDisks {
+		name := azure.GenerateDataDiskName(s.Name, disk.NameSuffix)
+		found := false
+		for i := range dataDisks {
+			if !strings.EqualFold(to.String(dataDisks[i].Name), name) {
+				continue
+			}
+			found = true
+			if to.Int32(dataDisks[i].DiskSizeGB) < disk.DiskSizeGB {
+				dataDisks[i].DiskSizeGB = to.Int32Ptr(disk.DiskSizeGB)
+				updated = true
+			}
+			break
+		}
+		if found {
+			continue
+		}
+
+		dataDisk, err := s.generateDataDisk(disk)
+		if err != nil {
+			// A data disk that cannot be attached must not fail an existing machine, only the update of its data disks.
+			var reconcileErr azure.ReconcileError
+			if errors.As(err, &reconcileErr) && reconcileErr.IsTerminal() {
+				err = reconcileErr.Unwrap()
+			}
+			return nil, errors.Wrapf(err, "failed to attach data disk %s", name)
+		}
+		dataDisks = append(dataDisks, dataDisk)
+		updated = true
+	}
+
+	if !updated {
+		return nil, nil
+	}
+	return &dataDisks, nil
+}
+
+// identityUpdate returns the identity to assign the user-assigned identities of the spec to the VM and unassign the other ones,
+// or nil if the user-assigned identities of the VM are up to date.
+func (s *VMSpec) identityUpdate(existing *compute.VirtualMachineIdentity) (*compute.VirtualMachineIdentity, error) {
+	if s.Identity != infrav1.VMIdentityUserAssigned {
+		return nil, nil
+	}
+
+	identity, err := converters.VMIdentityToVMSDK(s.Identity, s.UserAssignedIdentities)
+	if err != nil {
+		return nil, err
+	}
+
+	existingIdentities := make(map[string]string)
+	if existing != nil {
+		for id := range existing.UserAssignedIdentities {
+			existingIdentities[strings.ToLower(id)] = id
+		}
+	}
+
+	updated := false
+	for id := range identity.UserAssignedIdentities {
+		if _, ok := existingIdentities[strings.ToLower(id)]; ok {
+			delete(existingIdentities, strings.ToLower(id))
+		} else {
+			updated = true
+		}
+	}
+	// A null value unassigns an identity from the VM.
+	for _, id := range existingIdentities {
+		identity.UserAssignedIdentities[id] = nil
+		updated = true
+	}
+
+	if !updated {
+		return nil, nil
+	}
+	return identity, nil
+}
+
+// diagnosticsProfileUpdate returns the diagnostics profile of the spec, or nil if the boot diagnostics of the VM are up to date.
+func (s *VMSpec) diagnosticsProfileUpdate(existing *compute.DiagnosticsProfile) *compute.DiagnosticsProfile {
+	desired := s.generateDiagnosticsProfile()
+
+	var existingBootDiagnostics compute.BootDiagnostics
+	if existing != nil && existing.BootDiagnostics != nil {
+		existingBootDiagnostics = *existing.BootDiagnostics
+	}
+	if to.Bool(existingBootDiagnostics.Enabled) == to.Bool(desired.BootDiagnostics.Enabled) &&
+		strings.EqualFold(strings.TrimSuffix(to.String(existingBootDiagnostics.StorageURI), "/"), strings.TrimSuffix(to.String(desired.BootDiagnostics.StorageURI), "/")) {
+		return nil
+	}
+	return desired
+}
+
+// Drift returns the tags of the existing VM that are missing or differ from the spec.
+// Tags that are not part of the spec are ignored, as other components may add their own.
+func (s *VMSpec) Drift(existing interface{}) ([]string, error) {
+	existingVM, ok := existing.(compute.VirtualMachine)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.VirtualMachine", existing)
+	}
+
+	desired := s.tags()
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var drift []string
+	for _, key := range keys {
+		got, ok := existingVM.Tags[key]
+		if !ok || to.String(got) != to.String(desired[key]) {
+			drift = append(drift, fmt.Sprintf("tags[%s]", key))
+		}
+	}
+	return drift, nil
+}
+
+// tags returns the tags of the VM.
+func (s *VMSpec) tags() map[string]*string {
+	return converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        to.StringPtr(s.Name),
+		Role:        to.StringPtr(s.Role),
+		Additional:  s.AdditionalTags,
+	}))
+}
+
 // generateStorageProfile generates a pointer to a compute.StorageProfile which can utilized for VM creation.
 func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 	storageProfile := &compute.StorageProfile{
@@ -196,31 +399,14 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 		if s.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
 			storageProfile.OsDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(s.OSDisk.ManagedDisk.DiskEncryptionSet.ID)}
 		}
+		storageProfile.OsDisk.ManagedDisk.SecurityProfile = converters.DiskSecurityProfileToSDK(s.OSDisk.ManagedDisk.SecurityProfile)
 	}
 
 	dataDisks := make([]compute.DataDisk, len(s.DataDisks))
 	for i, disk := range s.DataDisks {
-		dataDisks[i] = compute.DataDisk{
-			CreateOption: compute.DiskCreateOptionTypesEmpty,
-			DiskSizeGB:   to.Int32Ptr(disk.DiskSizeGB),
-			Lun:          disk.Lun,
-			Name:         to.StringPtr(azure.GenerateDataDiskName(s.Name, disk.NameSuffix)),
-			Caching:      compute.CachingTypes(disk.CachingType),
-		}
-
-		if disk.ManagedDisk != nil {
-			dataDisks[i].ManagedDisk = &compute.ManagedDiskParameters{
-				StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
-			}
-
-			if disk.ManagedDisk.DiskEncryptionSet != nil {
-				dataDisks[i].ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(disk.ManagedDisk.DiskEncryptionSet.ID)}
-			}
-
-			// check the support for ultra disks based on location and vm size
-			if disk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) && !s.SKU.HasLocationCapability(resourceskus.UltraSSDAvailable, s.Location, s.Zone) {
-				return nil, azure.WithTerminalError(fmt.Errorf("vm size %s does not support ultra disks in location %s. select a different vm size or disable ultra disks", s.Size, s.Location))
-			}
+		dataDisks[i], err = s.generateDataDisk(disk)
+		if err != nil {
+			return nil, err
 		}
 	}
 	storageProfile.DataDisks = &dataDisks
@@ -235,6 +421,39 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 	return storageProfile, nil
 }
 
+// generateDataDisk generates a compute.DataDisk to create an empty data disk attached to the VM.
+func (s *VMSpec) generateDataDisk(disk infrav1.DataDisk) (compute.DataDisk, error) {
+	dataDisk := compute.DataDisk{
+		CreateOption: compute.DiskCreateOptionTypesEmpty,
+		DiskSizeGB:   to.Int32Ptr(disk.DiskSizeGB),
+		Lun:          disk.Lun,
+		Name:         to.StringPtr(azure.GenerateDataDiskName(s.Name, disk.NameSuffix)),
+		Caching:      compute.CachingTypes(disk.CachingType),
+	}
+
+	if disk.ManagedDisk != nil {
+		dataDisk.ManagedDisk = &compute.ManagedDiskParameters{
+			StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
+		}
+
+		if disk.ManagedDisk.DiskEncryptionSet != nil {
+			dataDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: to.StringPtr(disk.ManagedDisk.DiskEncryptionSet.ID)}
+		}
+
+		// check the support for ultra disks based on location and vm size
+		if disk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) && !s.SKU.HasLocationCapability(resourceskus.UltraSSDAvailable, s.Location, s.Zone) {
+			return compute.DataDisk{}, azure.WithTerminalError(fmt.Errorf("vm size %s does not support ultra disks in location %s. select a different vm size or disable ultra disks", s.Size, s.Location))
+		}
+	}
+
+	return dataDisk, nil
+}
+
+// generateDiagnosticsProfile generates the diagnostics profile of the VM.
+func (s *VMSpec) generateDiagnosticsProfile() *compute.DiagnosticsProfile {
+	return converters.GetDiagnosticsProfile(s.Diagnostics)
+}
+
 func (s *VMSpec) generateOSProfile() (*compute.OSProfile, error) {
 	sshKey, err := base64.StdEncoding.DecodeString(s.SSHKeyData)
 	if err != nil {
@@ -282,13 +501,22 @@ func (s *VMSpec) generateSecurityProfile() (*compute.SecurityProfile, error) {
 		return nil, nil
 	}
 
-	if !s.SKU.HasCapability(resourceskus.EncryptionAtHost) {
+	if to.Bool(s.SecurityProfile.EncryptionAtHost) && !s.SKU.HasCapability(resourceskus.EncryptionAtHost) {
 		return nil, azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", s.Size))
 	}
 
-	return &compute.SecurityProfile{
-		EncryptionAtHost: s.SecurityProfile.EncryptionAtHost,
-	}, nil
+	switch s.SecurityProfile.SecurityType {
+	case infrav1.SecurityTypesTrustedLaunch:
+		if !s.SKU.SupportsTrustedLaunch() {
+			return nil, azure.WithTerminalError(errors.Errorf("trusted launch is not supported for VM type %s", s.Size))
+		}
+	case infrav1.SecurityTypesConfidentialVM:
+		if !s.SKU.SupportsConfidentialVM() {
+			return nil, azure.WithTerminalError(errors.Errorf("confidential VMs are not supported for VM type %s", s.Size))
+		}
+	}
+
+	return converters.SecurityProfileToSDK(s.SecurityProfile), nil
 }
 
 func (s *VMSpec) generateNICRefs() *[]compute.NetworkInterfaceReference {
@@ -341,6 +569,23 @@ func (s *VMSpec) getAvailabilitySet() *compute.SubResource {
 	return as
 }
 
+func (s *VMSpec) getCapacityReservation() *compute.CapacityReservationProfile {
+	if s.CapacityReservationGroupID == "" {
+		return nil
+	}
+	return &compute.CapacityReservationProfile{
+		CapacityReservationGroup: subResource(s.CapacityReservationGroupID),
+	}
+}
+
+// subResource returns a reference to the resource with the given ID, or nil if the ID is empty.
+func subResource(id string) *compute.SubResource {
+	if id == "" {
+		return nil
+	}
+	return &compute.SubResource{ID: to.StringPtr(id)}
+}
+
 func (s *VMSpec) getZones() *[]string {
 	var zones *[]string
 	if s.Zone != "" {
@@ -17,6 +17,7 @@ limitations under the License.
 package virtualmachines
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
@@ -71,6 +72,54 @@ var (
 		},
 	}
 
+	validSKUWithTrustedLaunch = resourceskus.SKU{
+		Name: to.StringPtr("Standard_D2v3"),
+		Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  to.StringPtr(resourceskus.VCPUs),
+				Value: to.StringPtr("2"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.MemoryGB),
+				Value: to.StringPtr("4"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.HyperVGenerations),
+				Value: to.StringPtr("V1,V2"),
+			},
+		},
+	}
+
+	validSKUWithConfidentialVM = resourceskus.SKU{
+		Name: to.StringPtr("Standard_DC2as_v5"),
+		Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  to.StringPtr(resourceskus.VCPUs),
+				Value: to.StringPtr("2"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.MemoryGB),
+				Value: to.StringPtr("8"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.HyperVGenerations),
+				Value: to.StringPtr("V2"),
+			},
+			{
+				Name:  to.StringPtr(resourceskus.ConfidentialComputingType),
+				Value: to.StringPtr("SNP"),
+			},
+		},
+	}
+
 	validSKUWithEphemeralOS = resourceskus.SKU{
 		Name: to.StringPtr("Standard_D2v3"),
 		Kind: to.StringPtr(string(resourceskus.VirtualMachines)),
@@ -388,6 +437,51 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "can create a vm in a capacity reservation group",
+			spec: &VMSpec{
+				Name:                       "my-vm",
+				Role:                       infrav1.Node,
+				NICIDs:                     []string{"my-nic"},
+				SSHKeyData:                 "fakesshpublickey",
+				Size:                       "Standard_D2v3",
+				Zone:                       "1",
+				CapacityReservationGroupID: "fake-capacity-reservation-group-id",
+				Image:                      &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SKU:                        validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).CapacityReservation.CapacityReservationGroup.ID).To(Equal(to.StringPtr("fake-capacity-reservation-group-id")))
+				g.Expect(result.(compute.VirtualMachine).HostGroup).To(BeNil())
+				g.Expect(result.(compute.VirtualMachine).ProximityPlacementGroup).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a vm on a dedicated host group in a proximity placement group",
+			spec: &VMSpec{
+				Name:                      "my-vm",
+				Role:                      infrav1.Node,
+				NICIDs:                    []string{"my-nic"},
+				SSHKeyData:                "fakesshpublickey",
+				Size:                      "Standard_D2v3",
+				Zone:                      "1",
+				DedicatedHostGroupID:      "fake-host-group-id",
+				ProximityPlacementGroupID: "fake-proximity-placement-group-id",
+				Image:                     &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SKU:                       validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).CapacityReservation).To(BeNil())
+				g.Expect(result.(compute.VirtualMachine).HostGroup.ID).To(Equal(to.StringPtr("fake-host-group-id")))
+				g.Expect(result.(compute.VirtualMachine).ProximityPlacementGroup.ID).To(Equal(to.StringPtr("fake-proximity-placement-group-id")))
+			},
+			expectedError: "",
+		},
 		{
 			name: "can create a vm with EphemeralOSDisk",
 			spec: &VMSpec{
@@ -436,6 +530,113 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "reconcile error that cannot be recovered occurred: encryption at host is not supported for VM type Standard_D2v3. Object will not be requeued",
 		},
+		{
+			name: "can create a trusted launch vm",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesTrustedLaunch,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: to.BoolPtr(true),
+						VTpmEnabled:       to.BoolPtr(true),
+					},
+				},
+				SKU: validSKUWithTrustedLaunch,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				securityProfile := result.(compute.VirtualMachine).VirtualMachineProperties.SecurityProfile
+				g.Expect(securityProfile.SecurityType).To(Equal(compute.SecurityTypesTrustedLaunch))
+				g.Expect(securityProfile.EncryptionAtHost).To(BeNil())
+				g.Expect(*securityProfile.UefiSettings.SecureBootEnabled).To(BeTrue())
+				g.Expect(*securityProfile.UefiSettings.VTpmEnabled).To(BeTrue())
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a confidential vm",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_DC2as_v5",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Linux",
+					DiskSizeGB: to.Int32Ptr(128),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						SecurityProfile: &infrav1.VMDiskSecurityProfile{
+							SecurityEncryptionType: infrav1.SecurityEncryptionTypeVMGuestStateOnly,
+						},
+					},
+				},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesConfidentialVM,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: to.BoolPtr(false),
+						VTpmEnabled:       to.BoolPtr(true),
+					},
+				},
+				SKU: validSKUWithConfidentialVM,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				vm := result.(compute.VirtualMachine)
+				g.Expect(vm.SecurityProfile.SecurityType).To(Equal(compute.SecurityTypesConfidentialVM))
+				g.Expect(*vm.SecurityProfile.UefiSettings.VTpmEnabled).To(BeTrue())
+				g.Expect(vm.StorageProfile.OsDisk.ManagedDisk.SecurityProfile.SecurityEncryptionType).To(Equal(compute.SecurityEncryptionTypesVMGuestStateOnly))
+			},
+			expectedError: "",
+		},
+		{
+			name: "creating a trusted launch vm for unsupported VM type fails",
+			spec: &VMSpec{
+				Name:            "my-vm",
+				Role:            infrav1.Node,
+				NICIDs:          []string{"my-nic"},
+				SSHKeyData:      "fakesshpublickey",
+				Size:            "Standard_D2v3",
+				Zone:            "1",
+				Image:           &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesTrustedLaunch},
+				SKU:             validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: trusted launch is not supported for VM type Standard_D2v3. Object will not be requeued",
+		},
+		{
+			name: "creating a confidential vm for unsupported VM type fails",
+			spec: &VMSpec{
+				Name:            "my-vm",
+				Role:            infrav1.Node,
+				NICIDs:          []string{"my-nic"},
+				SSHKeyData:      "fakesshpublickey",
+				Size:            "Standard_D2v3",
+				Zone:            "1",
+				Image:           &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesConfidentialVM},
+				SKU:             validSKUWithTrustedLaunch,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: confidential VMs are not supported for VM type Standard_D2v3. Object will not be requeued",
+		},
 		{
 			name: "cannot create vm with EphemeralOSDisk if does not support ephemeral os",
 			spec: &VMSpec{
@@ -881,3 +1082,319 @@ func TestParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchParameters(t *testing.T) {
+	identityID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/my-identity"
+	otherIdentityID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/my-other-identity"
+
+	newSpec := func() *VMSpec {
+		return &VMSpec{
+			Name:     "my-vm",
+			Location: "test-location",
+			Size:     "Standard_D2v3",
+			SKU:      validSKU,
+			DataDisks: []infrav1.DataDisk{
+				{
+					NameSuffix: "mydisk",
+					DiskSizeGB: 64,
+					Lun:        to.Int32Ptr(0),
+				},
+			},
+			Identity:               infrav1.VMIdentityUserAssigned,
+			UserAssignedIdentities: []infrav1.UserAssignedIdentity{{ProviderID: "azure://" + identityID}},
+		}
+	}
+	newExisting := func() compute.VirtualMachine {
+		return compute.VirtualMachine{
+			Identity: &compute.VirtualMachineIdentity{
+				Type: compute.ResourceIdentityTypeUserAssigned,
+				UserAssignedIdentities: map[string]*compute.VirtualMachineIdentityUserAssignedIdentitiesValue{
+					identityID: {},
+				},
+			},
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				HardwareProfile: &compute.HardwareProfile{VMSize: "Standard_D2v3"},
+				StorageProfile: &compute.StorageProfile{
+					DataDisks: &[]compute.DataDisk{
+						{
+							Name:         to.StringPtr("my-vm_mydisk"),
+							Lun:          to.Int32Ptr(0),
+							DiskSizeGB:   to.Int32Ptr(64),
+							CreateOption: compute.DiskCreateOptionTypesEmpty,
+						},
+					},
+				},
+				DiagnosticsProfile: &compute.DiagnosticsProfile{
+					BootDiagnostics: &compute.BootDiagnostics{Enabled: to.BoolPtr(true)},
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name          string
+		spec          func(*VMSpec)
+		existing      func(*compute.VirtualMachine)
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "vm is up to date",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "vm size is changed within its family",
+			spec: func(s *VMSpec) {
+				s.Size = "Standard_D4v3"
+				s.VMSizesInFamily = []string{"Standard_D2v3", "Standard_D4v3"}
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(compute.VirtualMachineUpdate{
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						HardwareProfile: &compute.HardwareProfile{VMSize: "Standard_D4v3"},
+					},
+				}))
+			},
+		},
+		{
+			name: "vm size cannot be changed to another family",
+			spec: func(s *VMSpec) {
+				s.Size = "Standard_E4v3"
+				s.SKU.Family = to.StringPtr("standardEv3Family")
+				s.VMSizesInFamily = []string{"Standard_E2v3", "Standard_E4v3"}
+			},
+			expect:        func(g *WithT, result interface{}) {},
+			expectedError: "vm size Standard_D2v3 cannot be changed in place to Standard_E4v3, which is not in the same family standardEv3Family",
+		},
+		{
+			name: "data disk is added and existing data disk is grown",
+			spec: func(s *VMSpec) {
+				s.DataDisks[0].DiskSizeGB = 128
+				s.DataDisks = append(s.DataDisks, infrav1.DataDisk{
+					NameSuffix:  "myotherdisk",
+					DiskSizeGB:  32,
+					Lun:         to.Int32Ptr(1),
+					CachingType: "None",
+					ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: "Premium_LRS"},
+				})
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(compute.VirtualMachineUpdate{
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						StorageProfile: &compute.StorageProfile{
+							DataDisks: &[]compute.DataDisk{
+								{
+									Name:         to.StringPtr("my-vm_mydisk"),
+									Lun:          to.Int32Ptr(0),
+									DiskSizeGB:   to.Int32Ptr(128),
+									CreateOption: compute.DiskCreateOptionTypesEmpty,
+								},
+								{
+									Name:         to.StringPtr("my-vm_myotherdisk"),
+									Lun:          to.Int32Ptr(1),
+									DiskSizeGB:   to.Int32Ptr(32),
+									CreateOption: compute.DiskCreateOptionTypesEmpty,
+									Caching:      compute.CachingTypesNone,
+									ManagedDisk: &compute.ManagedDiskParameters{
+										StorageAccountType: compute.StorageAccountTypesPremiumLRS,
+									},
+								},
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "data disk removed from the spec is kept and smaller data disk is not shrunk",
+			spec: func(s *VMSpec) {
+				s.DataDisks = nil
+			},
+			existing: func(vm *compute.VirtualMachine) {
+				(*vm.StorageProfile.DataDisks)[0].DiskSizeGB = to.Int32Ptr(256)
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "ultra data disk is not supported by the vm size",
+			spec: func(s *VMSpec) {
+				s.DataDisks = append(s.DataDisks, infrav1.DataDisk{
+					NameSuffix:  "myultradisk",
+					DiskSizeGB:  32,
+					Lun:         to.Int32Ptr(1),
+					ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: string(compute.StorageAccountTypesUltraSSDLRS)},
+				})
+			},
+			expect:        func(g *WithT, result interface{}) {},
+			expectedError: "failed to attach data disk my-vm_myultradisk: vm size Standard_D2v3 does not support ultra disks in location test-location. select a different vm size or disable ultra disks",
+		},
+		{
+			name: "user-assigned identity is replaced",
+			spec: func(s *VMSpec) {
+				s.UserAssignedIdentities = []infrav1.UserAssignedIdentity{{ProviderID: "azure://" + otherIdentityID}}
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(compute.VirtualMachineUpdate{
+					Identity: &compute.VirtualMachineIdentity{
+						Type: compute.ResourceIdentityTypeUserAssigned,
+						UserAssignedIdentities: map[string]*compute.VirtualMachineIdentityUserAssignedIdentitiesValue{
+							otherIdentityID: {},
+							identityID:      nil,
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "user-assigned identities are compared case insensitively",
+			existing: func(vm *compute.VirtualMachine) {
+				vm.Identity.UserAssignedIdentities = map[string]*compute.VirtualMachineIdentityUserAssignedIdentitiesValue{
+					strings.ToUpper(identityID): {},
+				}
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "boot diagnostics are enabled",
+			existing: func(vm *compute.VirtualMachine) {
+				vm.DiagnosticsProfile = nil
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(compute.VirtualMachineUpdate{
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						DiagnosticsProfile: &compute.DiagnosticsProfile{
+							BootDiagnostics: &compute.BootDiagnostics{Enabled: to.BoolPtr(true)},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "boot diagnostics are moved to a user-managed storage account",
+			spec: func(s *VMSpec) {
+				s.Diagnostics = &infrav1.Diagnostics{
+					Boot: &infrav1.BootDiagnostics{
+						StorageAccountType: infrav1.UserManagedDiagnosticsStorage,
+						UserManaged:        &infrav1.UserManagedBootDiagnostics{StorageAccountURI: "https://fake.blob.core.windows.net/"},
+					},
+				}
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(compute.VirtualMachineUpdate{
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						DiagnosticsProfile: &compute.DiagnosticsProfile{
+							BootDiagnostics: &compute.BootDiagnostics{
+								Enabled:    to.BoolPtr(true),
+								StorageURI: to.StringPtr("https://fake.blob.core.windows.net/"),
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "disabled boot diagnostics are up to date",
+			spec: func(s *VMSpec) {
+				s.Diagnostics = &infrav1.Diagnostics{
+					Boot: &infrav1.BootDiagnostics{StorageAccountType: infrav1.DisabledDiagnosticsStorage},
+				}
+			},
+			existing: func(vm *compute.VirtualMachine) {
+				vm.DiagnosticsProfile.BootDiagnostics.Enabled = to.BoolPtr(false)
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := newSpec()
+			if tc.spec != nil {
+				tc.spec(spec)
+			}
+			existing := newExisting()
+			if tc.existing != nil {
+				tc.existing(&existing)
+			}
+
+			result, err := spec.PatchParameters(existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestDrift(t *testing.T) {
+	spec := &VMSpec{
+		Name:           "my-vm",
+		ClusterName:    "my-cluster",
+		Role:           infrav1.Node,
+		AdditionalTags: infrav1.Tags{"foo": "bar"},
+	}
+	testcases := []struct {
+		name          string
+		existing      interface{}
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "no drift, extra tags are ignored",
+			existing: compute.VirtualMachine{
+				Tags: map[string]*string{
+					"Name": to.StringPtr("my-vm"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr(infrav1.Node),
+					"foo":   to.StringPtr("bar"),
+					"other": to.StringPtr("tag"),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "tag modified and tag deleted",
+			existing: compute.VirtualMachine{
+				Tags: map[string]*string{
+					"Name": to.StringPtr("my-vm"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					"foo": to.StringPtr("baz"),
+				},
+			},
+			expected: []string{"tags[foo]", "tags[sigs.k8s.io_cluster-api-provider-azure_role]"},
+		},
+		{
+			name:          "existing is not a VirtualMachine",
+			existing:      network.VirtualNetwork{},
+			expectedError: "network.VirtualNetwork is not a compute.VirtualMachine",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			drift, err := spec.Drift(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drift).To(Equal(tc.expected))
+		})
+	}
+}
@@ -31,14 +31,17 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/identities"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/placementgroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
-const serviceName = "virtualmachine"
+// ServiceName is the name of the virtual machines service.
+const ServiceName = "virtualmachine"
 
 // VMScope defines the scope interface for a virtual machines service.
 type VMScope interface {
@@ -59,6 +62,8 @@ type Service struct {
 	interfacesGetter async.Getter
 	publicIPsGetter  async.Getter
 	identitiesGetter identities.Client
+	placementGetter  placementgroups.Client
+	imagesGetter     virtualmachineimages.Client
 }
 
 // New creates a new service.
@@ -69,13 +74,15 @@ func New(scope VMScope) *Service {
 		interfacesGetter: networkinterfaces.NewClient(scope),
 		publicIPsGetter:  publicips.NewClient(scope),
 		identitiesGetter: identities.NewClient(scope),
-		Reconciler:       async.New(scope, Client, Client),
+		placementGetter:  placementgroups.NewClient(scope),
+		imagesGetter:     virtualmachineimages.NewClient(scope),
+		Reconciler:       async.NewWithUpdater(scope, Client, Client, Client),
 	}
 }
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a virtual machine.
@@ -91,10 +98,20 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	result, err := s.CreateOrUpdateResource(ctx, vmSpec, serviceName)
-	s.Scope.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, err)
+	if err := s.validatePlacement(ctx, vmSpec); err != nil {
+		s.Scope.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, err)
+		return err
+	}
+
+	if err := s.validateImageSecurityType(ctx, vmSpec); err != nil {
+		s.Scope.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, err)
+		return err
+	}
+
+	result, err := s.CreateOrUpdateResource(ctx, vmSpec, ServiceName)
+	s.Scope.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, err)
 	// Set the DiskReady condition here since the disk gets created with the VM.
-	s.Scope.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, err)
+	s.Scope.UpdatePutStatus(infrav1.DisksReadyCondition, ServiceName, err)
 	if err == nil && result != nil {
 		vm, ok := result.(compute.VirtualMachine)
 		if !ok {
@@ -130,6 +147,42 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return err
 }
 
+// validatePlacement checks that the capacity reservation group, the dedicated host group and the proximity placement group
+// of a virtual machine that is yet to be created can host it in its zone.
+func (s *Service) validatePlacement(ctx context.Context, vmSpec azure.ResourceSpecGetter) error {
+	spec, ok := vmSpec.(*VMSpec)
+	if !ok {
+		return errors.Errorf("%T is not a valid VM spec", vmSpec)
+	}
+	if spec.ProviderID != "" || (spec.CapacityReservationGroupID == "" && spec.DedicatedHostGroupID == "" && spec.ProximityPlacementGroupID == "") {
+		return nil
+	}
+
+	placement := placementgroups.Placement{
+		CapacityReservationGroupID: spec.CapacityReservationGroupID,
+		DedicatedHostGroupID:       spec.DedicatedHostGroupID,
+		ProximityPlacementGroupID:  spec.ProximityPlacementGroupID,
+		SKU:                        spec.SKU,
+	}
+	if spec.Zone != "" {
+		placement.Zones = []string{spec.Zone}
+	}
+	return placementgroups.Validate(ctx, s.placementGetter, placement)
+}
+
+// validateImageSecurityType checks that the image of a virtual machine that is yet to be created supports its security type.
+func (s *Service) validateImageSecurityType(ctx context.Context, vmSpec azure.ResourceSpecGetter) error {
+	spec, ok := vmSpec.(*VMSpec)
+	if !ok {
+		return errors.Errorf("%T is not a valid VM spec", vmSpec)
+	}
+	if spec.ProviderID != "" || spec.SecurityProfile == nil {
+		return nil
+	}
+
+	return virtualmachineimages.ValidateSecurityType(ctx, s.imagesGetter, spec.Location, spec.Image, spec.SecurityProfile.SecurityType)
+}
+
 // Delete deletes the virtual machine with the provided name.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.Delete")
@@ -143,13 +196,13 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	err := s.DeleteResource(ctx, vmSpec, serviceName)
+	err := s.DeleteResource(ctx, vmSpec, ServiceName)
 	if err != nil {
 		s.Scope.SetVMState(infrav1.Deleting)
 	} else {
 		s.Scope.SetVMState(infrav1.Deleted)
 	}
-	s.Scope.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, err)
+	s.Scope.UpdateDeleteStatus(infrav1.VMRunningCondition, ServiceName, err)
 	return err
 }
 
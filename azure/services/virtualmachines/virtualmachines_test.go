@@ -33,7 +33,10 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/identities/mock_identities"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/placementgroups/mock_placementgroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages/mock_virtualmachineimages"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines/mock_virtualmachines"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -55,6 +58,53 @@ var (
 		Image:             &infrav1.Image{ID: to.StringPtr("fake-image-id")},
 		BootstrapData:     "fake data",
 	}
+	fakeCapacityReservationGroupID         = "/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Compute/capacityReservationGroups/test-crg"
+	fakeVMSpecWithCapacityReservationGroup = VMSpec{
+		Name:                       "test-vm",
+		ResourceGroup:              "test-group",
+		Location:                   "test-location",
+		ClusterName:                "test-cluster",
+		Role:                       infrav1.Node,
+		NICIDs:                     []string{"nic-id-1"},
+		Size:                       "Standard_Fake_Size",
+		Zone:                       "1",
+		CapacityReservationGroupID: fakeCapacityReservationGroupID,
+		SKU: resourceskus.SKU{
+			Name: to.StringPtr("Standard_Fake_Size"),
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{
+					Name:  to.StringPtr(resourceskus.CapacityReservationSupported),
+					Value: to.StringPtr(string(resourceskus.CapabilitySupported)),
+				},
+			},
+		},
+	}
+	fakeVMSpecWithTrustedLaunch = VMSpec{
+		Name:          "test-vm",
+		ResourceGroup: "test-group",
+		Location:      "test-location",
+		ClusterName:   "test-cluster",
+		Role:          infrav1.Node,
+		NICIDs:        []string{"nic-id-1"},
+		Size:          "Standard_Fake_Size",
+		Image: &infrav1.Image{
+			Marketplace: &infrav1.AzureMarketplaceImage{
+				ImagePlan: infrav1.ImagePlan{
+					Publisher: "fake-publisher",
+					Offer:     "fake-offer",
+					SKU:       "fake-sku",
+				},
+				Version: "latest",
+			},
+		},
+		SecurityProfile: &infrav1.SecurityProfile{
+			SecurityType: infrav1.SecurityTypesTrustedLaunch,
+			UefiSettings: &infrav1.UefiSettings{
+				SecureBootEnabled: to.BoolPtr(true),
+				VTpmEnabled:       to.BoolPtr(true),
+			},
+		},
+	}
 	fakeExistingVM = compute.VirtualMachine{
 		ID:   to.StringPtr("subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm"),
 		Name: to.StringPtr("test-vm-name"),
@@ -123,23 +173,23 @@ func TestReconcileVM(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder)
 	}{
 		{
 			name:          "noop if no vm spec is found",
 			expectedError: "",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VMSpec().Return(nil)
 			},
 		},
 		{
 			name:          "create vm succeeds",
 			expectedError: "",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VMSpec().Return(&fakeVMSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
-				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, ServiceName).Return(fakeExistingVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, ServiceName, nil)
 				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
 				s.SetAnnotation("cluster-api-provider-azure", "true")
 				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
@@ -151,21 +201,21 @@ func TestReconcileVM(t *testing.T) {
 		{
 			name:          "creating vm fails",
 			expectedError: "#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VMSpec().Return(&fakeVMSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil, internalError)
-				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, internalError)
-				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, ServiceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, internalError)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
 			name:          "create vm succeeds but failed to get network interfaces",
 			expectedError: "failed to fetch VM addresses: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VMSpec().Return(&fakeVMSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
-				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, ServiceName).Return(fakeExistingVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, ServiceName, nil)
 				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
 				s.SetAnnotation("cluster-api-provider-azure", "true")
 				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(network.Interface{}, internalError)
@@ -174,17 +224,77 @@ func TestReconcileVM(t *testing.T) {
 		{
 			name:          "create vm succeeds but failed to get public IPs",
 			expectedError: "failed to fetch VM addresses: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VMSpec().Return(&fakeVMSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
-				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, ServiceName).Return(fakeExistingVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, ServiceName, nil)
 				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
 				s.SetAnnotation("cluster-api-provider-azure", "true")
 				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
 				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(network.PublicIPAddress{}, internalError)
 			},
 		},
+		{
+			name:          "create vm with a capacity reservation group",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VMSpec().Return(&fakeVMSpecWithCapacityReservationGroup)
+				mpg.ListCapacityReservations(gomockinternal.AContext(), fakeCapacityReservationGroupID).Return([]compute.CapacityReservation{
+					{Sku: &compute.Sku{Name: to.StringPtr("Standard_Fake_Size")}, Zones: &[]string{"1"}},
+				}, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpecWithCapacityReservationGroup, ServiceName).Return(fakeExistingVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, ServiceName, nil)
+				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
+				s.SetAddresses(fakeNodeAddresses)
+				s.SetVMState(infrav1.Succeeded)
+			},
+		},
+		{
+			name:          "capacity reservation group has no capacity reservation for the vm",
+			expectedError: "reconcile error that cannot be recovered occurred: capacity reservation group " + fakeCapacityReservationGroupID + " has no capacity reservation for vm size Standard_Fake_Size in zone 1. Object will not be requeued",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VMSpec().Return(&fakeVMSpecWithCapacityReservationGroup)
+				mpg.ListCapacityReservations(gomockinternal.AContext(), fakeCapacityReservationGroupID).Return([]compute.CapacityReservation{
+					{Sku: &compute.Sku{Name: to.StringPtr("Standard_Fake_Size")}, Zones: &[]string{"2"}},
+				}, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, gomock.Any())
+			},
+		},
+		{
+			name:          "create trusted launch vm from a generation 2 image",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VMSpec().Return(&fakeVMSpecWithTrustedLaunch)
+				mvmi.Get(gomockinternal.AContext(), "test-location", "fake-publisher", "fake-offer", "fake-sku", "latest").Return(compute.VirtualMachineImage{
+					VirtualMachineImageProperties: &compute.VirtualMachineImageProperties{HyperVGeneration: compute.HyperVGenerationTypesV2},
+				}, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpecWithTrustedLaunch, ServiceName).Return(fakeExistingVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, ServiceName, nil)
+				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				mnic.Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
+				s.SetAddresses(fakeNodeAddresses)
+				s.SetVMState(infrav1.Succeeded)
+			},
+		},
+		{
+			name:          "image does not support the security type of the vm",
+			expectedError: "reconcile error that cannot be recovered occurred: the VM image does not support security type TrustedLaunch: it has Hyper-V generation \"V1\" and security type feature \"\". Object will not be requeued",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, mpg *mock_placementgroups.MockClientMockRecorder, mvmi *mock_virtualmachineimages.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VMSpec().Return(&fakeVMSpecWithTrustedLaunch)
+				mvmi.Get(gomockinternal.AContext(), "test-location", "fake-publisher", "fake-offer", "fake-sku", "latest").Return(compute.VirtualMachineImage{
+					VirtualMachineImageProperties: &compute.VirtualMachineImageProperties{HyperVGeneration: compute.HyperVGenerationTypesV1},
+				}, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, ServiceName, gomock.Any())
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -198,14 +308,18 @@ func TestReconcileVM(t *testing.T) {
 			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
 			interfaceMock := mock_async.NewMockGetter(mockCtrl)
 			publicIPMock := mock_async.NewMockGetter(mockCtrl)
+			placementMock := mock_placementgroups.NewMockClient(mockCtrl)
+			imagesMock := mock_virtualmachineimages.NewMockClient(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), interfaceMock.EXPECT(), publicIPMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), interfaceMock.EXPECT(), publicIPMock.EXPECT(), placementMock.EXPECT(), imagesMock.EXPECT(), asyncMock.EXPECT())
 
 			s := &Service{
 				Scope:            scopeMock,
 				interfacesGetter: interfaceMock,
 				publicIPsGetter:  publicIPMock,
+				placementGetter:  placementMock,
+				imagesGetter:     imagesMock,
 				Reconciler:       asyncMock,
 			}
 
@@ -238,9 +352,9 @@ func TestDeleteVM(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VMSpec().AnyTimes().Return(&fakeVMSpec)
-				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, ServiceName).Return(nil)
 				s.SetVMState(infrav1.Deleted)
-				s.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, nil)
+				s.UpdateDeleteStatus(infrav1.VMRunningCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -248,9 +362,9 @@ func TestDeleteVM(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VMSpec().AnyTimes().Return(&fakeVMSpec)
-				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, ServiceName).Return(internalError)
 				s.SetVMState(infrav1.Deleting)
-				s.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, internalError)
+				s.UpdateDeleteStatus(infrav1.VMRunningCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -258,9 +372,9 @@ func TestDeleteVM(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VMSpec().AnyTimes().Return(&fakeVMSpec)
-				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVMSpec, ServiceName).Return(nil)
 				s.SetVMState(infrav1.Deleted)
-				s.UpdateDeleteStatus(infrav1.VMRunningCondition, serviceName, nil)
+				s.UpdateDeleteStatus(infrav1.VMRunningCondition, ServiceName, nil)
 			},
 		},
 	}
@@ -279,6 +279,18 @@ func (mr *MockVNetScopeMockRecorder) UpdateSubnetCIDRs(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnetCIDRs", reflect.TypeOf((*MockVNetScope)(nil).UpdateSubnetCIDRs), arg0, arg1)
 }
 
+// UpdateVnet mocks base method.
+func (m *MockVNetScope) UpdateVnet(arg0 string, arg1 v1beta1.Tags, arg2 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateVnet", arg0, arg1, arg2)
+}
+
+// UpdateVnet indicates an expected call of UpdateVnet.
+func (mr *MockVNetScopeMockRecorder) UpdateVnet(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVnet", reflect.TypeOf((*MockVNetScope)(nil).UpdateVnet), arg0, arg1, arg2)
+}
+
 // VNetSpec mocks base method.
 func (m *MockVNetScope) VNetSpec() azure.ResourceSpecGetter {
 	m.ctrl.T.Helper()
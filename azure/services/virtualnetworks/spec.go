@@ -17,8 +17,11 @@ limitations under the License.
 package virtualnetworks
 
 import (
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
@@ -31,6 +34,11 @@ type VNetSpec struct {
 	Location       string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
+
+	DDoSProtectionPlanID string
+	Encryption           *infrav1.VnetEncryption
+	DNSServers           []string
+	FlowTimeoutInMinutes *int32
 }
 
 // ResourceName returns the name of the vnet.
@@ -49,11 +57,43 @@ func (s *VNetSpec) OwnerResourceName() string {
 }
 
 // Parameters returns the parameters for the vnet.
+// The DDoS protection plan, encryption, DNS servers and flow timeout of a vnet managed by CAPZ are updated to match the spec,
+// while the ones of a custom vnet are only checked against the settings of the spec.
 func (s *VNetSpec) Parameters(existing interface{}) (interface{}, error) {
 	if existing != nil {
-		// vnet already exists, nothing to update.
-		return nil, nil
+		existingVnet, ok := existing.(network.VirtualNetwork)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.VirtualNetwork", existing)
+		}
+
+		if !converters.MapToTags(existingVnet.Tags).HasOwned(s.ClusterName) {
+			if mismatches := s.settingsMismatches(existingVnet, true); len(mismatches) > 0 {
+				return nil, errors.Errorf("custom vnet %s does not match the %s of the spec", s.Name, strings.Join(mismatches, ", "))
+			}
+			// custom vnet is never modified.
+			return nil, nil
+		}
+
+		if len(s.settingsMismatches(existingVnet, false)) == 0 {
+			// vnet is up to date, nothing to update.
+			return nil, nil
+		}
+
+		// Update the existing vnet, so that its subnets and peerings are preserved.
+		if existingVnet.VirtualNetworkPropertiesFormat == nil {
+			existingVnet.VirtualNetworkPropertiesFormat = &network.VirtualNetworkPropertiesFormat{}
+		}
+		s.setSettings(existingVnet.VirtualNetworkPropertiesFormat)
+		return existingVnet, nil
+	}
+
+	properties := &network.VirtualNetworkPropertiesFormat{
+		AddressSpace: &network.AddressSpace{
+			AddressPrefixes: &s.CIDRs,
+		},
 	}
+	s.setSettings(properties)
+
 	return network.VirtualNetwork{
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
@@ -62,11 +102,80 @@ func (s *VNetSpec) Parameters(existing interface{}) (interface{}, error) {
 			Role:        to.StringPtr(infrav1.CommonRole),
 			Additional:  s.AdditionalTags,
 		})),
-		Location: to.StringPtr(s.Location),
-		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
-			AddressSpace: &network.AddressSpace{
-				AddressPrefixes: &s.CIDRs,
-			},
-		},
+		Location:                       to.StringPtr(s.Location),
+		VirtualNetworkPropertiesFormat: properties,
 	}, nil
 }
+
+// setSettings sets the DDoS protection plan, encryption, DNS servers and flow timeout of the spec on vnet properties.
+func (s *VNetSpec) setSettings(properties *network.VirtualNetworkPropertiesFormat) {
+	properties.DdosProtectionPlan = nil
+	properties.EnableDdosProtection = to.BoolPtr(false)
+	if s.DDoSProtectionPlanID != "" {
+		properties.DdosProtectionPlan = &network.SubResource{ID: to.StringPtr(s.DDoSProtectionPlanID)}
+		properties.EnableDdosProtection = to.BoolPtr(true)
+	}
+
+	properties.Encryption = nil
+	if s.Encryption != nil {
+		properties.Encryption = &network.VirtualNetworkEncryption{
+			Enabled:     to.BoolPtr(true),
+			Enforcement: network.VirtualNetworkEncryptionEnforcement(s.Encryption.Enforcement),
+		}
+	}
+
+	properties.DhcpOptions = nil
+	if len(s.DNSServers) > 0 {
+		properties.DhcpOptions = &network.DhcpOptions{DNSServers: to.StringSlicePtr(s.DNSServers)}
+	}
+
+	properties.FlowTimeoutInMinutes = s.FlowTimeoutInMinutes
+}
+
+// settingsMismatches returns the names of the DDoS protection plan, encryption, DNS servers and flow timeout settings
+// of a vnet that do not match the spec. If onlySpecified is true, the settings that are not set in the spec are ignored.
+func (s *VNetSpec) settingsMismatches(vnet network.VirtualNetwork, onlySpecified bool) []string {
+	properties := vnet.VirtualNetworkPropertiesFormat
+	if properties == nil {
+		properties = &network.VirtualNetworkPropertiesFormat{}
+	}
+
+	var mismatches []string
+	if !onlySpecified || s.DDoSProtectionPlanID != "" {
+		var planID string
+		if properties.DdosProtectionPlan != nil {
+			planID = to.String(properties.DdosProtectionPlan.ID)
+		}
+		if !strings.EqualFold(planID, s.DDoSProtectionPlanID) || to.Bool(properties.EnableDdosProtection) != (s.DDoSProtectionPlanID != "") {
+			mismatches = append(mismatches, "DDoS protection plan")
+		}
+	}
+
+	if !onlySpecified || s.Encryption != nil {
+		encrypted := properties.Encryption != nil && to.Bool(properties.Encryption.Enabled)
+		switch {
+		case encrypted != (s.Encryption != nil):
+			mismatches = append(mismatches, "encryption")
+		case encrypted && string(properties.Encryption.Enforcement) != string(s.Encryption.Enforcement):
+			mismatches = append(mismatches, "encryption")
+		}
+	}
+
+	if !onlySpecified || len(s.DNSServers) > 0 {
+		var dnsServers []string
+		if properties.DhcpOptions != nil {
+			dnsServers = to.StringSlice(properties.DhcpOptions.DNSServers)
+		}
+		if strings.Join(dnsServers, ",") != strings.Join(s.DNSServers, ",") {
+			mismatches = append(mismatches, "DNS servers")
+		}
+	}
+
+	if !onlySpecified || s.FlowTimeoutInMinutes != nil {
+		if to.Int32(properties.FlowTimeoutInMinutes) != to.Int32(s.FlowTimeoutInMinutes) {
+			mismatches = append(mismatches, "flow timeout")
+		}
+	}
+
+	return mismatches
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworks
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+const ddosProtectionPlanID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/ddosProtectionPlans/my-plan"
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *VNetSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name: "vnet does not exist",
+			spec: &VNetSpec{
+				ResourceGroup:        "my-rg",
+				Name:                 "my-vnet",
+				CIDRs:                []string{"10.0.0.0/8"},
+				Location:             "westus",
+				ClusterName:          "my-cluster",
+				DDoSProtectionPlanID: ddosProtectionPlanID,
+				Encryption:           &infrav1.VnetEncryption{Enforcement: infrav1.VnetEncryptionEnforcementDropUnencrypted},
+				DNSServers:           []string{"10.0.0.4", "10.0.0.5"},
+				FlowTimeoutInMinutes: to.Int32Ptr(10),
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.VirtualNetwork{
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":               to.StringPtr("common"),
+						"Name": to.StringPtr("my-vnet"),
+					},
+					Location: to.StringPtr("westus"),
+					VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+						AddressSpace:         &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+						DdosProtectionPlan:   &network.SubResource{ID: to.StringPtr(ddosProtectionPlanID)},
+						EnableDdosProtection: to.BoolPtr(true),
+						Encryption: &network.VirtualNetworkEncryption{
+							Enabled:     to.BoolPtr(true),
+							Enforcement: network.VirtualNetworkEncryptionEnforcementDropUnencrypted,
+						},
+						DhcpOptions:          &network.DhcpOptions{DNSServers: &[]string{"10.0.0.4", "10.0.0.5"}},
+						FlowTimeoutInMinutes: to.Int32Ptr(10),
+					},
+				}))
+			},
+		},
+		{
+			name: "managed vnet is up to date",
+			spec: &VNetSpec{
+				Name:                 "my-vnet",
+				ClusterName:          "my-cluster",
+				DDoSProtectionPlanID: ddosProtectionPlanID,
+				DNSServers:           []string{"10.0.0.4"},
+			},
+			existing: network.VirtualNetwork{
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+				},
+				VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+					DdosProtectionPlan:   &network.SubResource{ID: to.StringPtr("/subscriptions/123/resourceGroups/MY-RG/providers/Microsoft.Network/ddosProtectionPlans/my-plan")},
+					EnableDdosProtection: to.BoolPtr(true),
+					DhcpOptions:          &network.DhcpOptions{DNSServers: &[]string{"10.0.0.4"}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managed vnet is updated and keeps its subnets",
+			spec: &VNetSpec{
+				Name:        "my-vnet",
+				ClusterName: "my-cluster",
+				Encryption:  &infrav1.VnetEncryption{Enforcement: infrav1.VnetEncryptionEnforcementAllowUnencrypted},
+			},
+			existing: network.VirtualNetwork{
+				Tags: map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+				},
+				VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+					AddressSpace:         &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+					Subnets:              &[]network.Subnet{{Name: to.StringPtr("my-subnet")}},
+					DdosProtectionPlan:   &network.SubResource{ID: to.StringPtr(ddosProtectionPlanID)},
+					EnableDdosProtection: to.BoolPtr(true),
+					DhcpOptions:          &network.DhcpOptions{DNSServers: &[]string{"10.0.0.4"}},
+					FlowTimeoutInMinutes: to.Int32Ptr(10),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.VirtualNetwork{
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": to.StringPtr("owned"),
+					},
+					VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+						AddressSpace:         &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+						Subnets:              &[]network.Subnet{{Name: to.StringPtr("my-subnet")}},
+						EnableDdosProtection: to.BoolPtr(false),
+						Encryption: &network.VirtualNetworkEncryption{
+							Enabled:     to.BoolPtr(true),
+							Enforcement: network.VirtualNetworkEncryptionEnforcementAllowUnencrypted,
+						},
+					},
+				}))
+			},
+		},
+		{
+			name: "custom vnet matches the spec",
+			spec: &VNetSpec{
+				Name:                 "my-vnet",
+				ClusterName:          "my-cluster",
+				FlowTimeoutInMinutes: to.Int32Ptr(10),
+			},
+			existing: network.VirtualNetwork{
+				VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+					DdosProtectionPlan:   &network.SubResource{ID: to.StringPtr(ddosProtectionPlanID)},
+					EnableDdosProtection: to.BoolPtr(true),
+					FlowTimeoutInMinutes: to.Int32Ptr(10),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "custom vnet does not match the spec",
+			spec: &VNetSpec{
+				Name:                 "my-vnet",
+				ClusterName:          "my-cluster",
+				DDoSProtectionPlanID: ddosProtectionPlanID,
+				Encryption:           &infrav1.VnetEncryption{Enforcement: infrav1.VnetEncryptionEnforcementDropUnencrypted},
+				DNSServers:           []string{"10.0.0.4"},
+			},
+			existing: network.VirtualNetwork{
+				VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+					Encryption: &network.VirtualNetworkEncryption{
+						Enabled:     to.BoolPtr(true),
+						Enforcement: network.VirtualNetworkEncryptionEnforcementDropUnencrypted,
+					},
+					DhcpOptions: &network.DhcpOptions{DNSServers: &[]string{"10.0.0.5"}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "custom vnet my-vnet does not match the DDoS protection plan, DNS servers of the spec",
+		},
+		{
+			name:          "existing is not a vnet",
+			spec:          &VNetSpec{Name: "my-vnet"},
+			existing:      "wrong type",
+			expect:        func(g *WithT, result interface{}) {},
+			expectedError: "string is not a network.VirtualNetwork",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -31,7 +31,8 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "virtualnetworks"
+// ServiceName is the name of this service.
+const ServiceName = "virtualnetworks"
 
 // VNetScope defines the scope interface for a virtual network service.
 type VNetScope interface {
@@ -41,6 +42,7 @@ type VNetScope interface {
 	VNetSpec() azure.ResourceSpecGetter
 	ClusterName() string
 	IsVnetManaged() bool
+	UpdateVnet(string, infrav1.Tags, []string)
 	UpdateSubnetCIDRs(string, []string)
 }
 
@@ -66,7 +68,7 @@ func New(scope VNetScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile idempotently creates or updates a virtual network.
@@ -82,21 +84,17 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	result, err := s.CreateOrUpdateResource(ctx, vnetSpec, serviceName)
+	result, err := s.CreateOrUpdateResource(ctx, vnetSpec, ServiceName)
 	if err == nil && result != nil {
 		existingVnet, ok := result.(network.VirtualNetwork)
 		if !ok {
 			return errors.Errorf("%T is not a network.VirtualNetwork", result)
 		}
-		vnet := s.Scope.Vnet()
-		vnet.ID = to.String(existingVnet.ID)
-		vnet.Tags = converters.MapToTags(existingVnet.Tags)
-
 		var prefixes []string
 		if existingVnet.VirtualNetworkPropertiesFormat != nil && existingVnet.VirtualNetworkPropertiesFormat.AddressSpace != nil {
 			prefixes = to.StringSlice(existingVnet.VirtualNetworkPropertiesFormat.AddressSpace.AddressPrefixes)
 		}
-		vnet.CIDRBlocks = prefixes
+		s.Scope.UpdateVnet(to.String(existingVnet.ID), converters.MapToTags(existingVnet.Tags), prefixes)
 
 		// Update the subnet CIDRs if they already exist.
 		// This makes sure the subnet CIDRs are up to date and there are no validation errors when updating the VNet.
@@ -109,7 +107,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	}
 
 	if s.Scope.IsVnetManaged() {
-		s.Scope.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, err)
 	}
 
 	return err
@@ -133,8 +131,8 @@ func (s *Service) Delete(ctx context.Context) error {
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			// already deleted or doesn't exist, cleanup status and return.
-			s.Scope.DeleteLongRunningOperationState(vnetSpec.ResourceName(), serviceName, infrav1.DeleteFuture)
-			s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, nil)
+			s.Scope.DeleteLongRunningOperationState(vnetSpec.ResourceName(), ServiceName, infrav1.DeleteFuture)
+			s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			return nil
 		}
 		return errors.Wrap(err, "could not get VNet management state")
@@ -144,8 +142,8 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	err = s.DeleteResource(ctx, vnetSpec, serviceName)
-	s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, err)
+	err = s.DeleteResource(ctx, vnetSpec, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, err)
 	return err
 }
 
@@ -121,7 +121,7 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, nil)
 				s.IsVnetManaged().Return(false)
 			},
 		},
@@ -130,9 +130,9 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, nil)
 				s.IsVnetManaged().Return(true)
-				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -140,9 +140,9 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, internalError)
 				s.IsVnetManaged().Return(true)
-				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, internalError)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -150,8 +150,8 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(customVnet, nil)
-				s.Vnet().Return(&infrav1.VnetSpec{})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(customVnet, nil)
+				s.UpdateVnet("/subscriptions/subscription/resourceGroups/test-group/providers/Microsoft.Network/virtualNetworks/test-vnet", infrav1.Tags{"foo": "bar", "something": "else"}, []string{"fake-cidr"})
 				s.UpdateSubnetCIDRs("test-subnet", []string{"subnet-cidr"})
 				s.UpdateSubnetCIDRs("test-subnet-2", []string{"subnet-cidr-1", "subnet-cidr-2"})
 				s.IsVnetManaged().Return(false)
@@ -211,8 +211,8 @@ func TestDeleteVnet(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.VNetID("123", fakeVNetSpec.ResourceGroupName(), fakeVNetSpec.Name)).Return(managedTags, nil)
 				s.ClusterName().Return("test-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -223,8 +223,8 @@ func TestDeleteVnet(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.VNetID("123", fakeVNetSpec.ResourceGroupName(), fakeVNetSpec.Name)).Return(managedTags, nil)
 				s.ClusterName().Return("test-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -21,6 +21,7 @@ limitations under the License.
 package mock_vmextensions
 
 import (
+	context "context"
 	reflect "reflect"
 
 	autorest "github.com/Azure/go-autorest/autorest"
@@ -252,3 +253,41 @@ func (mr *MockVMExtensionScopeMockRecorder) VMExtensionSpecs() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VMExtensionSpecs", reflect.TypeOf((*MockVMExtensionScope)(nil).VMExtensionSpecs))
 }
+
+// MockSerialConsoleLogGetter is a mock of SerialConsoleLogGetter interface.
+type MockSerialConsoleLogGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSerialConsoleLogGetterMockRecorder
+}
+
+// MockSerialConsoleLogGetterMockRecorder is the mock recorder for MockSerialConsoleLogGetter.
+type MockSerialConsoleLogGetterMockRecorder struct {
+	mock *MockSerialConsoleLogGetter
+}
+
+// NewMockSerialConsoleLogGetter creates a new mock instance.
+func NewMockSerialConsoleLogGetter(ctrl *gomock.Controller) *MockSerialConsoleLogGetter {
+	mock := &MockSerialConsoleLogGetter{ctrl: ctrl}
+	mock.recorder = &MockSerialConsoleLogGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSerialConsoleLogGetter) EXPECT() *MockSerialConsoleLogGetterMockRecorder {
+	return m.recorder
+}
+
+// GetSerialConsoleLog mocks base method.
+func (m *MockSerialConsoleLogGetter) GetSerialConsoleLog(ctx context.Context, resourceGroupName, vmName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSerialConsoleLog", ctx, resourceGroupName, vmName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSerialConsoleLog indicates an expected call of GetSerialConsoleLog.
+func (mr *MockSerialConsoleLogGetterMockRecorder) GetSerialConsoleLog(ctx, resourceGroupName, vmName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSerialConsoleLog", reflect.TypeOf((*MockSerialConsoleLogGetter)(nil).GetSerialConsoleLog), ctx, resourceGroupName, vmName)
+}
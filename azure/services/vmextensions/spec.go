@@ -17,6 +17,9 @@ limitations under the License.
 package vmextensions
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
@@ -48,13 +51,18 @@ func (s *VMExtensionSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the VM extension.
 func (s *VMExtensionSpec) Parameters(existing interface{}) (interface{}, error) {
 	if existing != nil {
-		_, ok := existing.(compute.VirtualMachineExtension)
+		existingExtension, ok := existing.(compute.VirtualMachineExtension)
 		if !ok {
 			return nil, errors.Errorf("%T is not a compute.VirtualMachineExtension", existing)
 		}
 
-		// VM extension already exists, nothing to update.
-		return nil, nil
+		// Protected settings are never returned by Azure, so only a change of version or settings updates the VM extension.
+		if existingExtension.VirtualMachineExtensionProperties != nil &&
+			to.String(existingExtension.TypeHandlerVersion) == s.Version &&
+			settingsMatch(existingExtension.Settings, s.Settings) {
+			// VM extension is up to date, nothing to update.
+			return nil, nil
+		}
 	}
 
 	return compute.VirtualMachineExtension{
@@ -68,3 +76,25 @@ func (s *VMExtensionSpec) Parameters(existing interface{}) (interface{}, error)
 		Location: to.StringPtr(s.Location),
 	}, nil
 }
+
+// settingsMatch returns true if the settings of an existing VM extension, which are decoded from JSON, are the desired settings.
+func settingsMatch(existing interface{}, desired map[string]string) bool {
+	existingSettings := make(map[string]interface{})
+	if existing != nil {
+		raw, err := json.Marshal(existing)
+		if err != nil || json.Unmarshal(raw, &existingSettings) != nil {
+			return false
+		}
+	}
+
+	if len(existingSettings) != len(desired) {
+		return false
+	}
+	for key, value := range desired {
+		existingValue, ok := existingSettings[key]
+		if !ok || fmt.Sprint(existingValue) != value {
+			return false
+		}
+	}
+	return true
+}
@@ -77,6 +77,47 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "vmextension that already exists with settings decoded from JSON",
+			spec: &fakeVMExtensionSpec,
+			existing: fakeVMExtensionWithProperties(func(p *compute.VirtualMachineExtensionProperties) {
+				p.Settings = map[string]interface{}{"my-setting": "my-value"}
+				p.ProtectedSettings = nil
+			}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "vmextension that already exists with a different version",
+			spec: &fakeVMExtensionSpec,
+			existing: fakeVMExtensionWithProperties(func(p *compute.VirtualMachineExtensionProperties) {
+				p.TypeHandlerVersion = to.StringPtr("0.9")
+			}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(fakeVMExtensionParams))
+			},
+			expectedError: "",
+		},
+		{
+			name: "vmextension that already exists with different settings",
+			spec: &fakeVMExtensionSpec,
+			existing: fakeVMExtensionWithProperties(func(p *compute.VirtualMachineExtensionProperties) {
+				p.Settings = map[string]interface{}{"my-setting": "my-old-value", "my-old-setting": "my-value"}
+			}),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(fakeVMExtensionParams))
+			},
+			expectedError: "",
+		},
+		{
+			name:          "existing is not a vmextension",
+			spec:          &fakeVMExtensionSpec,
+			existing:      "not a vmextension",
+			expect:        func(g *WithT, result interface{}) {},
+			expectedError: "string is not a compute.VirtualMachineExtension",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -95,3 +136,12 @@ func TestParameters(t *testing.T) {
 		})
 	}
 }
+
+func fakeVMExtensionWithProperties(modify func(*compute.VirtualMachineExtensionProperties)) compute.VirtualMachineExtension {
+	properties := *fakeVMExtensionParams.VirtualMachineExtensionProperties
+	modify(&properties)
+	return compute.VirtualMachineExtension{
+		VirtualMachineExtensionProperties: &properties,
+		Location:                          fakeVMExtensionParams.Location,
+	}
+}
@@ -18,7 +18,10 @@ package vmextensions
 
 import (
 	"context"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -27,7 +30,12 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "vmextensions"
+const (
+	serviceName = "vmextensions"
+
+	// serialConsoleLogTailLines is the number of lines of the serial console log added to the error of a failed extension.
+	serialConsoleLogTailLines = 20
+)
 
 // VMExtensionScope defines the scope interface for a vm extension service.
 type VMExtensionScope interface {
@@ -36,18 +44,28 @@ type VMExtensionScope interface {
 	VMExtensionSpecs() []azure.ResourceSpecGetter
 }
 
+// SerialConsoleLogGetter gets the serial console log of a virtual machine.
+type SerialConsoleLogGetter interface {
+	GetSerialConsoleLog(ctx context.Context, resourceGroupName, vmName string) (string, error)
+}
+
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope VMExtensionScope
 	async.Reconciler
+	getter                 async.Getter
+	serialConsoleLogGetter SerialConsoleLogGetter
 }
 
-// New creates a new vm extension service.
-func New(scope VMExtensionScope) *Service {
+// New creates a new vm extension service. The serial console log of the VM is added to the error of an extension
+// which failed to provision.
+func New(scope VMExtensionScope, serialConsoleLogGetter SerialConsoleLogGetter) *Service {
 	client := newClient(scope)
 	return &Service{
-		Scope:      scope,
-		Reconciler: async.New(scope, client, client),
+		Scope:                  scope,
+		Reconciler:             async.New(scope, client, client),
+		getter:                 client,
+		serialConsoleLogGetter: serialConsoleLogGetter,
 	}
 }
 
@@ -73,25 +91,68 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	// If multiple errors occur, we return the most pressing one.
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var resultErr error
+	var failedSpec azure.ResourceSpecGetter
 	for _, extensionSpec := range specs {
 		_, err := s.CreateOrUpdateResource(ctx, extensionSpec, serviceName)
 		if err != nil {
 			if !azure.IsOperationNotDoneError(err) || resultErr == nil {
 				resultErr = err
+				failedSpec = extensionSpec
 			}
 		}
 	}
 
-	if azure.IsOperationNotDoneError(resultErr) {
+	switch {
+	case azure.IsOperationPlannedError(resultErr):
+		// The extensions were only planned in dry-run mode, so there is no bootstrapping to report on.
+	case azure.IsOperationNotDoneError(resultErr):
 		resultErr = errors.Wrapf(resultErr, "extension is still in provisioning state. This likely means that bootstrapping has not yet completed on the VM")
-	} else if resultErr != nil {
-		resultErr = errors.Wrapf(resultErr, "extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Check VM boot diagnostics logs to learn more")
+	case resultErr != nil:
+		if tail := s.serialConsoleLogTail(ctx, failedSpec); tail != "" {
+			resultErr = azure.BootstrapFailedError{
+				Err:                  errors.Wrapf(resultErr, "extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Last lines of the VM serial console log:\n%s", tail),
+				SerialConsoleLogTail: tail,
+			}
+		} else {
+			resultErr = errors.Wrapf(resultErr, "extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Check VM boot diagnostics logs to learn more")
+		}
 	}
 
 	s.Scope.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, resultErr)
 	return resultErr
 }
 
+// serialConsoleLogTail returns the last lines of the serial console log of the VM of an extension whose provisioning
+// failed. It returns an empty string if the extension did not fail to provision, e.g. because the request to Azure
+// failed, or if the log cannot be retrieved, e.g. because boot diagnostics are disabled.
+func (s *Service) serialConsoleLogTail(ctx context.Context, spec azure.ResourceSpecGetter) string {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "vmextensions.Service.serialConsoleLogTail")
+	defer done()
+
+	existing, err := s.getter.Get(ctx, spec)
+	if err != nil {
+		log.V(2).Info("unable to get extension", "extension", spec.ResourceName(), "error", err.Error())
+		return ""
+	}
+	extension, ok := existing.(compute.VirtualMachineExtension)
+	if !ok || extension.VirtualMachineExtensionProperties == nil ||
+		infrav1.ProvisioningState(to.String(extension.ProvisioningState)) != infrav1.Failed {
+		return ""
+	}
+
+	serialConsoleLog, err := s.serialConsoleLogGetter.GetSerialConsoleLog(ctx, spec.ResourceGroupName(), spec.OwnerResourceName())
+	if err != nil {
+		log.V(2).Info("unable to get serial console log", "virtual machine", spec.OwnerResourceName(), "error", err.Error())
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(serialConsoleLog, "\r", ""), "\n"), "\n")
+	if len(lines) > serialConsoleLogTailLines {
+		lines = lines[len(lines)-serialConsoleLogTailLines:]
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
 // Delete is a no-op. VM Extensions will be deleted as part of VM deletion.
 func (s *Service) Delete(_ context.Context) error {
 	return nil
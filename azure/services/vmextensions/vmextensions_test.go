@@ -19,9 +19,12 @@ package vmextensions
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -58,20 +61,33 @@ var (
 	internalError        = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
 	extensionFailedError = errors.Wrapf(internalError, "extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Check VM boot diagnostics logs to learn more")
 
+	bootDiagnosticsDisabledError = errors.New("boot diagnostics of virtual machine my-vm have no serial console log")
+	serialConsoleLog             = "line 1\r\n" + strings.Repeat("cloud-init: running\r\n", serialConsoleLogTailLines) + "cloud-init: kubeadm join failed\r\n"
+	serialConsoleLogTail         = strings.Repeat("cloud-init: running\n", serialConsoleLogTailLines-1) + "cloud-init: kubeadm join failed"
+	extensionFailedWithLogError  = errors.Wrapf(internalError, "extension state failed. This likely means the Kubernetes node bootstrapping process failed or timed out. Last lines of the VM serial console log:\n%s", serialConsoleLogTail)
+
+	failedExtension = compute.VirtualMachineExtension{
+		VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{ProvisioningState: to.StringPtr("Failed")},
+	}
+	succeededExtension = compute.VirtualMachineExtension{
+		VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{ProvisioningState: to.StringPtr("Succeeded")},
+	}
+
 	notDoneError          = azure.NewOperationNotDoneError(&infrav1.Future{})
 	extensionNotDoneError = errors.Wrapf(notDoneError, "extension is still in provisioning state. This likely means that bootstrapping has not yet completed on the VM")
 )
 
 func TestReconcileVMExtension(t *testing.T) {
 	testcases := []struct {
-		name          string
-		expectedError string
-		expect        func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		name            string
+		expectedError   string
+		expectedLogTail string
+		expect          func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, e *mock_async.MockGetterMockRecorder, l *mock_vmextensions.MockSerialConsoleLogGetterMockRecorder)
 	}{
 		{
 			name:          "extension is in succeeded state",
 			expectedError: "",
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, e *mock_async.MockGetterMockRecorder, l *mock_vmextensions.MockSerialConsoleLogGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
@@ -80,16 +96,50 @@ func TestReconcileVMExtension(t *testing.T) {
 		{
 			name:          "extension is in failed state",
 			expectedError: extensionFailedError.Error(),
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, e *mock_async.MockGetterMockRecorder, l *mock_vmextensions.MockSerialConsoleLogGetterMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, internalError)
+				e.Get(gomockinternal.AContext(), &extensionSpec1).Return(failedExtension, nil)
+				l.GetSerialConsoleLog(gomockinternal.AContext(), "my-rg", "my-vm").Return("", bootDiagnosticsDisabledError)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionFailedError.Error()))
+			},
+		},
+		{
+			name:            "extension is in failed state with serial console log",
+			expectedError:   extensionFailedWithLogError.Error(),
+			expectedLogTail: serialConsoleLogTail,
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, e *mock_async.MockGetterMockRecorder, l *mock_vmextensions.MockSerialConsoleLogGetterMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, internalError)
+				e.Get(gomockinternal.AContext(), &extensionSpec1).Return(failedExtension, nil)
+				l.GetSerialConsoleLog(gomockinternal.AContext(), "my-rg", "my-vm").Return(serialConsoleLog, nil)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionFailedWithLogError.Error()))
+			},
+		},
+		{
+			name:          "extension request fails without the extension failing to provision",
+			expectedError: extensionFailedError.Error(),
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, e *mock_async.MockGetterMockRecorder, l *mock_vmextensions.MockSerialConsoleLogGetterMockRecorder) {
+				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, internalError)
+				e.Get(gomockinternal.AContext(), &extensionSpec1).Return(succeededExtension, nil)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionFailedError.Error()))
+			},
+		},
+		{
+			name:          "extension request fails and the extension cannot be fetched",
+			expectedError: extensionFailedError.Error(),
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, e *mock_async.MockGetterMockRecorder, l *mock_vmextensions.MockSerialConsoleLogGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, internalError)
+				e.Get(gomockinternal.AContext(), &extensionSpec1).Return(nil, internalError)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionFailedError.Error()))
 			},
 		},
 		{
 			name:          "extension is still creating",
 			expectedError: extensionNotDoneError.Error(),
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, e *mock_async.MockGetterMockRecorder, l *mock_vmextensions.MockSerialConsoleLogGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, notDoneError)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionNotDoneError.Error()))
@@ -98,7 +148,7 @@ func TestReconcileVMExtension(t *testing.T) {
 		{
 			name:          "reconcile multiple extensions",
 			expectedError: "",
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, e *mock_async.MockGetterMockRecorder, l *mock_vmextensions.MockSerialConsoleLogGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1, &extensionSpec2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec2, serviceName).Return(nil, nil)
@@ -108,10 +158,12 @@ func TestReconcileVMExtension(t *testing.T) {
 		{
 			name:          "error creating the first extension",
 			expectedError: extensionFailedError.Error(),
-			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_vmextensions.MockVMExtensionScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, e *mock_async.MockGetterMockRecorder, l *mock_vmextensions.MockSerialConsoleLogGetterMockRecorder) {
 				s.VMExtensionSpecs().Return([]azure.ResourceSpecGetter{&extensionSpec1, &extensionSpec2})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec1, serviceName).Return(nil, internalError)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &extensionSpec2, serviceName).Return(nil, nil)
+				e.Get(gomockinternal.AContext(), &extensionSpec1).Return(failedExtension, nil)
+				l.GetSerialConsoleLog(gomockinternal.AContext(), "my-rg", "my-vm").Return("", bootDiagnosticsDisabledError)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, gomockinternal.ErrStrEq(extensionFailedError.Error()))
 			},
 		},
@@ -126,18 +178,25 @@ func TestReconcileVMExtension(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_vmextensions.NewMockVMExtensionScope(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			logGetterMock := mock_vmextensions.NewMockSerialConsoleLogGetter(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), getterMock.EXPECT(), logGetterMock.EXPECT())
 
 			s := &Service{
-				Scope:      scopeMock,
-				Reconciler: asyncMock,
+				Scope:                  scopeMock,
+				Reconciler:             asyncMock,
+				getter:                 getterMock,
+				serialConsoleLogGetter: logGetterMock,
 			}
 
 			err := s.Reconcile(context.TODO())
 			if tc.expectedError != "" {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err).To(MatchError(tc.expectedError))
+				var bootstrapErr azure.BootstrapFailedError
+				g.Expect(errors.As(err, &bootstrapErr)).To(Equal(tc.expectedLogTail != ""))
+				g.Expect(bootstrapErr.SerialConsoleLogTail).To(Equal(tc.expectedLogTail))
 			} else {
 				g.Expect(err).NotTo(HaveOccurred())
 			}
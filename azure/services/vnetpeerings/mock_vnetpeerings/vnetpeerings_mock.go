@@ -21,10 +21,12 @@ limitations under the License.
 package mock_vnetpeerings
 
 import (
+	context "context"
 	reflect "reflect"
 
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
 	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -239,6 +241,21 @@ func (mr *MockVnetPeeringScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockVnetPeeringScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
 }
 
+// VnetPeeringAuthorizer mocks base method.
+func (m *MockVnetPeeringScope) VnetPeeringAuthorizer(ctx context.Context, subscriptionID string, identityRef, remoteIdentityRef *v1.ObjectReference) (azure.Authorizer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetPeeringAuthorizer", ctx, subscriptionID, identityRef, remoteIdentityRef)
+	ret0, _ := ret[0].(azure.Authorizer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VnetPeeringAuthorizer indicates an expected call of VnetPeeringAuthorizer.
+func (mr *MockVnetPeeringScopeMockRecorder) VnetPeeringAuthorizer(ctx, subscriptionID, identityRef, remoteIdentityRef interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetPeeringAuthorizer", reflect.TypeOf((*MockVnetPeeringScope)(nil).VnetPeeringAuthorizer), ctx, subscriptionID, identityRef, remoteIdentityRef)
+}
+
 // VnetPeeringSpecs mocks base method.
 func (m *MockVnetPeeringScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	m.ctrl.T.Helper()
@@ -20,17 +20,26 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/pointers"
 )
 
 // VnetPeeringSpec defines the specification for a virtual network peering.
 type VnetPeeringSpec struct {
-	SourceResourceGroup string
-	SourceVnetName      string
-	RemoteResourceGroup string
-	RemoteVnetName      string
-	PeeringName         string
-	SubscriptionID      string
+	SourceResourceGroup       string
+	SourceVnetName            string
+	RemoteResourceGroup       string
+	RemoteVnetName            string
+	PeeringName               string
+	SubscriptionID            string
+	SourceSubscriptionID      string
+	SourceIdentityRef         *corev1.ObjectReference
+	RemoteIdentityRef         *corev1.ObjectReference
+	AllowForwardedTraffic     *bool
+	AllowGatewayTransit       *bool
+	AllowVirtualNetworkAccess *bool
+	UseRemoteGateways         *bool
 }
 
 // ResourceName returns the name of the virtual network peering.
@@ -51,20 +60,40 @@ func (s *VnetPeeringSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the virtual network peering.
 func (s *VnetPeeringSpec) Parameters(existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.VirtualNetworkPeering); !ok {
+		existingPeering, ok := existing.(network.VirtualNetworkPeering)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.VnetPeering", existing)
 		}
-		// virtual network peering already exists
-		return nil, nil
+		if !s.propertiesChanged(existingPeering) {
+			// virtual network peering already exists with the expected properties
+			return nil, nil
+		}
 	}
 	vnetID := azure.VNetID(s.SubscriptionID, s.RemoteResourceGroup, s.RemoteVnetName)
 	peeringProperties := network.VirtualNetworkPeeringPropertiesFormat{
 		RemoteVirtualNetwork: &network.SubResource{
 			ID: to.StringPtr(vnetID),
 		},
+		AllowForwardedTraffic:     s.AllowForwardedTraffic,
+		AllowGatewayTransit:       s.AllowGatewayTransit,
+		AllowVirtualNetworkAccess: s.AllowVirtualNetworkAccess,
+		UseRemoteGateways:         s.UseRemoteGateways,
 	}
 	return network.VirtualNetworkPeering{
 		Name:                                  to.StringPtr(s.PeeringName),
 		VirtualNetworkPeeringPropertiesFormat: &peeringProperties,
 	}, nil
 }
+
+// propertiesChanged returns true if a property set in the spec differs in the existing virtual network peering.
+// Properties that are not set in the spec are left to Azure.
+func (s *VnetPeeringSpec) propertiesChanged(existing network.VirtualNetworkPeering) bool {
+	properties := existing.VirtualNetworkPeeringPropertiesFormat
+	if properties == nil {
+		properties = &network.VirtualNetworkPeeringPropertiesFormat{}
+	}
+	return pointers.BoolChanged(s.AllowForwardedTraffic, properties.AllowForwardedTraffic) ||
+		pointers.BoolChanged(s.AllowGatewayTransit, properties.AllowGatewayTransit) ||
+		pointers.BoolChanged(s.AllowVirtualNetworkAccess, properties.AllowVirtualNetworkAccess) ||
+		pointers.BoolChanged(s.UseRemoteGateways, properties.UseRemoteGateways)
+}
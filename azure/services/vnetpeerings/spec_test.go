@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnetpeerings
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+)
+
+func TestParameters(t *testing.T) {
+	spokeToHub := VnetPeeringSpec{
+		PeeringName:           "spoke-to-hub",
+		SourceVnetName:        "spoke",
+		SourceResourceGroup:   "spoke-group",
+		RemoteVnetName:        "hub",
+		RemoteResourceGroup:   "hub-group",
+		SubscriptionID:        "hub-sub",
+		AllowForwardedTraffic: to.BoolPtr(true),
+		UseRemoteGateways:     to.BoolPtr(true),
+	}
+
+	testcases := []struct {
+		name          string
+		spec          VnetPeeringSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new peering to a virtual network in another subscription",
+			spec:     spokeToHub,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.VirtualNetworkPeering{
+					Name: to.StringPtr("spoke-to-hub"),
+					VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+						RemoteVirtualNetwork: &network.SubResource{
+							ID: to.StringPtr("/subscriptions/hub-sub/resourceGroups/hub-group/providers/Microsoft.Network/virtualNetworks/hub"),
+						},
+						AllowForwardedTraffic: to.BoolPtr(true),
+						UseRemoteGateways:     to.BoolPtr(true),
+					},
+				}))
+			},
+		},
+		{
+			name: "existing peering with the expected properties",
+			spec: spokeToHub,
+			existing: network.VirtualNetworkPeering{
+				Name: to.StringPtr("spoke-to-hub"),
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					AllowForwardedTraffic:     to.BoolPtr(true),
+					AllowGatewayTransit:       to.BoolPtr(false),
+					AllowVirtualNetworkAccess: to.BoolPtr(true),
+					UseRemoteGateways:         to.BoolPtr(true),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "existing peering with a changed property is updated",
+			spec: spokeToHub,
+			existing: network.VirtualNetworkPeering{
+				Name: to.StringPtr("spoke-to-hub"),
+				VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+					AllowForwardedTraffic: to.BoolPtr(true),
+					UseRemoteGateways:     to.BoolPtr(false),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetworkPeering{}))
+				g.Expect(result.(network.VirtualNetworkPeering).UseRemoteGateways).To(Equal(to.BoolPtr(true)))
+			},
+		},
+		{
+			name:          "existing is not a virtual network peering",
+			spec:          spokeToHub,
+			existing:      struct{}{},
+			expectedError: "struct {} is not a network.VnetPeering",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, result)
+		})
+	}
+}
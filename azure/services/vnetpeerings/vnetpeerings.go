@@ -19,6 +19,8 @@ package vnetpeerings
 import (
 	"context"
 
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
@@ -34,12 +36,18 @@ type VnetPeeringScope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
 	VnetPeeringSpecs() []azure.ResourceSpecGetter
+	VnetPeeringAuthorizer(ctx context.Context, subscriptionID string, identityRef, remoteIdentityRef *corev1.ObjectReference) (azure.Authorizer, error)
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope VnetPeeringScope
 	async.Reconciler
+	// remoteReconcilers holds the reconcilers of the peerings that are managed in another subscription
+	// or with another identity than the cluster's, keyed by subscription and identity.
+	remoteReconcilers map[string]async.Reconciler
+	// newRemoteReconciler creates a reconciler for the peerings managed with the given authorizer.
+	newRemoteReconciler func(auth azure.Authorizer) async.Reconciler
 }
 
 // New creates a new service.
@@ -48,6 +56,10 @@ func New(scope VnetPeeringScope) *Service {
 	return &Service{
 		Scope:      scope,
 		Reconciler: async.New(scope, Client, Client),
+		newRemoteReconciler: func(auth azure.Authorizer) async.Reconciler {
+			remoteClient := NewClient(auth)
+			return async.New(scope, remoteClient, remoteClient)
+		},
 	}
 }
 
@@ -74,7 +86,12 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	var result error
 	for _, peeringSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, peeringSpec, ServiceName); err != nil {
+		r, err := s.reconcilerFor(ctx, peeringSpec)
+		if err != nil {
+			result = err
+			continue
+		}
+		if _, err := r.CreateOrUpdateResource(ctx, peeringSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
@@ -103,7 +120,12 @@ func (s *Service) Delete(ctx context.Context) error {
 	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
 	var result error
 	for _, peeringSpec := range specs {
-		if err := s.DeleteResource(ctx, peeringSpec, ServiceName); err != nil {
+		r, err := s.reconcilerFor(ctx, peeringSpec)
+		if err != nil {
+			result = err
+			continue
+		}
+		if err := r.DeleteResource(ctx, peeringSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || result == nil {
 				result = err
 			}
@@ -113,6 +135,45 @@ func (s *Service) Delete(ctx context.Context) error {
 	return result
 }
 
+// reconcilerFor returns the reconciler of a peering, which uses the subscription of the source virtual network
+// and either the identity of the cluster or the identity referenced by the peering, and authorizes the tenant of the
+// remote virtual network when it differs.
+func (s *Service) reconcilerFor(ctx context.Context, spec azure.ResourceSpecGetter) (async.Reconciler, error) {
+	peeringSpec, ok := spec.(*VnetPeeringSpec)
+	if !ok {
+		return s.Reconciler, nil
+	}
+	if peeringSpec.SourceIdentityRef == nil && peeringSpec.RemoteIdentityRef == nil && (peeringSpec.SourceSubscriptionID == "" || peeringSpec.SourceSubscriptionID == s.Scope.SubscriptionID()) {
+		return s.Reconciler, nil
+	}
+
+	subscriptionID := peeringSpec.SourceSubscriptionID
+	if subscriptionID == "" {
+		subscriptionID = s.Scope.SubscriptionID()
+	}
+
+	key := subscriptionID
+	if ref := peeringSpec.SourceIdentityRef; ref != nil {
+		key += "/" + ref.Namespace + "/" + ref.Name
+	}
+	if ref := peeringSpec.RemoteIdentityRef; ref != nil {
+		key += "/remote/" + ref.Namespace + "/" + ref.Name
+	}
+	if r, ok := s.remoteReconcilers[key]; ok {
+		return r, nil
+	}
+	auth, err := s.Scope.VnetPeeringAuthorizer(ctx, subscriptionID, peeringSpec.SourceIdentityRef, peeringSpec.RemoteIdentityRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to authorize virtual network peering %s", peeringSpec.ResourceName())
+	}
+	r := s.newRemoteReconciler(auth)
+	if s.remoteReconcilers == nil {
+		s.remoteReconcilers = make(map[string]async.Reconciler)
+	}
+	s.remoteReconcilers[key] = r
+	return r, nil
+}
+
 // IsManaged returns always returns true as CAPZ does not support BYO VNet peering.
 func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	return true, nil
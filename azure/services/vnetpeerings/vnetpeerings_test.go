@@ -25,8 +25,11 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings/mock_vnetpeerings"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -360,3 +363,105 @@ func TestDeleteVnetPeerings(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileRemoteVnetPeerings(t *testing.T) {
+	identityRef := &corev1.ObjectReference{Name: "hub-identity", Namespace: "default", Kind: "AzureClusterIdentity"}
+	peeringClusterToHub := VnetPeeringSpec{
+		PeeringName:          "vnet1-to-hub",
+		SourceVnetName:       "vnet1",
+		SourceResourceGroup:  "group1",
+		RemoteVnetName:       "hub",
+		RemoteResourceGroup:  "hub-group",
+		SubscriptionID:       "hub-sub",
+		SourceSubscriptionID: "sub1",
+	}
+	peeringHubToCluster := VnetPeeringSpec{
+		PeeringName:          "hub-to-vnet1",
+		SourceVnetName:       "hub",
+		SourceResourceGroup:  "hub-group",
+		RemoteVnetName:       "vnet1",
+		RemoteResourceGroup:  "group1",
+		SubscriptionID:       "sub1",
+		SourceSubscriptionID: "hub-sub",
+		SourceIdentityRef:    identityRef,
+	}
+	specs := []azure.ResourceSpecGetter{&peeringClusterToHub, &peeringHubToCluster}
+	peeringClusterToOtherTenant := peeringClusterToHub
+	peeringClusterToOtherTenant.RemoteIdentityRef = identityRef
+	crossTenantSpecs := []azure.ResourceSpecGetter{&peeringClusterToOtherTenant, &peeringHubToCluster}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, remote *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "peering from a remote subscription is created with the referenced identity",
+			expectedError: "",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, remote *mock_async.MockReconcilerMockRecorder) {
+				p.VnetPeeringSpecs().Return(specs)
+				p.SubscriptionID().Return("sub1").AnyTimes()
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &peeringClusterToHub, ServiceName).Return(&peeringClusterToHub, nil)
+				p.VnetPeeringAuthorizer(gomockinternal.AContext(), "hub-sub", identityRef, nil).Return(nil, nil)
+				remote.CreateOrUpdateResource(gomockinternal.AContext(), &peeringHubToCluster, ServiceName).Return(&peeringHubToCluster, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "peering to a virtual network of another tenant is created with the cluster identity and the remote identity",
+			expectedError: "",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, remote *mock_async.MockReconcilerMockRecorder) {
+				p.VnetPeeringSpecs().Return(crossTenantSpecs)
+				p.SubscriptionID().Return("sub1").AnyTimes()
+				p.VnetPeeringAuthorizer(gomockinternal.AContext(), "sub1", nil, identityRef).Return(nil, nil)
+				remote.CreateOrUpdateResource(gomockinternal.AContext(), &peeringClusterToOtherTenant, ServiceName).Return(&peeringClusterToOtherTenant, nil)
+				p.VnetPeeringAuthorizer(gomockinternal.AContext(), "hub-sub", identityRef, nil).Return(nil, nil)
+				remote.CreateOrUpdateResource(gomockinternal.AContext(), &peeringHubToCluster, ServiceName).Return(&peeringHubToCluster, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "failure to authorize in the remote subscription is returned",
+			expectedError: "failed to authorize virtual network peering hub-to-vnet1: identity not found",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, remote *mock_async.MockReconcilerMockRecorder) {
+				p.VnetPeeringSpecs().Return(specs)
+				p.SubscriptionID().Return("sub1").AnyTimes()
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &peeringClusterToHub, ServiceName).Return(&peeringClusterToHub, nil)
+				p.VnetPeeringAuthorizer(gomockinternal.AContext(), "hub-sub", identityRef, nil).Return(nil, errors.New("identity not found"))
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, gomock.Any())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_vnetpeerings.NewMockVnetPeeringScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			remoteAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), remoteAsyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+				newRemoteReconciler: func(auth azure.Authorizer) async.Reconciler {
+					return remoteAsyncMock
+				},
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -56,6 +56,7 @@ type ScaleSetSpec struct {
 	VNetResourceGroup            string
 	PublicLBName                 string
 	PublicLBAddressPoolName      string
+	PublicLBInboundNATPoolNames  []string
 	AcceleratedNetworking        *bool
 	TerminateNotificationTimeout *int
 	Identity                     infrav1.VMIdentity
@@ -65,6 +66,10 @@ type ScaleSetSpec struct {
 	AdditionalCapabilities       *infrav1.AdditionalCapabilities
 	FailureDomains               []string
 	VMExtensions                 []infrav1.VMExtension
+	Diagnostics                  *infrav1.Diagnostics
+	CapacityReservationGroupID   string
+	DedicatedHostGroupID         string
+	ProximityPlacementGroupID    string
 }
 
 // TagsSpec defines the specification for a set of tags.
@@ -28,6 +28,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -168,6 +169,14 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "AzureClusterIdentity", deprecatedManagerCredsWarning)
 	}
 
+	for _, peering := range azureCluster.Spec.NetworkSpec.Vnet.Peerings {
+		if peering.IdentityRef != nil {
+			if err := EnsureClusterIdentity(ctx, acr.Client, azureCluster, peering.IdentityRef, infrav1.ClusterFinalizer); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
 	// Create the scope.
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
 		Client:       acr.Client,
@@ -210,6 +219,11 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		return reconcile.Result{}, err
 	}
 
+	// Release the identities that are no longer referenced, e.g. after a virtual network peering was removed.
+	if err := releaseUnreferencedClusterIdentities(ctx, acr.Client, azureCluster); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	acs, err := acr.createAzureClusterService(clusterScope)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
@@ -241,6 +255,21 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		return reconcile.Result{}, wrappedErr
 	}
 
+	if feature.Gates.Enabled(feature.DriftDetection) {
+		if drift := clusterScope.UpdateDriftStatus(); drift != "" {
+			acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, infrav1.DriftDetectedReason, "Azure resources differ from the spec: %s", drift)
+		}
+	}
+
+	if operations := clusterScope.UpdatePlanStatus(); operations != "" {
+		acr.Recorder.Eventf(azureCluster, corev1.EventTypeNormal, infrav1.ChangesPendingReason, "Planned Azure operations: %s", operations)
+	}
+	if clusterScope.DryRun() {
+		// Nothing was created in dry-run mode, so the AzureCluster must not be marked as ready.
+		log.Info("Planned AzureCluster reconciliation in dry-run mode")
+		return reconcile.Result{}, nil
+	}
+
 	// Set APIEndpoints so the Cluster API Cluster Controller can pull them
 	if azureCluster.Spec.ControlPlaneEndpoint.Host == "" {
 		azureCluster.Spec.ControlPlaneEndpoint.Host = clusterScope.APIServerHost()
@@ -289,16 +318,83 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		return reconcile.Result{}, wrappedErr
 	}
 
+	if operations := clusterScope.UpdatePlanStatus(); operations != "" {
+		acr.Recorder.Eventf(azureCluster, corev1.EventTypeNormal, infrav1.ChangesPendingReason, "Planned Azure operations: %s", operations)
+	}
+	if clusterScope.DryRun() {
+		// Nothing was deleted in dry-run mode, so keep the finalizer to avoid orphaning Azure resources.
+		log.Info("Planned AzureCluster deletion in dry-run mode")
+		return reconcile.Result{}, nil
+	}
+
 	// Cluster is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(azureCluster, infrav1.ClusterFinalizer)
 
-	if azureCluster.Spec.IdentityRef != nil {
-		// Cluster is deleted so remove the identity finalizer.
-		err := RemoveClusterIdentityFinalizer(ctx, acr.Client, azureCluster, azureCluster.Spec.IdentityRef, infrav1.ClusterFinalizer)
-		if err != nil {
+	// Cluster is deleted so remove the finalizer of the identities of the cluster and of its virtual network peerings,
+	// including the ones recorded in the status that are no longer referenced.
+	identityRefs := append(referencedClusterIdentities(azureCluster), azureCluster.Status.IdentityRefs...)
+	for i := range identityRefs {
+		if err := RemoveClusterIdentityFinalizer(ctx, acr.Client, azureCluster, &identityRefs[i], infrav1.ClusterFinalizer); err != nil && !apierrors.IsNotFound(err) {
 			return reconcile.Result{}, err
 		}
 	}
+	azureCluster.Status.IdentityRefs = nil
 
 	return reconcile.Result{}, nil
 }
+
+// referencedClusterIdentities returns the AzureClusterIdentities referenced by the AzureCluster, for the cluster itself
+// and for its virtual network peerings, without duplicates.
+func referencedClusterIdentities(azureCluster *infrav1.AzureCluster) []corev1.ObjectReference {
+	var identityRefs []corev1.ObjectReference
+	seen := make(map[client.ObjectKey]bool)
+	add := func(ref *corev1.ObjectReference) {
+		if ref == nil {
+			return
+		}
+		key := clusterIdentityKey(azureCluster, *ref)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		identityRefs = append(identityRefs, *ref)
+	}
+
+	add(azureCluster.Spec.IdentityRef)
+	for _, peering := range azureCluster.Spec.NetworkSpec.Vnet.Peerings {
+		add(peering.IdentityRef)
+	}
+	return identityRefs
+}
+
+// releaseUnreferencedClusterIdentities removes the finalizer of the AzureCluster from the identities recorded in its status
+// that it no longer references, and records the identities it references in its status.
+func releaseUnreferencedClusterIdentities(ctx context.Context, c client.Client, azureCluster *infrav1.AzureCluster) error {
+	identityRefs := referencedClusterIdentities(azureCluster)
+	referenced := make(map[client.ObjectKey]bool, len(identityRefs))
+	for _, ref := range identityRefs {
+		referenced[clusterIdentityKey(azureCluster, ref)] = true
+	}
+
+	for i, ref := range azureCluster.Status.IdentityRefs {
+		if referenced[clusterIdentityKey(azureCluster, ref)] {
+			continue
+		}
+		if err := RemoveClusterIdentityFinalizer(ctx, c, azureCluster, &azureCluster.Status.IdentityRefs[i], infrav1.ClusterFinalizer); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to release AzureClusterIdentity %s", ref.Name)
+		}
+	}
+
+	azureCluster.Status.IdentityRefs = identityRefs
+	return nil
+}
+
+// clusterIdentityKey returns the key of an AzureClusterIdentity referenced by the AzureCluster, which defaults to the
+// namespace of the AzureCluster.
+func clusterIdentityKey(azureCluster *infrav1.AzureCluster, ref corev1.ObjectReference) client.ObjectKey {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = azureCluster.Namespace
+	}
+	return client.ObjectKey{Namespace: namespace, Name: ref.Name}
+}
@@ -18,15 +18,26 @@ package controllers
 
 import (
 	"context"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var _ = Describe("AzureClusterReconciler", func() {
@@ -50,4 +61,193 @@ var _ = Describe("AzureClusterReconciler", func() {
 			Expect(result.RequeueAfter).To(BeZero())
 		})
 	})
+
+	Context("Reconcile an AzureCluster against a fake Azure Resource Manager", func() {
+		var (
+			srv  *fakearm.Server
+			stop func()
+		)
+
+		BeforeEach(func() {
+			srv, stop = startFakeARM()
+		})
+
+		AfterEach(func() {
+			stop()
+		})
+
+		It("should create the network infrastructure despite throttling, conflicts and failed provisioning", func() {
+			ctx := context.Background()
+			vnetPath := regexp.MustCompile(`(?i)/virtualNetworks/[^/]+$`)
+			nsgPath := regexp.MustCompile(`(?i)/networkSecurityGroups/[^/]+$`)
+			lbPath := regexp.MustCompile(`(?i)/loadBalancers/[^/]+$`)
+			srv.InjectFault(fakearm.Fault{Method: http.MethodPut, Path: vnetPath, StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second, Times: 1})
+			srv.InjectFault(fakearm.Fault{Method: http.MethodPut, Path: nsgPath, StatusCode: http.StatusConflict, Times: 1})
+			srv.InjectFault(fakearm.Fault{Method: http.MethodPut, Path: lbPath, FailProvisioning: true, Times: 1})
+
+			name := test.RandomName("fakearm", 10)
+			cluster, azureCluster := createFakeARMCluster(ctx, name)
+			defer deleteAndWait(ctx, cluster)
+			defer deleteAndWait(ctx, azureCluster)
+
+			By("Waiting for the AzureCluster to be ready")
+			waitForAzureClusterReady(ctx, azureCluster)
+			Expect(conditions.IsTrue(azureCluster, infrav1.NetworkInfrastructureReadyCondition)).To(BeTrue())
+			Expect(azureCluster.Spec.ControlPlaneEndpoint.Host).NotTo(BeEmpty())
+
+			resourceGroupID := "/subscriptions/" + fakearm.SubscriptionID + "/resourceGroups/" + name
+			Expect(srv.ResourceIDs()).To(ContainElements(
+				resourceGroupID+"/providers/Microsoft.Network/virtualNetworks/"+name+"-vnet",
+				resourceGroupID+"/providers/Microsoft.Network/networkSecurityGroups/"+name+"-node-nsg",
+				resourceGroupID+"/providers/Microsoft.Network/loadBalancers/"+name+"-public-lb",
+			))
+
+			By("Checking that the failed requests were retried")
+			Expect(countRequests(srv, http.MethodPut, vnetPath)).To(BeNumerically(">=", 2))
+			Expect(countRequests(srv, http.MethodPut, nsgPath)).To(BeNumerically(">", 2))
+			Expect(countRequests(srv, http.MethodPut, lbPath)).To(BeNumerically(">", 2))
+		})
+	})
 })
+
+// startFakeARM starts a fake Azure Resource Manager server. The controllers of the test environment send the
+// requests for the objects whose spec.azureEnvironment is fakearm.EnvironmentName to it until stop is called.
+func startFakeARM() (srv *fakearm.Server, stop func()) {
+	srv = fakearm.NewServer()
+	dir, err := os.MkdirTemp("", "fakearm")
+	Expect(err).NotTo(HaveOccurred())
+	restore, err := srv.SetEnvironment(dir)
+	Expect(err).NotTo(HaveOccurred())
+	return srv, func() {
+		restore()
+		srv.Close()
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	}
+}
+
+// createFakeARMCluster creates a Cluster and its AzureCluster, which is reconciled against the fake Azure Resource Manager.
+func createFakeARMCluster(ctx context.Context, name string) (*clusterv1.Cluster, *infrav1.AzureCluster) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				APIVersion: infrav1.GroupVersion.String(),
+				Kind:       "AzureCluster",
+				Name:       name,
+				Namespace:  "default",
+			},
+		},
+	}
+	Expect(testEnv.Create(ctx, cluster)).To(Succeed())
+
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       cluster.Name,
+					UID:        cluster.UID,
+				},
+			},
+		},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				Location:         "eastus",
+				SubscriptionID:   fakearm.SubscriptionID,
+				AzureEnvironment: fakearm.EnvironmentName,
+			},
+		},
+	}
+	// The defaulting webhook does not run in the test environment.
+	azureCluster.Default()
+	Expect(testEnv.Create(ctx, azureCluster)).To(Succeed())
+	return cluster, azureCluster
+}
+
+// waitForAzureClusterReady waits for the AzureCluster to be ready, and updates it with its latest state.
+func waitForAzureClusterReady(ctx context.Context, azureCluster *infrav1.AzureCluster) {
+	Eventually(func() bool {
+		if err := testEnv.Get(ctx, client.ObjectKeyFromObject(azureCluster), azureCluster); err != nil {
+			return false
+		}
+		return azureCluster.Status.Ready
+	}, 2*time.Minute, time.Second).Should(BeTrue())
+}
+
+// deleteAndWait deletes the object and waits for its finalizers to be removed.
+func deleteAndWait(ctx context.Context, obj client.Object) {
+	Expect(testEnv.Delete(ctx, obj)).To(Succeed())
+	Eventually(func() bool {
+		return apierrors.IsNotFound(testEnv.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+	}, 2*time.Minute, time.Second).Should(BeTrue())
+}
+
+// countRequests returns the number of requests received by the server with the method and a path matching the pattern.
+func countRequests(srv *fakearm.Server, method string, path *regexp.Regexp) int {
+	count := 0
+	for _, request := range srv.Requests() {
+		if request.Method == method && path.MatchString(request.Path) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestReleaseUnreferencedClusterIdentities(t *testing.T) {
+	g := NewWithT(t)
+
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				IdentityRef: &corev1.ObjectReference{Name: "cluster-identity"},
+			},
+			NetworkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Peerings: infrav1.VnetPeerings{
+						{VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{RemoteVnetName: "hub", IdentityRef: &corev1.ObjectReference{Name: "hub-identity", Namespace: "identities"}}},
+						{VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{RemoteVnetName: "spoke", IdentityRef: &corev1.ObjectReference{Name: "cluster-identity", Namespace: "default"}}},
+					},
+				},
+			},
+		},
+		Status: infrav1.AzureClusterStatus{
+			IdentityRefs: []corev1.ObjectReference{
+				{Name: "cluster-identity"},
+				{Name: "removed-identity", Namespace: "identities"},
+				{Name: "deleted-identity"},
+			},
+		},
+	}
+	finalizer := clusterIdentityFinalizer(infrav1.ClusterFinalizer, "default", "my-cluster")
+	newIdentity := func(namespace, name string) *infrav1.AzureClusterIdentity {
+		return &infrav1.AzureClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Finalizers: []string{finalizer}},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(setupScheme(g)).WithRuntimeObjects(
+		newIdentity("default", "cluster-identity"),
+		newIdentity("identities", "hub-identity"),
+		newIdentity("identities", "removed-identity"),
+	).Build()
+
+	g.Expect(releaseUnreferencedClusterIdentities(context.TODO(), c, azureCluster)).To(Succeed())
+	g.Expect(azureCluster.Status.IdentityRefs).To(Equal([]corev1.ObjectReference{
+		{Name: "cluster-identity"},
+		{Name: "hub-identity", Namespace: "identities"},
+	}))
+
+	identity := &infrav1.AzureClusterIdentity{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "identities", Name: "removed-identity"}, identity)).To(Succeed())
+	g.Expect(identity.Finalizers).To(BeEmpty())
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "cluster-identity"}, identity)).To(Succeed())
+	g.Expect(identity.Finalizers).To(ConsistOf(finalizer))
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "identities", Name: "hub-identity"}, identity)).To(Succeed())
+	g.Expect(identity.Finalizers).To(ConsistOf(finalizer))
+}
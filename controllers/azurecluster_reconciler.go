@@ -22,11 +22,15 @@ import (
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
@@ -43,9 +47,34 @@ import (
 type azureClusterService struct {
 	scope *scope.ClusterScope
 	// services is the list of services that are reconciled by this controller.
-	// The order of the services is important as it determines the order in which the services are reconciled.
 	services []azure.ServiceReconciler
-	skuCache *resourceskus.Cache
+	// dependencies maps the name of a service to the names of the services that must be reconciled before it.
+	// Services that do not depend on each other are reconciled concurrently.
+	// If nil, services are reconciled sequentially in the order in which they appear in services.
+	dependencies map[string][]string
+	skuCache     *resourceskus.Cache
+}
+
+// clusterServiceDependencies declares the order in which the AzureCluster services must be reconciled.
+// Besides the Azure resources a service references, a dependency is also needed whenever a service reads
+// parts of the AzureCluster spec that another service writes, e.g. subnet IDs and CIDRs.
+var clusterServiceDependencies = map[string][]string{
+	groups.ServiceName:                    nil,
+	virtualnetworks.ServiceName:           {groups.ServiceName},
+	applicationsecuritygroups.ServiceName: {groups.ServiceName},
+	securitygroups.ServiceName:            {virtualnetworks.ServiceName, applicationsecuritygroups.ServiceName},
+	routetables.ServiceName:               {virtualnetworks.ServiceName},
+	publicipprefixes.ServiceName:          {groups.ServiceName},
+	publicips.ServiceName:                 {virtualnetworks.ServiceName, publicipprefixes.ServiceName},
+	natgateways.ServiceName:               {publicips.ServiceName, securitygroups.ServiceName, routetables.ServiceName},
+	subnets.ServiceName:                   {securitygroups.ServiceName, routetables.ServiceName, natgateways.ServiceName},
+	vnetpeerings.ServiceName:              {virtualnetworks.ServiceName},
+	loadbalancers.ServiceName:             {subnets.ServiceName, publicips.ServiceName},
+	privatedns.ServiceName:                {virtualnetworks.ServiceName, loadbalancers.ServiceName},
+	privateendpoints.ServiceName:          {subnets.ServiceName},
+	bastionhosts.ServiceName:              {subnets.ServiceName, publicips.ServiceName},
+	azurefirewalls.ServiceName:            {subnets.ServiceName, publicips.ServiceName},
+	tags.ServiceName:                      {vnetpeerings.ServiceName, privatedns.ServiceName, privateendpoints.ServiceName, bastionhosts.ServiceName, azurefirewalls.ServiceName},
 }
 
 // newAzureClusterService populates all the services based on input scope.
@@ -59,22 +88,27 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 		services: []azure.ServiceReconciler{
 			groups.New(scope),
 			virtualnetworks.New(scope),
+			applicationsecuritygroups.New(scope),
 			securitygroups.New(scope),
 			routetables.New(scope),
+			publicipprefixes.New(scope),
 			publicips.New(scope),
 			natgateways.New(scope),
 			subnets.New(scope),
 			vnetpeerings.New(scope),
 			loadbalancers.New(scope),
 			privatedns.New(scope),
+			privateendpoints.New(scope),
 			bastionhosts.New(scope),
+			azurefirewalls.New(scope),
 			tags.New(scope),
 		},
-		skuCache: skuCache,
+		dependencies: clusterServiceDependencies,
+		skuCache:     skuCache,
 	}, nil
 }
 
-// Reconcile reconciles all the services in a predetermined order.
+// Reconcile reconciles all the services, running independent services concurrently.
 func (s *azureClusterService) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Reconcile")
 	defer done()
@@ -86,16 +120,22 @@ func (s *azureClusterService) Reconcile(ctx context.Context) error {
 	s.scope.SetDNSName()
 	s.scope.SetControlPlaneSecurityRules()
 
-	for _, service := range s.services {
-		if err := service.Reconcile(ctx); err != nil {
-			return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", service.Name())
-		}
+	graph, err := newServiceGraph(s.services, s.dependencies)
+	if err != nil {
+		return errors.Wrap(err, "failed to order AzureCluster services")
 	}
 
-	return nil
+	return graph.run(ctx, func(ctx context.Context, service azure.ServiceReconciler) error {
+		// Operations planned in dry-run mode do not block the services that depend on them, so that the whole
+		// reconciliation gets planned.
+		if err := service.Reconcile(ctx); err != nil && !azure.IsOperationPlannedError(err) {
+			return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", service.Name())
+		}
+		return nil
+	})
 }
 
-// Delete reconciles all the services in a predetermined order.
+// Delete deletes all the services in the reverse order from the order in which they are reconciled.
 func (s *azureClusterService) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Delete")
 	defer done()
@@ -120,21 +160,36 @@ func (s *azureClusterService) Delete(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to get vnet peerings service")
 		}
-		if err := vnetPeeringsSvc.Delete(ctx); err != nil {
+		if err := vnetPeeringsSvc.Delete(ctx); err != nil && !azure.IsOperationPlannedError(err) {
 			return errors.Wrap(err, "failed to delete peerings")
 		}
+		// The records and links of an existing private DNS zone are not part of the resource group either.
+		if s.scope.HasExistingPrivateDNSZone() {
+			privateDNSSvc, err := s.getService(privatedns.ServiceName)
+			if err != nil {
+				return errors.Wrap(err, "failed to get private dns service")
+			}
+			if err := privateDNSSvc.Delete(ctx); err != nil && !azure.IsOperationPlannedError(err) {
+				return errors.Wrap(err, "failed to delete private dns records and links")
+			}
+		}
 		// Delete the entire resource group directly.
-		if err := groupSvc.Delete(ctx); err != nil {
+		if err := groupSvc.Delete(ctx); err != nil && !azure.IsOperationPlannedError(err) {
 			return errors.Wrap(err, "failed to delete resource group")
 		}
 	} else {
 		// If the resource group is not managed we need to delete resources inside the group one by one.
-		// services are deleted in reverse order from the order in which they are reconciled.
-		for i := len(s.services) - 1; i >= 0; i-- {
-			if err := s.services[i].Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete AzureCluster service %s", s.services[i].Name())
-			}
+		// Each service is deleted once all the services that depend on it have been deleted.
+		graph, err := newServiceGraph(s.services, s.dependencies)
+		if err != nil {
+			return errors.Wrap(err, "failed to order AzureCluster services")
 		}
+		return graph.reverse().run(ctx, func(ctx context.Context, service azure.ServiceReconciler) error {
+			if err := service.Delete(ctx); err != nil && !azure.IsOperationPlannedError(err) {
+				return errors.Wrapf(err, "failed to delete AzureCluster service %s", service.Name())
+			}
+			return nil
+		})
 	}
 
 	return nil
@@ -20,19 +20,31 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAzureClusterServiceReconcile(t *testing.T) {
@@ -49,6 +61,15 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 					three.Reconcile(gomockinternal.AContext()).Return(nil))
 			},
 		},
+		"operations planned in dry-run mode do not stop the reconciliation": {
+			expectedError: "",
+			expect: func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					one.Reconcile(gomockinternal.AContext()).Return(azure.NewOperationPlannedError(&infrav1.Future{Type: infrav1.PutFuture})),
+					two.Reconcile(gomockinternal.AContext()).Return(nil),
+					three.Reconcile(gomockinternal.AContext()).Return(azure.NewOperationPlannedError(&infrav1.Future{Type: infrav1.PatchFuture})))
+			},
+		},
 		"service reconcile fails": {
 			expectedError: "failed to reconcile AzureCluster service two: some error happened",
 			expect: func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
@@ -100,8 +121,9 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 
 func TestAzureClusterServiceDelete(t *testing.T) {
 	cases := map[string]struct {
-		expectedError string
-		expect        func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
+		expectedError          string
+		existingPrivateDNSZone bool
+		expect                 func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
 	}{
 		"Resource Group is deleted successfully": {
 			expectedError: "",
@@ -115,6 +137,20 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 					grp.Delete(gomockinternal.AContext()).Return(nil))
 			},
 		},
+		"Records and links of an existing private DNS zone are deleted with the resource group": {
+			expectedError:          "",
+			existingPrivateDNSZone: true,
+			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				grp.Name().Return(groups.ServiceName).AnyTimes()
+				vpr.Name().Return(vnetpeerings.ServiceName).AnyTimes()
+				one.Name().Return(privatedns.ServiceName).AnyTimes()
+				gomock.InOrder(
+					grp.IsManaged(gomockinternal.AContext()).Return(true, nil),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					one.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
 		"Error when checking if resource group is managed": {
 			expectedError: "failed to determine if the AzureCluster resource group is managed: an error happened",
 			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
@@ -177,9 +213,15 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 
 			tc.expect(groupsMock.EXPECT(), vnetpeeringsMock.EXPECT(), svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
 
+			azureCluster := &infrav1.AzureCluster{}
+			if tc.existingPrivateDNSZone {
+				azureCluster.Spec.NetworkSpec.APIServerLB.Type = infrav1.Internal
+				azureCluster.Spec.NetworkSpec.PrivateDNSZone = &infrav1.PrivateDNSZoneClassSpec{ResourceGroup: "my-dns-rg"}
+			}
+
 			s := &azureClusterService{
 				scope: &scope.ClusterScope{
-					AzureCluster: &infrav1.AzureCluster{},
+					AzureCluster: azureCluster,
 				},
 				services: []azure.ServiceReconciler{
 					groupsMock,
@@ -201,3 +243,117 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureClusterServiceReconcileWithFakeARM(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := fakearm.NewServer()
+	defer srv.Close()
+	srv.UseEnvironment(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				Location:         "eastus",
+				AzureEnvironment: fakearm.EnvironmentName,
+			},
+			ResourceGroup: "my-rg",
+			NetworkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					ResourceGroup: "my-rg",
+					Name:          "my-vnet",
+					VnetClassSpec: infrav1.VnetClassSpec{
+						CIDRBlocks: []string{"10.0.0.0/8"},
+					},
+				},
+				Subnets: infrav1.Subnets{
+					{
+						SubnetClassSpec: infrav1.SubnetClassSpec{
+							Name:       "cp-subnet",
+							Role:       infrav1.SubnetControlPlane,
+							CIDRBlocks: []string{"10.0.0.0/16"},
+						},
+						SecurityGroup: infrav1.SecurityGroup{Name: "cp-nsg"},
+					},
+					{
+						SubnetClassSpec: infrav1.SubnetClassSpec{
+							Name:       "node-subnet",
+							Role:       infrav1.SubnetNode,
+							CIDRBlocks: []string{"10.1.0.0/16"},
+						},
+						SecurityGroup: infrav1.SecurityGroup{Name: "node-nsg"},
+						RouteTable:    infrav1.RouteTable{Name: "node-routetable"},
+					},
+				},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(setupScheme(g)).WithRuntimeObjects(cluster, azureCluster).Build()
+
+	// The scope reads the credentials and the Azure environment of the server set by UseEnvironment, so the real
+	// service clients send their requests to the server.
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:       client,
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := &azureClusterService{
+		scope: clusterScope,
+		services: []azure.ServiceReconciler{
+			groups.New(clusterScope),
+			virtualnetworks.New(clusterScope),
+			securitygroups.New(clusterScope),
+			routetables.New(clusterScope),
+			subnets.New(clusterScope),
+		},
+		dependencies: clusterServiceDependencies,
+		skuCache:     resourceskus.NewStaticCache([]compute.ResourceSku{}, "eastus"),
+	}
+
+	// Each long-running operation is in progress on the first reconciliation, and is picked up again by the next one.
+	g.Eventually(func() error {
+		return s.Reconcile(context.TODO())
+	}, 10*time.Second, 10*time.Millisecond).Should(Succeed())
+
+	resourceGroupID := "/subscriptions/" + fakearm.SubscriptionID + "/resourceGroups/my-rg"
+	g.Expect(srv.ResourceIDs()).To(ConsistOf(
+		// The resource groups client sends the path of the resource group in lower case.
+		"/subscriptions/"+fakearm.SubscriptionID+"/resourcegroups/my-rg",
+		resourceGroupID+"/providers/Microsoft.Network/virtualNetworks/my-vnet",
+		resourceGroupID+"/providers/Microsoft.Network/networkSecurityGroups/cp-nsg",
+		resourceGroupID+"/providers/Microsoft.Network/networkSecurityGroups/node-nsg",
+		resourceGroupID+"/providers/Microsoft.Network/routeTables/node-routetable",
+		resourceGroupID+"/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/cp-subnet",
+		resourceGroupID+"/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/node-subnet",
+	))
+
+	var subnet network.Subnet
+	found, err := srv.GetResource(resourceGroupID+"/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/node-subnet", &subnet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(to.String(subnet.NetworkSecurityGroup.ID)).To(Equal(resourceGroupID + "/providers/Microsoft.Network/networkSecurityGroups/node-nsg"))
+	g.Expect(to.String(subnet.RouteTable.ID)).To(Equal(resourceGroupID + "/providers/Microsoft.Network/routeTables/node-routetable"))
+
+	for _, condition := range []clusterv1.ConditionType{
+		infrav1.ResourceGroupReadyCondition,
+		infrav1.VNetReadyCondition,
+		infrav1.SecurityGroupsReadyCondition,
+		infrav1.RouteTablesReadyCondition,
+		infrav1.SubnetsReadyCondition,
+	} {
+		g.Expect(conditions.IsTrue(azureCluster, condition)).To(BeTrue(), "condition %s", condition)
+	}
+	g.Expect(clusterScope.ControlPlaneSubnet().ID).To(Equal(resourceGroupID + "/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/cp-subnet"))
+}
@@ -28,6 +28,8 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -273,9 +275,21 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 	// Initialize the cache to be used by the AzureMachine services.
 	err := machineScope.InitMachineCache(ctx)
 	if err != nil {
+		// An exceeded quota may free up, e.g. once the VMs replaced by a rollout are deleted, so the machine is not failed.
+		if resourceskus.IsQuotaExceeded(err) && errors.As(err, &reconcileError) && reconcileError.IsTransient() {
+			amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, infrav1.QuotaExceededReason, err.Error())
+			log.Error(err, "Insufficient compute quota to create the VM")
+			conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, infrav1.QuotaExceededReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+		}
 		if errors.As(err, &reconcileError) && reconcileError.IsTerminal() {
-			amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "SKUNotFound", errors.Wrap(err, "failed to initialize machine cache").Error())
+			reason := "SKUNotFound"
+			if resourceskus.IsLocationRestricted(err) || resourceskus.IsZoneRestricted(err) {
+				reason = infrav1.SKURestrictedReason
+			}
+			amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, reason, errors.Wrap(err, "failed to initialize machine cache").Error())
 			log.Error(err, "Failed to initialize machine cache")
+			conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, reason, clusterv1.ConditionSeverityError, err.Error())
 			machineScope.SetFailureReason(capierrors.InvalidConfigurationMachineError)
 			machineScope.SetFailureMessage(err)
 			machineScope.SetNotReady()
@@ -299,6 +313,12 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 	}
 
 	if err := ams.Reconcile(ctx); err != nil {
+		// Report the end of the serial console log of a VM that failed to bootstrap, as it usually tells why.
+		var bootstrapErr azure.BootstrapFailedError
+		if errors.As(err, &bootstrapErr) {
+			amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, infrav1.BootstrapFailedReason, "Last lines of the VM serial console log:\n%s", bootstrapErr.SerialConsoleLogTail)
+		}
+
 		// This means that a VM was created and managed by this controller, but is not present anymore.
 		// In this case, we mark it as failed and leave it to MHC for remediation
 		if errors.As(err, &azure.VMDeletedError{}) {
@@ -335,6 +355,21 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile AzureMachine")
 	}
 
+	if feature.Gates.Enabled(feature.DriftDetection) {
+		if drift := machineScope.UpdateDriftStatus(); drift != "" {
+			amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, infrav1.DriftDetectedReason, "Azure resources differ from the spec: %s", drift)
+		}
+	}
+
+	if operations := machineScope.UpdatePlanStatus(); operations != "" {
+		amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeNormal, infrav1.ChangesPendingReason, "Planned Azure operations: %s", operations)
+	}
+	if machineScope.DryRun() {
+		// Nothing was created in dry-run mode, so the AzureMachine must not be marked as ready.
+		log.Info("Planned AzureMachine reconciliation in dry-run mode")
+		return reconcile.Result{}, nil
+	}
+
 	machineScope.SetReady()
 
 	return reconcile.Result{}, nil
@@ -378,6 +413,15 @@ func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineS
 		log.Info("Skipping AzureMachine Deletion; will delete whole resource group.")
 	}
 
+	if operations := machineScope.UpdatePlanStatus(); operations != "" {
+		amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeNormal, infrav1.ChangesPendingReason, "Planned Azure operations: %s", operations)
+	}
+	if machineScope.DryRun() {
+		// Nothing was deleted in dry-run mode, so keep the finalizer to avoid orphaning Azure resources.
+		log.Info("Planned AzureMachine deletion in dry-run mode")
+		return reconcile.Result{}, nil
+	}
+
 	// we're done deleting this AzureMachine so remove the finalizer.
 	log.Info("Removing finalizer from AzureMachine")
 	controllerutil.RemoveFinalizer(machineScope.AzureMachine, infrav1.MachineFinalizer)
@@ -18,9 +18,13 @@ package controllers
 
 import (
 	"context"
+	"net/http"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -30,8 +34,10 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -59,6 +65,120 @@ var _ = Describe("AzureMachineReconciler", func() {
 			Expect(result.RequeueAfter).To(BeZero())
 		})
 	})
+
+	Context("Reconcile an AzureMachine against a fake Azure Resource Manager", func() {
+		var (
+			srv  *fakearm.Server
+			stop func()
+		)
+
+		BeforeEach(func() {
+			srv, stop = startFakeARM()
+		})
+
+		AfterEach(func() {
+			stop()
+		})
+
+		It("should create the virtual machine despite conflicts and failed provisioning", func() {
+			ctx := context.Background()
+			Expect(srv.AddResourceSKU("Standard_D2s_v3", "virtualMachines", "eastus", map[string]string{"vCPUs": "2", "MemoryGB": "8"})).To(Succeed())
+			Expect(srv.AddResourceSKU("Aligned", "availabilitySets", "eastus", map[string]string{"MaximumPlatformFaultDomainCount": "3"})).To(Succeed())
+			nicPath := regexp.MustCompile(`(?i)/networkInterfaces/[^/]+$`)
+			vmPath := regexp.MustCompile(`(?i)/virtualMachines/[^/]+$`)
+			srv.InjectFault(fakearm.Fault{Method: http.MethodPut, Path: nicPath, StatusCode: http.StatusConflict, Times: 1})
+			srv.InjectFault(fakearm.Fault{Method: http.MethodPut, Path: vmPath, FailProvisioning: true, Times: 1})
+
+			name := test.RandomName("fakearm", 10)
+			cluster, azureCluster := createFakeARMCluster(ctx, name)
+			defer deleteAndWait(ctx, cluster)
+			defer deleteAndWait(ctx, azureCluster)
+			waitForAzureClusterReady(ctx, azureCluster)
+
+			// The Cluster controller does not run in the test environment.
+			cluster.Status.InfrastructureReady = true
+			Expect(testEnv.Status().Update(ctx, cluster)).To(Succeed())
+
+			bootstrapSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-bootstrap",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{"value": []byte("#cloud-config")},
+			}
+			Expect(testEnv.Create(ctx, bootstrapSecret)).To(Succeed())
+			defer func() {
+				Expect(testEnv.Delete(ctx, bootstrapSecret)).To(Succeed())
+			}()
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: cluster.Name,
+					Version:     to.StringPtr("v1.23.5"),
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: to.StringPtr(bootstrapSecret.Name),
+					},
+					InfrastructureRef: corev1.ObjectReference{
+						APIVersion: infrav1.GroupVersion.String(),
+						Kind:       "AzureMachine",
+						Name:       name,
+						Namespace:  "default",
+					},
+				},
+			}
+			Expect(testEnv.Create(ctx, machine)).To(Succeed())
+			defer deleteAndWait(ctx, machine)
+
+			azureMachine := &infrav1.AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: clusterv1.GroupVersion.String(),
+							Kind:       "Machine",
+							Name:       machine.Name,
+							UID:        machine.UID,
+						},
+					},
+				},
+				Spec: infrav1.AzureMachineSpec{
+					VMSize: "Standard_D2s_v3",
+				},
+			}
+			// The defaulting webhook does not run in the test environment.
+			azureMachine.Default()
+			Expect(testEnv.Create(ctx, azureMachine)).To(Succeed())
+			defer deleteAndWait(ctx, azureMachine)
+
+			By("Waiting for the AzureMachine to be ready")
+			Eventually(func() bool {
+				if err := testEnv.Get(ctx, client.ObjectKeyFromObject(azureMachine), azureMachine); err != nil {
+					return false
+				}
+				return azureMachine.Status.Ready
+			}, 2*time.Minute, time.Second).Should(BeTrue())
+			Expect(conditions.IsTrue(azureMachine, infrav1.VMRunningCondition)).To(BeTrue())
+			Expect(azureMachine.Status.FailureReason).To(BeNil())
+			Expect(azureMachine.Spec.ProviderID).NotTo(BeNil())
+
+			resourceGroupID := "/subscriptions/" + fakearm.SubscriptionID + "/resourceGroups/" + name
+			Expect(srv.ResourceIDs()).To(ContainElements(
+				resourceGroupID+"/providers/Microsoft.Network/networkInterfaces/"+name+"-nic",
+				resourceGroupID+"/providers/Microsoft.Compute/virtualMachines/"+name,
+			))
+
+			By("Checking that the failed requests were retried")
+			Expect(countRequests(srv, http.MethodPut, nicPath)).To(BeNumerically(">=", 2))
+			Expect(countRequests(srv, http.MethodPut, vmPath)).To(BeNumerically(">=", 2))
+		})
+	})
 })
 
 func TestConditions(t *testing.T) {
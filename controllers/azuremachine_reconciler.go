@@ -61,7 +61,7 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 			disks.New(machineScope),
 			virtualmachines.New(machineScope),
 			roleassignments.New(machineScope),
-			vmextensions.New(machineScope),
+			vmextensions.New(machineScope, virtualmachines.NewClient(machineScope)),
 			tags.New(machineScope),
 		},
 		skuCache: cache,
@@ -78,7 +78,8 @@ func (s *azureMachineService) Reconcile(ctx context.Context) error {
 	}
 
 	for _, service := range s.services {
-		if err := service.Reconcile(ctx); err != nil {
+		// Operations planned in dry-run mode do not stop the reconciliation, so that all the services get planned.
+		if err := service.Reconcile(ctx); err != nil && !azure.IsOperationPlannedError(err) {
 			return errors.Wrapf(err, "failed to reconcile AzureMachine service %s", service.Name())
 		}
 	}
@@ -93,7 +94,7 @@ func (s *azureMachineService) Delete(ctx context.Context) error {
 
 	// Delete services in reverse order of creation.
 	for i := len(s.services) - 1; i >= 0; i-- {
-		if err := s.services[i].Delete(ctx); err != nil {
+		if err := s.services[i].Delete(ctx); err != nil && !azure.IsOperationPlannedError(err) {
 			return errors.Wrapf(err, "failed to delete AzureMachine service %s", s.services[i].Name())
 		}
 	}
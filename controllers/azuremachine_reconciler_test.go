@@ -47,6 +47,15 @@ func TestAzureMachineServiceReconcile(t *testing.T) {
 					three.Reconcile(gomockinternal.AContext()).Return(nil))
 			},
 		},
+		"operations planned in dry-run mode do not stop the reconciliation": {
+			expectedError: "",
+			expect: func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					one.Reconcile(gomockinternal.AContext()).Return(azure.NewOperationPlannedError(&infrav1.Future{Type: infrav1.PutFuture})),
+					two.Reconcile(gomockinternal.AContext()).Return(nil),
+					three.Reconcile(gomockinternal.AContext()).Return(azure.NewOperationPlannedError(&infrav1.Future{Type: infrav1.PatchFuture})))
+			},
+		},
 		"service reconcile fails": {
 			expectedError: "failed to reconcile AzureMachine service foo: some error happened",
 			expect: func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// serviceGraph runs a set of services concurrently while honoring the dependencies between them.
+type serviceGraph struct {
+	services []azure.ServiceReconciler
+	// names holds the name of each service, or an empty string if names were not needed to build the graph.
+	names []string
+	// deps holds, for each service, the indexes of the services that must complete before it runs.
+	deps [][]int
+}
+
+// newServiceGraph builds a serviceGraph from a map of service name to the names of the services it depends on.
+// Dependencies on services that are not part of services are ignored. If dependencies is nil, each service
+// depends on the one before it, so the services run sequentially in the order given.
+func newServiceGraph(services []azure.ServiceReconciler, dependencies map[string][]string) (*serviceGraph, error) {
+	g := &serviceGraph{
+		services: services,
+		names:    make([]string, len(services)),
+		deps:     make([][]int, len(services)),
+	}
+
+	if dependencies == nil {
+		for i := 1; i < len(services); i++ {
+			g.deps[i] = []int{i - 1}
+		}
+		return g, nil
+	}
+
+	index := make(map[string]int, len(services))
+	for i, service := range services {
+		g.names[i] = service.Name()
+		index[g.names[i]] = i
+	}
+	for i, name := range g.names {
+		for _, dep := range dependencies[name] {
+			if j, ok := index[dep]; ok {
+				g.deps[i] = append(g.deps[i], j)
+			}
+		}
+	}
+
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// validate returns an error if the graph has a dependency cycle, which would otherwise block forever.
+func (g *serviceGraph) validate() error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(g.services))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visiting:
+			return errors.Errorf("dependency cycle detected at service %s", g.names[i])
+		case visited:
+			return nil
+		}
+		state[i] = visiting
+		for _, j := range g.deps[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		return nil
+	}
+	for i := range g.services {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reverse returns a graph in which each service waits for the services that depend on it.
+// It is used to tear down resources in the opposite order to that in which they were created.
+func (g *serviceGraph) reverse() *serviceGraph {
+	r := &serviceGraph{
+		services: g.services,
+		names:    g.names,
+		deps:     make([][]int, len(g.services)),
+	}
+	// Walk the services backwards so that, for a sequential graph, dependencies stay ordered like the original slice.
+	for i := len(g.deps) - 1; i >= 0; i-- {
+		for _, j := range g.deps[i] {
+			r.deps[j] = append(r.deps[j], i)
+		}
+	}
+	return r
+}
+
+// run calls fn for every service in the graph. Each service starts as soon as all of its dependencies have
+// succeeded, so independent services run concurrently. Services whose dependencies failed are skipped.
+// The errors returned by fn are aggregated with aggregateServiceErrors.
+func (g *serviceGraph) run(ctx context.Context, fn func(context.Context, azure.ServiceReconciler) error) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.serviceGraph.run")
+	defer done()
+
+	n := len(g.services)
+	finished := make([]chan struct{}, n)
+	for i := range finished {
+		finished[i] = make(chan struct{})
+	}
+	// failed[i] is only written before finished[i] is closed, and only read after it is.
+	failed := make([]bool, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := range g.services {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(finished[i])
+			for _, j := range g.deps[i] {
+				<-finished[j]
+				if failed[j] {
+					log.V(4).Info("skipping service because a dependency did not complete", "service", g.names[i], "dependency", g.names[j])
+					failed[i] = true
+					return
+				}
+			}
+			if err := fn(ctx, g.services[i]); err != nil {
+				errs[i] = err
+				failed[i] = true
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return aggregateServiceErrors(errs)
+}
+
+// aggregateServiceErrors combines the errors of several services into one while preserving the reconcile
+// semantics the controllers rely on: a single error is returned as is, any terminal error makes the
+// aggregate terminal, and errors that are all transient are requeued after the shortest requested delay.
+func aggregateServiceErrors(errs []error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+
+	agg := kerrors.NewAggregate(nonNil)
+	allTransient := true
+	var requeueAfter time.Duration
+	for _, err := range nonNil {
+		var reconcileErr azure.ReconcileError
+		if !errors.As(err, &reconcileErr) {
+			allTransient = false
+			continue
+		}
+		if reconcileErr.IsTerminal() {
+			return azure.WithTerminalError(agg)
+		}
+		if !reconcileErr.IsTransient() {
+			allTransient = false
+			continue
+		}
+		if requeueAfter == 0 || reconcileErr.RequeueAfter() < requeueAfter {
+			requeueAfter = reconcileErr.RequeueAfter()
+		}
+	}
+	if allTransient {
+		return azure.WithTransientError(agg, requeueAfter)
+	}
+	return agg
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+func TestServiceGraphRun(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	base := mock_azure.NewMockServiceReconciler(mockCtrl)
+	left := mock_azure.NewMockServiceReconciler(mockCtrl)
+	right := mock_azure.NewMockServiceReconciler(mockCtrl)
+	last := mock_azure.NewMockServiceReconciler(mockCtrl)
+	base.EXPECT().Name().Return("base").AnyTimes()
+	left.EXPECT().Name().Return("left").AnyTimes()
+	right.EXPECT().Name().Return("right").AnyTimes()
+	last.EXPECT().Name().Return("last").AnyTimes()
+
+	// left and right only complete once both have started, which can only happen if they run concurrently.
+	leftStarted, rightStarted := make(chan struct{}), make(chan struct{})
+	waitFor := func(started chan struct{}, other chan struct{}) func(context.Context) error {
+		return func(context.Context) error {
+			close(started)
+			select {
+			case <-other:
+				return nil
+			case <-time.After(10 * time.Second):
+				return errors.New("services were not reconciled concurrently")
+			}
+		}
+	}
+	baseCall := base.EXPECT().Reconcile(gomockinternal.AContext()).Return(nil)
+	leftCall := left.EXPECT().Reconcile(gomockinternal.AContext()).DoAndReturn(waitFor(leftStarted, rightStarted)).After(baseCall)
+	rightCall := right.EXPECT().Reconcile(gomockinternal.AContext()).DoAndReturn(waitFor(rightStarted, leftStarted)).After(baseCall)
+	last.EXPECT().Reconcile(gomockinternal.AContext()).Return(nil).After(leftCall).After(rightCall)
+
+	graph, err := newServiceGraph([]azure.ServiceReconciler{base, left, right, last}, map[string][]string{
+		"left":  {"base"},
+		"right": {"base", "unknown"},
+		"last":  {"left", "right"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(graph.run(context.TODO(), func(ctx context.Context, service azure.ServiceReconciler) error {
+		return service.Reconcile(ctx)
+	})).To(Succeed())
+}
+
+func TestServiceGraphRunSkipsDependentsOfFailedServices(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	failing := mock_azure.NewMockServiceReconciler(mockCtrl)
+	independent := mock_azure.NewMockServiceReconciler(mockCtrl)
+	dependent := mock_azure.NewMockServiceReconciler(mockCtrl)
+	failing.EXPECT().Name().Return("failing").AnyTimes()
+	independent.EXPECT().Name().Return("independent").AnyTimes()
+	dependent.EXPECT().Name().Return("dependent").AnyTimes()
+
+	failing.EXPECT().Reconcile(gomockinternal.AContext()).Return(errors.New("failing error"))
+	independent.EXPECT().Reconcile(gomockinternal.AContext()).Return(azure.WithTransientError(errors.New("independent error"), 15*time.Second))
+
+	graph, err := newServiceGraph([]azure.ServiceReconciler{failing, independent, dependent}, map[string][]string{
+		"dependent": {"failing"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	err = graph.run(context.TODO(), func(ctx context.Context, service azure.ServiceReconciler) error {
+		return service.Reconcile(ctx)
+	})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(HavePrefix("[failing error, "))
+	g.Expect(err.Error()).To(ContainSubstring("independent error"))
+}
+
+func TestServiceGraphReverse(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	one := mock_azure.NewMockServiceReconciler(mockCtrl)
+	two := mock_azure.NewMockServiceReconciler(mockCtrl)
+	three := mock_azure.NewMockServiceReconciler(mockCtrl)
+	one.EXPECT().Name().Return("one").AnyTimes()
+	two.EXPECT().Name().Return("two").AnyTimes()
+	three.EXPECT().Name().Return("three").AnyTimes()
+
+	gomock.InOrder(
+		three.EXPECT().Delete(gomockinternal.AContext()).Return(nil),
+		two.EXPECT().Delete(gomockinternal.AContext()).Return(nil),
+		one.EXPECT().Delete(gomockinternal.AContext()).Return(nil))
+
+	graph, err := newServiceGraph([]azure.ServiceReconciler{one, two, three}, map[string][]string{
+		"two":   {"one"},
+		"three": {"two"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(graph.reverse().run(context.TODO(), func(ctx context.Context, service azure.ServiceReconciler) error {
+		return service.Delete(ctx)
+	})).To(Succeed())
+}
+
+func TestNewServiceGraphDetectsCycles(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	one := mock_azure.NewMockServiceReconciler(mockCtrl)
+	two := mock_azure.NewMockServiceReconciler(mockCtrl)
+	one.EXPECT().Name().Return("one").AnyTimes()
+	two.EXPECT().Name().Return("two").AnyTimes()
+
+	_, err := newServiceGraph([]azure.ServiceReconciler{one, two}, map[string][]string{
+		"one": {"two"},
+		"two": {"one"},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("dependency cycle detected")))
+}
+
+func TestAggregateServiceErrors(t *testing.T) {
+	cases := map[string]struct {
+		errs         []error
+		expectNil    bool
+		terminal     bool
+		transient    bool
+		requeueAfter time.Duration
+	}{
+		"no errors": {
+			errs:      []error{nil, nil},
+			expectNil: true,
+		},
+		"single error is returned unchanged": {
+			errs:         []error{nil, azure.WithTransientError(errors.New("foo"), 30*time.Second)},
+			transient:    true,
+			requeueAfter: 30 * time.Second,
+		},
+		"all transient errors requeue after the shortest delay": {
+			errs: []error{
+				azure.WithTransientError(errors.New("foo"), 30*time.Second),
+				azure.WithTransientError(errors.New("bar"), 10*time.Second),
+			},
+			transient:    true,
+			requeueAfter: 10 * time.Second,
+		},
+		"terminal error wins": {
+			errs: []error{
+				azure.WithTransientError(errors.New("foo"), 30*time.Second),
+				azure.WithTerminalError(errors.New("bar")),
+				errors.New("baz"),
+			},
+			terminal: true,
+		},
+		"plain error prevents transient requeue": {
+			errs: []error{
+				azure.WithTransientError(errors.New("foo"), 30*time.Second),
+				errors.New("bar"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			err := aggregateServiceErrors(tc.errs)
+			if tc.expectNil {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			var reconcileErr azure.ReconcileError
+			isReconcileErr := errors.As(err, &reconcileErr)
+			g.Expect(isReconcileErr && reconcileErr.IsTerminal()).To(Equal(tc.terminal))
+			g.Expect(isReconcileErr && reconcileErr.IsTransient()).To(Equal(tc.transient))
+			if tc.transient {
+				g.Expect(reconcileErr.RequeueAfter()).To(Equal(tc.requeueAfter))
+			}
+		})
+	}
+}
@@ -53,6 +53,29 @@ func (src *AzureMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.VMExtensions = restored.Spec.Template.VMExtensions
 	}
 
+	if restored.Spec.Template.Diagnostics != nil {
+		dst.Spec.Template.Diagnostics = restored.Spec.Template.Diagnostics
+	}
+
+	dst.Spec.Template.CapacityReservationGroupID = restored.Spec.Template.CapacityReservationGroupID
+	dst.Spec.Template.DedicatedHostGroupID = restored.Spec.Template.DedicatedHostGroupID
+	dst.Spec.Template.ProximityPlacementGroupID = restored.Spec.Template.ProximityPlacementGroupID
+
+	if restored.Spec.Template.SecurityProfile != nil && dst.Spec.Template.SecurityProfile != nil {
+		dst.Spec.Template.SecurityProfile.SecurityType = restored.Spec.Template.SecurityProfile.SecurityType
+		dst.Spec.Template.SecurityProfile.UefiSettings = restored.Spec.Template.SecurityProfile.UefiSettings
+	}
+
+	if restored.Spec.Template.OSDisk.ManagedDisk != nil && dst.Spec.Template.OSDisk.ManagedDisk != nil {
+		dst.Spec.Template.OSDisk.ManagedDisk.SecurityProfile = restored.Spec.Template.OSDisk.ManagedDisk.SecurityProfile
+	}
+
+	for i := range dst.Spec.Template.DataDisks {
+		if i < len(restored.Spec.Template.DataDisks) && restored.Spec.Template.DataDisks[i].ManagedDisk != nil && dst.Spec.Template.DataDisks[i].ManagedDisk != nil {
+			dst.Spec.Template.DataDisks[i].ManagedDisk.SecurityProfile = restored.Spec.Template.DataDisks[i].ManagedDisk.SecurityProfile
+		}
+	}
+
 	if restored.Spec.Template.SpotVMOptions != nil && restored.Spec.Template.SpotVMOptions.EvictionPolicy != nil {
 		dst.Spec.Template.SpotVMOptions.EvictionPolicy = restored.Spec.Template.SpotVMOptions.EvictionPolicy
 	}
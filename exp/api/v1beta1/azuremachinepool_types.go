@@ -91,6 +91,26 @@ type (
 		// VMExtensions specifies a list of extensions to be added to the scale set.
 		// +optional
 		VMExtensions []infrav1.VMExtension `json:"vmExtensions,omitempty"`
+
+		// Diagnostics specifies the diagnostics settings of the scale set instances.
+		// If omitted, boot diagnostics are stored in a storage account managed by Azure.
+		// +optional
+		Diagnostics *infrav1.Diagnostics `json:"diagnostics,omitempty"`
+
+		// CapacityReservationGroupID is the Azure resource ID of the capacity reservation group the scale set instances
+		// are allocated from, which guarantees capacity for the VM size. It cannot be combined with Spot VMs, a dedicated
+		// host group, a proximity placement group or ultra disks.
+		// +optional
+		CapacityReservationGroupID string `json:"capacityReservationGroupID,omitempty"`
+
+		// DedicatedHostGroupID is the Azure resource ID of the dedicated host group the scale set instances are placed on.
+		// The host group must support automatic placement. It cannot be combined with Spot VMs.
+		// +optional
+		DedicatedHostGroupID string `json:"dedicatedHostGroupID,omitempty"`
+
+		// ProximityPlacementGroupID is the Azure resource ID of the proximity placement group the scale set is placed in.
+		// +optional
+		ProximityPlacementGroupID string `json:"proximityPlacementGroupID,omitempty"`
 	}
 
 	// AzureMachinePoolSpec defines the desired state of AzureMachinePool.
@@ -87,6 +87,9 @@ func (amp *AzureMachinePool) Validate(old runtime.Object) error {
 		amp.ValidateUserAssignedIdentity,
 		amp.ValidateStrategy(),
 		amp.ValidateSystemAssignedIdentity(old),
+		amp.ValidateDiagnostics,
+		amp.ValidatePlacement,
+		amp.ValidateSecurityProfile,
 	}
 
 	var errs []error
@@ -112,6 +115,35 @@ func (amp *AzureMachinePool) ValidateImage() error {
 	return nil
 }
 
+// ValidateDiagnostics of an AzureMachinePool.
+func (amp *AzureMachinePool) ValidateDiagnostics() error {
+	if errs := infrav1.ValidateDiagnostics(amp.Spec.Template.Diagnostics, field.NewPath("diagnostics")); len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// ValidatePlacement of an AzureMachinePool.
+func (amp *AzureMachinePool) ValidatePlacement() error {
+	template := amp.Spec.Template
+	if errs := infrav1.ValidatePlacement(template.CapacityReservationGroupID, template.DedicatedHostGroupID, template.ProximityPlacementGroupID, template.SpotVMOptions, template.DataDisks); len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// ValidateSecurityProfile of an AzureMachinePool.
+func (amp *AzureMachinePool) ValidateSecurityProfile() error {
+	template := amp.Spec.Template
+	if errs := infrav1.ValidateSecurityProfile(template.SecurityProfile, template.OSDisk, template.DataDisks); len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
 // ValidateTerminateNotificationTimeout termination notification timeout to be between 5 and 15.
 func (amp *AzureMachinePool) ValidateTerminateNotificationTimeout() error {
 	if amp.Spec.Template.TerminateNotificationTimeout == nil {
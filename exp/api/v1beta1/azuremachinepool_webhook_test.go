@@ -144,6 +144,31 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			}),
 			wantErr: false,
 		},
+		{
+			name:    "azuremachinepool with capacity reservation group",
+			amp:     createMachinePoolWithPlacement("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg", ""),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with capacity reservation group and dedicated host group",
+			amp:     createMachinePoolWithPlacement("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg", "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with invalid dedicated host group ID",
+			amp:     createMachinePoolWithPlacement("", "my-host-group"),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with trusted launch",
+			amp:     createMachinePoolWithSecurityProfile(&infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesTrustedLaunch, UefiSettings: &infrav1.UefiSettings{SecureBootEnabled: to.BoolPtr(true)}}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with confidential vm without vTPM",
+			amp:     createMachinePoolWithSecurityProfile(&infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesConfidentialVM}),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -381,6 +406,27 @@ func createMachinePoolWithStrategy(strategy AzureMachinePoolDeploymentStrategy)
 	}
 }
 
+func createMachinePoolWithPlacement(capacityReservationGroupID, dedicatedHostGroupID string) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				CapacityReservationGroupID: capacityReservationGroupID,
+				DedicatedHostGroupID:       dedicatedHostGroupID,
+			},
+		},
+	}
+}
+
+func createMachinePoolWithSecurityProfile(securityProfile *infrav1.SecurityProfile) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				SecurityProfile: securityProfile,
+			},
+		},
+	}
+}
+
 func TestAzureMachinePool_ValidateCreateFailure(t *testing.T) {
 	g := NewWithT(t)
 
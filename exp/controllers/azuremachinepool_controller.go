@@ -28,6 +28,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
@@ -37,6 +38,7 @@ import (
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -293,11 +295,20 @@ func (ampr *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, mac
 		if errors.As(err, &reconcileError) {
 			if reconcileError.IsTerminal() {
 				log.Error(err, "failed to reconcile AzureMachinePool", "name", machinePoolScope.Name())
+				if resourceskus.IsLocationRestricted(err) || resourceskus.IsZoneRestricted(err) {
+					ampr.Recorder.Eventf(machinePoolScope.AzureMachinePool, corev1.EventTypeWarning, infrav1.SKURestrictedReason, err.Error())
+					conditions.MarkFalse(machinePoolScope.AzureMachinePool, infrav1.ScaleSetRunningCondition, infrav1.SKURestrictedReason, clusterv1.ConditionSeverityError, err.Error())
+				}
 				return reconcile.Result{}, nil
 			}
 
 			if reconcileError.IsTransient() {
 				log.Error(err, "failed to reconcile AzureMachinePool", "name", machinePoolScope.Name())
+				// An exceeded quota may free up, e.g. once the instances replaced by a rollout are deleted.
+				if resourceskus.IsQuotaExceeded(err) {
+					ampr.Recorder.Eventf(machinePoolScope.AzureMachinePool, corev1.EventTypeWarning, infrav1.QuotaExceededReason, err.Error())
+					conditions.MarkFalse(machinePoolScope.AzureMachinePool, infrav1.ScaleSetRunningCondition, infrav1.QuotaExceededReason, clusterv1.ConditionSeverityWarning, err.Error())
+				}
 				return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
 			}
 
@@ -34,6 +34,10 @@ const (
 	// owner: @alexeldeib
 	// alpha: v0.4
 	AKS featuregate.Feature = "AKS"
+
+	// DriftDetection is the feature gate for reporting differences between the spec and the live Azure resources.
+	// alpha: v1.7
+	DriftDetection featuregate.Feature = "DriftDetection"
 )
 
 func init() {
@@ -44,5 +48,6 @@ func init() {
 // To add a new feature, define a key for it above and add it here.
 var defaultCAPZFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	// Every feature should be initiated here:
-	AKS: {Default: false, PreRelease: featuregate.Alpha},
+	AKS:            {Default: false, PreRelease: featuregate.Alpha},
+	DriftDetection: {Default: false, PreRelease: featuregate.Alpha},
 }
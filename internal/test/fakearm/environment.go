@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+const (
+	// EnvironmentName is the AzureCluster spec.azureEnvironment which makes the controllers read the
+	// Azure environment configured by UseEnvironment.
+	EnvironmentName = "AzureStackCloud"
+
+	// SubscriptionID is the subscription ID used by the Authorizer of the server.
+	SubscriptionID = "00000000-0000-0000-0000-000000000000"
+	// TenantID is the tenant ID used by the Authorizer of the server.
+	TenantID = "11111111-1111-1111-1111-111111111111"
+	// ClientID is the client ID used by the Authorizer of the server.
+	ClientID = "22222222-2222-2222-2222-222222222222"
+)
+
+// Environment returns an Azure environment whose Resource Manager and Active Directory endpoints are the server.
+func (s *Server) Environment() azureautorest.Environment {
+	return azureautorest.Environment{
+		Name:                       EnvironmentName,
+		ResourceManagerEndpoint:    s.URL() + "/",
+		ActiveDirectoryEndpoint:    s.URL() + "/",
+		TokenAudience:              s.URL() + "/",
+		ResourceManagerVMDNSSuffix: "cloudapp.fakearm.test",
+		ResourceIdentifiers: azureautorest.ResourceIdentifier{
+			Graph:               s.URL() + "/",
+			KeyVault:            s.URL() + "/",
+			Datalake:            s.URL() + "/",
+			Batch:               s.URL() + "/",
+			OperationalInsights: s.URL() + "/",
+			Storage:             s.URL() + "/",
+		},
+	}
+}
+
+// UseEnvironment sets the environment variables read by the controllers for the duration of the test, so that
+// the scopes of objects whose spec.azureEnvironment is EnvironmentName send their requests to the server.
+func (s *Server) UseEnvironment(t *testing.T) {
+	t.Helper()
+	vars, err := s.environmentVariables(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range vars {
+		t.Setenv(key, value)
+	}
+}
+
+// SetEnvironment sets the same environment variables as UseEnvironment for test frameworks which cannot use
+// testing.T.Setenv, such as Ginkgo. The Azure environment file is written to dir. The returned function restores
+// the previous environment variables.
+func (s *Server) SetEnvironment(dir string) (restore func(), err error) {
+	vars, err := s.environmentVariables(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[string]*string{}
+	restore = func() {
+		for key, value := range previous {
+			if value == nil {
+				os.Unsetenv(key)
+			} else {
+				os.Setenv(key, *value)
+			}
+		}
+	}
+	for key, value := range vars {
+		if old, ok := os.LookupEnv(key); ok {
+			previous[key] = &old
+		} else {
+			previous[key] = nil
+		}
+		if err := os.Setenv(key, value); err != nil {
+			restore()
+			return nil, errors.Wrapf(err, "failed to set %s", key)
+		}
+	}
+	return restore, nil
+}
+
+// environmentVariables writes the Azure environment of the server to dir and returns the environment variables
+// pointing the controllers to it.
+func (s *Server) environmentVariables(dir string) (map[string]string, error) {
+	raw, err := json.Marshal(s.Environment())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the Azure environment")
+	}
+	path := filepath.Join(dir, "environment.json")
+	if err := os.WriteFile(path, raw, 0600); err != nil {
+		return nil, errors.Wrap(err, "failed to write the Azure environment")
+	}
+	return map[string]string{
+		azureautorest.EnvironmentFilepathName: path,
+		auth.SubscriptionID:                   SubscriptionID,
+		auth.TenantID:                         TenantID,
+		auth.ClientID:                         ClientID,
+		auth.ClientSecret:                     "fake-secret",
+	}, nil
+}
+
+// Authorizer returns an azure.Authorizer which sends the requests of the Azure service clients to the server.
+func (s *Server) Authorizer() azure.Authorizer {
+	return &authorizer{baseURI: s.URL()}
+}
+
+// authorizer is an azure.Authorizer for the server, which does not require any credentials.
+type authorizer struct {
+	baseURI string
+}
+
+func (a *authorizer) SubscriptionID() string          { return SubscriptionID }
+func (a *authorizer) ClientID() string                { return ClientID }
+func (a *authorizer) ClientSecret() string            { return "fake-secret" }
+func (a *authorizer) CloudEnvironment() string        { return EnvironmentName }
+func (a *authorizer) TenantID() string                { return TenantID }
+func (a *authorizer) BaseURI() string                 { return a.baseURI }
+func (a *authorizer) Authorizer() autorest.Authorizer { return autorest.NullAuthorizer{} }
+func (a *authorizer) HashKey() string                 { return "fakearm" }
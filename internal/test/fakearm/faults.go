@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Fault is an error injected in the responses of the server.
+type Fault struct {
+	// Method is the HTTP method of the requests to fail. An empty method matches all methods.
+	Method string
+	// Path matches the path of the requests to fail, e.g. regexp.MustCompile("(?i)/virtualMachines/"). A nil Path matches all paths.
+	Path *regexp.Regexp
+	// StatusCode is the HTTP status code of the failed requests, e.g. http.StatusTooManyRequests or http.StatusConflict.
+	// It is ignored when FailProvisioning is true.
+	StatusCode int
+	// Code is the ARM error code. It defaults to a code matching the status code.
+	Code string
+	// Message is the ARM error message. It defaults to a message naming the failed request.
+	Message string
+	// RetryAfter is returned in the Retry-After header of the failed requests when it is set.
+	RetryAfter time.Duration
+	// FailProvisioning accepts the request but makes its long-running operation fail, leaving the
+	// resource in the Failed provisioning state.
+	FailProvisioning bool
+	// Times is the number of requests to fail. Zero fails all the matching requests.
+	Times int
+}
+
+// InjectFault makes the server fail the requests matching the fault. Faults are matched in the order they were injected.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all the injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the first fault matching the request, if any, and consumes one of its times.
+// It must be called with the server lock held.
+func (s *Server) matchFault(method, path string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && !strings.EqualFold(fault.Method, method) {
+			continue
+		}
+		if fault.Path != nil && !fault.Path.MatchString(path) {
+			continue
+		}
+		// Provisioning failures only apply to requests that start a long-running operation.
+		if fault.FailProvisioning && method != http.MethodPut && method != http.MethodPatch && method != http.MethodDelete {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func (f *Fault) code() string {
+	if f.Code != "" {
+		return f.Code
+	}
+	switch {
+	case f.FailProvisioning:
+		return "ProvisioningFailed"
+	case f.StatusCode == http.StatusTooManyRequests:
+		return "TooManyRequests"
+	case f.StatusCode == http.StatusConflict:
+		return "Conflict"
+	default:
+		return strings.ReplaceAll(http.StatusText(f.StatusCode), " ", "")
+	}
+}
+
+func (f *Fault) message(method, path string) string {
+	if f.Message != "" {
+		return f.Message
+	}
+	return fmt.Sprintf("injected fault for %s %s", method, path)
+}
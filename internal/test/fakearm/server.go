@@ -0,0 +1,559 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakearm implements an in-process fake of the Azure Resource Manager API, so that the Azure services
+// and controllers can be tested offline against the real Azure SDK clients.
+//
+// The server stores any resource PUT to it, keyed by its ARM resource ID, and serves it back on GET, PATCH and
+// DELETE. Creations, updates and deletions are long-running operations polled through the Azure-AsyncOperation
+// header, like the real API. Child resources, such as the subnets of a virtual network, are stored separately
+// and are not embedded in their parent.
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// operationsPath is the path under which the status of long-running operations is served.
+	operationsPath = "/fakearm/operations/"
+
+	provisioningStateSucceeded = "Succeeded"
+	provisioningStateFailed    = "Failed"
+
+	operationStatusInProgress = "InProgress"
+	operationStatusSucceeded  = "Succeeded"
+	operationStatusFailed     = "Failed"
+)
+
+// Server is a fake Azure Resource Manager server.
+type Server struct {
+	server *httptest.Server
+
+	mu sync.Mutex
+	// resources holds the stored resources keyed by their lowercase resource ID.
+	resources  map[string]map[string]interface{}
+	operations map[string]*operation
+	faults     []*Fault
+	requests   []Request
+	nextOpID   int
+
+	pollsBeforeDone int
+	retryAfter      time.Duration
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+}
+
+// operation is a long-running operation which completes after a number of polls.
+type operation struct {
+	remainingPolls int
+	status         string
+	err            *serviceError
+	// complete applies the result of the operation to the stored resources. It is called with the server lock held.
+	complete func()
+}
+
+// serviceError is the error returned in the body of failed requests and operations.
+type serviceError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithPollsBeforeDone sets the number of times long-running operations report they are in progress before
+// completing. With zero polls, requests complete synchronously. The default is one poll.
+func WithPollsBeforeDone(polls int) Option {
+	return func(s *Server) {
+		s.pollsBeforeDone = polls
+	}
+}
+
+// WithRetryAfter sets the Retry-After header returned with long-running operations. The default is zero,
+// which makes clients poll again immediately.
+func WithRetryAfter(retryAfter time.Duration) Option {
+	return func(s *Server) {
+		s.retryAfter = retryAfter
+	}
+}
+
+// NewServer starts a new fake Azure Resource Manager server. It must be closed with Close.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		resources:       map[string]map[string]interface{}{},
+		operations:      map[string]*operation{},
+		pollsBeforeDone: 1,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns the base URL of the server, to be used as the Azure Resource Manager endpoint.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddResource stores a resource, for example a resource created outside of the controllers or a resource SKU.
+// The id, name and type of the resource are set from its ID.
+func (s *Server) AddResource(id string, resource interface{}) error {
+	body, err := toMap(resource)
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert resource %s", id)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources[strings.ToLower(id)] = withIdentity(body, id)
+	return nil
+}
+
+// AddResourceSKU stores a resource SKU available in all the zones of the location with the given capabilities,
+// e.g. map[string]string{"vCPUs": "2", "MemoryGB": "8"} for a virtual machine size. Unlike AddResource with a
+// compute.ResourceSku, it keeps the read-only fields of the SKU which the SDK does not marshal.
+func (s *Server) AddResourceSKU(name, resourceType, location string, capabilities map[string]string) error {
+	skuCapabilities := make([]map[string]string, 0, len(capabilities))
+	for capability, value := range capabilities {
+		skuCapabilities = append(skuCapabilities, map[string]string{"name": capability, "value": value})
+	}
+	return s.AddResource(fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Compute/skus/%s", SubscriptionID, name), map[string]interface{}{
+		"resourceType": resourceType,
+		"locations":    []string{location},
+		"locationInfo": []map[string]interface{}{
+			{"location": location, "zones": []string{"1", "2", "3"}},
+		},
+		"capabilities": skuCapabilities,
+	})
+}
+
+// GetResource returns a stored resource decoded into out, which must be a pointer. It returns false if the resource does not exist.
+func (s *Server) GetResource(id string, out interface{}) (bool, error) {
+	s.mu.Lock()
+	resource, ok := s.resources[strings.ToLower(id)]
+	var raw []byte
+	var err error
+	if ok {
+		raw, err = json.Marshal(resource)
+	}
+	s.mu.Unlock()
+	if !ok || err != nil {
+		return ok, err
+	}
+	return true, json.Unmarshal(raw, out)
+}
+
+// ResourceIDs returns the IDs of all the stored resources.
+func (s *Server) ResourceIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.resources))
+	for _, resource := range s.resources {
+		ids = append(ids, resource["id"].(string))
+	}
+	return ids
+}
+
+// Requests returns the requests received by the server so far, excluding the polling of long-running operations.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := "/" + strings.Join(strings.FieldsFunc(r.URL.Path, func(c rune) bool { return c == '/' }), "/")
+	if strings.HasSuffix(path, "/oauth2/token") || strings.HasSuffix(path, "/oauth2/v2.0/token") {
+		serveToken(w)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasPrefix(path, operationsPath) {
+		s.serveOperation(w, strings.TrimPrefix(path, operationsPath))
+		return
+	}
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path})
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+
+	fault := s.matchFault(r.Method, path)
+	if fault != nil && !fault.FailProvisioning {
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+		}
+		writeError(w, fault.StatusCode, fault.code(), fault.message(r.Method, path))
+		return
+	}
+
+	if isTagsPath(path) {
+		s.serveTags(w, r.Method, strings.TrimSuffix(path, tagsSuffix), body)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && isCollectionPath(path):
+		s.serveList(w, path)
+	case r.Method == http.MethodGet:
+		s.serveGet(w, path)
+	case r.Method == http.MethodPut:
+		s.servePut(w, r.Method, path, body, fault)
+	case r.Method == http.MethodPatch:
+		s.servePatch(w, r.Method, path, body, fault)
+	case r.Method == http.MethodDelete:
+		s.serveDelete(w, path, fault)
+	case r.Method == http.MethodPost:
+		s.servePost(w, path)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("method %s is not supported", r.Method))
+	}
+}
+
+func (s *Server) serveGet(w http.ResponseWriter, path string) {
+	resource, ok := s.resources[strings.ToLower(path)]
+	if !ok {
+		writeNotFound(w, path)
+		return
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+func (s *Server) serveList(w http.ResponseWriter, path string) {
+	items := []interface{}{}
+	for key, resource := range s.resources {
+		if parentPath(key) == strings.ToLower(path) {
+			items = append(items, resource)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": items})
+}
+
+func (s *Server) servePut(w http.ResponseWriter, method string, path string, body []byte, fault *Fault) {
+	desired := map[string]interface{}{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &desired); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+	}
+	key := strings.ToLower(path)
+	existing, exists := s.resources[key]
+	desired = withIdentity(desired, path)
+	if exists {
+		// Preserve the tags of the resource, which ARM only updates when they are part of the request.
+		if _, ok := desired["tags"]; !ok && existing["tags"] != nil {
+			desired["tags"] = existing["tags"]
+		}
+	}
+	s.writeResource(w, method, path, desired, exists, fault)
+}
+
+func (s *Server) servePatch(w http.ResponseWriter, method string, path string, body []byte, fault *Fault) {
+	existing, ok := s.resources[strings.ToLower(path)]
+	if !ok {
+		writeNotFound(w, path)
+		return
+	}
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	}
+	desired := mergePatch(deepCopy(existing), patch)
+	s.writeResource(w, method, path, withIdentity(desired, path), true, fault)
+}
+
+// writeResource stores the desired state of a resource created or updated with a PUT or PATCH request, and writes the response.
+func (s *Server) writeResource(w http.ResponseWriter, method string, path string, desired map[string]interface{}, exists bool, fault *Fault) {
+	key := strings.ToLower(path)
+	if fault == nil && s.pollsBeforeDone == 0 {
+		setProvisioningState(desired, provisioningStateSucceeded)
+		s.resources[key] = desired
+		status := http.StatusCreated
+		if exists {
+			status = http.StatusOK
+		}
+		writeJSON(w, status, desired)
+		return
+	}
+
+	inProgress := "Creating"
+	if exists {
+		inProgress = "Updating"
+	}
+	setProvisioningState(desired, inProgress)
+	s.resources[key] = desired
+	op := s.startOperation(func() {
+		if resource, ok := s.resources[key]; ok {
+			setProvisioningState(resource, provisioningStateSucceeded)
+		}
+	})
+	if fault != nil {
+		s.failOperation(op, fault, method, path, func() {
+			if resource, ok := s.resources[key]; ok {
+				setProvisioningState(resource, provisioningStateFailed)
+			}
+		})
+	}
+	s.writeOperationHeaders(w, op)
+	writeJSON(w, http.StatusCreated, desired)
+}
+
+func (s *Server) serveDelete(w http.ResponseWriter, path string, fault *Fault) {
+	key := strings.ToLower(path)
+	if _, ok := s.resources[key]; !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if fault == nil && s.pollsBeforeDone == 0 {
+		s.deleteTree(key)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	setProvisioningState(s.resources[key], "Deleting")
+	op := s.startOperation(func() {
+		s.deleteTree(key)
+	})
+	if fault != nil {
+		s.failOperation(op, fault, http.MethodDelete, path, func() {
+			if resource, ok := s.resources[key]; ok {
+				setProvisioningState(resource, provisioningStateFailed)
+			}
+		})
+	}
+	s.writeOperationHeaders(w, op)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// servePost handles resource actions, such as starting a virtual machine, which complete immediately.
+func (s *Server) servePost(w http.ResponseWriter, path string) {
+	parent := parentPath(path)
+	if _, ok := s.resources[parent]; !ok {
+		writeNotFound(w, parent)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// deleteTree deletes a resource and all the resources under it, like the resources of a resource group.
+func (s *Server) deleteTree(key string) {
+	for id := range s.resources {
+		if id == key || strings.HasPrefix(id, key+"/") {
+			delete(s.resources, id)
+		}
+	}
+}
+
+// startOperation creates a long-running operation which calls complete once it succeeds.
+func (s *Server) startOperation(complete func()) string {
+	s.nextOpID++
+	id := strconv.Itoa(s.nextOpID)
+	s.operations[id] = &operation{
+		remainingPolls: s.pollsBeforeDone,
+		status:         operationStatusInProgress,
+		complete:       complete,
+	}
+	return id
+}
+
+// failOperation makes a long-running operation fail with the error of the fault, and calls fail once it does.
+func (s *Server) failOperation(id string, fault *Fault, method, path string, fail func()) {
+	op := s.operations[id]
+	op.err = &serviceError{Code: fault.code(), Message: fault.message(method, path)}
+	op.complete = fail
+}
+
+func (s *Server) writeOperationHeaders(w http.ResponseWriter, id string) {
+	url := s.URL() + operationsPath + id
+	w.Header().Set("Azure-AsyncOperation", url)
+	w.Header().Set("Location", url)
+	w.Header().Set("Retry-After", strconv.Itoa(int(s.retryAfter.Seconds())))
+}
+
+func (s *Server) serveOperation(w http.ResponseWriter, id string) {
+	op, ok := s.operations[id]
+	if !ok {
+		writeNotFound(w, operationsPath+id)
+		return
+	}
+	if op.status == operationStatusInProgress {
+		if op.remainingPolls > 0 {
+			op.remainingPolls--
+		} else {
+			op.complete()
+			op.status = operationStatusSucceeded
+			if op.err != nil {
+				op.status = operationStatusFailed
+			}
+		}
+	}
+	response := map[string]interface{}{"status": op.status}
+	if op.err != nil && op.status == operationStatusFailed {
+		response["error"] = op.err
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(s.retryAfter.Seconds())))
+	writeJSON(w, http.StatusOK, response)
+}
+
+// serveToken serves a fake Azure Active Directory token, so clients configured with a client secret can authenticate.
+func serveToken(w http.ResponseWriter) {
+	expiresOn := time.Now().Add(time.Hour).Unix()
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token":   "fake-token",
+		"refresh_token":  "",
+		"expires_in":     "3600",
+		"expires_on":     strconv.FormatInt(expiresOn, 10),
+		"not_before":     strconv.FormatInt(expiresOn-3600, 10),
+		"resource":       "",
+		"token_type":     "Bearer",
+		"ext_expires_in": "3600",
+	})
+}
+
+// isCollectionPath returns true if the path lists resources rather than identifying one. ARM resource IDs are
+// made of name/value pairs, e.g. /subscriptions/{id}/resourceGroups/{name}/providers/{namespace}/{type}/{name},
+// so collections have an odd number of segments.
+func isCollectionPath(path string) bool {
+	return len(strings.Split(strings.Trim(path, "/"), "/"))%2 == 1
+}
+
+// parentPath returns the lowercase path of the collection or resource the path belongs to.
+func parentPath(path string) string {
+	path = strings.ToLower(path)
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// withIdentity sets the id, name and type of a resource from its ID.
+func withIdentity(resource map[string]interface{}, id string) map[string]interface{} {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	resource["id"] = id
+	resource["name"] = segments[len(segments)-1]
+	if _, ok := resource["type"]; !ok {
+		resource["type"] = resourceType(segments)
+	}
+	return resource
+}
+
+// resourceType returns the type of the resource with the given ID segments, e.g. Microsoft.Network/virtualNetworks/subnets.
+func resourceType(segments []string) string {
+	for i := len(segments) - 2; i >= 0; i-- {
+		if strings.EqualFold(segments[i], "providers") && i+1 < len(segments) {
+			types := []string{segments[i+1]}
+			for j := i + 2; j < len(segments); j += 2 {
+				types = append(types, segments[j])
+			}
+			return strings.Join(types, "/")
+		}
+	}
+	if len(segments) >= 2 {
+		return fmt.Sprintf("Microsoft.Resources/%s", segments[len(segments)-2])
+	}
+	return ""
+}
+
+func setProvisioningState(resource map[string]interface{}, state string) {
+	properties, ok := resource["properties"].(map[string]interface{})
+	if !ok {
+		properties = map[string]interface{}{}
+		resource["properties"] = properties
+	}
+	properties["provisioningState"] = state
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386) to target.
+func mergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		patchObject, isObject := value.(map[string]interface{})
+		targetObject, targetIsObject := target[key].(map[string]interface{})
+		if isObject && targetIsObject {
+			target[key] = mergePatch(targetObject, patchObject)
+		} else {
+			target[key] = value
+		}
+	}
+	return target
+}
+
+func deepCopy(resource map[string]interface{}) map[string]interface{} {
+	out, err := toMap(resource)
+	if err != nil {
+		// A resource decoded from JSON can always be encoded back.
+		panic(err)
+	}
+	return out
+}
+
+func toMap(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]interface{}{}
+	return out, json.Unmarshal(raw, &out)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(raw)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{"error": serviceError{Code: code, Message: message}})
+}
+
+func writeNotFound(w http.ResponseWriter, path string) {
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The resource %s was not found.", path))
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
+)
+
+const (
+	resourceGroupID = "/subscriptions/" + fakearm.SubscriptionID + "/resourceGroups/my-rg"
+	publicIPID      = resourceGroupID + "/providers/Microsoft.Network/publicIPAddresses/my-pip"
+)
+
+func newPublicIPsClient(srv *fakearm.Server) network.PublicIPAddressesClient {
+	client := network.NewPublicIPAddressesClientWithBaseURI(srv.URL(), fakearm.SubscriptionID)
+	azure.SetAutoRestClientDefaults(&client.Client, autorest.NullAuthorizer{})
+	client.PollingDelay = 0
+	return client
+}
+
+func TestLongRunningOperations(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := fakearm.NewServer(fakearm.WithPollsBeforeDone(2), fakearm.WithRetryAfter(time.Second))
+	defer srv.Close()
+	g.Expect(srv.AddResource(resourceGroupID, resources.Group{Location: to.StringPtr("eastus")})).To(Succeed())
+	client := newPublicIPsClient(srv)
+	ctx := context.TODO()
+
+	createFuture, err := client.CreateOrUpdate(ctx, "my-rg", "my-pip", network.PublicIPAddress{Location: to.StringPtr("eastus")})
+	g.Expect(err).NotTo(HaveOccurred())
+	delay, ok := createFuture.GetPollingDelay()
+	g.Expect(ok).To(BeTrue())
+	g.Expect(delay).To(Equal(time.Second))
+	for i := 0; i < 2; i++ {
+		done, err := createFuture.DoneWithContext(ctx, client)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(done).To(BeFalse())
+	}
+	done, err := createFuture.DoneWithContext(ctx, client)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeTrue())
+	pip, err := createFuture.Result(client)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(pip.ID)).To(Equal(publicIPID))
+	g.Expect(pip.ProvisioningState).To(Equal(network.ProvisioningStateSucceeded))
+
+	list, err := client.List(ctx, "my-rg")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(list.Values()).To(HaveLen(1))
+
+	deleteFuture, err := client.Delete(ctx, "my-rg", "my-pip")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(deleteFuture.WaitForCompletionRef(ctx, client.Client)).To(Succeed())
+	_, err = client.Get(ctx, "my-rg", "my-pip", "")
+	g.Expect(azure.ResourceNotFound(err)).To(BeTrue())
+}
+
+func TestDeleteResourceGroupDeletesItsResources(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := fakearm.NewServer(fakearm.WithPollsBeforeDone(0))
+	defer srv.Close()
+	g.Expect(srv.AddResource(resourceGroupID, resources.Group{Location: to.StringPtr("eastus")})).To(Succeed())
+	g.Expect(srv.AddResource(publicIPID, network.PublicIPAddress{Location: to.StringPtr("eastus")})).To(Succeed())
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodDelete, srv.URL()+resourceGroupID, http.NoBody)
+	g.Expect(err).NotTo(HaveOccurred())
+	resp, err := http.DefaultClient.Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Body.Close()).To(Succeed())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	g.Expect(srv.ResourceIDs()).To(BeEmpty())
+}
+
+func TestFaults(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("throttling", func(t *testing.T) {
+		g := NewWithT(t)
+		srv := fakearm.NewServer()
+		defer srv.Close()
+		srv.InjectFault(fakearm.Fault{
+			Method:     http.MethodGet,
+			Path:       regexp.MustCompile("(?i)/publicIPAddresses/"),
+			StatusCode: http.StatusTooManyRequests,
+			RetryAfter: time.Second,
+			Times:      1,
+		})
+		client := newPublicIPsClient(srv)
+
+		// The client retries throttled requests after the Retry-After duration, and the fault only applies once.
+		start := time.Now()
+		_, err := client.Get(ctx, "my-rg", "my-pip", "")
+		g.Expect(azure.ResourceNotFound(err)).To(BeTrue())
+		g.Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+		g.Expect(srv.Requests()).To(HaveLen(2))
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		g := NewWithT(t)
+		srv := fakearm.NewServer()
+		defer srv.Close()
+		srv.InjectFault(fakearm.Fault{Method: http.MethodPut, StatusCode: http.StatusConflict})
+		client := newPublicIPsClient(srv)
+
+		_, err := client.CreateOrUpdate(ctx, "my-rg", "my-pip", network.PublicIPAddress{})
+		g.Expect(err).To(MatchError(ContainSubstring(`Code="Conflict"`)))
+	})
+
+	t.Run("provisioning failure", func(t *testing.T) {
+		g := NewWithT(t)
+		srv := fakearm.NewServer()
+		defer srv.Close()
+		srv.InjectFault(fakearm.Fault{Method: http.MethodPut, FailProvisioning: true, Message: "no capacity"})
+		client := newPublicIPsClient(srv)
+
+		future, err := client.CreateOrUpdate(ctx, "my-rg", "my-pip", network.PublicIPAddress{})
+		g.Expect(err).NotTo(HaveOccurred())
+		err = future.WaitForCompletionRef(ctx, client.Client)
+		g.Expect(err).To(MatchError(ContainSubstring("no capacity")))
+
+		var pip network.PublicIPAddress
+		found, err := srv.GetResource(publicIPID, &pip)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(found).To(BeTrue())
+		g.Expect(pip.ProvisioningState).To(Equal(network.ProvisioningStateFailed))
+	})
+}
+
+func TestAsyncReconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+	scopeMock.EXPECT().GetLongRunningOperationState(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	srv := fakearm.NewServer()
+	defer srv.Close()
+	client := publicips.NewClient(srv.Authorizer())
+	s := async.New(scopeMock, client, client)
+	spec := &publicips.PublicIPSpec{
+		Name:          "my-pip",
+		ResourceGroup: "my-rg",
+		ClusterName:   "my-cluster",
+		DNSName:       "my-dns",
+		Location:      "eastus",
+	}
+
+	result, err := s.CreateOrUpdateResource(context.TODO(), spec, publicips.ServiceName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(BeAssignableToTypeOf(network.PublicIPAddress{}))
+	g.Expect(to.String(result.(network.PublicIPAddress).ID)).To(Equal(publicIPID))
+
+	// Throttled reads which cannot be retried before the reconcile times out are requeued after the Retry-After duration.
+	srv.InjectFault(fakearm.Fault{Method: http.MethodGet, StatusCode: http.StatusTooManyRequests, RetryAfter: 42 * time.Second, Times: 1})
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	_, err = s.CreateOrUpdateResource(ctx, spec, publicips.ServiceName)
+	var reconcileErr azure.ReconcileError
+	g.Expect(errors.As(err, &reconcileErr)).To(BeTrue())
+	g.Expect(reconcileErr.IsTransient()).To(BeTrue())
+	g.Expect(reconcileErr.RequeueAfter()).To(Equal(42 * time.Second))
+
+	g.Expect(s.DeleteResource(context.TODO(), spec, publicips.ServiceName)).To(Succeed())
+	found, err := srv.GetResource(publicIPID, &network.PublicIPAddress{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeFalse())
+}
+
+func TestTags(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := fakearm.NewServer()
+	defer srv.Close()
+	g.Expect(srv.AddResource(resourceGroupID, resources.Group{Location: to.StringPtr("eastus"), Tags: map[string]*string{"foo": to.StringPtr("bar")}})).To(Succeed())
+	client := tags.NewClient(srv.Authorizer())
+
+	_, err := client.UpdateAtScope(context.TODO(), resourceGroupID, resources.TagsPatchResource{
+		Operation:  resources.TagsPatchOperationMerge,
+		Properties: &resources.Tags{Tags: map[string]*string{"baz": to.StringPtr("qux")}},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	existing, err := client.GetAtScope(context.TODO(), resourceGroupID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(existing.Properties.Tags).To(Equal(map[string]*string{"foo": to.StringPtr("bar"), "baz": to.StringPtr("qux")}))
+}
+
+func TestUseEnvironment(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := fakearm.NewServer()
+	defer srv.Close()
+	srv.UseEnvironment(t)
+	g.Expect(srv.AddResource(resourceGroupID, resources.Group{Location: to.StringPtr("eastus")})).To(Succeed())
+
+	// Clients authenticating with the credentials from the environment get a token from the server.
+	settings, err := auth.GetSettingsFromEnvironment()
+	g.Expect(err).NotTo(HaveOccurred())
+	settings.Environment = srv.Environment()
+	config, err := settings.GetClientCredentials()
+	g.Expect(err).NotTo(HaveOccurred())
+	config.AADEndpoint = settings.Environment.ActiveDirectoryEndpoint
+	config.Resource = settings.Environment.ResourceManagerEndpoint
+	authorizer, err := config.Authorizer()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	client := resources.NewGroupsClientWithBaseURI(srv.URL(), settings.GetSubscriptionID())
+	client.Authorizer = authorizer
+	group, err := client.Get(context.TODO(), "my-rg")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(to.String(group.Location)).To(Equal("eastus"))
+}
+
+func TestSetEnvironment(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := fakearm.NewServer()
+	defer srv.Close()
+	t.Setenv(auth.SubscriptionID, "previous-subscription")
+
+	restore, err := srv.SetEnvironment(t.TempDir())
+	g.Expect(err).NotTo(HaveOccurred())
+	settings, err := auth.GetSettingsFromEnvironment()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(settings.GetSubscriptionID()).To(Equal(fakearm.SubscriptionID))
+	environment, err := azureautorest.EnvironmentFromName(fakearm.EnvironmentName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(environment.ResourceManagerEndpoint).To(Equal(srv.URL() + "/"))
+
+	restore()
+	g.Expect(os.Getenv(auth.SubscriptionID)).To(Equal("previous-subscription"))
+	_, ok := os.LookupEnv(azureautorest.EnvironmentFilepathName)
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestAddResourceSKU(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := fakearm.NewServer()
+	defer srv.Close()
+	g.Expect(srv.AddResourceSKU("Standard_D2s_v3", "virtualMachines", "eastus", map[string]string{"vCPUs": "2"})).To(Succeed())
+
+	client := compute.NewResourceSkusClientWithBaseURI(srv.URL(), fakearm.SubscriptionID)
+	azure.SetAutoRestClientDefaults(&client.Client, autorest.NullAuthorizer{})
+	skus, err := client.ListComplete(context.TODO(), "", "")
+	g.Expect(err).NotTo(HaveOccurred())
+	sku := skus.Value()
+	g.Expect(to.String(sku.Name)).To(Equal("Standard_D2s_v3"))
+	g.Expect(to.String(sku.ResourceType)).To(Equal("virtualMachines"))
+	g.Expect(*sku.LocationInfo).To(HaveLen(1))
+	g.Expect(*(*sku.LocationInfo)[0].Zones).To(ConsistOf("1", "2", "3"))
+	g.Expect(to.String((*sku.Capabilities)[0].Name)).To(Equal("vCPUs"))
+	g.Expect(to.String((*sku.Capabilities)[0].Value)).To(Equal("2"))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// tagsSuffix is the suffix of the path of the tags of a resource, served by the Microsoft.Resources/tags API.
+const tagsSuffix = "/providers/Microsoft.Resources/tags/default"
+
+func isTagsPath(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), strings.ToLower(tagsSuffix))
+}
+
+// tagsPatch is the body of a request to update the tags of a resource.
+type tagsPatch struct {
+	Operation  string `json:"operation"`
+	Properties struct {
+		Tags map[string]interface{} `json:"tags"`
+	} `json:"properties"`
+}
+
+// serveTags serves the tags of the resource at scope. Tags are read from and written to the resource itself.
+func (s *Server) serveTags(w http.ResponseWriter, method, scope string, body []byte) {
+	resource, ok := s.resources[strings.ToLower(scope)]
+	if !ok {
+		writeNotFound(w, scope)
+		return
+	}
+	tags, _ := resource["tags"].(map[string]interface{})
+	if tags == nil {
+		tags = map[string]interface{}{}
+	}
+
+	switch method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPatch:
+		patch := tagsPatch{Operation: "Replace"}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+		switch {
+		case method == http.MethodPut || strings.EqualFold(patch.Operation, "Replace"):
+			tags = patch.Properties.Tags
+		case strings.EqualFold(patch.Operation, "Merge"):
+			for k, v := range patch.Properties.Tags {
+				tags[k] = v
+			}
+		case strings.EqualFold(patch.Operation, "Delete"):
+			for k := range patch.Properties.Tags {
+				delete(tags, k)
+			}
+		}
+		resource["tags"] = tags
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "method "+method+" is not supported on tags")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":         scope + tagsSuffix,
+		"name":       "default",
+		"type":       "Microsoft.Resources/tags",
+		"properties": map[string]interface{}{"tags": tags},
+	})
+}
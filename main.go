@@ -39,6 +39,8 @@ import (
 	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha4"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/ratelimit"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1alpha3exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha3"
 	infrav1alpha4exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha4"
@@ -115,6 +117,12 @@ var (
 	webhookPort                        int
 	reconcileTimeout                   time.Duration
 	enableTracing                      bool
+	skuCacheRefreshInterval            time.Duration
+	azureReadQPS                       float64
+	azureReadBurst                     int
+	azureWriteQPS                      float64
+	azureWriteBurst                    int
+	azureRateLimitsConfig              string
 )
 
 // InitFlags initializes all command-line flags.
@@ -241,9 +249,63 @@ func InitFlags(fs *pflag.FlagSet) {
 		"Enable tracing to the opentelemetry-collector service in the same namespace.",
 	)
 
+	fs.DurationVar(&skuCacheRefreshInterval,
+		"sku-cache-refresh-interval",
+		resourceskus.DefaultRefreshInterval,
+		"The interval at which cached Azure resource SKU information is refreshed in the background (e.g. 1h)",
+	)
+
+	fs.Float64Var(&azureReadQPS,
+		"azure-read-qps",
+		0,
+		"The maximum average number of Azure Resource Manager read requests per second sent to each subscription. Zero disables the limit",
+	)
+
+	fs.IntVar(&azureReadBurst,
+		"azure-read-burst",
+		0,
+		"The maximum number of Azure Resource Manager read requests sent at once to each subscription. Defaults to --azure-read-qps",
+	)
+
+	fs.Float64Var(&azureWriteQPS,
+		"azure-write-qps",
+		0,
+		"The maximum average number of Azure Resource Manager write requests per second sent to each subscription. Zero disables the limit",
+	)
+
+	fs.IntVar(&azureWriteBurst,
+		"azure-write-burst",
+		0,
+		"The maximum number of Azure Resource Manager write requests sent at once to each subscription. Defaults to --azure-write-qps",
+	)
+
+	fs.StringVar(&azureRateLimitsConfig,
+		"azure-rate-limits-config",
+		"",
+		"Path to a YAML file, such as a mounted ConfigMap, with per-subscription and per-service limits of Azure Resource Manager requests. Subscription limits set in the file take precedence over the flags",
+	)
+
 	feature.MutableGates.AddFlag(fs)
 }
 
+// rateLimitsConfig returns the client-side rate limits of Azure Resource Manager requests from the flags and config file.
+func rateLimitsConfig() (ratelimit.Config, error) {
+	config := ratelimit.Config{}
+	if azureRateLimitsConfig != "" {
+		var err error
+		if config, err = ratelimit.LoadConfig(azureRateLimitsConfig); err != nil {
+			return config, err
+		}
+	}
+	if config.Subscription.Read.QPS == 0 {
+		config.Subscription.Read = ratelimit.Limit{QPS: azureReadQPS, Burst: azureReadBurst}
+	}
+	if config.Subscription.Write.QPS == 0 {
+		config.Subscription.Write = ratelimit.Limit{QPS: azureWriteQPS, Burst: azureWriteBurst}
+	}
+	return config, nil
+}
+
 func main() {
 	InitFlags(pflag.CommandLine)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
@@ -317,6 +379,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	rateLimits, err := rateLimitsConfig()
+	if err == nil {
+		err = ratelimit.Configure(rateLimits)
+	}
+	if err != nil {
+		setupLog.Error(err, "unable to configure Azure rate limits")
+		os.Exit(1)
+	}
+	if rateLimits.Enabled() {
+		setupLog.Info("Rate limiting Azure Resource Manager requests", "limits", rateLimits)
+	}
+
+	if err := mgr.Add(resourceskus.NewRefresher(skuCacheRefreshInterval)); err != nil {
+		setupLog.Error(err, "unable to add resource sku cache refresher")
+		os.Exit(1)
+	}
+
 	registerControllers(ctx, mgr)
 
 	registerWebhooks(mgr)
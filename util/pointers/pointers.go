@@ -0,0 +1,31 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pointers
+
+import "github.com/Azure/go-autorest/autorest/to"
+
+// BoolChanged returns true if want is set and differs from got.
+// A nil want leaves the value to Azure, so it never reports a change.
+func BoolChanged(want, got *bool) bool {
+	return want != nil && to.Bool(want) != to.Bool(got)
+}
+
+// Int32Changed returns true if want is set and differs from got.
+// A nil want leaves the value to Azure, so it never reports a change.
+func Int32Changed(want, got *int32) bool {
+	return want != nil && to.Int32(want) != to.Int32(got)
+}
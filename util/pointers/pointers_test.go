@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pointers
+
+import (
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/onsi/gomega"
+)
+
+func TestBoolChanged(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Want     *bool
+		Got      *bool
+		Expected bool
+	}{
+		{Name: "NilWant", Want: nil, Got: to.BoolPtr(true), Expected: false},
+		{Name: "SameValue", Want: to.BoolPtr(true), Got: to.BoolPtr(true), Expected: false},
+		{Name: "DifferentValue", Want: to.BoolPtr(true), Got: to.BoolPtr(false), Expected: true},
+		{Name: "FalseWantNilGot", Want: to.BoolPtr(false), Got: nil, Expected: false},
+		{Name: "TrueWantNilGot", Want: to.BoolPtr(true), Got: nil, Expected: true},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			g.Expect(BoolChanged(c.Want, c.Got)).To(gomega.Equal(c.Expected))
+		})
+	}
+}
+
+func TestInt32Changed(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Want     *int32
+		Got      *int32
+		Expected bool
+	}{
+		{Name: "NilWant", Want: nil, Got: to.Int32Ptr(4), Expected: false},
+		{Name: "SameValue", Want: to.Int32Ptr(4), Got: to.Int32Ptr(4), Expected: false},
+		{Name: "DifferentValue", Want: to.Int32Ptr(4), Got: to.Int32Ptr(15), Expected: true},
+		{Name: "NilGot", Want: to.Int32Ptr(4), Got: nil, Expected: true},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			g.Expect(Int32Changed(c.Want, c.Got)).To(gomega.Equal(c.Expected))
+		})
+	}
+}